@@ -0,0 +1,21 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+var Night float
+var Storm float
+
+func Fragment(position vec4, texCoord vec2, color vec4) vec4 {
+	clr := imageSrc0UnsafeAt(texCoord)
+
+	// Night makes everything darker and slightly blue.
+	nightColor := clr.rgb * vec3(0.45, 0.5, 0.75)
+	rgb := mix(clr.rgb, nightColor, Night)
+
+	// Storm washes out the colors.
+	gray := dot(rgb, vec3(0.299, 0.587, 0.114))
+	rgb = mix(rgb, vec3(gray*0.85), Storm*0.5)
+
+	return vec4(rgb, clr.a)
+}
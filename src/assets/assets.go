@@ -39,7 +39,11 @@ func VolumeMultiplier(level int) float64 {
 	}
 }
 
-func MakeOpenAssetFunc(ctx *ge.Context, gamedataFolder string) func(path string) io.ReadCloser {
+// MakeOpenAssetFunc returns a game assets loader function.
+//
+// If modFolder is not empty, the mod assets are used instead of the
+// embedded assets with the same path (see ReadModFile).
+func MakeOpenAssetFunc(ctx *ge.Context, gamedataFolder, modFolder string) func(path string) io.ReadCloser {
 	return func(path string) io.ReadCloser {
 		if strings.HasPrefix(path, "$") {
 			f, err := openfile(filepath.Join(gamedataFolder, path[len("$"):]))
@@ -48,6 +52,12 @@ func MakeOpenAssetFunc(ctx *ge.Context, gamedataFolder string) func(path string)
 			}
 			return f
 		}
+		if modFolder != "" {
+			f, err := openfile(filepath.Join(modFolder, "assets", path))
+			if err == nil {
+				return f
+			}
+		}
 		f, err := gameAssets.Open("_data/" + path)
 		if err != nil {
 			ctx.OnCriticalError(err)
@@ -0,0 +1,7 @@
+package assets
+
+// ReadCampaign returns the embedded campaign file contents.
+// See gamedata.LoadCampaign.
+func ReadCampaign() ([]byte, error) {
+	return gameAssets.ReadFile("_data/raw/campaign.json")
+}
@@ -42,6 +42,7 @@ func RegisterImageResources(ctx *ge.Context, config *Config, progress *float64)
 		ImageAchievementSpectator:      {Path: "image/achievement/spectator.png"},
 		ImageAchievementGladiator:      {Path: "image/achievement/gladiator.png"},
 		ImageAchievementDarkness:       {Path: "image/achievement/darkness.png"},
+		ImageAchievementApex:           {Path: "image/achievement/apex.png"},
 
 		ImageLock: {Path: "image/ui/lock.png"},
 
@@ -105,6 +106,12 @@ func RegisterImageResources(ctx *ge.Context, config *Config, progress *float64)
 		ImageActionIncreaseTech:   {Path: "image/ui/action_increase_tech.png"},
 		ImageActionIncreaseTechX2: {Path: "image/ui/action_increase_tech_x2.png"},
 		ImageActionAbomb:          {Path: "image/ui/action_abomb.png"},
+		ImageActionResearch:       {Path: "image/ui/action_research.png"},
+		ImageActionBurrow:         {Path: "image/ui/action_burrow.png"},
+		ImageActionRecall:         {Path: "image/ui/action_recall.png"},
+		ImageActionUpgradeTurret:  {Path: "image/ui/action_upgrade_turret.png"},
+		ImageActionRecycleTurret:  {Path: "image/ui/action_recycle_turret.png"},
+		ImageActionRaid:           {Path: "image/ui/action_raid.png"},
 
 		ImageTeleportEffectSmall:        {Path: "image/effects/teleport_effect_small.png", FrameWidth: 32},
 		ImageTeleportEffectBig:          {Path: "image/effects/teleport_effect_big.png", FrameWidth: 64},
@@ -183,6 +190,16 @@ func RegisterImageResources(ctx *ge.Context, config *Config, progress *float64)
 		ImageArkCoreSelector:      {Path: "image/colonies/ark_core_selector.png"},
 		ImageArkCoreAllianceColor: {Path: "image/colonies/ark_core_alliance_color.png"},
 
+		ImageHiveCore:              {Path: "image/colonies/hive_core.png"},
+		ImageHiveCoreFlying:        {Path: "image/colonies/hive_core_flying.png"},
+		ImageHiveCoreSelector:      {Path: "image/colonies/hive_core_selector.png"},
+		ImageHiveCoreAllianceColor: {Path: "image/colonies/hive_core_alliance_color.png"},
+
+		ImageBeaconCore:              {Path: "image/colonies/beacon_core.png"},
+		ImageBeaconCoreFlying:        {Path: "image/colonies/beacon_core_flying.png"},
+		ImageBeaconCoreSelector:      {Path: "image/colonies/beacon_core_selector.png"},
+		ImageBeaconCoreAllianceColor: {Path: "image/colonies/beacon_core_alliance_color.png"},
+
 		ImageTankCore:              {Path: "image/colonies/tank_core.png"},
 		ImageTankCoreFlying:        {Path: "image/colonies/tank_core_flying.png"},
 		ImageTankCoreSelector:      {Path: "image/colonies/tank_core_selector.png"},
@@ -242,6 +259,11 @@ func RegisterImageResources(ctx *ge.Context, config *Config, progress *float64)
 		ImageCommanderAgent:     {Path: "image/drones/commander_agent.png", FrameWidth: 17, FrameHeight: 14},
 		ImageTargeterAgent:      {Path: "image/drones/targeter_agent.png", FrameWidth: 15, FrameHeight: 14},
 		ImageBomberAgent:        {Path: "image/drones/bomber_agent.png", FrameWidth: 23, FrameHeight: 18},
+		ImageMinelayerAgent:     {Path: "image/drones/minelayer_agent.png", FrameWidth: 15, FrameHeight: 12},
+		ImageShielderAgent:      {Path: "image/drones/shielder_agent.png", FrameWidth: 17, FrameHeight: 14},
+		ImageRelayAgent:         {Path: "image/drones/relay_agent.png", FrameWidth: 15, FrameHeight: 16},
+		ImageTitanAgent:         {Path: "image/drones/titan_agent.png", FrameWidth: 33, FrameHeight: 24},
+		ImageHiveAgent:          {Path: "image/drones/hive_agent.png", FrameWidth: 23, FrameHeight: 22},
 
 		ImageDreadnoughtDamageMask: {Path: "image/shaders/dreadnought_damage_mask.png"},
 		ImageColonyDamageMask:      {Path: "image/shaders/colony_damage_mask.png"},
@@ -254,6 +276,7 @@ func RegisterImageResources(ctx *ge.Context, config *Config, progress *float64)
 		ImageEssenceSourceDissolveMask:    {Path: "image/resources/essence_source_dissolve_mask.png"},
 		ImageEssenceRedCrystalSource:      {Path: "image/resources/red_crystal.png", FrameWidth: 16},
 		ImageEssenceCrystalSource:         {Path: "image/resources/crystal_source.png", FrameWidth: 16},
+		ImageEssenceFrostCrystalSource:    {Path: "image/resources/frost_crystal_source.png", FrameWidth: 16},
 		ImageEssenceGoldSource:            {Path: "image/resources/gold_source.png", FrameWidth: 28},
 		ImageEssenceSulfurSource:          {Path: "image/resources/sulfur_source.png", FrameWidth: 28},
 		ImageEssenceMagmaRockSource:       {Path: "image/resources/magma_rock_source.png", FrameWidth: 16},
@@ -292,10 +315,13 @@ func RegisterImageResources(ctx *ge.Context, config *Config, progress *float64)
 		ImageBuilderCreep:        {Path: "image/creeps/builder_creep.png", FrameWidth: 31, FrameHeight: 31},
 		ImageWispLair:            {Path: "image/creeps/wisp_lair.png"},
 		ImageWisp:                {Path: "image/creeps/wisp.png", FrameWidth: 22},
+		ImageScavengerWagon:      {Path: "image/creeps/scavenger_wagon.png", FrameWidth: 25, FrameHeight: 19},
+		ImageScavengerGuard:      {Path: "image/creeps/scavenger_guard.png", FrameWidth: 23, FrameHeight: 16},
 
 		ImageBackgroundTiles:        {Path: "image/landscape/moon/tiles.png"},
 		ImageBackgroundForestTiles:  {Path: "image/landscape/forest/tiles.png"},
 		ImageBackgroundInfernoTiles: {Path: "image/landscape/inferno/tiles.png"},
+		ImageBackgroundIceTiles:     {Path: "image/landscape/ice/tiles.png"},
 
 		ImageMountainSmall:         {Path: "image/landscape/moon/mountain_small.png", FrameWidth: 32},
 		ImageMountainMedium:        {Path: "image/landscape/moon/mountain_medium.png", FrameWidth: 48},
@@ -312,6 +338,11 @@ func RegisterImageResources(ctx *ge.Context, config *Config, progress *float64)
 		ImageInfernoMountainBig:    {Path: "image/landscape/inferno/mountain_big.png", FrameWidth: 64},
 		ImageInfernoMountainWide:   {Path: "image/landscape/inferno/mountain_wide.png", FrameWidth: 64},
 		ImageInfernoMountainTall:   {Path: "image/landscape/inferno/mountain_tall.png", FrameWidth: 48},
+		ImageIceMountainSmall:      {Path: "image/landscape/ice/mountain_small.png", FrameWidth: 32},
+		ImageIceMountainMedium:     {Path: "image/landscape/ice/mountain_medium.png", FrameWidth: 48},
+		ImageIceMountainBig:        {Path: "image/landscape/ice/mountain_big.png", FrameWidth: 64},
+		ImageIceMountainWide:       {Path: "image/landscape/ice/mountain_wide.png", FrameWidth: 64},
+		ImageIceMountainTall:       {Path: "image/landscape/ice/mountain_tall.png", FrameWidth: 48},
 
 		ImageLandCrack:  {Path: "image/landscape/landcrack.png", FrameWidth: 32},
 		ImageLandCrack2: {Path: "image/landscape/landcrack2.png", FrameWidth: 32},
@@ -324,6 +355,12 @@ func RegisterImageResources(ctx *ge.Context, config *Config, progress *float64)
 		ImageLavaPuddle4: {Path: "image/landscape/inferno/lava4.png", FrameWidth: 32},
 		ImageLavaPuddle5: {Path: "image/landscape/inferno/lava5.png", FrameWidth: 32},
 
+		ImageIceLake:  {Path: "image/landscape/ice/lake.png", FrameWidth: 32},
+		ImageIceLake2: {Path: "image/landscape/ice/lake2.png", FrameWidth: 32},
+
+		ImageIceLakeFrozen:  {Path: "image/landscape/ice/lake_frozen.png", FrameWidth: 32},
+		ImageIceLakeFrozen2: {Path: "image/landscape/ice/lake2_frozen.png", FrameWidth: 32},
+
 		ImageTrees: {Path: "image/landscape/forest/trees.png", FrameWidth: 32},
 
 		ImageLavaGeyser: {Path: "image/landscape/inferno/geyser.png"},
@@ -385,6 +422,9 @@ func RegisterImageResources(ctx *ge.Context, config *Config, progress *float64)
 		ImageItemSuperCreeps:       {Path: "image/ui/items/super_creeps.png"},
 		ImageItemFortress:          {Path: "image/ui/items/fortress.png"},
 		ImageItemAtomWeapon:        {Path: "image/ui/items/atom_weapon.png"},
+		ImageItemTechTree:          {Path: "image/ui/items/tech_tree.png"},
+		ImageItemWorldEvents:       {Path: "image/ui/items/world_events.png"},
+		ImageItemScavengers:        {Path: "image/ui/items/scavengers.png"},
 
 		ImageUIGamepadRadar:    {Path: "image/ui/gamepad_radar.png"},
 		ImageUIGamepadRadarDot: {Path: "image/ui/gamepad_radar_dot.png"},
@@ -468,6 +508,7 @@ const (
 	ImageAchievementSpectator
 	ImageAchievementGladiator
 	ImageAchievementDarkness
+	ImageAchievementApex
 
 	ImageLock
 
@@ -598,6 +639,12 @@ const (
 	ImageActionIncreaseTech
 	ImageActionIncreaseTechX2
 	ImageActionAbomb
+	ImageActionResearch
+	ImageActionBurrow
+	ImageActionRecall
+	ImageActionUpgradeTurret
+	ImageActionRecycleTurret
+	ImageActionRaid
 
 	ImageFactionDiode
 	ImageUberBoss
@@ -621,6 +668,16 @@ const (
 	ImageArkCoreSelector
 	ImageArkCoreAllianceColor
 
+	ImageHiveCore
+	ImageHiveCoreFlying
+	ImageHiveCoreSelector
+	ImageHiveCoreAllianceColor
+
+	ImageBeaconCore
+	ImageBeaconCoreFlying
+	ImageBeaconCoreSelector
+	ImageBeaconCoreAllianceColor
+
 	ImageTankCore
 	ImageTankCoreFlying
 	ImageTankCoreSelector
@@ -678,8 +735,14 @@ const (
 	ImageCommanderAgent
 	ImageTargeterAgent
 	ImageBomberAgent
+	ImageMinelayerAgent
+	ImageShielderAgent
+	ImageRelayAgent
+	ImageTitanAgent
+	ImageHiveAgent
 	ImageEssenceRedCrystalSource
 	ImageEssenceCrystalSource
+	ImageEssenceFrostCrystalSource
 	ImageEssenceGoldSource
 	ImageEssenceSulfurSource
 	ImageEssenceMagmaRockSource
@@ -714,10 +777,13 @@ const (
 	ImageCrawlerCreepBase
 	ImageWispLair
 	ImageWisp
+	ImageScavengerWagon
+	ImageScavengerGuard
 
 	ImageBackgroundTiles
 	ImageBackgroundForestTiles
 	ImageBackgroundInfernoTiles
+	ImageBackgroundIceTiles
 	ImageMountainSmall
 	ImageMountainMedium
 	ImageMountainBig
@@ -733,6 +799,11 @@ const (
 	ImageInfernoMountainBig
 	ImageInfernoMountainTall
 	ImageInfernoMountainWide
+	ImageIceMountainSmall
+	ImageIceMountainMedium
+	ImageIceMountainBig
+	ImageIceMountainTall
+	ImageIceMountainWide
 	ImageLandCrack
 	ImageLandCrack2
 	ImageLandCrack3
@@ -742,6 +813,10 @@ const (
 	ImageLavaPuddle3
 	ImageLavaPuddle4
 	ImageLavaPuddle5
+	ImageIceLake
+	ImageIceLake2
+	ImageIceLakeFrozen
+	ImageIceLakeFrozen2
 	ImageTrees
 	ImageLavaGeyser
 
@@ -802,6 +877,9 @@ const (
 	ImageItemSuperCreeps
 	ImageItemFortress
 	ImageItemAtomWeapon
+	ImageItemTechTree
+	ImageItemWorldEvents
+	ImageItemScavengers
 
 	ImageUIGamepadRadar
 	ImageUIGamepadRadarDot
@@ -0,0 +1,23 @@
+package assets
+
+import (
+	"io"
+	"path/filepath"
+)
+
+// ReadModFile reads a file from the mod folder.
+//
+// A mod folder may contain:
+//
+//	stats.json    - a stats file overlay (see gamedata.Ruleset)
+//	campaign.json - extra campaign missions (see gamedata.LoadCampaign)
+//	assets/       - files that replace the game assets, like "assets/image/drones/worker_agent.png"
+//	lang/         - extra translation files, like "lang/en.txt"
+func ReadModFile(modFolder, name string) ([]byte, error) {
+	f, err := openfile(filepath.Join(modFolder, name))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
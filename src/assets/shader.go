@@ -21,6 +21,7 @@ func RegisterShaderResources(ctx *ge.Context, config *Config, progress *float64)
 		ShaderColonyTeleport:   {Path: "shader/colony_teleport.go"},
 		ShaderSharpen:          {Path: "shader/sharpen.go"},
 		ShaderHueRotate:        {Path: "shader/hue_rotate.go"},
+		ShaderWorldEvent:       {Path: "shader/world_event.go"},
 	}
 
 	singleThread := runtime.GOMAXPROCS(-1) == 1
@@ -50,4 +51,5 @@ const (
 	ShaderColonyTeleport
 	ShaderSharpen
 	ShaderHueRotate
+	ShaderWorldEvent
 )
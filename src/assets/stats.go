@@ -0,0 +1,7 @@
+package assets
+
+// ReadRuleset returns the embedded stats file contents.
+// See gamedata.Ruleset.
+func ReadRuleset() ([]byte, error) {
+	return gameAssets.ReadFile("_data/raw/stats.json")
+}
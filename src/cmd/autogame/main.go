@@ -37,7 +37,7 @@ func main() {
 		Mute:       true,
 		FixedDelta: true,
 	})
-	ctx.Loader.OpenAssetFunc = assets.MakeOpenAssetFunc(ctx, "")
+	ctx.Loader.OpenAssetFunc = assets.MakeOpenAssetFunc(ctx, "", "")
 	ctx.Dict = langs.NewDictionary("en", 2)
 
 	var rng gmath.Rand
@@ -49,10 +49,12 @@ func main() {
 
 	var gameDataFolder string
 	var serverAddress string
+	var modFolder string
 	flag.StringVar(&state.MemProfile, "memprofile", "", "collect app heap allocations profile")
 	flag.StringVar(&state.CPUProfile, "cpuprofile", "", "collect app cpu profile")
 	flag.StringVar(&gameDataFolder, "data", "", "a game data folder path")
 	flag.StringVar(&serverAddress, "server", DefaultServerAddr, "leaderboard server address")
+	flag.StringVar(&modFolder, "mod", "", "a mod folder path")
 	flag.Parse()
 
 	if runtime.GOARCH != "wasm" {
@@ -104,7 +106,14 @@ func main() {
 		}
 	}
 
-	ctx.Loader.OpenAssetFunc = assets.MakeOpenAssetFunc(ctx, gameDataFolder)
+	if modFolder != "" {
+		if err := state.LoadMod(modFolder); err != nil {
+			panic(fmt.Sprintf("load mod: %v", err))
+		}
+		state.Logf("loaded %q mod", state.ModName)
+	}
+
+	ctx.Loader.OpenAssetFunc = assets.MakeOpenAssetFunc(ctx, gameDataFolder, state.ModFolder)
 	assets.RegisterRawResources(ctx)
 	keymaps := controls.BindKeymap(ctx)
 	state.CombinedInput = keymaps.CombinedInput
@@ -230,6 +239,22 @@ func getDefaultSessionState() *session.State {
 				AtomicBomb:            true,
 			},
 		}),
+		KothLevelConfig: newLevelConfig(&gamedata.LevelConfig{
+			ReplayLevelConfig: serverapi.ReplayLevelConfig{
+				RawGameMode:   "koth",
+				DronesPower:   1,
+				InitialCreeps: 1,
+				Symmetry:      int(gamedata.SymmetryRotational),
+			},
+		}),
+		VersusLevelConfig: newLevelConfig(&gamedata.LevelConfig{
+			ReplayLevelConfig: serverapi.ReplayLevelConfig{
+				RawGameMode:   "versus",
+				DronesPower:   1,
+				InitialCreeps: 1,
+				Symmetry:      int(gamedata.SymmetryRotational),
+			},
+		}),
 		ArenaLevelConfig: newLevelConfig(&gamedata.LevelConfig{
 			ReplayLevelConfig: serverapi.ReplayLevelConfig{
 				ArenaProgression: 1,
@@ -276,36 +301,14 @@ func getDefaultSessionState() *session.State {
 		state.SplashLevelConfig = &config
 	}
 
+	// King of the hill and versus are competitive modes, they can't be played solo.
+	state.KothLevelConfig.PlayersMode = serverapi.PmodePlayerAndBot
+	state.VersusLevelConfig.PlayersMode = serverapi.PmodePlayerAndBot
+
 	{
-		config := state.ClassicLevelConfig.Clone()
-		config.RawGameMode = "tutorial"
+		// The tutorial is the first campaign mission.
+		config := gamedata.FindCampaignMission("intro").MakeLevelConfig()
 		state.TutorialLevelConfig = &config
-		config.WorldSize = 0
-		config.Resources = 1
-		config.Relicts = false
-		config.StartingResources = false
-		config.Teleporters = 1
-		config.InterfaceMode = 2
-		config.InitialCreeps = 0
-		config.EliteResources = true
-		config.EnemyBoss = false
-		config.CreepDifficulty = 0
-		config.BossDifficulty = 0
-		config.NumCreepBases = 0
-		config.Environment = int(gamedata.EnvInferno)
-
-		config.ExtraDrones = []*gamedata.AgentStats{}
-		for i := 0; i < 2; i++ {
-			config.ExtraDrones = append(config.ExtraDrones, gamedata.ServoAgentStats)
-		}
-		for i := 0; i < 5; i++ {
-			config.ExtraDrones = append(config.ExtraDrones, gamedata.WorkerAgentStats)
-		}
-		for i := 0; i < 3; i++ {
-			config.ExtraDrones = append(config.ExtraDrones, gamedata.ScoutAgentStats)
-		}
-
-		config.Finalize()
 	}
 
 	for _, core := range gamedata.CoreStatsList {
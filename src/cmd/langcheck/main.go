@@ -26,7 +26,7 @@ func main() {
 		Mute:       true,
 		FixedDelta: true,
 	})
-	ctx.Loader.OpenAssetFunc = assets.MakeOpenAssetFunc(ctx, "")
+	ctx.Loader.OpenAssetFunc = assets.MakeOpenAssetFunc(ctx, "", "")
 	assets.RegisterRawResources(ctx)
 
 	eng := translations[0]
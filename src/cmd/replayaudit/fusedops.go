@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// The replays are audited on the platforms we can run here (amd64, 386, wasm),
+// but none of them fuse x*y+z into a single instruction.
+// arm64 does that, so the simulation packages are checked statically:
+// their arm64 assembly should not contain any fused multiply-add instructions.
+//
+// The fusion can be prevented by an explicit float64 conversion
+// or by a detmath function (see the detmath package docs).
+
+// simulationPackages lists the packages that affect the simulation results.
+var simulationPackages = []string{
+	"github.com/quasilyte/roboden-game/detmath",
+	"github.com/quasilyte/roboden-game/gamedata",
+	"github.com/quasilyte/roboden-game/pathing",
+	"github.com/quasilyte/roboden-game/spatial",
+	"github.com/quasilyte/roboden-game/scenes/staging",
+}
+
+// renderingPositions lists the inlined code that can only affect the rendering.
+// For example, ebiten.ColorScale arithmetics can be fused,
+// but the sprite colors are never read by the simulation.
+var renderingPositions = []string{
+	"github.com/hajimehoshi/ebiten/",
+}
+
+var (
+	fusedOpRegexp = regexp.MustCompile(`\((\S+\.go:\d+)\)\s+(FMADDD|FMSUBD|FNMADDD|FNMSUBD|FMADDS|FMSUBS|FNMADDS|FNMSUBS)\s`)
+	funcRegexp    = regexp.MustCompile(`^(\S+) STEXT`)
+)
+
+type fusedOp struct {
+	pos      string
+	funcName string
+}
+
+// checkFusedOps returns the fused operations found in the simulation packages.
+//
+// The instructions that come from an inlined function are reported with
+// the inlined function position, so the caller function is reported too.
+func checkFusedOps(tmpDir string) []fusedOp {
+	// windows/arm64 doesn't need cgo to build the game, unlike linux/arm64;
+	// the generated float code is the same.
+	// The -a flag is needed: the cached packages are not compiled (and printed) again.
+	var output bytes.Buffer
+	cmd := exec.Command("go", "build", "-a",
+		"-gcflags=github.com/quasilyte/roboden-game/...=-S",
+		"-o", filepath.Join(tmpDir, "runsim_fma.exe"), "./cmd/runsim")
+	cmd.Env = append(os.Environ(), "GOOS=windows", "GOARCH=arm64")
+	cmd.Stderr = &output
+	if err := cmd.Run(); err != nil {
+		panic(fmt.Sprintf("build windows/arm64: %v: %s", err, lastLine(output.String())))
+	}
+
+	set := make(map[fusedOp]struct{})
+	funcName := ""
+	scanner := bufio.NewScanner(&output)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := funcRegexp.FindStringSubmatch(line); m != nil {
+			funcName = m[1]
+			continue
+		}
+		m := fusedOpRegexp.FindStringSubmatch(line)
+		if m == nil || !isSimulationFunc(funcName) {
+			continue
+		}
+		pos := trimPosPrefix(m[1])
+		if isRenderingPos(pos) {
+			continue
+		}
+		set[fusedOp{pos: pos, funcName: funcName}] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		panic(err)
+	}
+
+	result := make([]fusedOp, 0, len(set))
+	for op := range set {
+		result = append(result, op)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].pos != result[j].pos {
+			return result[i].pos < result[j].pos
+		}
+		return result[i].funcName < result[j].funcName
+	})
+	return result
+}
+
+func isSimulationFunc(funcName string) bool {
+	for _, pkg := range simulationPackages {
+		if strings.HasPrefix(funcName, pkg+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func isRenderingPos(pos string) bool {
+	for _, prefix := range renderingPositions {
+		if strings.HasPrefix(pos, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func trimPosPrefix(pos string) string {
+	if wd, err := os.Getwd(); err == nil && strings.HasPrefix(pos, wd) {
+		return strings.TrimPrefix(pos[len(wd):], string(filepath.Separator))
+	}
+	if i := strings.Index(pos, "/pkg/mod/"); i != -1 {
+		return pos[i+len("/pkg/mod/"):]
+	}
+	return pos
+}
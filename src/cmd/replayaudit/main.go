@@ -0,0 +1,269 @@
+// replayaudit runs the same replays under different GOARCH builds
+// and compares the per-tick state hashes of these simulations.
+//
+// It should be executed from the repository src folder:
+//
+//	go run ./cmd/replayaudit -dir ../replays -archs amd64,386,wasm
+//
+// The non-native builds are executed with go_$GOOS_$GOARCH_exec wrappers
+// (the same ones that are used by the go test), so the wasm binaries
+// are executed with go_js_wasm_exec from the Go distribution.
+//
+// Before running the replays, the simulation packages are checked for the
+// fused multiply-add instructions in their arm64 code (see checkFusedOps).
+// Without -dir, only this check is performed:
+//
+//	go run ./cmd/replayaudit
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+func main() {
+	dir := flag.String("dir", "",
+		"path to a folder that contains replay JSON files")
+	archsFlag := flag.String("archs", "amd64,386,wasm",
+		"a comma-separated list of GOARCH values to compare")
+	modFlag := flag.String("mod", "",
+		"a mod folder path (for the modded game replays)")
+	timeoutFlag := flag.Int("timeout", 300,
+		"simulation timeout in seconds")
+	hashIntervalFlag := flag.Int("hash-interval", 1,
+		"compare the state hashes every N ticks")
+	fusedOpsFlag := flag.Bool("fused-ops", true,
+		"check the simulation packages arm64 code for the fused multiply-add instructions")
+	flag.Parse()
+
+	if *dir == "" && !*fusedOpsFlag {
+		panic("--dir can't be empty")
+	}
+	tmpDir, err := os.MkdirTemp("", "replayaudit")
+	if err != nil {
+		panic(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if *fusedOpsFlag {
+		fmt.Println("checking the simulation packages for the fused operations")
+		ops := checkFusedOps(tmpDir)
+		for _, op := range ops {
+			fmt.Printf("FAIL %s: fused multiply-add in %s\n", op.pos, op.funcName)
+		}
+		if len(ops) != 0 {
+			fmt.Printf("found %d fused operations, the arm64 simulation results will be different\n", len(ops))
+			os.RemoveAll(tmpDir)
+			os.Exit(1)
+		}
+		fmt.Println("OK   no fused operations found")
+		if *dir == "" {
+			return
+		}
+	}
+
+	archs := strings.Split(*archsFlag, ",")
+	if len(archs) < 2 {
+		panic("--archs should contain at least 2 values")
+	}
+
+	replays, err := filepath.Glob(filepath.Join(*dir, "*.json"))
+	if err != nil {
+		panic(err)
+	}
+	sort.Strings(replays)
+	if len(replays) == 0 {
+		panic(fmt.Sprintf("%s contains no replays", *dir))
+	}
+
+	goroot := goEnv("GOROOT")
+	builds := make([]*runsimBuild, len(archs))
+	for i, arch := range archs {
+		fmt.Printf("building runsim for %s\n", arch)
+		builds[i] = buildRunsim(tmpDir, goroot, arch)
+	}
+
+	numFailed := 0
+	for _, replay := range replays {
+		runs := make([]simulationRun, len(builds))
+		for i, b := range builds {
+			hashesFile := filepath.Join(tmpDir, b.goarch+".hashes")
+			args := []string{
+				"-trust",
+				"-timeout", fmt.Sprint(*timeoutFlag),
+				"-hashes", hashesFile,
+				"-hash-interval", fmt.Sprint(*hashIntervalFlag),
+			}
+			if *modFlag != "" {
+				args = append(args, "-mod", *modFlag)
+			}
+			runs[i] = b.run(replay, hashesFile, args)
+		}
+		if problem := compareRuns(builds, runs); problem != "" {
+			numFailed++
+			fmt.Printf("FAIL %s: %s\n", filepath.Base(replay), problem)
+		} else {
+			fmt.Printf("OK   %s: %d hashes, %s\n", filepath.Base(replay), len(runs[0].hashes), runs[0].result)
+		}
+	}
+
+	fmt.Printf("%d/%d replays are consistent across %s\n", len(replays)-numFailed, len(replays), *archsFlag)
+	if numFailed != 0 {
+		os.Exit(1)
+	}
+}
+
+type runsimBuild struct {
+	goarch   string
+	binary   string
+	execPath string // An empty string means that the binary is executed directly
+}
+
+type simulationRun struct {
+	result string
+	err    string
+	hashes []string
+}
+
+func buildRunsim(tmpDir, goroot, goarch string) *runsimBuild {
+	goos := runtime.GOOS
+	if goarch == "wasm" {
+		goos = "js"
+	}
+	b := &runsimBuild{
+		goarch: goarch,
+		binary: filepath.Join(tmpDir, "runsim_"+goarch),
+	}
+
+	cmd := exec.Command("go", "build", "-trimpath", "-o", b.binary, "./cmd/runsim")
+	cmd.Env = append(os.Environ(), "GOOS="+goos, "GOARCH="+goarch)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		panic(fmt.Sprintf("build %s/%s: %v: %s", goos, goarch, err, out))
+	}
+
+	if goos == runtime.GOOS && goarch == runtime.GOARCH {
+		return b
+	}
+	// Without the exec wrapper we hope that the binary can be executed natively,
+	// like a 386 binary on amd64 or an arm64 binary with binfmt+qemu.
+	b.execPath = findExecWrapper(goroot, fmt.Sprintf("go_%s_%s_exec", goos, goarch))
+	if b.execPath == "" && goos == "js" {
+		panic("can't find go_js_wasm_exec (is node installed?)")
+	}
+	return b
+}
+
+func (b *runsimBuild) run(replay, hashesFile string, args []string) simulationRun {
+	var run simulationRun
+
+	replayData, err := os.ReadFile(replay)
+	if err != nil {
+		panic(err)
+	}
+
+	var cmd *exec.Cmd
+	if b.execPath != "" {
+		cmd = exec.Command(b.execPath, append([]string{b.binary}, args...)...)
+	} else {
+		cmd = exec.Command(b.binary, args...)
+	}
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdin = bytes.NewReader(replayData)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		// The hashes are still useful: they show where the simulation went wrong.
+		run.err = fmt.Sprintf("%v: %s", err, lastLine(stderr.String()))
+	}
+	run.result = lastLine(stdout.String())
+
+	f, err := os.Open(hashesFile)
+	if err != nil {
+		if run.err == "" {
+			panic(err)
+		}
+		return run
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		run.hashes = append(run.hashes, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		panic(err)
+	}
+	return run
+}
+
+func compareRuns(builds []*runsimBuild, runs []simulationRun) string {
+	for i, run := range runs {
+		if run.err != "" {
+			return fmt.Sprintf("%s simulation failed: %s", builds[i].goarch, run.err)
+		}
+	}
+
+	want := runs[0]
+	for i := 1; i < len(runs); i++ {
+		have := runs[i]
+		n := len(want.hashes)
+		if len(have.hashes) < n {
+			n = len(have.hashes)
+		}
+		for j := 0; j < n; j++ {
+			if have.hashes[j] == want.hashes[j] {
+				continue
+			}
+			tick := strings.Fields(want.hashes[j])[0]
+			return fmt.Sprintf("%s and %s diverge at tick %s", builds[0].goarch, builds[i].goarch, tick)
+		}
+		if len(have.hashes) != len(want.hashes) {
+			return fmt.Sprintf("%s and %s have different number of ticks: %d vs %d",
+				builds[0].goarch, builds[i].goarch, len(want.hashes), len(have.hashes))
+		}
+		if have.result != want.result {
+			return fmt.Sprintf("%s and %s results mismatch: %s vs %s",
+				builds[0].goarch, builds[i].goarch, want.result, have.result)
+		}
+	}
+
+	return ""
+}
+
+func findExecWrapper(goroot, name string) string {
+	if p, err := exec.LookPath(name); err == nil {
+		return p
+	}
+	// The wasm wrapper is located in lib/wasm since Go 1.21, it was in misc/wasm before.
+	for _, dir := range []string{"lib/wasm", "misc/wasm"} {
+		p := filepath.Join(goroot, dir, name)
+		if _, err := os.Stat(p); err == nil {
+			return p
+		}
+	}
+	return ""
+}
+
+func goEnv(key string) string {
+	out, err := exec.Command("go", "env", key).Output()
+	if err != nil {
+		panic(err)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func lastLine(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.LastIndexByte(s, '\n'); i != -1 {
+		return s[i+1:]
+	}
+	return s
+}
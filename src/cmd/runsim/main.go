@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bufio"
 	"encoding/json"
 	"errors"
 	"flag"
@@ -21,6 +22,9 @@ func main() {
 	timeoutFlag := flag.Int("timeout", 30, "simulation timeout in seconds")
 	debugFlag := flag.Bool("debug", false, "whether to enable debug logs")
 	trustFlag := flag.Bool("trust", false, "whether to allow 0 levelgen checksums")
+	modFlag := flag.String("mod", "", "a mod folder path (for the modded game replays)")
+	hashesFlag := flag.String("hashes", "", "a file to write the per-tick state hashes to")
+	hashIntervalFlag := flag.Int("hash-interval", 1, "write the state hash every N ticks")
 	flag.Parse()
 
 	replayDataBytes, err := io.ReadAll(os.Stdin)
@@ -41,19 +45,46 @@ func main() {
 		Mute:       true,
 		FixedDelta: true,
 	})
-	ctx.Loader.OpenAssetFunc = assets.MakeOpenAssetFunc(ctx, "")
+	ctx.Loader.OpenAssetFunc = assets.MakeOpenAssetFunc(ctx, "", *modFlag)
 	ctx.Dict = langs.NewDictionary("en", 2)
 
 	runsim.PrepareAssets(ctx)
 
 	state := runsim.NewState(ctx)
 	state.Persistent.Settings.DebugLogs = *debugFlag
+	if *modFlag != "" {
+		if err := state.LoadMod(*modFlag); err != nil {
+			panic(err)
+		}
+	}
+
+	// An empty checksum means that the replay was recorded with the default ruleset.
+	if !gamedata.RulesetMatches(replayData.RulesetChecksum) {
+		panic(fmt.Errorf("replay ruleset checksum mismatch: %q != %q", replayData.RulesetChecksum, gamedata.RulesetChecksum))
+	}
 
 	config.Finalize()
 
 	controller := staging.NewController(state, config, nil)
 	controller.SetReplayActions(replayData)
-	simResult, err := runsim.Run(state, replayData.LevelGenChecksum, *timeoutFlag, controller)
+	var onTick func(tick int)
+	if *hashesFlag != "" {
+		f, err := os.Create(*hashesFlag)
+		if err != nil {
+			panic(err)
+		}
+		defer f.Close()
+		w := bufio.NewWriter(f)
+		defer w.Flush()
+		onTick = func(tick int) {
+			if tick%*hashIntervalFlag != 0 {
+				return
+			}
+			fmt.Fprintf(w, "%d %016x\n", tick, controller.StateHash())
+		}
+	}
+
+	simResult, err := runsim.RunWithTickFunc(state, replayData.LevelGenChecksum, *timeoutFlag, controller, onTick)
 	if err != nil {
 		panic(err)
 	}
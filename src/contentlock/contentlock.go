@@ -77,6 +77,8 @@ func Update(state *session.State) *Result {
 
 	stats := &state.Persistent.PlayerStats
 
+	migrateTutorialProgress(stats)
+
 	for id, info := range gamedata.GameModeInfoMap {
 		if stats.TotalScore < info.ScoreCost {
 			continue
@@ -146,3 +148,75 @@ func Update(state *session.State) *Result {
 
 	return result
 }
+
+// migrateTutorialProgress marks the tutorial mission as completed
+// for the players that finished the tutorial before the campaign was added.
+// Its score reward was already granted by the tutorial mode.
+func migrateTutorialProgress(stats *session.PlayerStats) {
+	if !stats.TutorialCompleted {
+		return
+	}
+	for _, m := range gamedata.CampaignMissionList {
+		if m.Mode != "tutorial" || xslices.Contains(stats.CampaignMissionsCompleted, m.ID) {
+			continue
+		}
+		stats.CampaignMissionsCompleted = append(stats.CampaignMissionsCompleted, m.ID)
+	}
+}
+
+// CompleteCampaignMission marks the mission as completed and
+// grants its rewards.
+// The returned result contains both the mission rewards and the
+// score-based unlocks (see Update).
+//
+// Completing the same mission twice doesn't give anything.
+func CompleteCampaignMission(state *session.State, m *gamedata.CampaignMission) *Result {
+	stats := &state.Persistent.PlayerStats
+	if xslices.Contains(stats.CampaignMissionsCompleted, m.ID) {
+		return &Result{}
+	}
+	stats.CampaignMissionsCompleted = append(stats.CampaignMissionsCompleted, m.ID)
+	if m.Mode != "tutorial" || !stats.TutorialCompleted {
+		// The tutorial score could be already granted by the tutorial mode.
+		stats.TotalScore += m.ScoreReward
+	}
+
+	result := &Result{}
+	for _, id := range m.Rewards.Modes {
+		if xslices.Contains(stats.ModesUnlocked, id) {
+			continue
+		}
+		result.ModesUnlocked = append(result.ModesUnlocked, id)
+		stats.ModesUnlocked = append(stats.ModesUnlocked, id)
+	}
+	for _, core := range m.Rewards.Cores {
+		if xslices.Contains(stats.CoresUnlocked, core.Name) {
+			continue
+		}
+		result.CoresUnlocked = append(result.CoresUnlocked, core.Name)
+		stats.CoresUnlocked = append(stats.CoresUnlocked, core.Name)
+	}
+	for _, drone := range m.Rewards.Drones {
+		if xslices.Contains(stats.DronesUnlocked, drone.Kind.String()) {
+			continue
+		}
+		result.DronesUnlocked = append(result.DronesUnlocked, drone.Kind)
+		stats.DronesUnlocked = append(stats.DronesUnlocked, drone.Kind.String())
+	}
+	for _, turret := range m.Rewards.Turrets {
+		if xslices.Contains(stats.TurretsUnlocked, turret.Kind.String()) {
+			continue
+		}
+		result.TurretsUnlocked = append(result.TurretsUnlocked, turret.Kind)
+		stats.TurretsUnlocked = append(stats.TurretsUnlocked, turret.Kind.String())
+	}
+
+	scoreResult := Update(state)
+	result.ModesUnlocked = append(result.ModesUnlocked, scoreResult.ModesUnlocked...)
+	result.OptionsUnlocked = append(result.OptionsUnlocked, scoreResult.OptionsUnlocked...)
+	result.CoresUnlocked = append(result.CoresUnlocked, scoreResult.CoresUnlocked...)
+	result.DronesUnlocked = append(result.DronesUnlocked, scoreResult.DronesUnlocked...)
+	result.TurretsUnlocked = append(result.TurretsUnlocked, scoreResult.TurretsUnlocked...)
+
+	return result
+}
@@ -27,6 +27,7 @@ const (
 	ActionMenuFocusUp
 
 	ActionPause
+	ActionSaveAndQuit
 
 	ActionPing
 
@@ -86,6 +87,10 @@ func BindKeymap(ctx *ge.Context) KeymapSet {
 		ActionMenuBack: {input.KeyGamepadBack, input.KeyGamepadB},
 		ActionPause:    {input.KeyGamepadStart, input.KeyGamepadHome},
 
+		// Only works while the exit confirmation is shown,
+		// so it doesn't conflict with the choice selection.
+		ActionSaveAndQuit: {input.KeyGamepadY},
+
 		ActionMenuFocusRight: {input.KeyGamepadRight},
 		ActionMenuFocusDown:  {input.KeyGamepadDown},
 		ActionMenuFocusLeft:  {input.KeyGamepadLeft},
@@ -132,6 +137,8 @@ func BindKeymap(ctx *ge.Context) KeymapSet {
 		ActionMenuBack: {input.KeyEscape},
 		ActionPause:    {input.KeySpace},
 
+		ActionSaveAndQuit: {input.KeyQ},
+
 		ActionMenuFocusRight: {input.KeyRight},
 		ActionMenuFocusDown:  {input.KeyDown},
 		ActionMenuFocusLeft:  {input.KeyLeft},
@@ -25,7 +25,7 @@ func ReplayText(d *langs.Dictionary, r *session.SavedReplay) string {
 		d.Get("menu.lobby.player_mode.two_bots"),
 	}
 	mismatchSuffix := ""
-	if r.Replay.GameVersion != gamedata.BuildNumber {
+	if r.Replay.GameVersion != gamedata.BuildNumber || !gamedata.RulesetMatches(r.Replay.RulesetChecksum) {
 		mismatchSuffix = " [!] " + d.Get("menu.replace.version_mismatch")
 	}
 	lines = append(lines, fmt.Sprintf("%s: %d%s", d.Get("menu.main.build"), r.Replay.GameVersion, mismatchSuffix))
@@ -92,6 +92,12 @@ func CoreText(d *langs.Dictionary, core *gamedata.ColonyCoreStats) string {
 	case gamedata.TankCoreStats:
 		traits = append(traits, d.Get("core.ability.cant_fly"))
 		traits = append(traits, d.Get("core.ability.weapons"))
+	case gamedata.HiveCoreStats:
+		traits = append(traits, d.Get("core.ability.crush"))
+		traits = append(traits, d.Get("core.ability.burrow"))
+	case gamedata.BeaconCoreStats:
+		traits = append(traits, d.Get("core.ability.crush"))
+		traits = append(traits, d.Get("core.ability.recall"))
 	}
 	if len(traits) != 0 {
 		textLines = append(textLines, "")
@@ -225,6 +231,16 @@ func DroneText(d *langs.Dictionary, drone *gamedata.AgentStats, showTier, global
 		traits = append(traits, d.Get("drone.ability.repair"))
 	case gamedata.AgentRecharger:
 		traits = append(traits, d.Get("drone.ability.recharge"))
+	case gamedata.AgentMinelayer:
+		traits = append(traits, d.Get("drone.ability.lay_mines"))
+	case gamedata.AgentShielder:
+		traits = append(traits, d.Get("drone.ability.shield_projection"))
+	case gamedata.AgentRelay:
+		traits = append(traits, d.Get("drone.ability.resource_teleport"))
+	case gamedata.AgentTitan:
+		traits = append(traits, d.Get("drone.ability.shockwave"))
+	case gamedata.AgentHive:
+		traits = append(traits, d.Get("drone.ability.drone_spawning"))
 	case gamedata.AgentRedminer:
 		traits = append(traits, d.Get("drone.ability.red_oil_scavenge"))
 	case gamedata.AgentServo:
@@ -0,0 +1,150 @@
+// Package detmath implements the float operations that give
+// the same results on every supported platform.
+//
+// Go compiler is allowed to fuse x*y+z into a single FMA instruction
+// on some platforms (arm64, ppc64, s390x, riscv64), so the intermediate
+// product is not rounded. This is enough to make the arm64 simulation
+// diverge from the amd64 (and wasm) one after a few thousands ticks.
+// An explicit float64 conversion forces the rounding and prevents the fusion.
+//
+// math.Sqrt is correctly rounded everywhere, so it can be used as is.
+// The trigonometric functions are ported from the Go math package
+// with the same fusion-preventing conversions.
+// The gmath.Rand Chance and RandPicker don't need a replacement:
+// they have no product that is added to something.
+//
+// Use cmd/replayaudit to check whether the replays
+// are simulated identically on different platforms.
+//
+// The results are bit-identical to the gmath (and math) ones on amd64 and wasm;
+// this is verified by the package tests.
+package detmath
+
+import (
+	"math"
+
+	"github.com/quasilyte/gmath"
+)
+
+// mulAdd returns a*b+c with two roundings, the way amd64 computes it.
+func mulAdd(a, b, c float64) float64 {
+	return float64(a*b) + c
+}
+
+// rounded forces the rounding of the vector components.
+//
+// The function arguments are inlined expressions too: an argument that is
+// a product can be fused with the addition inside the inlined function.
+// So every argument that is added to something is passed through rounded
+// (or an explicit float64 conversion), and every returned product is rounded.
+func rounded(v gmath.Vec) gmath.Vec {
+	return gmath.Vec{X: float64(v.X), Y: float64(v.Y)}
+}
+
+// DistanceTo is a deterministic version of gmath.Vec.DistanceTo.
+func DistanceTo(a, b gmath.Vec) float64 {
+	return math.Sqrt(DistanceSquaredTo(a, b))
+}
+
+// DistanceSquaredTo is a deterministic version of gmath.Vec.DistanceSquaredTo.
+func DistanceSquaredTo(a, b gmath.Vec) float64 {
+	a, b = rounded(a), rounded(b)
+	dx := a.X - b.X
+	dy := a.Y - b.Y
+	return float64(dx*dx) + float64(dy*dy)
+}
+
+// Len is a deterministic version of gmath.Vec.Len.
+func Len(v gmath.Vec) float64 {
+	return math.Sqrt(float64(v.X*v.X) + float64(v.Y*v.Y))
+}
+
+// Normalized is a deterministic version of gmath.Vec.Normalized.
+func Normalized(v gmath.Vec) gmath.Vec {
+	l := float64(v.X*v.X) + float64(v.Y*v.Y)
+	if l != 0 {
+		k := 1 / math.Sqrt(l)
+		return gmath.Vec{X: float64(v.X * k), Y: float64(v.Y * k)}
+	}
+	return v
+}
+
+// DirectionTo is a deterministic version of gmath.Vec.DirectionTo.
+func DirectionTo(v, v2 gmath.Vec) gmath.Vec {
+	return Normalized(rounded(v).Sub(rounded(v2)))
+}
+
+// Lerp is a deterministic version of gmath.Lerp.
+func Lerp(from, to, t float64) float64 {
+	from, to = float64(from), float64(to)
+	return from + float64((to-from)*t)
+}
+
+// LinearInterpolate is a deterministic version of gmath.Vec.LinearInterpolate.
+func LinearInterpolate(v, to gmath.Vec, t float64) gmath.Vec {
+	return gmath.Vec{
+		X: Lerp(v.X, to.X, t),
+		Y: Lerp(v.Y, to.Y, t),
+	}
+}
+
+// CubicInterpolate is a deterministic version of gmath.Vec.CubicInterpolate.
+func CubicInterpolate(v, preA, b, postB gmath.Vec, t float64) gmath.Vec {
+	return gmath.Vec{
+		X: cubicInterpolate(v.X, b.X, preA.X, postB.X, t),
+		Y: cubicInterpolate(v.Y, b.Y, preA.Y, postB.Y, t),
+	}
+}
+
+func cubicInterpolate(from, to, pre, post, t float64) float64 {
+	from, to, pre, post = float64(from), float64(to), float64(pre), float64(post)
+	t2 := float64(t * t)
+	t3 := float64(t2 * t)
+	k1 := float64((-pre + to) * t)
+	k2 := float64(float64(float64(2.0*pre)-float64(5.0*from))+float64(4.0*to)-post) * t2
+	k3 := float64(float64(float64(-pre+float64(3.0*from))-float64(3.0*to))+post) * t3
+	return 0.5 * (float64(from*2.0) + k1 + float64(k2) + float64(k3))
+}
+
+// AddScaled returns v+dir*k.
+//
+// This is a deterministic version of the v.Add(dir.Mulf(k)) expression;
+// the compiler can fuse it after the inlining.
+func AddScaled(v, dir gmath.Vec, k float64) gmath.Vec {
+	v = rounded(v)
+	return gmath.Vec{
+		X: v.X + float64(dir.X*k),
+		Y: v.Y + float64(dir.Y*k),
+	}
+}
+
+// MoveTowards is a deterministic version of gmath.Vec.MoveTowards.
+func MoveTowards(v, pos gmath.Vec, length float64) gmath.Vec {
+	v, pos = rounded(v), rounded(pos)
+	direction := pos.Sub(v) // Not normalized
+	dist := Len(direction)
+	if dist <= length || dist < gmath.Epsilon {
+		return pos
+	}
+	return gmath.Vec{
+		X: v.X + float64(direction.X/dist*length),
+		Y: v.Y + float64(direction.Y/dist*length),
+	}
+}
+
+// FloatRange is a deterministic version of gmath.Rand.FloatRange.
+// It consumes the same amount of random values.
+func FloatRange(r *gmath.Rand, min, max float64) float64 {
+	min, max = float64(min), float64(max)
+	return min + float64(r.Float()*(max-min))
+}
+
+// Offset is a deterministic version of gmath.Rand.Offset.
+func Offset(r *gmath.Rand, min, max float64) gmath.Vec {
+	return gmath.Vec{X: FloatRange(r, min, max), Y: FloatRange(r, min, max)}
+}
+
+// RandRad is a deterministic version of gmath.Rand.Rad.
+func RandRad(r *gmath.Rand) gmath.Rad {
+	return gmath.Rad(FloatRange(r, 0, 2*math.Pi))
+}
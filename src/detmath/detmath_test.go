@@ -0,0 +1,186 @@
+package detmath_test
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"runtime"
+	"testing"
+
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/roboden-game/detmath"
+)
+
+// These platforms never fuse the float operations,
+// so the gmath and math package results can be used as a reference.
+func testCanCompareWithGmath() bool {
+	switch runtime.GOARCH {
+	case "amd64", "386", "wasm":
+		return true
+	default:
+		return false
+	}
+}
+
+func TestVecOps(t *testing.T) {
+	// The reference is computed with big.Float that rounds every operation;
+	// this is how the results should look like on every platform.
+	round := func(f *big.Float) float64 {
+		v, _ := f.Float64()
+		return v
+	}
+	newFloat := func(x float64) *big.Float {
+		return new(big.Float).SetPrec(53).SetFloat64(x)
+	}
+	mul := func(a, b float64) float64 { return round(newFloat(0).Mul(newFloat(a), newFloat(b))) }
+	add := func(a, b float64) float64 { return round(newFloat(0).Add(newFloat(a), newFloat(b))) }
+
+	rng := rand.New(rand.NewSource(1))
+	randValue := func() float64 { return (rng.Float64() - 0.5) * 4000 }
+	for i := 0; i < 5000; i++ {
+		a := gmath.Vec{X: randValue(), Y: randValue()}
+		b := gmath.Vec{X: randValue(), Y: randValue()}
+		k := rng.Float64() * 10
+
+		dx := a.X - b.X
+		dy := a.Y - b.Y
+		wantDistSqr := add(mul(dx, dx), mul(dy, dy))
+		if have := detmath.DistanceSquaredTo(a, b); have != wantDistSqr {
+			t.Fatalf("DistanceSquaredTo(%v, %v): have %v, want %v", a, b, have, wantDistSqr)
+		}
+		if have := detmath.DistanceTo(a, b); have != math.Sqrt(wantDistSqr) {
+			t.Fatalf("DistanceTo(%v, %v): have %v, want %v", a, b, have, math.Sqrt(wantDistSqr))
+		}
+
+		wantScaled := gmath.Vec{X: add(a.X, mul(b.X, k)), Y: add(a.Y, mul(b.Y, k))}
+		if have := detmath.AddScaled(a, b, k); have != wantScaled {
+			t.Fatalf("AddScaled(%v, %v, %v): have %v, want %v", a, b, k, have, wantScaled)
+		}
+
+		if !testCanCompareWithGmath() {
+			continue
+		}
+		if have, want := detmath.DistanceSquaredTo(a, b), a.DistanceSquaredTo(b); have != want {
+			t.Fatalf("DistanceSquaredTo(%v, %v): have %v, want %v", a, b, have, want)
+		}
+		if have, want := detmath.DistanceTo(a, b), a.DistanceTo(b); have != want {
+			t.Fatalf("DistanceTo(%v, %v): have %v, want %v", a, b, have, want)
+		}
+		if have, want := detmath.Len(a), a.Len(); have != want {
+			t.Fatalf("Len(%v): have %v, want %v", a, have, want)
+		}
+		if have, want := detmath.AddScaled(a, b, k), a.Add(b.Mulf(k)); have != want {
+			t.Fatalf("AddScaled(%v, %v, %v): have %v, want %v", a, b, k, have, want)
+		}
+		if have, want := detmath.Lerp(a.X, b.X, k), gmath.Lerp(a.X, b.X, k); have != want {
+			t.Fatalf("Lerp(%v, %v, %v): have %v, want %v", a.X, b.X, k, have, want)
+		}
+		if have, want := detmath.MoveTowards(a, b, k*100), a.MoveTowards(b, k*100); have != want {
+			t.Fatalf("MoveTowards(%v, %v, %v): have %v, want %v", a, b, k*100, have, want)
+		}
+		if have, want := detmath.Normalized(a), a.Normalized(); have != want {
+			t.Fatalf("Normalized(%v): have %v, want %v", a, have, want)
+		}
+		if have, want := detmath.DirectionTo(a, b), a.DirectionTo(b); have != want {
+			t.Fatalf("DirectionTo(%v, %v): have %v, want %v", a, b, have, want)
+		}
+		if have, want := detmath.VecTowards(a, b, k), a.VecTowards(b, k); have != want {
+			t.Fatalf("VecTowards(%v, %v, %v): have %v, want %v", a, b, k, have, want)
+		}
+		if have, want := detmath.LinearInterpolate(a, b, k), a.LinearInterpolate(b, k); have != want {
+			t.Fatalf("LinearInterpolate(%v, %v, %v): have %v, want %v", a, b, k, have, want)
+		}
+		if have, want := detmath.AngleToPoint(a, b), a.AngleToPoint(b); have != want {
+			t.Fatalf("AngleToPoint(%v, %v): have %v, want %v", a, b, have, want)
+		}
+		pre := gmath.Vec{X: randValue(), Y: randValue()}
+		post := gmath.Vec{X: randValue(), Y: randValue()}
+		progress := k / 10
+		if have, want := detmath.CubicInterpolate(a, pre, b, post, progress), a.CubicInterpolate(pre, b, post, progress); have != want {
+			t.Fatalf("CubicInterpolate(%v, %v, %v, %v, %v): have %v, want %v", a, pre, b, post, progress, have, want)
+		}
+		angle := gmath.Rad(randValue() * 0.01)
+		if have, want := detmath.Rotated(a, angle), a.Rotated(angle); have != want {
+			t.Fatalf("Rotated(%v, %v): have %v, want %v", a, angle, have, want)
+		}
+		if have, want := detmath.RadToVec(angle), gmath.RadToVec(angle); have != want {
+			t.Fatalf("RadToVec(%v): have %v, want %v", angle, have, want)
+		}
+		if have, want := detmath.NormalizedRad(angle), angle.Normalized(); have != want {
+			t.Fatalf("NormalizedRad(%v): have %v, want %v", angle, have, want)
+		}
+		if have, want := detmath.MoveInDirection(a, k, angle), a.MoveInDirection(k, angle); have != want {
+			t.Fatalf("MoveInDirection(%v, %v, %v): have %v, want %v", a, k, angle, have, want)
+		}
+	}
+}
+
+func TestTrig(t *testing.T) {
+	if !testCanCompareWithGmath() {
+		t.Skipf("can't use math package as a reference on %s", runtime.GOARCH)
+	}
+
+	values := []float64{
+		0, math.Copysign(0, -1), math.NaN(), math.Inf(1), math.Inf(-1),
+		math.Pi, -math.Pi, math.Pi / 2, math.Pi / 4, 1 << 30, -(1 << 30),
+	}
+	rng := rand.New(rand.NewSource(2))
+	for i := 0; i < 20000; i++ {
+		values = append(values, (rng.Float64()-0.5)*100)
+	}
+
+	sameFloat := func(a, b float64) bool {
+		return math.Float64bits(a) == math.Float64bits(b) || (math.IsNaN(a) && math.IsNaN(b))
+	}
+	for _, x := range values {
+		if have, want := detmath.Sin(x), math.Sin(x); !sameFloat(have, want) {
+			t.Fatalf("Sin(%v): have %v, want %v", x, have, want)
+		}
+		if have, want := detmath.Cos(x), math.Cos(x); !sameFloat(have, want) {
+			t.Fatalf("Cos(%v): have %v, want %v", x, have, want)
+		}
+		for _, y := range values[:12] {
+			if have, want := detmath.Atan2(x, y), math.Atan2(x, y); !sameFloat(have, want) {
+				t.Fatalf("Atan2(%v, %v): have %v, want %v", x, y, have, want)
+			}
+			if have, want := detmath.Atan2(y, x), math.Atan2(y, x); !sameFloat(have, want) {
+				t.Fatalf("Atan2(%v, %v): have %v, want %v", y, x, have, want)
+			}
+		}
+	}
+}
+
+func TestRand(t *testing.T) {
+	var r1, r2 gmath.Rand
+	r1.SetSeed(10)
+	r2.SetSeed(10)
+	for i := 0; i < 1000; i++ {
+		min := float64(i) - 500
+		max := min + float64(i%7)*10
+		have := detmath.FloatRange(&r1, min, max)
+		want := r2.FloatRange(min, max)
+		if testCanCompareWithGmath() && have != want {
+			t.Fatalf("FloatRange(%v, %v): have %v, want %v", min, max, have, want)
+		}
+		if have < min || (max > min && have >= max) {
+			t.Fatalf("FloatRange(%v, %v): %v is out of range", min, max, have)
+		}
+	}
+
+	// The rand states must stay in sync.
+	for i := 0; i < 1000; i++ {
+		min := float64(i) - 500
+		max := min + 64
+		haveOffset, wantOffset := detmath.Offset(&r1, min, max), r2.Offset(min, max)
+		haveRad, wantRad := detmath.RandRad(&r1), r2.Rad()
+		if !testCanCompareWithGmath() {
+			continue
+		}
+		if haveOffset != wantOffset {
+			t.Fatalf("Offset(%v, %v): have %v, want %v", min, max, haveOffset, wantOffset)
+		}
+		if haveRad != wantRad {
+			t.Fatalf("RandRad(): have %v, want %v", haveRad, wantRad)
+		}
+	}
+}
@@ -0,0 +1,286 @@
+package detmath
+
+import (
+	"math"
+
+	"github.com/quasilyte/gmath"
+)
+
+// The code below is adapted from the Go math package (sin.go, atan.go, atan2.go).
+// Every product that is added to something is rounded explicitly.
+
+var sinCoeffs = [...]float64{
+	1.58962301576546568060e-10, // 0x3de5d8fd1fd19ccd
+	-2.50507477628578072866e-8, // 0xbe5ae5e5a9291f5d
+	2.75573136213857245213e-6,  // 0x3ec71de3567d48a1
+	-1.98412698295895385996e-4, // 0xbf2a01a019bfdf03
+	8.33333333332211858878e-3,  // 0x3f8111111110f7d0
+	-1.66666666666666307295e-1, // 0xbfc5555555555548
+}
+
+var cosCoeffs = [...]float64{
+	-1.13585365213876817300e-11, // 0xbda8fa49a0861a9b
+	2.08757008419747316778e-9,   // 0x3e21ee9d7b4e3f05
+	-2.75573141792967388112e-7,  // 0xbe927e4f7eac4bc6
+	2.48015872888517045348e-5,   // 0x3efa01a019c844f5
+	-1.38888888888730564116e-3,  // 0xbf56c16c16c14f91
+	4.16666666666665929218e-2,   // 0x3fa555555555554b
+}
+
+// reduceThreshold is the same as in the Go math package.
+// The bigger arguments need a Payne-Hanek reduction; we don't expect
+// such angles in the game, so they're handled by the math package.
+const reduceThreshold = 1 << 29
+
+func Sin(x float64) float64 {
+	x = float64(x)
+	switch {
+	case x == 0 || math.IsNaN(x):
+		return x // return ±0 || NaN()
+	case math.IsInf(x, 0):
+		return math.NaN()
+	}
+
+	// make argument positive but save the sign
+	sign := false
+	if x < 0 {
+		x = -x
+		sign = true
+	}
+	if x >= reduceThreshold {
+		if sign {
+			return -math.Sin(x)
+		}
+		return math.Sin(x)
+	}
+
+	j, z := reduceAngle(x)
+	// reflect in x axis
+	if j > 3 {
+		sign = !sign
+		j -= 4
+	}
+	var y float64
+	if j == 1 || j == 2 {
+		y = cosPoly(z)
+	} else {
+		y = sinPoly(z)
+	}
+	if sign {
+		y = -y
+	}
+	return y
+}
+
+func Cos(x float64) float64 {
+	x = float64(x)
+	switch {
+	case math.IsNaN(x) || math.IsInf(x, 0):
+		return math.NaN()
+	}
+
+	// make argument positive
+	sign := false
+	x = math.Abs(x)
+	if x >= reduceThreshold {
+		return math.Cos(x)
+	}
+
+	j, z := reduceAngle(x)
+	if j > 3 {
+		j -= 4
+		sign = !sign
+	}
+	if j > 1 {
+		sign = !sign
+	}
+	var y float64
+	if j == 1 || j == 2 {
+		y = sinPoly(z)
+	} else {
+		y = cosPoly(z)
+	}
+	if sign {
+		y = -y
+	}
+	return y
+}
+
+func reduceAngle(x float64) (uint64, float64) {
+	const (
+		PI4A = 7.85398125648498535156e-1  // 0x3fe921fb40000000, Pi/4 split into three parts
+		PI4B = 3.77489470793079817668e-8  // 0x3e64442d00000000,
+		PI4C = 2.69515142907905952645e-15 // 0x3ce8469898cc5170,
+	)
+	j := uint64(x * (4 / math.Pi)) // integer part of x/(Pi/4), as integer for tests on the phase angle
+	y := float64(j)                // integer part of x/(Pi/4), as float
+
+	// map zeros to origin
+	if j&1 == 1 {
+		j++
+		y++
+	}
+	j &= 7 // octant modulo 2Pi radians (360 degrees)
+	// Extended precision modular arithmetic
+	z := ((x - float64(y*PI4A)) - float64(y*PI4B)) - float64(y*PI4C)
+	return j, z
+}
+
+func sinPoly(z float64) float64 {
+	zz := z * z
+	p := mulAdd(sinCoeffs[0], zz, sinCoeffs[1])
+	p = mulAdd(p, zz, sinCoeffs[2])
+	p = mulAdd(p, zz, sinCoeffs[3])
+	p = mulAdd(p, zz, sinCoeffs[4])
+	p = mulAdd(p, zz, sinCoeffs[5])
+	return z + float64(z*zz*p)
+}
+
+func cosPoly(z float64) float64 {
+	zz := z * z
+	p := mulAdd(cosCoeffs[0], zz, cosCoeffs[1])
+	p = mulAdd(p, zz, cosCoeffs[2])
+	p = mulAdd(p, zz, cosCoeffs[3])
+	p = mulAdd(p, zz, cosCoeffs[4])
+	p = mulAdd(p, zz, cosCoeffs[5])
+	return (1.0 - float64(0.5*zz)) + float64(zz*zz*p)
+}
+
+func Atan2(y, x float64) float64 {
+	y, x = float64(y), float64(x)
+	// special cases
+	switch {
+	case math.IsNaN(y) || math.IsNaN(x):
+		return math.NaN()
+	case y == 0:
+		if x >= 0 && !math.Signbit(x) {
+			return math.Copysign(0, y)
+		}
+		return math.Copysign(math.Pi, y)
+	case x == 0:
+		return math.Copysign(math.Pi/2, y)
+	case math.IsInf(x, 0):
+		if math.IsInf(x, 1) {
+			switch {
+			case math.IsInf(y, 0):
+				return math.Copysign(math.Pi/4, y)
+			default:
+				return math.Copysign(0, y)
+			}
+		}
+		switch {
+		case math.IsInf(y, 0):
+			return math.Copysign(3*math.Pi/4, y)
+		default:
+			return math.Copysign(math.Pi, y)
+		}
+	case math.IsInf(y, 0):
+		return math.Copysign(math.Pi/2, y)
+	}
+
+	// Call atan and determine the quadrant.
+	q := atan(y / x)
+	if x < 0 {
+		if q <= 0 {
+			return q + math.Pi
+		}
+		return q - math.Pi
+	}
+	return q
+}
+
+func atan(x float64) float64 {
+	if x == 0 {
+		return x
+	}
+	if x > 0 {
+		return satan(x)
+	}
+	return -satan(-x)
+}
+
+// satan reduces its argument (known to be positive)
+// to the range [0, 0.66] and calls xatan.
+func satan(x float64) float64 {
+	const (
+		Morebits = 6.123233995736765886130e-17 // pi/2 = PIO2 + Morebits
+		Tan3pio8 = 2.41421356237309504880      // tan(3*pi/8)
+	)
+	if x <= 0.66 {
+		return xatan(x)
+	}
+	if x > Tan3pio8 {
+		return math.Pi/2 - xatan(1/x) + Morebits
+	}
+	return math.Pi/4 + xatan((x-1)/(x+1)) + 0.5*Morebits
+}
+
+// xatan evaluates a series valid in the range [0, 0.66].
+func xatan(x float64) float64 {
+	const (
+		P0 = -8.750608600031904122785e-01
+		P1 = -1.615753718733365076637e+01
+		P2 = -7.500855792314704667340e+01
+		P3 = -1.228866684490136173410e+02
+		P4 = -6.485021904942025371773e+01
+		Q0 = +2.485846490142306297962e+01
+		Q1 = +1.650270098316988542046e+02
+		Q2 = +4.328810604912902668951e+02
+		Q3 = +4.853903996359136964868e+02
+		Q4 = +1.945506571482613964425e+02
+	)
+	z := float64(x * x) // z+Q0 below could be fused
+	p := mulAdd(P0, z, P1)
+	p = mulAdd(p, z, P2)
+	p = mulAdd(p, z, P3)
+	p = mulAdd(p, z, P4)
+	q := mulAdd(z+Q0, z, Q1)
+	q = mulAdd(q, z, Q2)
+	q = mulAdd(q, z, Q3)
+	q = mulAdd(q, z, Q4)
+	z = z * p / q
+	return float64(x*z) + x
+}
+
+// RadToVec is a deterministic version of gmath.RadToVec.
+func RadToVec(angle gmath.Rad) gmath.Vec {
+	return gmath.Vec{X: Cos(float64(angle)), Y: Sin(float64(angle))}
+}
+
+// VecTowards is a deterministic version of gmath.Vec.VecTowards.
+func VecTowards(v, pos gmath.Vec, length float64) gmath.Vec {
+	dir := RadToVec(AngleToPoint(v, pos))
+	return gmath.Vec{X: float64(dir.X * length), Y: float64(dir.Y * length)}
+}
+
+// NormalizedRad is a deterministic version of gmath.Rad.Normalized.
+func NormalizedRad(r gmath.Rad) gmath.Rad {
+	angle := float64(r)
+	angle -= float64(math.Floor(angle/(2*math.Pi)) * 2 * math.Pi)
+	return gmath.Rad(angle)
+}
+
+// AngleToPoint is a deterministic version of gmath.Vec.AngleToPoint.
+func AngleToPoint(v, pos gmath.Vec) gmath.Rad {
+	d := rounded(pos).Sub(rounded(v))
+	return gmath.Rad(Atan2(d.Y, d.X))
+}
+
+// Rotated is a deterministic version of gmath.Vec.Rotated.
+func Rotated(v gmath.Vec, angle gmath.Rad) gmath.Vec {
+	sine := Sin(float64(angle))
+	cosi := Cos(float64(angle))
+	return gmath.Vec{
+		X: float64(v.X*cosi) - float64(v.Y*sine),
+		Y: float64(v.X*sine) + float64(v.Y*cosi),
+	}
+}
+
+// MoveInDirection is a deterministic version of gmath.Vec.MoveInDirection.
+func MoveInDirection(v gmath.Vec, dist float64, dir gmath.Rad) gmath.Vec {
+	v = rounded(v)
+	return gmath.Vec{
+		X: v.X + float64(dist*Cos(float64(dir))),
+		Y: v.Y + float64(dist*Sin(float64(dir))),
+	}
+}
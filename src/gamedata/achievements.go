@@ -19,6 +19,8 @@ const (
 	ModeArena
 	ModeInfArena
 	ModeReverse
+	ModeKingOfTheHill
+	ModeVersus
 
 	ModeTutorial
 
@@ -37,6 +39,10 @@ func (m Mode) String() string {
 		return "inf_arena"
 	case ModeReverse:
 		return "reverse"
+	case ModeKingOfTheHill:
+		return "koth"
+	case ModeVersus:
+		return "versus"
 	case ModeTutorial:
 		return "tutorial"
 	default:
@@ -44,6 +50,11 @@ func (m Mode) String() string {
 	}
 }
 
+// IsCompetitive reports whether this mode is played by two rival sides.
+func (m Mode) IsCompetitive() bool {
+	return m == ModeKingOfTheHill || m == ModeVersus
+}
+
 var AchievementList = []*Achievement{
 	// Any mode achievements.
 	{
@@ -72,6 +83,11 @@ var AchievementList = []*Achievement{
 		Mode: ModeAny,
 		Icon: assets.ImageAchievementDarkness,
 	},
+	{
+		Name: "apex",
+		Mode: ModeAny,
+		Icon: assets.ImageAchievementApex,
+	},
 
 	// Classic mode achievements.
 	{
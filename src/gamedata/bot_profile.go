@@ -0,0 +1,110 @@
+package gamedata
+
+type BotProfileKind int
+
+// The zero value is the default profile,
+// so the replays recorded before the profiles were introduced stay valid.
+const (
+	BotProfileNormal BotProfileKind = iota
+	BotProfileEasy
+	BotProfileHard
+	BotProfileTurtle
+	BotProfileRusher
+	BotProfileEconomist
+)
+
+// BotProfile describes the computer player behavior.
+//
+// All multipliers are 1 for the normal profile;
+// it gives exactly the same results as the bot without a profile.
+type BotProfile struct {
+	Kind BotProfileKind
+
+	// ActionDelay multiplies the delay between the bot actions.
+	// Lower values result in a higher "APM".
+	ActionDelay float64
+
+	// Aggression makes the bot attack the creep bases and the boss
+	// with smaller (higher values) or bigger (lower values) armies.
+	Aggression float64
+
+	// Expansion affects how eager the bot is to build new colonies.
+	Expansion float64
+
+	// TurretPreference affects how many turrets are built per colony
+	// and how often the bot tries to build them.
+	TurretPreference float64
+
+	// MistakeRate is a chance for the bot to skip a turn
+	// or to pick a random card instead of a calculated one.
+	MistakeRate float64
+
+	// DifficultyScore is added to the level difficulty score
+	// when the human player is opposed by this bot.
+	// An allied bot affects the score in the opposite way.
+	DifficultyScore int
+}
+
+var BotProfiles = []*BotProfile{
+	BotProfileNormal: {
+		Kind:             BotProfileNormal,
+		ActionDelay:      1,
+		Aggression:       1,
+		Expansion:        1,
+		TurretPreference: 1,
+	},
+	BotProfileEasy: {
+		Kind:             BotProfileEasy,
+		ActionDelay:      1.8,
+		Aggression:       0.7,
+		Expansion:        0.75,
+		TurretPreference: 1,
+		MistakeRate:      0.2,
+		DifficultyScore:  -40,
+	},
+	BotProfileHard: {
+		Kind:             BotProfileHard,
+		ActionDelay:      0.6,
+		Aggression:       1.25,
+		Expansion:        1.2,
+		TurretPreference: 1,
+		DifficultyScore:  30,
+	},
+	BotProfileTurtle: {
+		Kind:             BotProfileTurtle,
+		ActionDelay:      1,
+		Aggression:       0.6,
+		Expansion:        0.7,
+		TurretPreference: 1.8,
+		MistakeRate:      0.05,
+		DifficultyScore:  -15,
+	},
+	BotProfileRusher: {
+		Kind:             BotProfileRusher,
+		ActionDelay:      0.8,
+		Aggression:       1.6,
+		Expansion:        0.8,
+		TurretPreference: 0.5,
+		MistakeRate:      0.05,
+		DifficultyScore:  10,
+	},
+	BotProfileEconomist: {
+		Kind:             BotProfileEconomist,
+		ActionDelay:      1,
+		Aggression:       0.8,
+		Expansion:        1.6,
+		TurretPreference: 0.8,
+		MistakeRate:      0.05,
+		DifficultyScore:  5,
+	},
+}
+
+// botProfileDifficulty returns the profile difficulty score.
+// The profile index is not validated yet when the replay score is checked,
+// so an unknown profile is handled here.
+func botProfileDifficulty(kind int) int {
+	if kind < 0 || kind >= len(BotProfiles) {
+		return 0
+	}
+	return BotProfiles[kind].DifficultyScore
+}
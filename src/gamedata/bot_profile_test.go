@@ -0,0 +1,91 @@
+package gamedata
+
+import (
+	"testing"
+
+	"github.com/quasilyte/roboden-game/serverapi"
+)
+
+func TestBotProfiles(t *testing.T) {
+	for i, p := range BotProfiles {
+		if p.Kind != BotProfileKind(i) {
+			t.Fatalf("BotProfiles[%d] has kind %d", i, p.Kind)
+		}
+		if p.ActionDelay <= 0 || p.Aggression <= 0 || p.Expansion <= 0 || p.TurretPreference <= 0 {
+			t.Fatalf("BotProfiles[%d] has a non-positive multiplier", i)
+		}
+	}
+
+	// The default profile should not change the old bot behavior,
+	// otherwise the existing replays become invalid.
+	normal := BotProfiles[BotProfileNormal]
+	if normal.ActionDelay != 1 || normal.Aggression != 1 || normal.Expansion != 1 || normal.TurretPreference != 1 || normal.MistakeRate != 0 || normal.DifficultyScore != 0 {
+		t.Fatalf("normal profile is not neutral: %+v", normal)
+	}
+}
+
+func TestPlayerBotProfile(t *testing.T) {
+	tests := []struct {
+		mode        string
+		playersMode int
+		want        []BotProfileKind
+	}{
+		{"classic", serverapi.PmodeSingleBot, []BotProfileKind{BotProfileEasy}},
+		{"classic", serverapi.PmodePlayerAndBot, []BotProfileKind{BotProfileEasy, BotProfileEasy}},
+		{"classic", serverapi.PmodeTwoBots, []BotProfileKind{BotProfileEasy, BotProfileEasy}},
+		{"reverse", serverapi.PmodeSinglePlayer, []BotProfileKind{BotProfileEasy, BotProfileHard}},
+		{"reverse", serverapi.PmodePlayerAndBot, []BotProfileKind{BotProfileHard, BotProfileEasy}},
+		{"reverse", serverapi.PmodeTwoBots, []BotProfileKind{BotProfileEasy, BotProfileHard}},
+		{"versus", serverapi.PmodePlayerAndBot, []BotProfileKind{BotProfileEasy, BotProfileHard}},
+		{"versus", serverapi.PmodeTwoBots, []BotProfileKind{BotProfileEasy, BotProfileHard}},
+	}
+
+	for _, test := range tests {
+		config := MakeLevelConfig(ExecuteSimulation, serverapi.ReplayLevelConfig{
+			RawGameMode:        test.mode,
+			PlayersMode:        test.playersMode,
+			BotProfile:         int(BotProfileEasy),
+			OpponentBotProfile: int(BotProfileHard),
+		})
+		config.Finalize()
+		for i, want := range test.want {
+			if have := config.PlayerBotProfile(i).Kind; have != want {
+				t.Errorf("%s/%d: player %d: have %d profile, want %d", test.mode, test.playersMode, i, have, want)
+			}
+		}
+	}
+}
+
+func TestBotProfileDifficultyScore(t *testing.T) {
+	calcScore := func(mode string, playersMode int, ally, opponent BotProfileKind) int {
+		return CalcDifficultyScore(serverapi.ReplayLevelConfig{
+			RawGameMode:        mode,
+			PlayersMode:        playersMode,
+			BotProfile:         int(ally),
+			OpponentBotProfile: int(opponent),
+		}, 0)
+	}
+
+	// The enemy colony bot profile affects the reverse mode score.
+	reverse := serverapi.PmodeSinglePlayer
+	normal := calcScore("reverse", reverse, BotProfileNormal, BotProfileNormal)
+	if easy := calcScore("reverse", reverse, BotProfileNormal, BotProfileEasy); easy >= normal {
+		t.Fatalf("reverse: easy enemy score %d >= normal enemy score %d", easy, normal)
+	}
+	if hard := calcScore("reverse", reverse, BotProfileNormal, BotProfileHard); hard <= normal {
+		t.Fatalf("reverse: hard enemy score %d <= normal enemy score %d", hard, normal)
+	}
+
+	// An ally helps the player, so a stronger ally lowers the score.
+	coop := serverapi.PmodePlayerAndBot
+	normal = calcScore("classic", coop, BotProfileNormal, BotProfileNormal)
+	if easy := calcScore("classic", coop, BotProfileEasy, BotProfileNormal); easy <= normal {
+		t.Fatalf("classic: easy ally score %d <= normal ally score %d", easy, normal)
+	}
+	if hard := calcScore("classic", coop, BotProfileHard, BotProfileNormal); hard >= normal {
+		t.Fatalf("classic: hard ally score %d >= normal ally score %d", hard, normal)
+	}
+
+	// An unknown profile doesn't crash the replay validation.
+	calcScore("reverse", reverse, BotProfileNormal, BotProfileKind(len(BotProfiles)))
+}
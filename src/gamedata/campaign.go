@@ -0,0 +1,334 @@
+package gamedata
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/quasilyte/roboden-game/serverapi"
+)
+
+// CampaignMission is an authored scenario.
+//
+// Unlike the skirmish modes, a mission has most of its options fixed:
+// the map parameters, the available drones, core and turret designs.
+// The player can't change them in the lobby.
+//
+// The missions are loaded from the campaign file (see LoadCampaign).
+type CampaignMission struct {
+	ID string
+
+	// Mode is a raw game mode that is used to run this mission.
+	// The mode-specific managers (like the classic mode creep spawner)
+	// are activated the same way as for the skirmish games.
+	Mode string
+
+	// Seed is a fixed map seed.
+	// A zero value means that the seed is randomized on every launch.
+	Seed int64
+
+	Objective      GameObjective
+	ObjectiveValue int
+
+	Core   *ColonyCoreStats
+	Turret *AgentStats
+	Drones []*AgentStats
+
+	ExtraDrones []*AgentStats
+
+	ScoreReward int
+	Rewards     CampaignRewards
+
+	Environment EnvironmentKind
+
+	// Script is an optional scenario script.
+	Script *ScenarioScript
+
+	// config is a JSON object with the level config fields
+	// that override the mission defaults, like {"world_size": 1}.
+	config json.RawMessage
+}
+
+type CampaignRewards struct {
+	Cores   []*ColonyCoreStats
+	Drones  []*AgentStats
+	Turrets []*AgentStats
+	Modes   []string
+}
+
+func FindCampaignMission(id string) *CampaignMission {
+	m := findCampaignMission(id)
+	if m != nil {
+		return m
+	}
+	panic(fmt.Sprintf("requested a non-existing mission: %s", id))
+}
+
+func findCampaignMission(id string) *CampaignMission {
+	for _, m := range CampaignMissionList {
+		if m.ID == id {
+			return m
+		}
+	}
+	return nil
+}
+
+func CampaignMissionIndex(id string) int {
+	for i, m := range CampaignMissionList {
+		if m.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (m *CampaignMission) MakeLevelConfig() LevelConfig {
+	replayConfig := serverapi.ReplayLevelConfig{
+		RawGameMode: m.Mode,
+		Mission:     m.ID,
+		Seed:        m.Seed,
+
+		PlayersMode:   serverapi.PmodeSinglePlayer,
+		InterfaceMode: 2,
+
+		Relicts:     true,
+		GoldEnabled: true,
+
+		InitialCreeps:   1,
+		NumCreepBases:   2,
+		CreepDifficulty: 3,
+		DronesPower:     1,
+		CreepSpawnRate:  1,
+		BossDifficulty:  1,
+		GameSpeed:       1,
+		Teleporters:     1,
+
+		WorldSize:    2,
+		OilRegenRate: 2,
+		Terrain:      1,
+		Resources:    2,
+
+		CoreDesign:   m.Core.Name,
+		TurretDesign: m.Turret.Kind.String(),
+	}
+	for _, drone := range m.Drones {
+		replayConfig.Tier2Recipes = append(replayConfig.Tier2Recipes, drone.Kind.String())
+		replayConfig.DronePointsAllocated += drone.PointCost
+	}
+	replayConfig.Environment = int(m.Environment)
+	if len(m.config) != 0 {
+		// Validated during the campaign loading.
+		if err := json.Unmarshal(m.config, &replayConfig); err != nil {
+			panic(err)
+		}
+	}
+
+	config := MakeLevelConfig(ExecuteNormal, replayConfig)
+	config.Finalize()
+
+	return config
+}
+
+// CampaignMissionList is an ordered list of the campaign missions.
+// A mission is unlocked after the previous mission is completed.
+var CampaignMissionList []*CampaignMission
+
+// LoadCampaign parses and applies the campaign file data.
+//
+// The campaign file is a JSON document with the missions list:
+//
+//	{
+//	  "missions": [
+//	    {
+//	      "id": "outpost",
+//	      "mode": "classic",
+//	      "seed": 8086,
+//	      "objective": "build_base",
+//	      "objective_value": 3,
+//	      "core": "den",
+//	      "turret": "Gunpoint",
+//	      "drones": ["Freighter", "Redminer", "Repair", "Fighter", "Servo"],
+//	      "score_reward": 700,
+//	      "rewards": {"drones": ["Roomba"]},
+//	      "environment": "forest",
+//	      "config": {"world_size": 1, "resources": 3},
+//	      "script": {"triggers": []}
+//	    }
+//	  ]
+//	}
+//
+// The "config" object fields are the replay level config fields (see serverapi.ReplayLevelConfig).
+// The "script" object is a scenario script (see ScenarioScript).
+//
+// It can be called several times: the embedded campaign file
+// is loaded first, then an optional mod overlay is applied.
+// A mission replaces the existing mission with the same ID
+// or it's added to the end of the missions list.
+func LoadCampaign(data []byte) error {
+	var c campaignData
+	if err := json.Unmarshal(data, &c); err != nil {
+		return err
+	}
+	missions := make([]*CampaignMission, 0, len(c.Missions))
+	for i := range c.Missions {
+		m, err := makeCampaignMission(&c.Missions[i])
+		if err != nil {
+			return err
+		}
+		for _, other := range missions {
+			if other.ID == m.ID {
+				return fmt.Errorf("%s: duplicated mission id", m.ID)
+			}
+		}
+		missions = append(missions, m)
+	}
+
+	for _, m := range missions {
+		if i := CampaignMissionIndex(m.ID); i != -1 {
+			CampaignMissionList[i] = m
+			continue
+		}
+		CampaignMissionList = append(CampaignMissionList, m)
+	}
+	return nil
+}
+
+type campaignData struct {
+	Missions []campaignMissionData `json:"missions"`
+}
+
+type campaignMissionData struct {
+	ID             string              `json:"id"`
+	Mode           string              `json:"mode"`
+	Seed           int64               `json:"seed"`
+	Objective      string              `json:"objective"`
+	ObjectiveValue int                 `json:"objective_value"`
+	Core           string              `json:"core"`
+	Turret         string              `json:"turret"`
+	Drones         []string            `json:"drones"`
+	ExtraDrones    []string            `json:"extra_drones"`
+	ScoreReward    int                 `json:"score_reward"`
+	Rewards        campaignRewardsData `json:"rewards"`
+	Environment    string              `json:"environment"`
+	Config         json.RawMessage     `json:"config"`
+	Script         *ScenarioScript     `json:"script"`
+}
+
+type campaignRewardsData struct {
+	Cores   []string `json:"cores"`
+	Drones  []string `json:"drones"`
+	Turrets []string `json:"turrets"`
+	Modes   []string `json:"modes"`
+}
+
+var campaignObjectives = map[string]GameObjective{
+	"boss":                ObjectiveBoss,
+	"build_base":          ObjectiveBuildBase,
+	"destroy_creep_bases": ObjectiveDestroyCreepBases,
+	"super_elite":         ObjectiveAcquireSuperElite,
+	"trigger":             ObjectiveTrigger,
+}
+
+var campaignEnvironments = map[string]EnvironmentKind{
+	"forest":  EnvForest,
+	"inferno": EnvInferno,
+	"moon":    EnvMoon,
+	"ice":     EnvIce,
+}
+
+func makeCampaignMission(data *campaignMissionData) (*CampaignMission, error) {
+	if data.ID == "" {
+		return nil, fmt.Errorf("mission without an id")
+	}
+	m := &CampaignMission{
+		ID:             data.ID,
+		Mode:           data.Mode,
+		Seed:           data.Seed,
+		ObjectiveValue: data.ObjectiveValue,
+		ScoreReward:    data.ScoreReward,
+		Script:         data.Script,
+		config:         data.Config,
+	}
+
+	if _, ok := GameModeInfoMap[data.Mode]; !ok && data.Mode != "tutorial" {
+		return nil, fmt.Errorf("%s: unknown mode %q", m.ID, data.Mode)
+	}
+	objective, ok := campaignObjectives[data.Objective]
+	if !ok {
+		return nil, fmt.Errorf("%s: unknown objective %q", m.ID, data.Objective)
+	}
+	m.Objective = objective
+	env, ok := campaignEnvironments[data.Environment]
+	if !ok {
+		return nil, fmt.Errorf("%s: unknown environment %q", m.ID, data.Environment)
+	}
+	m.Environment = env
+
+	m.Core = findCoreByName(data.Core)
+	if m.Core == nil {
+		return nil, fmt.Errorf("%s: unknown core %q", m.ID, data.Core)
+	}
+	m.Turret = findTurretByName(data.Turret)
+	if m.Turret == nil {
+		return nil, fmt.Errorf("%s: unknown turret %q", m.ID, data.Turret)
+	}
+	for _, name := range data.Drones {
+		recipe := findRecipeByName(name)
+		if recipe.Result == nil || recipe.Result.Tier != 2 {
+			return nil, fmt.Errorf("%s: unknown tier-2 drone %q", m.ID, name)
+		}
+		m.Drones = append(m.Drones, recipe.Result)
+	}
+	for _, name := range data.ExtraDrones {
+		stats := findRulesetAgent(name)
+		if stats == nil {
+			return nil, fmt.Errorf("%s: unknown extra drone %q", m.ID, name)
+		}
+		m.ExtraDrones = append(m.ExtraDrones, stats)
+	}
+
+	for _, name := range data.Rewards.Cores {
+		core := findCoreByName(name)
+		if core == nil {
+			return nil, fmt.Errorf("%s: unknown reward core %q", m.ID, name)
+		}
+		m.Rewards.Cores = append(m.Rewards.Cores, core)
+	}
+	for _, name := range data.Rewards.Drones {
+		recipe := findRecipeByName(name)
+		if recipe.Result == nil {
+			return nil, fmt.Errorf("%s: unknown reward drone %q", m.ID, name)
+		}
+		m.Rewards.Drones = append(m.Rewards.Drones, recipe.Result)
+	}
+	for _, name := range data.Rewards.Turrets {
+		turret := findTurretByName(name)
+		if turret == nil {
+			return nil, fmt.Errorf("%s: unknown reward turret %q", m.ID, name)
+		}
+		m.Rewards.Turrets = append(m.Rewards.Turrets, turret)
+	}
+	for _, id := range data.Rewards.Modes {
+		if _, ok := GameModeInfoMap[id]; !ok {
+			return nil, fmt.Errorf("%s: unknown reward mode %q", m.ID, id)
+		}
+	}
+	m.Rewards.Modes = data.Rewards.Modes
+
+	if len(data.Config) != 0 {
+		// Only the known fields can be overridden.
+		var config serverapi.ReplayLevelConfig
+		dec := json.NewDecoder(bytes.NewReader(data.Config))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&config); err != nil {
+			return nil, fmt.Errorf("%s: config: %w", m.ID, err)
+		}
+	}
+	if data.Script != nil {
+		if err := validateScenarioScript(data.Script); err != nil {
+			return nil, fmt.Errorf("%s: script: %w", m.ID, err)
+		}
+	}
+
+	return m, nil
+}
@@ -0,0 +1,108 @@
+package gamedata
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/quasilyte/roboden-game/serverapi"
+)
+
+func TestCampaignMissions(t *testing.T) {
+	ids := map[string]struct{}{}
+	for _, m := range CampaignMissionList {
+		if _, ok := ids[m.ID]; ok {
+			t.Fatalf("duplicated mission %q", m.ID)
+		}
+		ids[m.ID] = struct{}{}
+
+		config := m.MakeLevelConfig()
+		if config.Mission != m.ID {
+			t.Fatalf("%s: mission ID is not set", m.ID)
+		}
+		if config.EnemyBoss != (m.Objective == ObjectiveBoss) {
+			t.Fatalf("%s: unexpected boss setting", m.ID)
+		}
+		if m.Mode == "tutorial" {
+			continue
+		}
+
+		// Mission replays should be re-created from the config alone.
+		config.Seed = 1
+		replay := serverapi.GameReplay{Config: config.ReplayLevelConfig}
+		if !IsValidReplay(replay) {
+			t.Fatalf("%s: config is not a valid replay config", m.ID)
+		}
+		if IsSendableReplay(replay) {
+			t.Fatalf("%s: mission replays should not be sendable", m.ID)
+		}
+		restored := MakeLevelConfig(ExecuteReplay, replay.Config)
+		if restored.EnemyBoss != config.EnemyBoss || len(restored.ExtraDrones) != len(config.ExtraDrones) {
+			t.Fatalf("%s: replay config doesn't match the mission config", m.ID)
+		}
+	}
+}
+
+func TestLoadCampaign(t *testing.T) {
+	tests := []struct {
+		src string
+		err string
+	}{
+		{`{"missions": []}`, ""},
+		{`{"missions": [{"id": "a", "mode": "classic", "objective": "boss", "core": "den", "turret": "Gunpoint", "environment": "moon"}]}`, ""},
+		{`{"missions": [{"id": "a", "mode": "arena", "objective": "trigger", "core": "ark", "turret": "BeamTower", "environment": "ice", "drones": ["Fighter"], "extra_drones": ["Worker"], "rewards": {"drones": ["Roomba"], "modes": ["koth"]}, "config": {"world_size": 3}, "script": {"triggers": [{"id": "x", "actions": [{"kind": "victory"}]}]}}]}`, ""},
+
+		{`{"missions": [{"mode": "classic"}]}`, "mission without an id"},
+		{`{"missions": [{"id": "a", "mode": "foo"}]}`, `unknown mode "foo"`},
+		{`{"missions": [{"id": "a", "mode": "classic", "objective": "foo"}]}`, `unknown objective "foo"`},
+		{`{"missions": [{"id": "a", "mode": "classic", "objective": "boss", "environment": "foo"}]}`, `unknown environment "foo"`},
+		{`{"missions": [{"id": "a", "mode": "classic", "objective": "boss", "environment": "moon", "core": "foo"}]}`, `unknown core "foo"`},
+		{`{"missions": [{"id": "a", "mode": "classic", "objective": "boss", "environment": "moon", "core": "den", "turret": "Fighter"}]}`, `unknown turret "Fighter"`},
+		{`{"missions": [{"id": "a", "mode": "classic", "objective": "boss", "environment": "moon", "core": "den", "turret": "Gunpoint", "drones": ["Worker"]}]}`, `unknown tier-2 drone "Worker"`},
+		{`{"missions": [{"id": "a", "mode": "classic", "objective": "boss", "environment": "moon", "core": "den", "turret": "Gunpoint", "rewards": {"modes": ["foo"]}}]}`, `unknown reward mode "foo"`},
+		{`{"missions": [{"id": "a", "mode": "classic", "objective": "boss", "environment": "moon", "core": "den", "turret": "Gunpoint", "config": {"wold_size": 1}}]}`, `unknown field "wold_size"`},
+		{`{"missions": [{"id": "a", "mode": "classic", "objective": "boss", "environment": "moon", "core": "den", "turret": "Gunpoint", "script": {"triggers": [{"id": "x"}]}}]}`, "empty actions list"},
+		{`{"missions": [{"id": "a", "mode": "classic", "objective": "boss", "environment": "moon", "core": "den", "turret": "Gunpoint"}, {"id": "a", "mode": "classic", "objective": "boss", "environment": "moon", "core": "den", "turret": "Gunpoint"}]}`, "duplicated mission id"},
+	}
+
+	defaultMissions := append([]*CampaignMission(nil), CampaignMissionList...)
+	defer func() {
+		CampaignMissionList = defaultMissions
+	}()
+
+	for _, test := range tests {
+		CampaignMissionList = append([]*CampaignMission(nil), defaultMissions...)
+		err := LoadCampaign([]byte(test.src))
+		if test.err == "" {
+			if err != nil {
+				t.Fatalf("load(%s): unexpected error: %v", test.src, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Fatalf("load(%s): expected %q error, got %v", test.src, test.err, err)
+		}
+	}
+
+	// A mission with the existing ID replaces the original mission,
+	// the other missions are added to the end of the list.
+	CampaignMissionList = append([]*CampaignMission(nil), defaultMissions...)
+	purgeIndex := CampaignMissionIndex("purge")
+	err := LoadCampaign([]byte(`{"missions": [
+		{"id": "extra", "mode": "classic", "objective": "boss", "core": "den", "turret": "Gunpoint", "environment": "ice"},
+		{"id": "purge", "mode": "arena", "objective": "trigger", "core": "den", "turret": "Gunpoint", "environment": "forest", "config": {"world_size": 3}}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(CampaignMissionList) != len(defaultMissions)+1 || CampaignMissionList[len(defaultMissions)].ID != "extra" {
+		t.Fatalf("the new mission is not added to the end of the list")
+	}
+	purge := FindCampaignMission("purge")
+	if CampaignMissionIndex("purge") != purgeIndex || purge.Mode != "arena" {
+		t.Fatalf("the mission is not replaced")
+	}
+	config := purge.MakeLevelConfig()
+	if config.WorldSize != 3 || config.Environment != int(EnvForest) || config.RawGameMode != "arena" {
+		t.Fatalf("the mission config overrides are not applied")
+	}
+}
@@ -35,30 +35,36 @@ func _() {
 	_ = x[AgentCommander-24]
 	_ = x[AgentTargeter-25]
 	_ = x[AgentFirebug-26]
-	_ = x[AgentGuardian-27]
-	_ = x[AgentStormbringer-28]
-	_ = x[AgentDestroyer-29]
-	_ = x[AgentBomber-30]
-	_ = x[AgentMarauder-31]
-	_ = x[AgentTrucker-32]
-	_ = x[AgentDevourer-33]
-	_ = x[AgentKindNum-34]
-	_ = x[AgentGunpoint-35]
-	_ = x[AgentTetherBeacon-36]
-	_ = x[AgentBeamTower-37]
-	_ = x[AgentHarvester-38]
-	_ = x[AgentSiege-39]
-	_ = x[AgentDroneFactory-40]
-	_ = x[AgentPowerPlant-41]
-	_ = x[AgentRepulseTower-42]
-	_ = x[AgentRelict-43]
-	_ = x[AgentMegaRoomba-44]
-	_ = x[agentLast-45]
+	_ = x[AgentMinelayer-27]
+	_ = x[AgentShielder-28]
+	_ = x[AgentRelay-29]
+	_ = x[AgentGuardian-30]
+	_ = x[AgentStormbringer-31]
+	_ = x[AgentDestroyer-32]
+	_ = x[AgentBomber-33]
+	_ = x[AgentMarauder-34]
+	_ = x[AgentTrucker-35]
+	_ = x[AgentDevourer-36]
+	_ = x[AgentTitan-37]
+	_ = x[AgentHive-38]
+	_ = x[AgentKindNum-39]
+	_ = x[AgentGunpoint-40]
+	_ = x[AgentTetherBeacon-41]
+	_ = x[AgentBeamTower-42]
+	_ = x[AgentHarvester-43]
+	_ = x[AgentSiege-44]
+	_ = x[AgentDroneFactory-45]
+	_ = x[AgentPowerPlant-46]
+	_ = x[AgentRepulseTower-47]
+	_ = x[AgentControlPoint-48]
+	_ = x[AgentRelict-49]
+	_ = x[AgentMegaRoomba-50]
+	_ = x[agentLast-51]
 }
 
-const _ColonyAgentKind_name = "agentFirstWorkerScoutFreighterRedminerCripplerFighterScavengerCourierPrismServoRepellerDisintegratorRepairClonerRechargerGeneratorMortarAntiAirDefenderKamikazeSkirmisherScarabRoombaCommanderTargeterFirebugGuardianStormbringerDestroyerBomberMarauderTruckerDevourerKindNumGunpointTetherBeaconBeamTowerHarvesterSiegeDroneFactoryPowerPlantRepulseTowerRelictMegaRoombaagentLast"
+const _ColonyAgentKind_name = "agentFirstWorkerScoutFreighterRedminerCripplerFighterScavengerCourierPrismServoRepellerDisintegratorRepairClonerRechargerGeneratorMortarAntiAirDefenderKamikazeSkirmisherScarabRoombaCommanderTargeterFirebugMinelayerShielderRelayGuardianStormbringerDestroyerBomberMarauderTruckerDevourerTitanHiveKindNumGunpointTetherBeaconBeamTowerHarvesterSiegeDroneFactoryPowerPlantRepulseTowerControlPointRelictMegaRoombaagentLast"
 
-var _ColonyAgentKind_index = [...]uint16{0, 10, 16, 21, 30, 38, 46, 53, 62, 69, 74, 79, 87, 100, 106, 112, 121, 130, 136, 143, 151, 159, 169, 175, 181, 190, 198, 205, 213, 225, 234, 240, 248, 255, 263, 270, 278, 290, 299, 308, 313, 325, 335, 347, 353, 363, 372}
+var _ColonyAgentKind_index = [...]uint16{0, 10, 16, 21, 30, 38, 46, 53, 62, 69, 74, 79, 87, 100, 106, 112, 121, 130, 136, 143, 151, 159, 169, 175, 181, 190, 198, 205, 214, 222, 227, 235, 247, 256, 262, 270, 277, 285, 290, 294, 301, 309, 321, 330, 339, 344, 356, 366, 378, 390, 396, 406, 415}
 
 func (i ColonyAgentKind) String() string {
 	if i >= ColonyAgentKind(len(_ColonyAgentKind_index)-1) {
@@ -8,9 +8,24 @@ import (
 	"github.com/quasilyte/roboden-game/assets"
 )
 
+type CoreAbility int
+
+const (
+	CoreAbilityNone CoreAbility = iota
+
+	// CoreAbilityBurrow makes the colony invulnerable for a while.
+	// A burrowed colony can't move.
+	CoreAbilityBurrow
+
+	// CoreAbilityRecall teleports all colony drones back to the colony.
+	CoreAbilityRecall
+)
+
 type ColonyCoreStats struct {
 	Name string
 
+	Ability CoreAbility
+
 	Image               resource.ImageID
 	Shadow              resource.ImageID
 	ShadowOffsetY       float64
@@ -51,18 +66,27 @@ func (stats *ColonyCoreStats) AllianceColorImageID() resource.ImageID {
 }
 
 func FindCoreByName(coreName string) *ColonyCoreStats {
+	if stats := findCoreByName(coreName); stats != nil {
+		return stats
+	}
+	panic(fmt.Sprintf("requested a non-existing core: %s", coreName))
+}
+
+func findCoreByName(coreName string) *ColonyCoreStats {
 	for _, stats := range CoreStatsList {
 		if stats.Name == coreName {
 			return stats
 		}
 	}
-	panic(fmt.Sprintf("requested a non-existing core: %s", coreName))
+	return nil
 }
 
 var CoreStatsList = []*ColonyCoreStats{
 	DenCoreStats,
 	ArkCoreStats,
 	TankCoreStats,
+	HiveCoreStats,
+	BeaconCoreStats,
 }
 
 var DenCoreStats = &ColonyCoreStats{
@@ -158,3 +182,59 @@ var TankCoreStats = &ColonyCoreStats{
 	CapacityRating:  4,
 	UnitLimitRating: 4,
 }
+
+var HiveCoreStats = &ColonyCoreStats{
+	Name:                "hive",
+	Ability:             CoreAbilityBurrow,
+	Image:               assets.ImageHiveCore,
+	Shadow:              assets.ImageDenShadow,
+	ShadowOffsetY:       10,
+	HatchOffsetY:        -22,
+	DiodeOffset:         gmath.Vec{X: 16, Y: -27},
+	AllianceColorOffset: gmath.Vec{Y: 27},
+	ScoreCost:           HiveCoreCost,
+
+	FlightHeight:  50,
+	DefaultHeight: 0,
+
+	Speed:             18,
+	JumpDist:          550,
+	DroneLimit:        140,
+	DroneLimitScaling: 1.1,
+	StartingDrones:    15,
+	ResourcesLimit:    600,
+	MaxHealth:         180,
+
+	MobilityRating:  4,
+	DefenseRating:   10,
+	CapacityRating:  10,
+	UnitLimitRating: 9,
+}
+
+var BeaconCoreStats = &ColonyCoreStats{
+	Name:                "beacon",
+	Ability:             CoreAbilityRecall,
+	Image:               assets.ImageBeaconCore,
+	Shadow:              assets.ImageDenShadow,
+	ShadowOffsetY:       10,
+	HatchOffsetY:        -22,
+	DiodeOffset:         gmath.Vec{X: 16, Y: -27},
+	AllianceColorOffset: gmath.Vec{Y: 27},
+	ScoreCost:           BeaconCoreCost,
+
+	FlightHeight:  50,
+	DefaultHeight: 0,
+
+	Speed:             24,
+	JumpDist:          650,
+	DroneLimit:        110,
+	DroneLimitScaling: 1.0,
+	StartingDrones:    12,
+	ResourcesLimit:    400,
+	MaxHealth:         110,
+
+	MobilityRating:  6,
+	DefenseRating:   7,
+	CapacityRating:  8,
+	UnitLimitRating: 8,
+}
@@ -34,6 +34,8 @@ const (
 	CreepCenturion
 )
 
+// The balance-related fields (costs, health, weapon stats) are
+// loaded from the stats file, see Ruleset.
 type CreepStats struct {
 	Kind        CreepKind
 	Image       resource.ImageID
@@ -86,6 +88,21 @@ var MagmaHazardWeapon = InitWeaponStats(&WeaponStats{
 	AlwaysExplodes:        true,
 })
 
+var MeteorHazardWeapon = InitWeaponStats(&WeaponStats{
+	MaxTargets:            1,
+	BurstSize:             1,
+	AttackRange:           400,
+	ImpactArea:            40,
+	ProjectileSpeed:       260,
+	AttackSound:           assets.AudioMagmaShot1,
+	ProjectileFireSound:   true,
+	ProjectileRotateSpeed: 4,
+	ProjectileImage:       assets.ImageMagmaBall,
+	TrailEffect:           ProjectileTrailMagma,
+	Explosion:             ProjectileExplosionLarge,
+	AlwaysExplodes:        true,
+})
+
 var AtomicBombWeapon = InitWeaponStats(&WeaponStats{
 	MaxTargets:          1,
 	BurstSize:           1,
@@ -103,50 +120,30 @@ var AtomicBombWeapon = InitWeaponStats(&WeaponStats{
 })
 
 var IonMortarCreepStats = &CreepStats{
-	Kind:      CreepTurret,
-	Image:     assets.ImageIonMortarCreep,
-	Speed:     0,
-	MaxHealth: 150,
+	Kind:  CreepTurret,
+	Image: assets.ImageIonMortarCreep,
 	Weapon: InitWeaponStats(&WeaponStats{
-		MaxTargets:          1,
-		BurstSize:           2,
-		BurstDelay:          0.4,
 		AttackSound:         assets.AudioIonMortarShot,
 		ProjectileFireSound: true,
-		AttackRange:         1000,
-		ImpactArea:          40,
-		ProjectileSpeed:     400,
-		Damage:              DamageValue{Health: 8, Energy: 50},
 		ProjectileImage:     assets.ImageIonMortarProjectile,
 		Explosion:           ProjectileExplosionIonBlast,
 		TrailEffect:         ProjectileTrailIonMortar,
-		Reload:              11.0,
 		FireOffsets:         []gmath.Vec{{Y: -12}},
 		TargetFlags:         TargetFlying,
 		AlwaysExplodes:      true,
 		ArcPower:            4.0,
-		Accuracy:            0.4,
 		RoundProjectile:     true,
 	}),
 	SuperWeapon: InitWeaponStats(&WeaponStats{
-		MaxTargets:          1,
-		BurstSize:           4,
-		BurstDelay:          0.2,
 		AttackSound:         assets.AudioIonMortarShot,
 		ProjectileFireSound: true,
-		AttackRange:         1050,
-		ImpactArea:          40,
-		ProjectileSpeed:     450,
-		Damage:              DamageValue{Health: 8, Energy: 50},
 		ProjectileImage:     assets.ImageSuperIonMortarProjectile,
 		Explosion:           ProjectileExplosionSuperIonBlast,
 		TrailEffect:         ProjectileTrailSuperIonMortar,
-		Reload:              12.0,
 		FireOffsets:         []gmath.Vec{{Y: -12}},
 		TargetFlags:         TargetFlying,
 		AlwaysExplodes:      true,
 		ArcPower:            4.0,
-		Accuracy:            0.4,
 		RoundProjectile:     true,
 	}),
 	Size:            28,
@@ -158,22 +155,13 @@ var IonMortarCreepStats = &CreepStats{
 }
 
 var TurretCreepStats = &CreepStats{
-	Kind:      CreepTurret,
-	Image:     assets.ImageTurretCreep,
-	Speed:     0,
-	MaxHealth: 120,
+	Kind:  CreepTurret,
+	Image: assets.ImageTurretCreep,
 	Weapon: InitWeaponStats(&WeaponStats{
-		MaxTargets:      1,
-		BurstSize:       1,
 		AttackSound:     assets.AudioMissile,
-		AttackRange:     290,
-		ImpactArea:      18,
-		ProjectileSpeed: 360,
-		Damage:          DamageValue{Health: 10},
 		ProjectileImage: assets.ImageMissile,
 		Explosion:       ProjectileExplosionNormal,
 		TrailEffect:     ProjectileTrailSmoke,
-		Reload:          3.5,
 		FireOffsets:     []gmath.Vec{{Y: -8}},
 		TargetFlags:     TargetFlying | TargetGround,
 	}),
@@ -186,23 +174,12 @@ var TurretCreepStats = &CreepStats{
 }
 
 var FortressCreepStats = &CreepStats{
-	Kind:      CreepFortress,
-	Image:     assets.ImageFortressCreep,
-	Speed:     0,
-	MaxHealth: 375,
+	Kind:  CreepFortress,
+	Image: assets.ImageFortressCreep,
 	Weapon: InitWeaponStats(&WeaponStats{
-		MaxTargets:      1,
 		AttackSound:     assets.AudioFortressAttack,
-		AttackRange:     350,
-		ImpactArea:      18,
-		ProjectileSpeed: 450,
-		Damage:          DamageValue{Health: 5, Energy: 10, Morale: 0.2},
-		BurstSize:       5,
-		AttacksPerBurst: 2,
-		BurstDelay:      0.1,
 		ProjectileImage: assets.ImageEnergySpear,
 		TrailEffect:     ProjectileTrailEnergySpear,
-		Reload:          2.7,
 		FireOffsets:     []gmath.Vec{{Y: -1}},
 		TargetFlags:     TargetFlying,
 		ArcPower:        0.4,
@@ -219,8 +196,6 @@ var FortressCreepStats = &CreepStats{
 var BaseCreepStats = &CreepStats{
 	Kind:            CreepBase,
 	Image:           assets.ImageCreepBase,
-	Speed:           0,
-	MaxHealth:       170,
 	Size:            60,
 	Disarmable:      false,
 	CanBeRepelled:   false,
@@ -232,8 +207,6 @@ var BaseCreepStats = &CreepStats{
 var CrawlerBaseCreepStats = &CreepStats{
 	Kind:            CreepCrawlerBase,
 	Image:           assets.ImageCrawlerCreepBase,
-	Speed:           0,
-	MaxHealth:       140,
 	Size:            60,
 	Disarmable:      false,
 	CanBeRepelled:   false,
@@ -245,8 +218,6 @@ var CrawlerBaseCreepStats = &CreepStats{
 var CrawlerBaseConstructionCreepStats = &CreepStats{
 	Kind:            CreepCrawlerBaseConstruction,
 	Image:           assets.ImageCrawlerCreepBase,
-	Speed:           0,
-	MaxHealth:       35,
 	Size:            40,
 	Disarmable:      false,
 	CanBeRepelled:   false,
@@ -258,8 +229,6 @@ var CrawlerBaseConstructionCreepStats = &CreepStats{
 var TurretConstructionCreepStats = &CreepStats{
 	Kind:            CreepTurretConstruction,
 	Image:           assets.ImageTurretCreep,
-	Speed:           0,
-	MaxHealth:       35,
 	Size:            40,
 	Disarmable:      false,
 	CanBeRepelled:   false,
@@ -271,8 +240,6 @@ var TurretConstructionCreepStats = &CreepStats{
 var IonMortarConstructionCreepStats = &CreepStats{
 	Kind:            CreepTurretConstruction,
 	Image:           assets.ImageIonMortarCreep,
-	Speed:           0,
-	MaxHealth:       35,
 	Size:            40,
 	Disarmable:      false,
 	CanBeRepelled:   false,
@@ -287,18 +254,9 @@ var WandererCreepStats = &CreepStats{
 	Image:       assets.ImageCreepTier1,
 	ShadowImage: assets.ImageSmallShadow,
 	Tier:        1,
-	Speed:       40,
-	MaxHealth:   14,
 	Weapon: InitWeaponStats(&WeaponStats{
-		MaxTargets:      1,
-		BurstSize:       1,
 		AttackSound:     assets.AudioWandererBeam,
-		AttackRange:     190,
-		ImpactArea:      10,
-		ProjectileSpeed: 400,
-		Damage:          DamageValue{Health: 4},
 		ProjectileImage: assets.ImageWandererProjectile,
-		Reload:          2.2,
 		TargetFlags:     TargetFlying | TargetGround,
 	}),
 	Disarmable:    true,
@@ -313,8 +271,6 @@ var WispCreepStats = &CreepStats{
 	AnimSpeed:     0.12,
 	ShadowImage:   assets.ImageMediumShadow,
 	Tier:          2,
-	Speed:         20,
-	MaxHealth:     35,
 	Disarmable:    false,
 	CanBeRepelled: false,
 	Flying:        true,
@@ -324,8 +280,6 @@ var WispCreepStats = &CreepStats{
 var WispLairCreepStats = &CreepStats{
 	Kind:          CreepWispLair,
 	Image:         assets.ImageWispLair,
-	Speed:         0,
-	MaxHealth:     160,
 	Size:          60,
 	Disarmable:    false,
 	CanBeRepelled: false,
@@ -338,19 +292,10 @@ var ServantCreepStats = &CreepStats{
 	Image:       assets.ImageServantCreep,
 	ShadowImage: assets.ImageMediumShadow,
 	Tier:        2,
-	Speed:       70,
-	MaxHealth:   70,
 	AnimSpeed:   0.15,
 	Weapon: InitWeaponStats(&WeaponStats{
-		MaxTargets:      1,
-		BurstSize:       1,
 		AttackSound:     assets.AudioServantShot,
-		AttackRange:     240,
-		ImpactArea:      10,
-		ProjectileSpeed: 340,
-		Damage:          DamageValue{Health: 4, Energy: 20},
 		ProjectileImage: assets.ImageServantProjectile,
-		Reload:          3.2,
 		TargetFlags:     TargetFlying | TargetGround,
 		Explosion:       ProjectileExplosionServant,
 	}),
@@ -365,22 +310,11 @@ var CrawlerCreepStats = &CreepStats{
 	Kind:      CreepCrawler,
 	Image:     assets.ImageCrawlerCreep,
 	AnimSpeed: 0.09,
-	Speed:     44,
-	MaxHealth: 18,
 	Weapon: InitWeaponStats(&WeaponStats{
-		MaxTargets:          1,
-		BurstSize:           2,
-		BurstDelay:          0.12,
-		AttackSound:         assets.AudioTankShot,
-		AttackRange:         160,
-		ImpactArea:          14,
-		ProjectileSpeed:     350,
-		Damage:              DamageValue{Health: 2},
-		ProjectileImage:     assets.ImageTankProjectile,
-		Reload:              1.7,
-		TargetFlags:         TargetFlying | TargetGround,
-		FireOffsets:         []gmath.Vec{{Y: -2}},
-		BuildingDamageBonus: -0.2,
+		AttackSound:     assets.AudioTankShot,
+		ProjectileImage: assets.ImageTankProjectile,
+		TargetFlags:     TargetFlying | TargetGround,
+		FireOffsets:     []gmath.Vec{{Y: -2}},
 	}),
 	Size:          24,
 	Disarmable:    true,
@@ -393,18 +327,9 @@ var EliteCrawlerCreepStats = &CreepStats{
 	Kind:      CreepCrawler,
 	Image:     assets.ImageEliteCrawlerCreep,
 	AnimSpeed: 0.09,
-	Speed:     40,
-	MaxHealth: 28,
 	Weapon: InitWeaponStats(&WeaponStats{
-		MaxTargets:      6,
-		BurstSize:       1,
 		AttackSound:     assets.AudioEliteCrawlerShot,
-		AttackRange:     160,
-		ImpactArea:      10,
-		ProjectileSpeed: 320,
-		Damage:          DamageValue{Health: 1},
 		ProjectileImage: assets.ImageEliteCrawlerProjectile,
-		Reload:          1.9,
 		TargetFlags:     TargetFlying | TargetGround,
 		FireOffsets:     []gmath.Vec{{Y: -2}},
 	}),
@@ -419,25 +344,13 @@ var HeavyCrawlerCreepStats = &CreepStats{
 	Kind:      CreepCrawler,
 	Image:     assets.ImageHeavyCrawlerCreep,
 	AnimSpeed: 0.16,
-	Speed:     30,
-	MaxHealth: 60,
 	Weapon: InitWeaponStats(&WeaponStats{
-		MaxTargets:          1,
-		BurstSize:           5,
-		BurstDelay:          0.1,
-		AttackSound:         assets.AudioHeavyCrawlerShot,
-		AttackRange:         260,
-		ImpactArea:          12,
-		ProjectileSpeed:     280,
-		Damage:              DamageValue{Health: 2},
-		ProjectileImage:     assets.ImageHeavyCrawlerProjectile,
-		Reload:              2.4,
-		TargetFlags:         TargetFlying | TargetGround,
-		FireOffsets:         []gmath.Vec{{Y: -2}},
-		Explosion:           ProjectileExplosionHeavyCrawlerLaser,
-		ArcPower:            1.5,
-		Accuracy:            0.85,
-		BuildingDamageBonus: 0.25,
+		AttackSound:     assets.AudioHeavyCrawlerShot,
+		ProjectileImage: assets.ImageHeavyCrawlerProjectile,
+		TargetFlags:     TargetFlying | TargetGround,
+		FireOffsets:     []gmath.Vec{{Y: -2}},
+		Explosion:       ProjectileExplosionHeavyCrawlerLaser,
+		ArcPower:        1.5,
 	}),
 	Size:          24,
 	Disarmable:    true,
@@ -450,40 +363,21 @@ var HowitzerCreepStats = &CreepStats{
 	Kind:      CreepHowitzer,
 	Image:     assets.ImageHowitzerCreep,
 	AnimSpeed: 0.2,
-	Speed:     10,
-	MaxHealth: 260,
 	Weapon: InitWeaponStats(&WeaponStats{
-		MaxTargets:          3,
-		BurstSize:           4,
-		BurstDelay:          0.2,
-		Accuracy:            0.7,
 		AttackSound:         assets.AudioHowitzerLaserShot,
-		AttackRange:         300,
-		ImpactArea:          14,
-		ProjectileSpeed:     480,
-		Damage:              DamageValue{Health: 2},
 		ProjectileImage:     assets.ImageHowitzerLaserProjectile,
-		Reload:              1.9,
 		TargetFlags:         TargetFlying,
 		FireOffsets:         []gmath.Vec{{Y: -2}},
 		ProjectileFireSound: true,
 	}),
 	SpecialWeapon: InitWeaponStats(&WeaponStats{
-		MaxTargets:          1,
-		BurstSize:           1,
 		AttackSound:         assets.AudioHowitzerShot,
-		AttackRange:         850,
-		ImpactArea:          26,
-		ProjectileSpeed:     150,
-		Damage:              DamageValue{Health: 20},
 		ProjectileImage:     assets.ImageHowitzerProjectile,
-		Reload:              16,
 		TargetFlags:         TargetGround,
 		Explosion:           ProjectileExplosionBigVertical,
 		TrailEffect:         ProjectileTrailSmoke,
 		AlwaysExplodes:      true,
 		ArcPower:            3,
-		Accuracy:            0.4,
 		ProjectileFireSound: true,
 	}),
 	Size:            32,
@@ -498,20 +392,10 @@ var StealthCrawlerCreepStats = &CreepStats{
 	Kind:      CreepCrawler,
 	Image:     assets.ImageStealthCrawlerCreep,
 	AnimSpeed: 0.09,
-	Speed:     70,
-	MaxHealth: 25,
 	Weapon: InitWeaponStats(&WeaponStats{
-		MaxTargets:          1,
-		BurstSize:           3,
-		BurstDelay:          0.4,
 		ProjectileFireSound: true,
 		AttackSound:         assets.AudioStealthCrawlerShot,
-		AttackRange:         200,
-		ImpactArea:          14,
-		ProjectileSpeed:     320,
-		Damage:              DamageValue{Health: 5, Slow: 2},
 		ProjectileImage:     assets.ImageStealthCrawlerProjectile,
-		Reload:              4.0,
 		TargetFlags:         TargetFlying | TargetGround,
 		FireOffsets:         []gmath.Vec{{Y: -2}},
 		Explosion:           ProjectileExplosionStealthLaser,
@@ -529,20 +413,10 @@ var AssaultCreepStats = &CreepStats{
 	AnimSpeed:   0.2,
 	ShadowImage: assets.ImageBigShadow,
 	Tier:        3,
-	Speed:       30,
-	MaxHealth:   100,
 	Weapon: InitWeaponStats(&WeaponStats{
-		MaxTargets:          1,
-		BurstSize:           1,
-		AttackSound:         assets.AudioAssaultShot,
-		AttackRange:         150,
-		ImpactArea:          10,
-		ProjectileSpeed:     460,
-		Damage:              DamageValue{Health: 3},
-		ProjectileImage:     assets.ImageAssaultProjectile,
-		Reload:              0.7,
-		TargetFlags:         TargetFlying | TargetGround,
-		BuildingDamageBonus: 0.6,
+		AttackSound:     assets.AudioAssaultShot,
+		ProjectileImage: assets.ImageAssaultProjectile,
+		TargetFlags:     TargetFlying | TargetGround,
 	}),
 	Disarmable:    true,
 	CanBeRepelled: true,
@@ -556,17 +430,9 @@ var DominatorCreepStats = &CreepStats{
 	Image:       assets.ImageCreepDominator,
 	ShadowImage: assets.ImageBigShadow,
 	Tier:        3,
-	Speed:       35,
-	MaxHealth:   175,
 	Weapon: InitWeaponStats(&WeaponStats{
-		MaxTargets:          1,
-		BurstSize:           1,
-		AttackSound:         assets.AudioDominatorShot,
-		AttackRange:         280,
-		Damage:              DamageValue{Health: 8, Morale: 0.8},
-		Reload:              1.65,
-		TargetFlags:         TargetFlying | TargetGround,
-		BuildingDamageBonus: -0.4,
+		AttackSound: assets.AudioDominatorShot,
+		TargetFlags: TargetFlying | TargetGround,
 	}),
 	BeamColor:     ge.RGB(0x7a51f2),
 	BeamWidth:     1,
@@ -583,8 +449,6 @@ var BuilderCreepStats = &CreepStats{
 	AnimSpeed:     0.1,
 	ShadowImage:   assets.ImageBigShadow,
 	Tier:          3,
-	Speed:         40,
-	MaxHealth:     190,
 	CanBeRepelled: false,
 	Disarmable:    false,
 	Flying:        true,
@@ -595,15 +459,8 @@ var UberBossCreepStats = &CreepStats{
 	Kind:        CreepUberBoss,
 	Image:       assets.ImageUberBoss,
 	ShadowImage: assets.ImageUberBossShadow,
-	Speed:       10,
-	MaxHealth:   600,
 	Weapon: InitWeaponStats(&WeaponStats{
-		MaxTargets:  5,
-		BurstSize:   1,
 		AttackSound: assets.AudioRailgun,
-		AttackRange: 220,
-		Damage:      DamageValue{Health: 9},
-		Reload:      2.8,
 		TargetFlags: TargetFlying | TargetGround,
 	}),
 	BeamSlideSpeed: 2,
@@ -619,24 +476,14 @@ var TemplarCreepStats = &CreepStats{
 	Image:       assets.ImageCreepTemplar,
 	ShadowImage: assets.ImageMediumShadow,
 	Tier:        2,
-	Speed:       40,
-	MaxHealth:   40,
 	Weapon: InitWeaponStats(&WeaponStats{
-		MaxTargets:  1,
-		BurstSize:   1,
 		AttackSound: assets.AudioTemplarAttack,
-		AttackRange: 300,
-		Damage:      DamageValue{Health: 1, Flags: DmgflagStun},
-		Reload:      2.6,
+		Damage:      DamageValue{Flags: DmgflagStun},
 		TargetFlags: TargetFlying,
 	}),
 	SuperWeapon: InitWeaponStats(&WeaponStats{
-		MaxTargets:  1,
-		BurstSize:   1,
 		AttackSound: assets.AudioTemplarAttack,
-		AttackRange: 300,
-		Damage:      DamageValue{Health: 1, Flags: DmgflagStun | DmgflagStunImproved},
-		Reload:      2.2,
+		Damage:      DamageValue{Flags: DmgflagStun | DmgflagStunImproved},
 		TargetFlags: TargetFlying,
 	}),
 	BeamExplosion:  assets.ImageStunExplosion,
@@ -655,22 +502,11 @@ var CenturionCreepStats = &CreepStats{
 	ShadowImage: assets.ImageMediumShadow,
 	AnimSpeed:   0.1,
 	Tier:        2,
-	Speed:       50,
-	MaxHealth:   55,
 	Weapon: InitWeaponStats(&WeaponStats{
-		MaxTargets:          1,
-		BurstSize:           2,
-		AttacksPerBurst:     1,
-		BurstDelay:          0.10,
 		ProjectileFireSound: true,
 		AttackSound:         assets.AudioCenturionShot,
-		AttackRange:         220,
-		ImpactArea:          10,
-		ProjectileSpeed:     425,
 		Explosion:           ProjectileExplosionPurpleZap,
-		Damage:              DamageValue{Health: 3},
 		ProjectileImage:     assets.ImageCenturionProjectile,
-		Reload:              2.45,
 		TargetFlags:         TargetFlying | TargetGround,
 		FireOffsets:         []gmath.Vec{{X: -10}, {X: 10}},
 	}),
@@ -686,24 +522,12 @@ var StunnerCreepStats = &CreepStats{
 	Image:       assets.ImageCreepTier2,
 	ShadowImage: assets.ImageMediumShadow,
 	Tier:        2,
-	Speed:       70,
-	MaxHealth:   40,
 	Weapon: InitWeaponStats(&WeaponStats{
-		MaxTargets:  3,
-		BurstSize:   1,
 		AttackSound: assets.AudioStunBeam,
-		AttackRange: 250,
-		Damage:      DamageValue{Health: 1, Energy: 40},
-		Reload:      2.8,
 		TargetFlags: TargetFlying | TargetGround,
 	}),
 	SuperWeapon: InitWeaponStats(&WeaponStats{
-		MaxTargets:  3,
-		BurstSize:   1,
 		AttackSound: assets.AudioStunBeam,
-		AttackRange: 250,
-		Damage:      DamageValue{Slow: 2, Health: 2, Energy: 55},
-		Reload:      2.6,
 		TargetFlags: TargetFlying | TargetGround,
 	}),
 	BeamSlideSpeed: 0.8,
@@ -36,6 +36,22 @@ func CalcDifficultyScore(config serverapi.ReplayLevelConfig, pointsAllocated int
 		if !config.GoldEnabled {
 			score -= 35
 		}
+		if config.Environment == int(EnvIce) {
+			// Crawlers are sliding faster over the frozen lakes.
+			score -= 5
+		}
+		if config.WorldEvents {
+			// Stealth crawlers are stronger during the night.
+			score -= 5
+		}
+		if config.Scavengers {
+			// Scavengers are fighting the creeps.
+			score += 5
+		}
+		if config.PlayersMode == serverapi.PmodeSinglePlayer {
+			// The colony is controlled by the opponent bot.
+			score += botProfileDifficulty(config.OpponentBotProfile)
+		}
 
 	case "classic":
 		if config.CoordinatorCreeps {
@@ -86,10 +102,29 @@ func CalcDifficultyScore(config serverapi.ReplayLevelConfig, pointsAllocated int
 		} else {
 			score += 20 - (config.OilRegenRate * 10)
 		}
+		if config.Environment == int(EnvIce) {
+			score += 10
+		}
+		if config.WorldEvents {
+			score += 5
+		}
+		if config.Scavengers {
+			// Scavengers trade with the colonies and fight the creeps.
+			score -= 5
+		}
+		if config.PlayersMode == serverapi.PmodePlayerAndBot {
+			score -= botProfileDifficulty(config.BotProfile)
+		}
 		score += 40 - (2 * pointsAllocated)
 		if config.StartingResources {
 			score -= 10
 		}
+		if config.TechTree {
+			score -= 10
+		}
+		if config.TurretUpgrades {
+			score -= 5
+		}
 		if config.CoreDesign != "ark" {
 			score += 5 - (config.Teleporters * 5)
 		}
@@ -124,10 +159,28 @@ func CalcDifficultyScore(config serverapi.ReplayLevelConfig, pointsAllocated int
 		} else {
 			score += 30 - (config.OilRegenRate * 15)
 		}
+		if config.Environment == int(EnvIce) {
+			score += 5
+		}
+		if config.WorldEvents {
+			score += 5
+		}
+		if config.Scavengers {
+			score -= 5
+		}
+		if config.PlayersMode == serverapi.PmodePlayerAndBot {
+			score -= botProfileDifficulty(config.BotProfile)
+		}
 		score += 40 - (2 * pointsAllocated)
 		if config.StartingResources {
 			score -= 5
 		}
+		if config.TechTree {
+			score -= 10
+		}
+		if config.TurretUpgrades {
+			score -= 5
+		}
 		if config.CoreDesign != "ark" {
 			score += 5 - (config.Teleporters * 5)
 		}
@@ -135,6 +188,14 @@ func CalcDifficultyScore(config serverapi.ReplayLevelConfig, pointsAllocated int
 
 	if config.FogOfWar {
 		score += 5
+		if config.Environment == int(EnvIce) {
+			// Blizzards reduce the vision.
+			score += 5
+		}
+		if config.WorldEvents {
+			// Nights reduce the vision.
+			score += 5
+		}
 	}
 
 	return gmath.ClampMin(score, 1)
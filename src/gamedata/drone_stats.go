@@ -53,6 +53,9 @@ const (
 	AgentCommander
 	AgentTargeter
 	AgentFirebug
+	AgentMinelayer
+	AgentShielder
+	AgentRelay
 
 	// Tier3
 	AgentGuardian
@@ -63,6 +66,10 @@ const (
 	AgentTrucker
 	AgentDevourer
 
+	// Tier4
+	AgentTitan
+	AgentHive
+
 	AgentKindNum
 
 	// Buildings (not real agents/drones)
@@ -76,6 +83,7 @@ const (
 	AgentDroneFactory
 	AgentPowerPlant
 	AgentRepulseTower
+	AgentControlPoint
 
 	// Other units
 	AgentRelict
@@ -90,7 +98,12 @@ func init() {
 	for k := ColonyAgentKind(agentFirst); k < agentLast; k++ {
 		DroneKindByName[k.String()] = k
 	}
+}
 
+// updateDroneDocs calculates the drone ratings that are
+// displayed in the drones overview.
+// It needs to be called after every drone stats change.
+func updateDroneDocs() {
 	type topEntry struct {
 		unit  *AgentStats
 		score float64
@@ -151,10 +164,10 @@ func init() {
 		var result topScores
 		for _, stats := range list {
 			if stats.Weapon != nil {
-				damage := stats.Weapon.Damage.Health * float64(stats.Weapon.BurstSize)
+				damage := float64(stats.Weapon.Damage.Health * float64(stats.Weapon.BurstSize))
 				switch stats.Kind {
 				case AgentPrism:
-					damage += (PrismDamagePerReflection * PrismMaxReflections) + PrismDamagePerMax
+					damage += float64(PrismDamagePerReflection*PrismMaxReflections) + PrismDamagePerMax
 				}
 				dps := damage / stats.Weapon.Reload
 				result.dpsTop = append(result.dpsTop, topEntry{unit: stats, score: dps})
@@ -163,7 +176,7 @@ func init() {
 				result.rangeTop = append(result.rangeTop, topEntry{unit: stats, score: attackRange})
 			}
 
-			defense := stats.MaxHealth + (stats.SelfRepair * 5)
+			defense := stats.MaxHealth + float64(stats.SelfRepair*5)
 			result.defenseTop = append(result.defenseTop, topEntry{unit: stats, score: defense})
 
 			upkeep := stats.Upkeep
@@ -210,18 +223,30 @@ func AllDroneStats() []*AgentStats {
 	for _, recipe := range Tier3agentMergeRecipes {
 		drones = append(drones, recipe.Result)
 	}
+	for _, recipe := range Tier4agentMergeRecipes {
+		drones = append(drones, recipe.Result)
+	}
 	return drones
 }
 
 func FindTurretByName(turretName string) *AgentStats {
+	if stats := findTurretByName(turretName); stats != nil {
+		return stats
+	}
+	panic(fmt.Sprintf("requested a non-existing turret: %s", turretName))
+}
+
+func findTurretByName(turretName string) *AgentStats {
 	for _, stats := range TurretStatsList {
 		if stats.Kind.String() == turretName {
 			return stats
 		}
 	}
-	panic(fmt.Sprintf("requested a non-existing turret: %s", turretName))
+	return nil
 }
 
+// The balance-related fields (costs, health, weapon stats) are
+// loaded from the stats file, see Ruleset.
 type AgentStats struct {
 	Kind         ColonyAgentKind
 	Image        resource.ImageID
@@ -290,12 +315,7 @@ var WorkerAgentStats = InitDroneStats(&AgentStats{
 	Size:        SizeSmall,
 	DiodeOffset: 5,
 	Tier:        1,
-	Cost:        8,
-	Upkeep:      2,
 	CanGather:   true,
-	MaxPayload:  1,
-	Speed:       80,
-	MaxHealth:   12,
 })
 
 var TargeterAgentStats = InitDroneStats(&AgentStats{
@@ -307,20 +327,11 @@ var TargeterAgentStats = InitDroneStats(&AgentStats{
 	PointCost:   3,
 	DiodeOffset: 4,
 	Tier:        2,
-	Cost:        25,
 	PowerScore:  15,
-	Upkeep:      13,
 	CanPatrol:   true,
-	Speed:       50,
-	MaxHealth:   20,
 	Weapon: InitWeaponStats(&WeaponStats{
-		AttackRange: 320,
-		Reload:      3.4,
-		EnergyCost:  1.5,
 		AttackSound: assets.AudioTargeterShot,
-		Damage:      DamageValue{Health: 2, Flags: DmgflagMark},
-		MaxTargets:  1,
-		BurstSize:   1,
+		Damage:      DamageValue{Flags: DmgflagMark},
 		TargetFlags: TargetFlying | TargetGround,
 	}),
 	BeamOpaqueTime: 0.1,
@@ -337,25 +348,13 @@ var CommanderAgentStats = InitDroneStats(&AgentStats{
 	PointCost:   2,
 	DiodeOffset: 5,
 	Tier:        2,
-	Cost:        28,
 	PowerScore:  10,
-	Upkeep:      9,
 	CanPatrol:   true,
-	Speed:       50,
-	MaxHealth:   30,
 	Weapon: InitWeaponStats(&WeaponStats{
-		AttackRange:               180,
-		AttackRangeMarkMultiplier: 2,
-		Reload:                    2.2,
-		AttackSound:               assets.AudioCommanderShot,
-		ProjectileImage:           assets.ImageCommanderProjectile,
-		ImpactArea:                14,
-		ProjectileSpeed:           240,
-		Damage:                    DamageValue{Health: 3},
-		MaxTargets:                1,
-		BurstSize:                 1,
-		TargetFlags:               TargetFlying | TargetGround,
-		Explosion:                 ProjectileExplosionCommanderLaser,
+		AttackSound:     assets.AudioCommanderShot,
+		ProjectileImage: assets.ImageCommanderProjectile,
+		TargetFlags:     TargetFlying | TargetGround,
+		Explosion:       ProjectileExplosionCommanderLaser,
 	}),
 })
 
@@ -366,57 +365,31 @@ var ScoutAgentStats = InitDroneStats(&AgentStats{
 	Size:        SizeSmall,
 	DiodeOffset: 5,
 	Tier:        1,
-	Cost:        10,
 	PowerScore:  8,
-	Upkeep:      4,
 	CanPatrol:   true,
-	Speed:       75,
-	MaxHealth:   12,
 	Weapon: InitWeaponStats(&WeaponStats{
-		AttackRange:               130,
-		AttackRangeMarkMultiplier: 2,
-		Reload:                    2.5,
-		AttackSound:               assets.AudioScoutShot,
-		ProjectileImage:           assets.ImageScoutProjectile,
-		ImpactArea:                10,
-		ProjectileSpeed:           180,
-		Damage:                    DamageValue{Health: 2, Disarm: 0.2},
-		MaxTargets:                1,
-		BurstSize:                 1,
-		TargetFlags:               TargetFlying | TargetGround,
-		Explosion:                 ProjectileExplosionScoutIon,
-		RoundProjectile:           true,
+		AttackSound:     assets.AudioScoutShot,
+		ProjectileImage: assets.ImageScoutProjectile,
+		TargetFlags:     TargetFlying | TargetGround,
+		Explosion:       ProjectileExplosionScoutIon,
+		RoundProjectile: true,
 	}),
 })
 
 var TruckerAgentStats = InitDroneStats(&AgentStats{
-	Kind:                 AgentTrucker,
-	IsFlying:             true,
-	Image:                assets.ImageTruckerAgent,
-	Size:                 SizeLarge,
-	DiodeOffset:          4,
-	Tier:                 3,
-	Cost:                 40,
-	PowerScore:           15,
-	Upkeep:               4,
-	CanGather:            true,
-	MaxPayload:           3,
-	Speed:                85,
-	MaxHealth:            45,
-	EnergyRegenRateBonus: 0.5,
+	Kind:        AgentTrucker,
+	IsFlying:    true,
+	Image:       assets.ImageTruckerAgent,
+	Size:        SizeLarge,
+	DiodeOffset: 4,
+	Tier:        3,
+	PowerScore:  15,
+	CanGather:   true,
 	Weapon: InitWeaponStats(&WeaponStats{
-		AttackRangeMarkMultiplier: 1.75,
-		AttackRange:               200,
-		Reload:                    2.6,
-		AttackSound:               assets.AudioCourierShot,
-		ProjectileImage:           assets.ImageCourierProjectile,
-		ImpactArea:                15,
-		ProjectileSpeed:           170,
-		Damage:                    DamageValue{Health: 2, Slow: 1, Morale: 0.2},
-		MaxTargets:                2,
-		BurstSize:                 1,
-		ProjectileRotateSpeed:     24,
-		TargetFlags:               TargetFlying,
+		AttackSound:           assets.AudioCourierShot,
+		ProjectileImage:       assets.ImageCourierProjectile,
+		ProjectileRotateSpeed: 24,
+		TargetFlags:           TargetFlying,
 	}),
 })
 
@@ -427,63 +400,38 @@ var CourierAgentStats = InitDroneStats(&AgentStats{
 	Size:        SizeMedium,
 	DiodeOffset: 5,
 	Tier:        2,
-	Cost:        20,
 	PowerScore:  9,
 	PointCost:   2,
-	Upkeep:      4,
 	CanGather:   true,
-	MaxPayload:  1,
-	Speed:       80,
-	MaxHealth:   30,
 	Weapon: InitWeaponStats(&WeaponStats{
-		AttackRangeMarkMultiplier: 1.75,
-		AttackRange:               140,
-		Reload:                    3.2,
-		AttackSound:               assets.AudioCourierShot,
-		ProjectileImage:           assets.ImageCourierProjectile,
-		ImpactArea:                10,
-		ProjectileSpeed:           170,
-		Damage:                    DamageValue{Health: 2, Slow: 1, Morale: 0.2},
-		MaxTargets:                1,
-		BurstSize:                 1,
-		ProjectileRotateSpeed:     24,
-		TargetFlags:               TargetFlying,
+		AttackSound:           assets.AudioCourierShot,
+		ProjectileImage:       assets.ImageCourierProjectile,
+		ProjectileRotateSpeed: 24,
+		TargetFlags:           TargetFlying,
 	}),
 	BeamSlideSpeed: 2.2,
 })
 
 var RedminerAgentStats = InitDroneStats(&AgentStats{
-	Kind:                 AgentRedminer,
-	IsFlying:             true,
-	Image:                assets.ImageRedminerAgent,
-	Size:                 SizeMedium,
-	DiodeOffset:          6,
-	Tier:                 2,
-	PointCost:            2,
-	Cost:                 16,
-	Upkeep:               6,
-	CanGather:            true,
-	MaxPayload:           1,
-	Speed:                75,
-	MaxHealth:            20,
-	EnergyRegenRateBonus: 0.2,
+	Kind:        AgentRedminer,
+	IsFlying:    true,
+	Image:       assets.ImageRedminerAgent,
+	Size:        SizeMedium,
+	DiodeOffset: 6,
+	Tier:        2,
+	PointCost:   2,
+	CanGather:   true,
 })
 
 var GeneratorAgentStats = InitDroneStats(&AgentStats{
-	Kind:                 AgentGenerator,
-	IsFlying:             true,
-	Image:                assets.ImageGeneratorAgent,
-	Size:                 SizeMedium,
-	DiodeOffset:          8,
-	Tier:                 2,
-	PointCost:            1,
-	Cost:                 16,
-	Upkeep:               2,
-	CanGather:            true,
-	MaxPayload:           1,
-	Speed:                90,
-	MaxHealth:            26,
-	EnergyRegenRateBonus: 1,
+	Kind:        AgentGenerator,
+	IsFlying:    true,
+	Image:       assets.ImageGeneratorAgent,
+	Size:        SizeMedium,
+	DiodeOffset: 8,
+	Tier:        2,
+	PointCost:   1,
+	CanGather:   true,
 })
 
 var ClonerAgentStats = InitDroneStats(&AgentStats{
@@ -494,12 +442,7 @@ var ClonerAgentStats = InitDroneStats(&AgentStats{
 	DiodeOffset: 5,
 	Tier:        2,
 	PointCost:   4,
-	Cost:        26,
-	Upkeep:      10,
 	CanGather:   true,
-	MaxPayload:  1,
-	Speed:       90,
-	MaxHealth:   16,
 })
 
 var RepairAgentStats = InitDroneStats(&AgentStats{
@@ -511,40 +454,25 @@ var RepairAgentStats = InitDroneStats(&AgentStats{
 	FireOffset:     -2,
 	Tier:           2,
 	PointCost:      4,
-	Cost:           26,
 	PowerScore:     5,
-	Upkeep:         18,
 	CanGather:      true,
 	HasSupport:     true,
-	MaxPayload:     1,
-	Speed:          100,
-	MaxHealth:      18,
-	SupportReload:  8.0,
-	SupportRange:   250,
 	BeamOpaqueTime: 0.2,
 	BeamSlideSpeed: 0.6,
 })
 
 var RechargerAgentStats = InitDroneStats(&AgentStats{
-	Kind:                 AgentRecharger,
-	IsFlying:             true,
-	Image:                assets.ImageRechargerAgent,
-	Size:                 SizeMedium,
-	DiodeOffset:          9,
-	Tier:                 2,
-	PointCost:            2,
-	Cost:                 15,
-	Upkeep:               6,
-	CanGather:            true,
-	HasSupport:           true,
-	MaxPayload:           1,
-	Speed:                90,
-	MaxHealth:            16,
-	EnergyRegenRateBonus: 0.2,
-	SupportReload:        7,
-	SupportRange:         350,
-	BeamOpaqueTime:       0.2,
-	BeamSlideSpeed:       0.8,
+	Kind:           AgentRecharger,
+	IsFlying:       true,
+	Image:          assets.ImageRechargerAgent,
+	Size:           SizeMedium,
+	DiodeOffset:    9,
+	Tier:           2,
+	PointCost:      2,
+	CanGather:      true,
+	HasSupport:     true,
+	BeamOpaqueTime: 0.2,
+	BeamSlideSpeed: 0.8,
 })
 
 var GuardianAgentStats = InitDroneStats(&AgentStats{
@@ -554,61 +482,37 @@ var GuardianAgentStats = InitDroneStats(&AgentStats{
 	Size:        SizeLarge,
 	DiodeOffset: -4,
 	Tier:        3,
-	Cost:        50,
 	PowerScore:  35,
-	Upkeep:      18,
 	CanPatrol:   true,
-	Speed:       55,
-	MaxHealth:   50,
-	SelfRepair:  0.75,
 	Weapon: InitWeaponStats(&WeaponStats{
-		AttackRangeMarkMultiplier: 1.5,
-		AttackRange:               260,
-		Reload:                    3.2,
-		EnergyCost:                2,
-		AttackSound:               assets.AudioDefenderShot,
-		Damage:                    DamageValue{Health: 3, Flags: DmgflagAggro},
-		MaxTargets:                2,
-		BurstSize:                 1,
-		TargetFlags:               TargetFlying | TargetGround,
+		AttackSound: assets.AudioDefenderShot,
+		Damage:      DamageValue{Flags: DmgflagAggro},
+		TargetFlags: TargetFlying | TargetGround,
 	}),
 	BeamOpaqueTime: 0.1,
 	BeamSlideSpeed: -1.6,
 })
 
 var ServoAgentStats = InitDroneStats(&AgentStats{
-	Kind:          AgentServo,
-	IsFlying:      true,
-	Image:         assets.ImageServoAgent,
-	Size:          SizeMedium,
-	DiodeOffset:   -4,
-	Tier:          2,
-	PointCost:     3,
-	Cost:          26,
-	Upkeep:        7,
-	CanGather:     true,
-	MaxPayload:    1,
-	Speed:         125,
-	MaxHealth:     18,
-	SupportReload: 8,
-	SupportRange:  310,
+	Kind:        AgentServo,
+	IsFlying:    true,
+	Image:       assets.ImageServoAgent,
+	Size:        SizeMedium,
+	DiodeOffset: -4,
+	Tier:        2,
+	PointCost:   3,
+	CanGather:   true,
 })
 
 var FreighterAgentStats = InitDroneStats(&AgentStats{
-	Kind:                 AgentFreighter,
-	IsFlying:             true,
-	Image:                assets.ImageFreighterAgent,
-	Size:                 SizeMedium,
-	DiodeOffset:          1,
-	Tier:                 2,
-	PointCost:            1,
-	Cost:                 18,
-	Upkeep:               0,
-	CanGather:            true,
-	MaxPayload:           3,
-	Speed:                70,
-	MaxHealth:            28,
-	EnergyRegenRateBonus: 0.5,
+	Kind:        AgentFreighter,
+	IsFlying:    true,
+	Image:       assets.ImageFreighterAgent,
+	Size:        SizeMedium,
+	DiodeOffset: 1,
+	Tier:        2,
+	PointCost:   1,
+	CanGather:   true,
 })
 
 var CripplerAgentStats = InitDroneStats(&AgentStats{
@@ -619,26 +523,14 @@ var CripplerAgentStats = InitDroneStats(&AgentStats{
 	DiodeOffset: 5,
 	Tier:        2,
 	PointCost:   2,
-	Cost:        16,
 	PowerScore:  9,
-	Upkeep:      4,
 	CanPatrol:   true,
 	CanCloak:    true,
-	Speed:       65,
-	MaxHealth:   18,
 	Weapon: InitWeaponStats(&WeaponStats{
-		AttackRangeMarkMultiplier: 1.5,
-		AttackRange:               255,
-		Reload:                    2.7,
-		AttackSound:               assets.AudioCripplerShot,
-		ProjectileImage:           assets.ImageCripplerProjectile,
-		ImpactArea:                10,
-		ProjectileSpeed:           250,
-		Damage:                    DamageValue{Health: 1, Slow: 2},
-		MaxTargets:                6,
-		BurstSize:                 1,
-		TargetFlags:               TargetFlying | TargetGround,
-		Explosion:                 ProjectileExplosionCripplerBlaster,
+		AttackSound:     assets.AudioCripplerShot,
+		ProjectileImage: assets.ImageCripplerProjectile,
+		TargetFlags:     TargetFlying | TargetGround,
+		Explosion:       ProjectileExplosionCripplerBlaster,
 	}),
 })
 
@@ -649,29 +541,15 @@ var StormbringerAgentStats = InitDroneStats(&AgentStats{
 	Size:        SizeLarge,
 	DiodeOffset: 7,
 	Tier:        3,
-	Cost:        50,
 	PowerScore:  40,
-	Upkeep:      18,
 	CanPatrol:   true,
 	CanGather:   true,
-	Speed:       100,
-	MaxHealth:   40,
 	Weapon: InitWeaponStats(&WeaponStats{
-		AttackRangeMarkMultiplier: 1.5,
-		AttackRange:               170,
-		Reload:                    2.6,
-		EnergyCost:                2,
-		AttackSound:               assets.AudioStormbringerShot,
-		ProjectileImage:           assets.ImageStormbringerProjectile,
-		ImpactArea:                18,
-		ProjectileSpeed:           200,
-		ProjectileRotateSpeed:     4,
-		Damage:                    DamageValue{Health: 1, Disarm: 0.2},
-		MaxTargets:                2,
-		BurstSize:                 4,
-		BurstDelay:                0.03,
-		TargetFlags:               TargetFlying | TargetGround,
-		Explosion:                 ProjectileExplosionShocker,
+		AttackSound:           assets.AudioStormbringerShot,
+		ProjectileImage:       assets.ImageStormbringerProjectile,
+		ProjectileRotateSpeed: 4,
+		TargetFlags:           TargetFlying | TargetGround,
+		Explosion:             ProjectileExplosionShocker,
 	}),
 })
 
@@ -690,25 +568,11 @@ var PrismAgentStats = InitDroneStats(&AgentStats{
 	DiodeOffset: 1,
 	Tier:        2,
 	PointCost:   3,
-	Cost:        26,
 	PowerScore:  26,
-	Upkeep:      12,
 	CanPatrol:   true,
-	Speed:       70,
-	MaxHealth:   34,
 	Weapon: InitWeaponStats(&WeaponStats{
-		AttackRangeMarkMultiplier: 1.25,
-		AttackRange:               230,
-		Reload:                    3.7,
-		EnergyCost:                3,
-		AttackSound:               assets.AudioPrismShot,
-		ImpactArea:                8,
-		ProjectileSpeed:           220,
-		Damage:                    DamageValue{Health: 4},
-		MaxTargets:                1,
-		BurstSize:                 1,
-		TargetFlags:               TargetFlying | TargetGround,
-		BuildingDamageBonus:       -0.25,
+		AttackSound: assets.AudioPrismShot,
+		TargetFlags: TargetFlying | TargetGround,
 	}),
 	BeamExplosion: assets.ImagePrismShotExplosion,
 })
@@ -722,24 +586,11 @@ var RoombaAgentStats = InitDroneStats(&AgentStats{
 	DiodeOffset: 2,
 	Tier:        2,
 	PointCost:   2,
-	Cost:        20,
 	PowerScore:  25,
-	Upkeep:      9,
-	Speed:       40,
-	MaxHealth:   55,
 	Weapon: InitWeaponStats(&WeaponStats{
-		AttackRange:         200,
-		Reload:              2,
 		AttackSound:         assets.AudioRoombaShot,
 		ProjectileImage:     assets.ImageRoombaProjectile,
-		ImpactArea:          10,
-		ProjectileSpeed:     400,
-		Damage:              DamageValue{Health: 4},
-		MaxTargets:          1,
-		BurstSize:           2,
-		BurstDelay:          0.65,
 		TargetFlags:         TargetFlying | TargetGround,
-		Accuracy:            0.8,
 		ProjectileFireSound: true,
 		Explosion:           ProjectileExplosionRoombaShot,
 		TrailEffect:         ProjectileTrailRoomba,
@@ -754,27 +605,13 @@ var FighterAgentStats = InitDroneStats(&AgentStats{
 	DiodeOffset: 1,
 	Tier:        2,
 	PointCost:   3,
-	Cost:        22,
 	PowerScore:  22,
-	Upkeep:      9,
 	CanPatrol:   true,
-	Speed:       90,
-	MaxHealth:   28,
 	Weapon: InitWeaponStats(&WeaponStats{
-		AttackRangeMarkMultiplier: 2,
-		AttackRange:               195,
-		Reload:                    1.9,
-		EnergyCost:                1.5,
-		AttackSound:               assets.AudioFighterBeam,
-		ProjectileImage:           assets.ImageFighterProjectile,
-		ImpactArea:                10,
-		ProjectileSpeed:           250,
-		Damage:                    DamageValue{Health: 5},
-		Explosion:                 ProjectileExplosionFighterLaser,
-		MaxTargets:                1,
-		BurstSize:                 1,
-		TargetFlags:               TargetFlying | TargetGround,
-		BuildingDamageBonus:       -0.4,
+		AttackSound:     assets.AudioFighterBeam,
+		ProjectileImage: assets.ImageFighterProjectile,
+		Explosion:       ProjectileExplosionFighterLaser,
+		TargetFlags:     TargetFlying | TargetGround,
 	}),
 })
 
@@ -787,32 +624,15 @@ var SkirmisherAgentStats = InitDroneStats(&AgentStats{
 	DiodeOffset: 3,
 	Tier:        2,
 	PointCost:   2,
-	Cost:        25,
 	PowerScore:  20,
-	Upkeep:      8,
 	CanPatrol:   true,
-	Speed:       80,
-	MaxHealth:   22,
-	SelfRepair:  0.5,
 	Weapon: InitWeaponStats(&WeaponStats{
-		AttackRangeMarkMultiplier: 1.5,
-		AttackRange:               160,
-		Reload:                    2,
-		EnergyCost:                1,
-		AttackSound:               assets.AudioSkirmisherShot,
-		ProjectileImage:           assets.ImageSkirmisherProjectile,
-		ImpactArea:                15,
-		ProjectileSpeed:           340,
-		Damage:                    DamageValue{Health: 2},
-		Explosion:                 ProjectileExplosionGreenZap,
-		MaxTargets:                1,
-		BurstSize:                 4,
-		AttacksPerBurst:           4,
-		BurstDelay:                0.3,
-		TargetFlags:               TargetFlying | TargetGround,
-		FlyingDamageBonus:         -0.5,
-		ArcPower:                  1.2,
-		RandArc:                   true,
+		AttackSound:     assets.AudioSkirmisherShot,
+		ProjectileImage: assets.ImageSkirmisherProjectile,
+		Explosion:       ProjectileExplosionGreenZap,
+		TargetFlags:     TargetFlying | TargetGround,
+		ArcPower:        1.2,
+		RandArc:         true,
 	}),
 })
 
@@ -825,22 +645,12 @@ var DefenderAgentStats = InitDroneStats(&AgentStats{
 	DiodeOffset: 6,
 	Tier:        2,
 	PointCost:   2,
-	Cost:        20,
 	PowerScore:  12,
-	Upkeep:      4,
 	CanPatrol:   true,
-	Speed:       55,
-	MaxHealth:   35,
 	Weapon: InitWeaponStats(&WeaponStats{
-		AttackRangeMarkMultiplier: 1.25,
-		AttackRange:               240,
-		Reload:                    3.5,
-		EnergyCost:                2,
-		AttackSound:               assets.AudioDefenderShot,
-		Damage:                    DamageValue{Health: 3, Flags: DmgflagAggro},
-		MaxTargets:                2,
-		BurstSize:                 1,
-		TargetFlags:               TargetFlying | TargetGround,
+		AttackSound: assets.AudioDefenderShot,
+		Damage:      DamageValue{Flags: DmgflagAggro},
+		TargetFlags: TargetFlying | TargetGround,
 	}),
 	BeamOpaqueTime: 0.1,
 	BeamSlideSpeed: -1.6,
@@ -856,56 +666,75 @@ var FirebugAgentStats = InitDroneStats(&AgentStats{
 	DiodeOffset: -1,
 	Tier:        2,
 	PointCost:   2,
-	Cost:        20,
 	PowerScore:  16,
-	Upkeep:      6,
 	CanPatrol:   true,
-	Speed:       85,
-	MaxHealth:   30,
 	Weapon: InitWeaponStats(&WeaponStats{
-		AttackRange: 55,
-		Reload:      2.4,
 		AttackSound: assets.AudioFirebugShot,
-		Damage:      DamageValue{Health: 13},
-		MaxTargets:  1,
-		BurstSize:   1,
 		TargetFlags: TargetFlying | TargetGround,
 	}),
 	BeamOpaqueTime: 0.15,
 	BeamSlideSpeed: 2.2,
 })
 
+var MinelayerAgentStats = InitDroneStats(&AgentStats{
+	ScoreCost:   MinelayerDroneCost,
+	Kind:        AgentMinelayer,
+	IsFlying:    true,
+	Image:       assets.ImageMinelayerAgent,
+	Size:        SizeMedium,
+	DiodeOffset: -5,
+	Tier:        2,
+	PointCost:   3,
+	PowerScore:  12,
+	CanGather:   true,
+})
+
+var ShielderAgentStats = InitDroneStats(&AgentStats{
+	ScoreCost:      ShielderDroneCost,
+	Kind:           AgentShielder,
+	IsFlying:       true,
+	Image:          assets.ImageShielderAgent,
+	Size:           SizeMedium,
+	DiodeOffset:    5,
+	FireOffset:     -2,
+	Tier:           2,
+	PointCost:      4,
+	PowerScore:     6,
+	CanGather:      true,
+	HasSupport:     true,
+	BeamOpaqueTime: 0.2,
+	BeamSlideSpeed: 0.6,
+})
+
+var RelayAgentStats = InitDroneStats(&AgentStats{
+	ScoreCost:   RelayDroneCost,
+	Kind:        AgentRelay,
+	IsFlying:    true,
+	Image:       assets.ImageRelayAgent,
+	Size:        SizeMedium,
+	DiodeOffset: 5,
+	Tier:        2,
+	PointCost:   3,
+	PowerScore:  5,
+	CanGather:   true,
+	HasSupport:  true,
+})
+
 var ScavengerAgentStats = InitDroneStats(&AgentStats{
-	Kind:          AgentScavenger,
-	IsFlying:      true,
-	Image:         assets.ImageScavengerAgent,
-	Size:          SizeMedium,
-	DiodeOffset:   -5,
-	Tier:          2,
-	PointCost:     2,
-	Cost:          18,
-	PowerScore:    14,
-	Upkeep:        6,
-	CanPatrol:     true,
-	HasSupport:    true,
-	Speed:         100,
-	MaxHealth:     22,
-	SupportReload: 16,
-	MaxPayload:    2,
+	Kind:        AgentScavenger,
+	IsFlying:    true,
+	Image:       assets.ImageScavengerAgent,
+	Size:        SizeMedium,
+	DiodeOffset: -5,
+	Tier:        2,
+	PointCost:   2,
+	PowerScore:  14,
+	CanPatrol:   true,
+	HasSupport:  true,
 	Weapon: InitWeaponStats(&WeaponStats{
-		AttackRangeMarkMultiplier: 1.5,
-		AttackRange:               160,
-		Reload:                    2.5,
-		AttackSound:               assets.AudioScavengerShot,
-		ProjectileImage:           assets.ImageScavengerProjectile,
-		ImpactArea:                8,
-		ProjectileSpeed:           250,
-		Damage:                    DamageValue{Health: 2},
-		MaxTargets:                1,
-		BurstSize:                 2,
-		BurstDelay:                0.12,
-		Accuracy:                  0.9,
-		TargetFlags:               TargetFlying | TargetGround,
+		AttackSound:     assets.AudioScavengerShot,
+		ProjectileImage: assets.ImageScavengerProjectile,
+		TargetFlags:     TargetFlying | TargetGround,
 	}),
 })
 
@@ -918,33 +747,17 @@ var ScarabAgentStats = InitDroneStats(&AgentStats{
 	DiodeOffset: -5,
 	Tier:        2,
 	PointCost:   3,
-	Cost:        20,
 	PowerScore:  9,
-	Upkeep:      8,
 	CanGather:   true,
 	CanPatrol:   true,
-	Speed:       65,
-	MaxHealth:   14,
-	MaxPayload:  1,
 	Weapon: InitWeaponStats(&WeaponStats{
-		AttackRangeMarkMultiplier: 1.5,
-		AttackRange:               150,
-		Reload:                    2.5,
-		AttackSound:               assets.AudioScarabShot,
-		ProjectileImage:           assets.ImageScarabProjectile,
-		ImpactArea:                8,
-		ProjectileSpeed:           350,
-		Damage:                    DamageValue{Health: 1.5},
-		MaxTargets:                1,
-		AttacksPerBurst:           2,
-		ArcPower:                  1,
-		RandArc:                   true,
-		RoundProjectile:           true,
-		BurstSize:                 2,
-		Accuracy:                  0.95,
-		TargetFlags:               TargetGround,
-		Explosion:                 ProjectileExplosionScarab,
-		BuildingDamageBonus:       0.25,
+		AttackSound:     assets.AudioScarabShot,
+		ProjectileImage: assets.ImageScarabProjectile,
+		ArcPower:        1,
+		RandArc:         true,
+		RoundProjectile: true,
+		TargetFlags:     TargetGround,
+		Explosion:       ProjectileExplosionScarab,
 	}),
 })
 
@@ -955,42 +768,24 @@ const (
 )
 
 var DevourerAgentStats = InitDroneStats(&AgentStats{
-	Kind:          AgentDevourer,
-	IsFlying:      true,
-	Image:         assets.ImageDevourerAgent,
-	Size:          SizeLarge,
-	DiodeOffset:   7,
-	Tier:          3,
-	Cost:          60,
-	PowerScore:    55,
-	Upkeep:        20,
-	CanPatrol:     true,
-	HasSupport:    true,
-	Speed:         75,
-	MaxHealth:     35,
-	MaxPayload:    1,
-	SupportReload: 25,
+	Kind:        AgentDevourer,
+	IsFlying:    true,
+	Image:       assets.ImageDevourerAgent,
+	Size:        SizeLarge,
+	DiodeOffset: 7,
+	Tier:        3,
+	PowerScore:  55,
+	CanPatrol:   true,
+	HasSupport:  true,
 	Weapon: InitWeaponStats(&WeaponStats{
-		AttackRangeMarkMultiplier: 1.5,
-		AttackRange:               200,
-		Reload:                    2.0,
-		AttackSound:               assets.AudioScarabShot,
-		ProjectileImage:           assets.ImageScarabProjectile,
-		ProjectileFireSound:       true,
-		ImpactArea:                10,
-		ProjectileSpeed:           350,
-		Damage:                    DamageValue{Health: 2},
-		Accuracy:                  0.95,
-		MaxTargets:                1,
-		AttacksPerBurst:           3,
-		ArcPower:                  1,
-		RandArc:                   true,
-		RoundProjectile:           true,
-		BurstDelay:                0.25,
-		BurstSize:                 3,
-		TargetFlags:               TargetFlying | TargetGround,
-		Explosion:                 ProjectileExplosionScarab,
-		BuildingDamageBonus:       0.25,
+		AttackSound:         assets.AudioScarabShot,
+		ProjectileImage:     assets.ImageScarabProjectile,
+		ProjectileFireSound: true,
+		ArcPower:            1,
+		RandArc:             true,
+		RoundProjectile:     true,
+		TargetFlags:         TargetFlying | TargetGround,
+		Explosion:           ProjectileExplosionScarab,
 	}),
 })
 
@@ -1003,30 +798,16 @@ var AntiAirAgentStats = InitDroneStats(&AgentStats{
 	DiodeOffset: 1,
 	Tier:        2,
 	PointCost:   2,
-	Cost:        28,
 	PowerScore:  26,
-	Upkeep:      11,
 	CanPatrol:   true,
-	Speed:       80,
-	MaxHealth:   18,
 	Weapon: InitWeaponStats(&WeaponStats{
-		AttackRangeMarkMultiplier: 1.5,
-		AttackRange:               270,
-		Reload:                    2.4,
-		AttackSound:               assets.AudioAntiAirMissiles,
-		ProjectileImage:           assets.ImageAntiAirMissile,
-		ImpactArea:                18,
-		ProjectileSpeed:           250,
-		Damage:                    DamageValue{Health: 2},
-		MaxTargets:                1,
-		BurstSize:                 4,
-		BurstDelay:                0.1,
-		Explosion:                 ProjectileExplosionNormal,
-		TrailEffect:               ProjectileTrailSmoke,
-		ArcPower:                  2,
-		Accuracy:                  0.9,
-		TargetFlags:               TargetFlying,
-		FireOffsets:               []gmath.Vec{{Y: -8}},
+		AttackSound:     assets.AudioAntiAirMissiles,
+		ProjectileImage: assets.ImageAntiAirMissile,
+		Explosion:       ProjectileExplosionNormal,
+		TrailEffect:     ProjectileTrailSmoke,
+		ArcPower:        2,
+		TargetFlags:     TargetFlying,
+		FireOffsets:     []gmath.Vec{{Y: -8}},
 	}),
 })
 
@@ -1039,28 +820,15 @@ var MortarAgentStats = InitDroneStats(&AgentStats{
 	DiodeOffset: 1,
 	Tier:        2,
 	PointCost:   2,
-	Cost:        18,
 	PowerScore:  14,
-	Upkeep:      7,
 	CanPatrol:   true,
-	Speed:       70,
-	MaxHealth:   30,
 	Weapon: InitWeaponStats(&WeaponStats{
-		AttackRangeMarkMultiplier: 1.75,
-		AttackRange:               370,
-		Reload:                    3.6,
-		AttackSound:               assets.AudioMortarShot,
-		ProjectileImage:           assets.ImageMortarProjectile,
-		ImpactArea:                18,
-		ProjectileSpeed:           180,
-		Damage:                    DamageValue{Health: 13},
-		MaxTargets:                1,
-		BurstSize:                 1,
-		Explosion:                 ProjectileExplosionNormal,
-		ArcPower:                  2.5,
-		Accuracy:                  0.9,
-		TargetFlags:               TargetGround,
-		RoundProjectile:           true,
+		AttackSound:     assets.AudioMortarShot,
+		ProjectileImage: assets.ImageMortarProjectile,
+		Explosion:       ProjectileExplosionNormal,
+		ArcPower:        2.5,
+		TargetFlags:     TargetGround,
+		RoundProjectile: true,
 	}),
 })
 
@@ -1071,23 +839,11 @@ var DestroyerAgentStats = InitDroneStats(&AgentStats{
 	Size:        SizeLarge,
 	DiodeOffset: 0,
 	Tier:        3,
-	Cost:        60,
 	PowerScore:  50,
-	Upkeep:      22,
 	CanPatrol:   true,
-	Speed:       85,
-	MaxHealth:   45,
 	Weapon: InitWeaponStats(&WeaponStats{
-		AttackRangeMarkMultiplier: 1.25,
-		AttackRange:               210,
-		Reload:                    1.8,
-		EnergyCost:                4.5,
-		AttackSound:               assets.AudioDestroyerBeam,
-		Damage:                    DamageValue{Health: 7},
-		MaxTargets:                1,
-		BurstSize:                 1,
-		TargetFlags:               TargetFlying | TargetGround,
-		BuildingDamageBonus:       -0.4,
+		AttackSound: assets.AudioDestroyerBeam,
+		TargetFlags: TargetFlying | TargetGround,
 	}),
 })
 
@@ -1098,45 +854,64 @@ var BomberAgentStats = InitDroneStats(&AgentStats{
 	Size:        SizeLarge,
 	DiodeOffset: 6,
 	Tier:        3,
-	Cost:        50,
 	PowerScore:  20,
-	Upkeep:      14,
 	CanPatrol:   true,
-	Speed:       65,
-	MaxHealth:   70,
 })
 
 var MarauderAgentStats = InitDroneStats(&AgentStats{
-	Kind:          AgentMarauder,
-	IsFlying:      true,
-	Image:         assets.ImageMarauderAgent,
-	Size:          SizeLarge,
-	DiodeOffset:   0,
-	Tier:          3,
-	Cost:          55,
-	PowerScore:    30,
-	Upkeep:        18,
-	CanPatrol:     true,
-	CanCloak:      true,
-	HasSupport:    true,
-	Speed:         100,
-	SupportReload: 14,
-	MaxHealth:     30,
-	MaxPayload:    3,
+	Kind:        AgentMarauder,
+	IsFlying:    true,
+	Image:       assets.ImageMarauderAgent,
+	Size:        SizeLarge,
+	DiodeOffset: 0,
+	Tier:        3,
+	PowerScore:  30,
+	CanPatrol:   true,
+	CanCloak:    true,
+	HasSupport:  true,
+	Weapon: InitWeaponStats(&WeaponStats{
+		ProjectileImage: assets.ImageMarauderProjectile,
+		AttackSound:     assets.AudioMarauderShot,
+		TargetFlags:     TargetFlying | TargetGround,
+		Explosion:       ProjectileExplosionCripplerBlaster,
+	}),
+})
+
+var TitanAgentStats = InitDroneStats(&AgentStats{
+	Kind:        AgentTitan,
+	IsFlying:    true,
+	Image:       assets.ImageTitanAgent,
+	Size:        SizeLarge,
+	DiodeOffset: 0,
+	Tier:        4,
+	PowerScore:  90,
+	CanPatrol:   true,
+	HasSupport:  true,
 	Weapon: InitWeaponStats(&WeaponStats{
-		AttackRangeMarkMultiplier: 1.25,
-		AttackRange:               255,
-		Reload:                    2.45,
-		ProjectileImage:           assets.ImageMarauderProjectile,
-		ImpactArea:                16,
-		ProjectileSpeed:           300,
-		AttackSound:               assets.AudioMarauderShot,
-		Damage:                    DamageValue{Health: 3.5, Slow: 2},
-		BurstSize:                 1,
-		MaxTargets:                3,
-		Accuracy:                  0.9,
-		TargetFlags:               TargetFlying | TargetGround,
-		Explosion:                 ProjectileExplosionCripplerBlaster,
+		AttackSound: assets.AudioDestroyerBeam,
+		TargetFlags: TargetFlying | TargetGround,
+	}),
+})
+
+var HiveAgentStats = InitDroneStats(&AgentStats{
+	Kind:        AgentHive,
+	IsFlying:    true,
+	Image:       assets.ImageHiveAgent,
+	Size:        SizeLarge,
+	DiodeOffset: 7,
+	Tier:        4,
+	PowerScore:  70,
+	CanPatrol:   true,
+	HasSupport:  true,
+	Weapon: InitWeaponStats(&WeaponStats{
+		AttackSound:         assets.AudioScarabShot,
+		ProjectileImage:     assets.ImageScarabProjectile,
+		ProjectileFireSound: true,
+		ArcPower:            1,
+		RandArc:             true,
+		RoundProjectile:     true,
+		TargetFlags:         TargetFlying | TargetGround,
+		Explosion:           ProjectileExplosionScarab,
 	}),
 })
 
@@ -1148,28 +923,14 @@ var RepellerAgentStats = InitDroneStats(&AgentStats{
 	DiodeOffset: 3,
 	Tier:        2,
 	PointCost:   3,
-	Cost:        28,
 	PowerScore:  14,
-	Upkeep:      9,
 	CanGather:   true,
-	MaxPayload:  1,
 	CanPatrol:   true,
-	Speed:       105,
-	MaxHealth:   24,
 	Weapon: InitWeaponStats(&WeaponStats{
-		AttackRangeMarkMultiplier: 1.25,
-		AttackRange:               170,
-		Reload:                    2.2,
-		EnergyCost:                1,
-		AttackSound:               assets.AudioRepellerBeam,
-		ProjectileImage:           assets.ImageRepellerProjectile,
-		ImpactArea:                10,
-		ProjectileSpeed:           200,
-		Damage:                    DamageValue{Health: 2, Disarm: 0.5},
-		MaxTargets:                2,
-		BurstSize:                 1,
-		TargetFlags:               TargetFlying | TargetGround,
-		Explosion:                 ProjectileExplosionShocker,
+		AttackSound:     assets.AudioRepellerBeam,
+		ProjectileImage: assets.ImageRepellerProjectile,
+		TargetFlags:     TargetFlying | TargetGround,
+		Explosion:       ProjectileExplosionShocker,
 	}),
 })
 
@@ -1182,49 +943,29 @@ var KamikazeAgentStats = InitDroneStats(&AgentStats{
 	DiodeOffset: 4,
 	Tier:        2,
 	PointCost:   1,
-	Cost:        14,
 	PowerScore:  12,
-	Upkeep:      3,
 	CanGather:   true,
-	MaxPayload:  1,
 	CanPatrol:   true,
-	Speed:       100,
-	MaxHealth:   20,
 })
 
 var DisintegratorAgentStats = InitDroneStats(&AgentStats{
-	ScoreCost:     DisintegratorDroneCost,
-	IsFlying:      true,
-	Kind:          AgentDisintegrator,
-	Image:         assets.ImageDisintegratorAgent,
-	Size:          SizeMedium,
-	DiodeOffset:   4,
-	Tier:          2,
-	PointCost:     3,
-	Cost:          18,
-	PowerScore:    16,
-	Upkeep:        7,
-	CanGather:     true,
-	HasSupport:    true,
-	MaxPayload:    1,
-	CanPatrol:     true,
-	Speed:         80,
-	MaxHealth:     20,
-	SupportReload: 8,
+	ScoreCost:   DisintegratorDroneCost,
+	IsFlying:    true,
+	Kind:        AgentDisintegrator,
+	Image:       assets.ImageDisintegratorAgent,
+	Size:        SizeMedium,
+	DiodeOffset: 4,
+	Tier:        2,
+	PointCost:   3,
+	PowerScore:  16,
+	CanGather:   true,
+	HasSupport:  true,
+	CanPatrol:   true,
 	Weapon: InitWeaponStats(&WeaponStats{
-		AttackRangeMarkMultiplier: 1.25,
-		AttackRange:               220,
-		AttackSound:               assets.AudioDisintegratorShot,
-		ProjectileImage:           assets.ImageDisintegratorProjectile,
-		ImpactArea:                18,
-		ProjectileSpeed:           210,
-		ProjectileRotateSpeed:     26,
-		Reload:                    10, // Approx, for balance calculations
-		Damage:                    DamageValue{Health: 16},
-		MaxTargets:                1,
-		BurstSize:                 1,
-		Explosion:                 ProjectileExplosionPurple,
-		TargetFlags:               TargetFlying | TargetGround,
-		GroundDamageBonus:         -0.5,
+		AttackSound:           assets.AudioDisintegratorShot,
+		ProjectileImage:       assets.ImageDisintegratorProjectile,
+		ProjectileRotateSpeed: 26,
+		Explosion:             ProjectileExplosionPurple,
+		TargetFlags:           TargetFlying | TargetGround,
 	}),
 })
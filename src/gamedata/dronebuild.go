@@ -42,6 +42,7 @@ func CreateDroneBuild(rng *gmath.Rand) []string {
 		RepairAgentStats,
 		RechargerAgentStats,
 		ClonerAgentStats,
+		ShielderAgentStats,
 	}
 	carryDrones := []*AgentStats{
 		FighterAgentStats,
@@ -68,6 +69,8 @@ func CreateDroneBuild(rng *gmath.Rand) []string {
 		DefenderAgentStats,
 		CommanderAgentStats,
 		TargeterAgentStats,
+		MinelayerAgentStats,
+		RelayAgentStats,
 	}
 	for round := 0; round < 3; round++ {
 		if points <= 0 {
@@ -9,4 +9,6 @@ var GameModeInfoMap = map[string]GameModeInfo{
 	"arena":     {ScoreCost: ArenaModeCost},
 	"inf_arena": {ScoreCost: InfArenaModeCost},
 	"reverse":   {ScoreCost: ReverseModeCost},
+	"koth":      {ScoreCost: KothModeCost},
+	"versus":    {ScoreCost: VersusModeCost},
 }
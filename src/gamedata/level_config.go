@@ -27,6 +27,33 @@ func (s WorldShape) String() string {
 	}
 }
 
+// WorldSymmetry defines how the generated map is reflected.
+// A symmetrical map is generated in halves: one half is
+// created as usual and then reflected onto the other half.
+type WorldSymmetry int
+
+const (
+	SymmetryNone WorldSymmetry = iota
+	SymmetryMirrorX
+	SymmetryMirrorY
+	SymmetryRotational
+)
+
+func (s WorldSymmetry) String() string {
+	switch s {
+	case SymmetryNone:
+		return "none"
+	case SymmetryMirrorX:
+		return "mirror_x"
+	case SymmetryMirrorY:
+		return "mirror_y"
+	case SymmetryRotational:
+		return "rotational"
+	default:
+		return "unknown"
+	}
+}
+
 type ExecutionMode int
 
 const (
@@ -60,11 +87,21 @@ func (pk PlayerKind) String() string {
 func MakeLevelConfig(mode ExecutionMode, config serverapi.ReplayLevelConfig) LevelConfig {
 	enemyBoss := config.RawGameMode == "classic" ||
 		config.RawGameMode == "reverse"
+	var extraDrones []*AgentStats
+	if config.Mission != "" {
+		// Campaign missions define these bits by themselves.
+		// This way a mission replay can be re-created from its config.
+		if m := findCampaignMission(config.Mission); m != nil {
+			enemyBoss = m.Objective == ObjectiveBoss
+			extraDrones = m.ExtraDrones
+		}
+	}
 	return LevelConfig{
 		ReplayLevelConfig: config,
 		ExecMode:          mode,
 		EliteResources:    true,
 		EnemyBoss:         enemyBoss,
+		ExtraDrones:       extraDrones,
 	}
 }
 
@@ -93,6 +130,10 @@ func (config *LevelConfig) Finalize() {
 		config.GameMode = ModeClassic
 	case "reverse":
 		config.GameMode = ModeReverse
+	case "koth":
+		config.GameMode = ModeKingOfTheHill
+	case "versus":
+		config.GameMode = ModeVersus
 	case "tutorial":
 		config.GameMode = ModeTutorial
 	default:
@@ -102,11 +143,29 @@ func (config *LevelConfig) Finalize() {
 	if config.GameMode == ModeTutorial {
 		config.Players = []PlayerKind{PlayerHuman}
 	} else if config.GameMode == ModeReverse {
+		// The first player always commands the creeps,
+		// the second player controls the colony.
 		switch config.PlayersMode {
 		case serverapi.PmodeSinglePlayer:
 			config.Players = []PlayerKind{PlayerHuman, PlayerComputer}
+		case serverapi.PmodePlayerAndBot:
+			config.Players = []PlayerKind{PlayerComputer, PlayerHuman}
+		case serverapi.PmodeTwoPlayers:
+			config.Players = []PlayerKind{PlayerHuman, PlayerHuman}
+		case serverapi.PmodeTwoBots:
+			config.Players = []PlayerKind{PlayerComputer, PlayerComputer}
+		default:
+			panic(fmt.Sprintf("unexpected mode: %d", config.PlayersMode))
+		}
+	} else if config.GameMode.IsCompetitive() {
+		// This is a competitive mode, it always needs two sides.
+		switch config.PlayersMode {
+		case serverapi.PmodePlayerAndBot:
+			config.Players = []PlayerKind{PlayerHuman, PlayerComputer}
 		case serverapi.PmodeTwoPlayers:
 			config.Players = []PlayerKind{PlayerHuman, PlayerHuman}
+		case serverapi.PmodeTwoBots:
+			config.Players = []PlayerKind{PlayerComputer, PlayerComputer}
 		default:
 			panic(fmt.Sprintf("unexpected mode: %d", config.PlayersMode))
 		}
@@ -135,6 +194,28 @@ func (config *LevelConfig) Finalize() {
 	config.DifficultyScore = CalcDifficultyScore(config.ReplayLevelConfig, pointsAllocated)
 }
 
+// PlayerBotProfile returns the profile of the computer player with the given index.
+//
+// In the modes with two rival sides, the bot that opposes the first human player
+// (or the first player, if there are no humans) uses the opponent profile.
+// All other bots use the ally profile.
+func (config *LevelConfig) PlayerBotProfile(playerIndex int) *BotProfile {
+	if config.GameMode != ModeReverse && !config.GameMode.IsCompetitive() {
+		return BotProfiles[config.BotProfile]
+	}
+	firstSide := 0
+	for i, pk := range config.Players {
+		if pk == PlayerHuman {
+			firstSide = i
+			break
+		}
+	}
+	if playerIndex != firstSide {
+		return BotProfiles[config.OpponentBotProfile]
+	}
+	return BotProfiles[config.BotProfile]
+}
+
 func (config *LevelConfig) Clone() LevelConfig {
 	cloned := *config
 
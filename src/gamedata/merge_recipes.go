@@ -8,12 +8,12 @@ import (
 //
 // yellow worker ++++++
 // yellow scout  ++++++
-// red worker    +++++
-// red scout     +++++++
-// green worker  +++++
+// red worker    ++++++
+// red scout     ++++++++
+// green worker  +++++++
 // green scout   +++++++
-// blue worker   ++++++
-// blue scout    ++++++
+// blue worker   +++++++
+// blue scout    +++++++
 //
 // Used:
 // mortar: green worker + red scout
@@ -40,178 +40,19 @@ import (
 // commander: yellow worker + green scout
 // targeter: green worker + green scout [! a non-standard combination]
 // firebug: blue worker + yellow scout
+// minelayer: red worker + green worker
+// shielder: blue worker + red scout
+// relay: green worker + blue scout
 //
-// Unused:
-// red worker + green worker
-// blue worker + red scout
-// green worker + blue scout
-var Tier2agentMergeRecipes = []AgentMergeRecipe{
-	{
-		Drone1: RecipeSubject{RedFactionTag, AgentWorker},
-		Drone2: RecipeSubject{BlueFactionTag, AgentScout},
-		Result: ClonerAgentStats,
-	},
-	{
-		Drone1: RecipeSubject{RedFactionTag, AgentScout},
-		Drone2: RecipeSubject{GreenFactionTag, AgentScout},
-		Result: FighterAgentStats,
-	},
-	{
-		Drone1: RecipeSubject{BlueFactionTag, AgentWorker},
-		Drone2: RecipeSubject{GreenFactionTag, AgentScout},
-		Result: RepairAgentStats,
-	},
-	{
-		Drone1: RecipeSubject{BlueFactionTag, AgentWorker},
-		Drone2: RecipeSubject{GreenFactionTag, AgentWorker},
-		Result: RechargerAgentStats,
-	},
-	{
-		Drone1: RecipeSubject{YellowFactionTag, AgentScout},
-		Drone2: RecipeSubject{GreenFactionTag, AgentScout},
-		Result: CripplerAgentStats,
-	},
-	{
-		Drone1: RecipeSubject{RedFactionTag, AgentWorker},
-		Drone2: RecipeSubject{YellowFactionTag, AgentWorker},
-		Result: RedminerAgentStats,
-	},
-	{
-		Drone1: RecipeSubject{YellowFactionTag, AgentWorker},
-		Drone2: RecipeSubject{BlueFactionTag, AgentWorker},
-		Result: ServoAgentStats,
-	},
-	{
-		Drone1: RecipeSubject{RedFactionTag, AgentWorker},
-		Drone2: RecipeSubject{YellowFactionTag, AgentScout},
-		Result: ScavengerAgentStats,
-	},
-	{
-		Drone1: RecipeSubject{RedFactionTag, AgentWorker},
-		Drone2: RecipeSubject{GreenFactionTag, AgentScout},
-		Result: CourierAgentStats,
-	},
-	{
-		Drone1: RecipeSubject{YellowFactionTag, AgentWorker},
-		Drone2: RecipeSubject{GreenFactionTag, AgentWorker},
-		Result: FreighterAgentStats,
-	},
-	{
-		Drone1: RecipeSubject{RedFactionTag, AgentWorker},
-		Drone2: RecipeSubject{BlueFactionTag, AgentWorker},
-		Result: RepellerAgentStats,
-	},
-	{
-		Drone1: RecipeSubject{GreenFactionTag, AgentWorker},
-		Drone2: RecipeSubject{YellowFactionTag, AgentScout},
-		Result: GeneratorAgentStats,
-	},
-	{
-		Drone1: RecipeSubject{RedFactionTag, AgentScout},
-		Drone2: RecipeSubject{RedFactionTag, AgentScout},
-		Result: RoombaAgentStats,
-	},
-	{
-		Drone1: RecipeSubject{GreenFactionTag, AgentWorker},
-		Drone2: RecipeSubject{RedFactionTag, AgentScout},
-		Result: MortarAgentStats,
-	},
-	{
-		Drone1: RecipeSubject{RedFactionTag, AgentScout},
-		Drone2: RecipeSubject{BlueFactionTag, AgentScout},
-		Result: AntiAirAgentStats,
-	},
-	{
-		Drone1: RecipeSubject{YellowFactionTag, AgentWorker},
-		Drone2: RecipeSubject{BlueFactionTag, AgentScout},
-		Result: DisintegratorAgentStats,
-	},
-	{
-		Drone1: RecipeSubject{YellowFactionTag, AgentWorker},
-		Drone2: RecipeSubject{GreenFactionTag, AgentScout},
-		Result: CommanderAgentStats,
-	},
-	{
-		Drone1: RecipeSubject{YellowFactionTag, AgentScout},
-		Drone2: RecipeSubject{BlueFactionTag, AgentScout},
-		Result: PrismAgentStats,
-	},
-	{
-		Drone1: RecipeSubject{GreenFactionTag, AgentWorker},
-		Drone2: RecipeSubject{GreenFactionTag, AgentScout},
-		Result: TargeterAgentStats,
-	},
-	{
-		Drone1: RecipeSubject{YellowFactionTag, AgentScout},
-		Drone2: RecipeSubject{RedFactionTag, AgentScout},
-		Result: DefenderAgentStats,
-	},
-	{
-		Drone1: RecipeSubject{BlueFactionTag, AgentWorker},
-		Drone2: RecipeSubject{BlueFactionTag, AgentScout},
-		Result: KamikazeAgentStats,
-	},
-	{
-		Drone1: RecipeSubject{GreenFactionTag, AgentScout},
-		Drone2: RecipeSubject{BlueFactionTag, AgentScout},
-		Result: SkirmisherAgentStats,
-	},
-	{
-		Drone1: RecipeSubject{YellowFactionTag, AgentWorker},
-		Drone2: RecipeSubject{RedFactionTag, AgentScout},
-		Result: ScarabAgentStats,
-	},
-	{
-		Drone1: RecipeSubject{BlueFactionTag, AgentWorker},
-		Drone2: RecipeSubject{YellowFactionTag, AgentScout},
-		Result: FirebugAgentStats,
-	},
-}
-
-var Tier3agentMergeRecipes = []AgentMergeRecipe{
-	{
-		Drone1:  RecipeSubject{Kind: AgentRepeller},
-		Drone2:  RecipeSubject{Kind: AgentGenerator},
-		EvoCost: 8,
-		Result:  StormbringerAgentStats,
-	},
-	{
-		Drone1:  RecipeSubject{Kind: AgentFreighter},
-		Drone2:  RecipeSubject{Kind: AgentCourier},
-		EvoCost: 8,
-		Result:  TruckerAgentStats,
-	},
-	{
-		Drone1:  RecipeSubject{Kind: AgentFighter},
-		Drone2:  RecipeSubject{Kind: AgentFighter},
-		EvoCost: 17,
-		Result:  DestroyerAgentStats,
-	},
-	{
-		Drone1:  RecipeSubject{Kind: AgentScarab},
-		Drone2:  RecipeSubject{Kind: AgentScarab},
-		EvoCost: 12,
-		Result:  DevourerAgentStats,
-	},
-	{
-		Drone1:  RecipeSubject{Kind: AgentScavenger},
-		Drone2:  RecipeSubject{Kind: AgentCrippler},
-		EvoCost: 10,
-		Result:  MarauderAgentStats,
-	},
-	{
-		Drone1:  RecipeSubject{Kind: AgentSkirmisher},
-		Drone2:  RecipeSubject{Kind: AgentDefender},
-		EvoCost: 9,
-		Result:  GuardianAgentStats,
-	},
-	{
-		Drone1:  RecipeSubject{Kind: AgentKamikaze},
-		Drone2:  RecipeSubject{Kind: AgentFirebug},
-		EvoCost: 7,
-		Result:  BomberAgentStats,
-	},
-}
+// Tier 3 drones are merged from two tier 2 drones and tier 4 (apex) drones
+// are merged from two tier 3 drones; these merges cost evolution points.
+//
+// The recipes are defined in the stats file (see Ruleset).
+var (
+	Tier2agentMergeRecipes []AgentMergeRecipe
+	Tier3agentMergeRecipes []AgentMergeRecipe
+	Tier4agentMergeRecipes []AgentMergeRecipe
+)
 
 type AgentMergeRecipe struct {
 	Drone1  RecipeSubject
@@ -239,15 +80,23 @@ func findRecipeByName(droneName string) AgentMergeRecipe {
 			return r
 		}
 	}
+	for _, r := range Tier4agentMergeRecipes {
+		if r.Result.Kind.String() == droneName {
+			return r
+		}
+	}
 	return AgentMergeRecipe{}
 }
 
 func FindRecipe(stats *AgentStats) AgentMergeRecipe {
 	var slice []AgentMergeRecipe
-	if stats.Tier == 2 {
+	switch stats.Tier {
+	case 2:
 		slice = Tier2agentMergeRecipes
-	} else {
+	case 3:
 		slice = Tier3agentMergeRecipes
+	default:
+		slice = Tier4agentMergeRecipes
 	}
 	for _, r := range slice {
 		if r.Result == stats {
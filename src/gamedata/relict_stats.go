@@ -20,24 +20,11 @@ var MegaRoombaAgentStats = InitDroneStats(&AgentStats{
 	Image:     assets.ImageMegaRoombaAgent,
 	Size:      SizeMedium,
 	Tier:      3,
-	Speed:     25,
-	MaxHealth: 170,
 	Weapon: InitWeaponStats(&WeaponStats{
-		AttackRange:         250,
-		Reload:              4.6,
 		AttackSound:         assets.AudioMegaRoombaShot1,
 		ProjectileImage:     assets.ImageMegaRoombaProjectile,
-		ImpactArea:          14,
-		ProjectileSpeed:     370,
-		Damage:              DamageValue{Health: 8, Slow: 2.5},
 		FireOffsets:         []gmath.Vec{{X: -8, Y: -1}, {X: 8, Y: -1}, {Y: 1}},
-		MaxTargets:          1,
-		BurstSize:           3,
-		AttacksPerBurst:     2,
-		BurstDelay:          0.6,
-		Accuracy:            0.85,
 		TargetFlags:         TargetFlying | TargetGround,
-		GroundDamageBonus:   -0.5,
 		ProjectileFireSound: true,
 	}),
 })
@@ -50,14 +37,8 @@ var RepulseTowerAgentStats = InitDroneStats(&AgentStats{
 	IsNeutral:  true,
 	Image:      assets.ImageRepulseTower,
 	Size:       SizeLarge,
-	Upkeep:     0,
-	MaxHealth:  140,
 	Weapon: InitWeaponStats(&WeaponStats{
-		AttackRange: 440,
-		Reload:      3.6,
-		MaxTargets:  4,
 		AttackSound: assets.AudioRepulseTowerAttack,
-		Damage:      DamageValue{Health: 4, Morale: 0.9},
 		TargetFlags: TargetGround | TargetFlying,
 	}),
 	FireOffset:     -14,
@@ -74,8 +55,18 @@ var PowerPlantAgentStats = InitDroneStats(&AgentStats{
 	IsNeutral:  true,
 	Image:      assets.ImagePowerPlantAgent,
 	Size:       SizeLarge,
-	Upkeep:     0,
-	MaxHealth:  140,
+})
+
+// ControlPointAgentStats describes a king of the hill mode capture zone.
+// Unlike other neutral buildings, it can be captured from the enemy.
+var ControlPointAgentStats = InitDroneStats(&AgentStats{
+	Kind:       AgentControlPoint,
+	IsFlying:   false,
+	IsTurret:   true,
+	IsBuilding: true,
+	IsNeutral:  true,
+	Image:      assets.ImagePowerPlantAgent,
+	Size:       SizeLarge,
 })
 
 var DroneFactoryAgentStats = InitDroneStats(&AgentStats{
@@ -86,8 +77,6 @@ var DroneFactoryAgentStats = InitDroneStats(&AgentStats{
 	IsNeutral:  true,
 	Image:      assets.ImageRelictFactoryAgent,
 	Size:       SizeLarge,
-	Upkeep:     0,
-	MaxHealth:  200,
 })
 
 var RelictAgentStats = InitDroneStats(&AgentStats{
@@ -98,18 +87,9 @@ var RelictAgentStats = InitDroneStats(&AgentStats{
 	Size:        SizeMedium,
 	DiodeOffset: 1,
 	Tier:        2,
-	Speed:       65,
-	MaxHealth:   25,
-	SelfRepair:  0.25,
 	Weapon: InitWeaponStats(&WeaponStats{
-		AttackRangeMarkMultiplier: 1.3,
-		AttackRange:               220,
-		Reload:                    2.5,
-		AttackSound:               assets.AudioRelictAgentShot,
-		Damage:                    DamageValue{Health: 6},
-		MaxTargets:                1,
-		TargetFlags:               TargetFlying | TargetGround,
-		BuildingDamageBonus:       -0.25,
+		AttackSound: assets.AudioRelictAgentShot,
+		TargetFlags: TargetFlying | TargetGround,
 	}),
 	BeamOpaqueTime: 0.15,
 	BeamSlideSpeed: 4.2,
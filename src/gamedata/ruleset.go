@@ -0,0 +1,528 @@
+package gamedata
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/quasilyte/roboden-game/assets"
+)
+
+// RulesetVersion is a stats file format version.
+// It should be incremented on every incompatible format change.
+const RulesetVersion = 1
+
+// RulesetChecksum identifies the balance data the game is running with.
+//
+// It's recorded inside the game replays: a replay can only be
+// simulated correctly with the same stats that were used during the game.
+var RulesetChecksum string
+
+// DefaultRulesetChecksum is a checksum of the embedded stats file.
+// It's a RulesetChecksum value for the non-modded game.
+var DefaultRulesetChecksum string
+
+// RulesetMatches reports whether the replay with the given ruleset checksum
+// can be simulated with the current ruleset.
+//
+// The replays recorded before the ruleset checksums were introduced
+// have an empty checksum; they were recorded with the default ruleset.
+func RulesetMatches(checksum string) bool {
+	if checksum == "" {
+		return RulesetChecksum == DefaultRulesetChecksum
+	}
+	return checksum == RulesetChecksum
+}
+
+// Ruleset is a data-driven part of the units definitions.
+//
+// The Go stats literals describe the units appearance and behavior
+// (images, sounds, flags), while the balance-related values like costs,
+// health, speed and weapon stats are loaded from the stats file.
+// The merge recipes are also defined by the stats file.
+//
+// A stats file is a JSON document:
+//
+//	{
+//	  "version": 1,
+//	  "agents": {
+//	    "Worker": {"cost": 8, "upkeep": 2, "speed": 80, "max_health": 12, "max_payload": 1}
+//	  },
+//	  "creeps": {
+//	    "turret": {"max_health": 120, "weapon": {"attack_range": 290, "damage": {"health": 10}}}
+//	  },
+//	  "tier2_recipes": [
+//	    {"drone1": "red worker", "drone2": "blue scout", "result": "Cloner"}
+//	  ],
+//	  "tier3_recipes": [
+//	    {"drone1": "Repeller", "drone2": "Generator", "evo_cost": 8, "result": "Stormbringer"}
+//	  ],
+//	  "tier4_recipes": [
+//	    {"drone1": "Destroyer", "drone2": "Guardian", "evo_cost": 20, "result": "Titan"}
+//	  ]
+//	}
+//
+// Agents are identified by their kind name (see DroneKindByName).
+// Creeps don't have unique kinds, so they're identified by their stats name
+// (see rulesetCreeps).
+//
+// All stats values are optional: an omitted value is not changed.
+type Ruleset struct {
+	Version int `json:"version"`
+
+	Agents map[string]*AgentStatsData `json:"agents"`
+	Creeps map[string]*CreepStatsData `json:"creeps"`
+
+	Tier2Recipes []MergeRecipeData `json:"tier2_recipes"`
+	Tier3Recipes []MergeRecipeData `json:"tier3_recipes"`
+	Tier4Recipes []MergeRecipeData `json:"tier4_recipes"`
+}
+
+type AgentStatsData struct {
+	Cost                 *float64 `json:"cost,omitempty"`
+	Upkeep               *int     `json:"upkeep,omitempty"`
+	Speed                *float64 `json:"speed,omitempty"`
+	MaxHealth            *float64 `json:"max_health,omitempty"`
+	SelfRepair           *float64 `json:"self_repair,omitempty"`
+	EnergyRegenRateBonus *float64 `json:"energy_regen_rate_bonus,omitempty"`
+	MaxPayload           *int     `json:"max_payload,omitempty"`
+	SupportReload        *float64 `json:"support_reload,omitempty"`
+	SupportRange         *float64 `json:"support_range,omitempty"`
+
+	Weapon *WeaponStatsData `json:"weapon,omitempty"`
+}
+
+type CreepStatsData struct {
+	Speed     *float64 `json:"speed,omitempty"`
+	MaxHealth *float64 `json:"max_health,omitempty"`
+
+	Weapon        *WeaponStatsData `json:"weapon,omitempty"`
+	SuperWeapon   *WeaponStatsData `json:"super_weapon,omitempty"`
+	SpecialWeapon *WeaponStatsData `json:"special_weapon,omitempty"`
+}
+
+type WeaponStatsData struct {
+	MaxTargets                *int     `json:"max_targets,omitempty"`
+	BurstSize                 *int     `json:"burst_size,omitempty"`
+	AttacksPerBurst           *int     `json:"attacks_per_burst,omitempty"`
+	BurstDelay                *float64 `json:"burst_delay,omitempty"`
+	Reload                    *float64 `json:"reload,omitempty"`
+	EnergyCost                *float64 `json:"energy_cost,omitempty"`
+	AttackRange               *float64 `json:"attack_range,omitempty"`
+	AttackRangeMarkMultiplier *float64 `json:"attack_range_mark_multiplier,omitempty"`
+	ImpactArea                *float64 `json:"impact_area,omitempty"`
+	ProjectileSpeed           *float64 `json:"projectile_speed,omitempty"`
+	Accuracy                  *float64 `json:"accuracy,omitempty"`
+
+	GroundDamageBonus   *float64 `json:"ground_damage_bonus,omitempty"`
+	FlyingDamageBonus   *float64 `json:"flying_damage_bonus,omitempty"`
+	BuildingDamageBonus *float64 `json:"building_damage_bonus,omitempty"`
+
+	Damage *DamageValueData `json:"damage,omitempty"`
+}
+
+type DamageValueData struct {
+	Health *float64 `json:"health,omitempty"`
+	Morale *float64 `json:"morale,omitempty"`
+	Disarm *float64 `json:"disarm,omitempty"`
+	Energy *float64 `json:"energy,omitempty"`
+	Slow   *float64 `json:"slow,omitempty"`
+}
+
+// MergeRecipeData describes a merge recipe.
+//
+// A tier 2 recipe subject is a "<faction> <kind>" pair, like "red worker".
+// A tier 3 recipe subject is a tier 2 drone kind name, like "Fighter".
+type MergeRecipeData struct {
+	Drone1  string  `json:"drone1"`
+	Drone2  string  `json:"drone2"`
+	EvoCost float64 `json:"evo_cost,omitempty"`
+	Result  string  `json:"result"`
+}
+
+var rulesetAgents = []*AgentStats{
+	// Drones.
+	WorkerAgentStats,
+	ScoutAgentStats,
+	FreighterAgentStats,
+	RedminerAgentStats,
+	CripplerAgentStats,
+	FighterAgentStats,
+	ScavengerAgentStats,
+	CourierAgentStats,
+	PrismAgentStats,
+	ServoAgentStats,
+	RepellerAgentStats,
+	DisintegratorAgentStats,
+	RepairAgentStats,
+	ClonerAgentStats,
+	RechargerAgentStats,
+	GeneratorAgentStats,
+	MortarAgentStats,
+	AntiAirAgentStats,
+	DefenderAgentStats,
+	KamikazeAgentStats,
+	SkirmisherAgentStats,
+	ScarabAgentStats,
+	RoombaAgentStats,
+	CommanderAgentStats,
+	TargeterAgentStats,
+	FirebugAgentStats,
+	MinelayerAgentStats,
+	ShielderAgentStats,
+	RelayAgentStats,
+	GuardianAgentStats,
+	StormbringerAgentStats,
+	DestroyerAgentStats,
+	BomberAgentStats,
+	MarauderAgentStats,
+	TruckerAgentStats,
+	DevourerAgentStats,
+	TitanAgentStats,
+	HiveAgentStats,
+
+	// Turrets.
+	GunpointAgentStats,
+	TetherBeaconAgentStats,
+	BeamTowerAgentStats,
+	HarvesterAgentStats,
+	SiegeAgentStats,
+
+	// Neutral buildings and other units.
+	DroneFactoryAgentStats,
+	PowerPlantAgentStats,
+	RepulseTowerAgentStats,
+	ControlPointAgentStats,
+	RelictAgentStats,
+	MegaRoombaAgentStats,
+}
+
+var rulesetCreeps = map[string]*CreepStats{
+	"ion_mortar":                IonMortarCreepStats,
+	"turret":                    TurretCreepStats,
+	"fortress":                  FortressCreepStats,
+	"base":                      BaseCreepStats,
+	"crawler_base":              CrawlerBaseCreepStats,
+	"crawler_base_construction": CrawlerBaseConstructionCreepStats,
+	"turret_construction":       TurretConstructionCreepStats,
+	"ion_mortar_construction":   IonMortarConstructionCreepStats,
+	"wanderer":                  WandererCreepStats,
+	"wisp":                      WispCreepStats,
+	"wisp_lair":                 WispLairCreepStats,
+	"servant":                   ServantCreepStats,
+	"crawler":                   CrawlerCreepStats,
+	"elite_crawler":             EliteCrawlerCreepStats,
+	"heavy_crawler":             HeavyCrawlerCreepStats,
+	"howitzer":                  HowitzerCreepStats,
+	"stealth_crawler":           StealthCrawlerCreepStats,
+	"assault":                   AssaultCreepStats,
+	"dominator":                 DominatorCreepStats,
+	"builder":                   BuilderCreepStats,
+	"uber_boss":                 UberBossCreepStats,
+	"templar":                   TemplarCreepStats,
+	"centurion":                 CenturionCreepStats,
+	"stunner":                   StunnerCreepStats,
+}
+
+func findRulesetAgent(name string) *AgentStats {
+	for _, stats := range rulesetAgents {
+		if stats.Kind.String() == name {
+			return stats
+		}
+	}
+	return nil
+}
+
+func init() {
+	data, err := assets.ReadRuleset()
+	if err != nil {
+		panic(err)
+	}
+	if err := LoadRuleset(data); err != nil {
+		panic(fmt.Sprintf("load stats file: %v", err))
+	}
+	DefaultRulesetChecksum = RulesetChecksum
+
+	// The missions refer to the drones and their recipes,
+	// so the campaign is loaded after the stats file.
+	data, err = assets.ReadCampaign()
+	if err != nil {
+		panic(err)
+	}
+	if err := LoadCampaign(data); err != nil {
+		panic(fmt.Sprintf("load campaign file: %v", err))
+	}
+}
+
+// LoadRuleset parses and applies the stats file data.
+//
+// It can be called several times: the embedded stats file
+// is loaded first, then an optional mod overlay is applied.
+// The stats values are replacing the old values.
+// A recipe replaces the existing recipe for the same drone
+// or it's added to the recipes list if there is no such recipe yet.
+func LoadRuleset(data []byte) error {
+	r, err := ParseRuleset(data)
+	if err != nil {
+		return err
+	}
+	if err := applyRuleset(r); err != nil {
+		return err
+	}
+
+	h := sha1.New()
+	h.Write([]byte(RulesetChecksum))
+	h.Write(data)
+	RulesetChecksum = hex.EncodeToString(h.Sum(nil))
+
+	updateDroneDocs()
+
+	return nil
+}
+
+func ParseRuleset(data []byte) (*Ruleset, error) {
+	var r Ruleset
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, err
+	}
+	if r.Version != RulesetVersion {
+		return nil, fmt.Errorf("unsupported version %d (want %d)", r.Version, RulesetVersion)
+	}
+	for name := range r.Agents {
+		if findRulesetAgent(name) == nil {
+			return nil, fmt.Errorf("unknown agent %q", name)
+		}
+	}
+	for name := range r.Creeps {
+		if rulesetCreeps[name] == nil {
+			return nil, fmt.Errorf("unknown creep %q", name)
+		}
+	}
+	for _, data := range r.Tier2Recipes {
+		if _, err := makeTier2Recipe(data); err != nil {
+			return nil, err
+		}
+	}
+	for _, data := range r.Tier3Recipes {
+		if _, err := makeTier3Recipe(data); err != nil {
+			return nil, err
+		}
+	}
+	for _, data := range r.Tier4Recipes {
+		if _, err := makeTier4Recipe(data); err != nil {
+			return nil, err
+		}
+	}
+	return &r, nil
+}
+
+func applyRuleset(r *Ruleset) error {
+	for name, data := range r.Agents {
+		stats := findRulesetAgent(name)
+		if data.Weapon != nil && stats.Weapon == nil {
+			return fmt.Errorf("%s: can't set weapon stats for a unit without a weapon", name)
+		}
+		applyAgentStats(stats, data)
+	}
+	for name, data := range r.Creeps {
+		stats := rulesetCreeps[name]
+		weapons := []struct {
+			stats *WeaponStats
+			data  *WeaponStatsData
+		}{
+			{stats.Weapon, data.Weapon},
+			{stats.SuperWeapon, data.SuperWeapon},
+			{stats.SpecialWeapon, data.SpecialWeapon},
+		}
+		for _, w := range weapons {
+			if w.data != nil && w.stats == nil {
+				return fmt.Errorf("%s: can't set weapon stats for a unit without a weapon", name)
+			}
+		}
+		applyCreepStats(stats, data)
+	}
+
+	for _, data := range r.Tier2Recipes {
+		recipe, err := makeTier2Recipe(data)
+		if err != nil {
+			return err
+		}
+		Tier2agentMergeRecipes = addMergeRecipe(Tier2agentMergeRecipes, recipe)
+	}
+	for _, data := range r.Tier3Recipes {
+		recipe, err := makeTier3Recipe(data)
+		if err != nil {
+			return err
+		}
+		Tier3agentMergeRecipes = addMergeRecipe(Tier3agentMergeRecipes, recipe)
+	}
+	for _, data := range r.Tier4Recipes {
+		recipe, err := makeTier4Recipe(data)
+		if err != nil {
+			return err
+		}
+		Tier4agentMergeRecipes = addMergeRecipe(Tier4agentMergeRecipes, recipe)
+	}
+
+	return nil
+}
+
+func addMergeRecipe(list []AgentMergeRecipe, recipe AgentMergeRecipe) []AgentMergeRecipe {
+	for i := range list {
+		if list[i].Result == recipe.Result {
+			list[i] = recipe
+			return list
+		}
+	}
+	return append(list, recipe)
+}
+
+func makeTier2Recipe(data MergeRecipeData) (AgentMergeRecipe, error) {
+	var recipe AgentMergeRecipe
+	result := findRulesetAgent(data.Result)
+	if result == nil || result.Tier != 2 {
+		return recipe, fmt.Errorf("%s: not a tier 2 drone", data.Result)
+	}
+	drone1, err := parseTier2RecipeSubject(data.Drone1)
+	if err != nil {
+		return recipe, fmt.Errorf("%s recipe: %w", data.Result, err)
+	}
+	drone2, err := parseTier2RecipeSubject(data.Drone2)
+	if err != nil {
+		return recipe, fmt.Errorf("%s recipe: %w", data.Result, err)
+	}
+	recipe.Drone1 = drone1
+	recipe.Drone2 = drone2
+	recipe.Result = result
+	return recipe, nil
+}
+
+func makeTier3Recipe(data MergeRecipeData) (AgentMergeRecipe, error) {
+	return makeEvoRecipe(data, 3)
+}
+
+func makeTier4Recipe(data MergeRecipeData) (AgentMergeRecipe, error) {
+	return makeEvoRecipe(data, 4)
+}
+
+// makeEvoRecipe creates a recipe that merges two drones of the previous tier;
+// unlike the tier 2 recipes, these recipes require evolution points.
+func makeEvoRecipe(data MergeRecipeData, tier int) (AgentMergeRecipe, error) {
+	var recipe AgentMergeRecipe
+	result := findRulesetAgent(data.Result)
+	if result == nil || result.Tier != tier {
+		return recipe, fmt.Errorf("%s: not a tier %d drone", data.Result, tier)
+	}
+	for i, name := range [2]string{data.Drone1, data.Drone2} {
+		stats := findRulesetAgent(name)
+		if stats == nil || stats.Tier != tier-1 {
+			return recipe, fmt.Errorf("%s recipe: %s is not a tier %d drone", data.Result, name, tier-1)
+		}
+		if i == 0 {
+			recipe.Drone1 = RecipeSubject{Kind: stats.Kind}
+		} else {
+			recipe.Drone2 = RecipeSubject{Kind: stats.Kind}
+		}
+	}
+	if data.EvoCost <= 0 {
+		return recipe, fmt.Errorf("%s recipe: evo cost should be positive", data.Result)
+	}
+	recipe.EvoCost = data.EvoCost
+	recipe.Result = result
+	return recipe, nil
+}
+
+func parseTier2RecipeSubject(s string) (RecipeSubject, error) {
+	factionName, kindName, ok := strings.Cut(s, " ")
+	if !ok {
+		return RecipeSubject{}, fmt.Errorf("malformed recipe subject %q", s)
+	}
+	var subject RecipeSubject
+	switch factionName {
+	case "yellow":
+		subject.Faction = YellowFactionTag
+	case "red":
+		subject.Faction = RedFactionTag
+	case "green":
+		subject.Faction = GreenFactionTag
+	case "blue":
+		subject.Faction = BlueFactionTag
+	default:
+		return subject, fmt.Errorf("unknown faction %q", factionName)
+	}
+	switch kindName {
+	case "worker":
+		subject.Kind = AgentWorker
+	case "scout":
+		subject.Kind = AgentScout
+	default:
+		return subject, fmt.Errorf("unknown tier 1 drone %q", kindName)
+	}
+	return subject, nil
+}
+
+func applyAgentStats(stats *AgentStats, data *AgentStatsData) {
+	setValue(&stats.Cost, data.Cost)
+	setValue(&stats.Upkeep, data.Upkeep)
+	setValue(&stats.Speed, data.Speed)
+	setValue(&stats.MaxHealth, data.MaxHealth)
+	setValue(&stats.SelfRepair, data.SelfRepair)
+	setValue(&stats.EnergyRegenRateBonus, data.EnergyRegenRateBonus)
+	setValue(&stats.MaxPayload, data.MaxPayload)
+	setValue(&stats.SupportReload, data.SupportReload)
+	setValue(&stats.SupportRange, data.SupportRange)
+	InitDroneStats(stats)
+
+	if data.Weapon != nil {
+		applyWeaponStats(stats.Weapon, data.Weapon)
+	}
+}
+
+func applyCreepStats(stats *CreepStats, data *CreepStatsData) {
+	setValue(&stats.Speed, data.Speed)
+	setValue(&stats.MaxHealth, data.MaxHealth)
+
+	if data.Weapon != nil {
+		applyWeaponStats(stats.Weapon, data.Weapon)
+	}
+	if data.SuperWeapon != nil {
+		applyWeaponStats(stats.SuperWeapon, data.SuperWeapon)
+	}
+	if data.SpecialWeapon != nil {
+		applyWeaponStats(stats.SpecialWeapon, data.SpecialWeapon)
+	}
+}
+
+func applyWeaponStats(stats *WeaponStats, data *WeaponStatsData) {
+	setValue(&stats.MaxTargets, data.MaxTargets)
+	setValue(&stats.BurstSize, data.BurstSize)
+	setValue(&stats.AttacksPerBurst, data.AttacksPerBurst)
+	setValue(&stats.BurstDelay, data.BurstDelay)
+	setValue(&stats.Reload, data.Reload)
+	setValue(&stats.EnergyCost, data.EnergyCost)
+	setValue(&stats.AttackRange, data.AttackRange)
+	setValue(&stats.AttackRangeMarkMultiplier, data.AttackRangeMarkMultiplier)
+	setValue(&stats.ImpactArea, data.ImpactArea)
+	setValue(&stats.ProjectileSpeed, data.ProjectileSpeed)
+	setValue(&stats.Accuracy, data.Accuracy)
+	setValue(&stats.GroundDamageBonus, data.GroundDamageBonus)
+	setValue(&stats.FlyingDamageBonus, data.FlyingDamageBonus)
+	setValue(&stats.BuildingDamageBonus, data.BuildingDamageBonus)
+	if data.Damage != nil {
+		setValue(&stats.Damage.Health, data.Damage.Health)
+		setValue(&stats.Damage.Morale, data.Damage.Morale)
+		setValue(&stats.Damage.Disarm, data.Damage.Disarm)
+		setValue(&stats.Damage.Energy, data.Damage.Energy)
+		setValue(&stats.Damage.Slow, data.Damage.Slow)
+	}
+	// Recalculate the derived values.
+	InitWeaponStats(stats)
+}
+
+func setValue[T any](dst *T, v *T) {
+	if v != nil {
+		*dst = *v
+	}
+}
@@ -0,0 +1,88 @@
+package gamedata
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedRuleset(t *testing.T) {
+	if RulesetChecksum == "" {
+		t.Fatal("empty ruleset checksum")
+	}
+	if len(Tier2agentMergeRecipes) == 0 || len(Tier3agentMergeRecipes) == 0 || len(Tier4agentMergeRecipes) == 0 {
+		t.Fatal("merge recipes are not loaded")
+	}
+	for _, stats := range rulesetAgents {
+		if err := validateAgentStats(stats); err != nil {
+			t.Fatalf("%s: %v", stats.Kind, err)
+		}
+	}
+	for name, stats := range rulesetCreeps {
+		if err := validateCreepStats(stats); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+	}
+}
+
+func TestParseRuleset(t *testing.T) {
+	tests := []struct {
+		src string
+		err string
+	}{
+		{`{"version": 1}`, ""},
+		{`{"version": 1, "agents": {"Worker": {"cost": 10}}}`, ""},
+		{`{"version": 1, "creeps": {"crawler": {"weapon": {"damage": {"health": 5}}}}}`, ""},
+		{`{"version": 1, "tier2_recipes": [{"drone1": "red worker", "drone2": "blue scout", "result": "Cloner"}]}`, ""},
+		{`{"version": 1, "tier3_recipes": [{"drone1": "Fighter", "drone2": "Fighter", "evo_cost": 10, "result": "Destroyer"}]}`, ""},
+		{`{"version": 1, "tier4_recipes": [{"drone1": "Destroyer", "drone2": "Guardian", "evo_cost": 20, "result": "Titan"}]}`, ""},
+
+		{`{}`, "unsupported version 0"},
+		{`{"version": 1, "agents": {"Foo": {}}}`, `unknown agent "Foo"`},
+		{`{"version": 1, "creeps": {"foo": {}}}`, `unknown creep "foo"`},
+		{`{"version": 1, "tier2_recipes": [{"drone1": "red worker", "drone2": "blue scout", "result": "Destroyer"}]}`, "not a tier 2 drone"},
+		{`{"version": 1, "tier2_recipes": [{"drone1": "redworker", "drone2": "blue scout", "result": "Cloner"}]}`, `malformed recipe subject "redworker"`},
+		{`{"version": 1, "tier2_recipes": [{"drone1": "pink worker", "drone2": "blue scout", "result": "Cloner"}]}`, `unknown faction "pink"`},
+		{`{"version": 1, "tier2_recipes": [{"drone1": "red fighter", "drone2": "blue scout", "result": "Cloner"}]}`, `unknown tier 1 drone "fighter"`},
+		{`{"version": 1, "tier3_recipes": [{"drone1": "Worker", "drone2": "Fighter", "evo_cost": 10, "result": "Destroyer"}]}`, "Worker is not a tier 2 drone"},
+		{`{"version": 1, "tier3_recipes": [{"drone1": "Fighter", "drone2": "Fighter", "result": "Destroyer"}]}`, "evo cost should be positive"},
+		{`{"version": 1, "tier4_recipes": [{"drone1": "Fighter", "drone2": "Guardian", "evo_cost": 20, "result": "Titan"}]}`, "Fighter is not a tier 3 drone"},
+		{`{"version": 1, "tier4_recipes": [{"drone1": "Destroyer", "drone2": "Guardian", "evo_cost": 20, "result": "Destroyer"}]}`, "not a tier 4 drone"},
+	}
+
+	for _, test := range tests {
+		_, err := ParseRuleset([]byte(test.src))
+		if test.err == "" {
+			if err != nil {
+				t.Fatalf("parse(%s): unexpected error: %v", test.src, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Fatalf("parse(%s):\nhave error %v\nwant %q", test.src, err, test.err)
+		}
+	}
+}
+
+func TestRulesetMatches(t *testing.T) {
+	if !RulesetMatches("") {
+		t.Fatal("legacy replays should match the default ruleset")
+	}
+	if !RulesetMatches(RulesetChecksum) {
+		t.Fatal("current ruleset checksum should match")
+	}
+	if RulesetMatches("bad") {
+		t.Fatal("unexpected match for a wrong checksum")
+	}
+
+	// Emulate a modded game.
+	defer func(checksum string) {
+		RulesetChecksum = checksum
+	}(RulesetChecksum)
+	RulesetChecksum = "modded"
+	if RulesetMatches("") {
+		t.Fatal("legacy replays should not match a modded ruleset")
+	}
+	if !RulesetMatches("modded") {
+		t.Fatal("modded ruleset checksum should match")
+	}
+}
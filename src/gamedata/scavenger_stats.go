@@ -0,0 +1,57 @@
+package gamedata
+
+import (
+	resource "github.com/quasilyte/ebitengine-resource"
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/roboden-game/assets"
+)
+
+// ScavengerStats describes a unit of the neutral scavengers faction.
+//
+// Scavengers are roaming the map in caravans;
+// they trade with the colonies and fight the creeps.
+type ScavengerStats struct {
+	NameTag   string
+	Image     resource.ImageID
+	AnimSpeed float64
+	Speed     float64
+	MaxHealth float64
+	Size      float64
+	Weapon    *WeaponStats
+
+	// Cargo is an amount of resources this unit carries for the trade.
+	Cargo float64
+}
+
+var ScavengerWagonStats = &ScavengerStats{
+	NameTag:   "scavenger_wagon",
+	Image:     assets.ImageScavengerWagon,
+	AnimSpeed: 0.16,
+	Speed:     32,
+	MaxHealth: 90,
+	Size:      24,
+	Cargo:     60,
+}
+
+var ScavengerGuardStats = &ScavengerStats{
+	NameTag:   "scavenger_guard",
+	Image:     assets.ImageScavengerGuard,
+	AnimSpeed: 0.09,
+	Speed:     32,
+	MaxHealth: 35,
+	Size:      24,
+	Weapon: InitWeaponStats(&WeaponStats{
+		MaxTargets:      1,
+		BurstSize:       2,
+		BurstDelay:      0.12,
+		Reload:          1.8,
+		AttackRange:     180,
+		ImpactArea:      14,
+		ProjectileSpeed: 350,
+		Damage:          DamageValue{Health: 3},
+		AttackSound:     assets.AudioTankShot,
+		ProjectileImage: assets.ImageTankProjectile,
+		TargetFlags:     TargetFlying | TargetGround,
+		FireOffsets:     []gmath.Vec{{Y: -2}},
+	}),
+}
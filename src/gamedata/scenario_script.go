@@ -0,0 +1,227 @@
+package gamedata
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ScenarioScript is a set of trigger->action rules that is loaded from a data file.
+//
+// Scripts make it possible to create a new scenario (like a campaign mission)
+// without touching the game code: all the conditions and actions are
+// interpreted by the staging script runner.
+// A mission script is a part of the campaign file (see LoadCampaign).
+//
+// A script is a JSON object:
+//
+//	{
+//	  "triggers": [
+//	    {
+//	      "id": "first_wave",
+//	      "conditions": [{"kind": "timer", "value": 120}],
+//	      "actions": [
+//	        {"kind": "message", "text": "campaign.mission.outpost.first_wave"},
+//	        {"kind": "spawn_creeps", "creep": "crawler", "count": 4, "pos": {"anchor": "colony", "x": 400}}
+//	      ]
+//	    }
+//	  ]
+//	}
+type ScenarioScript struct {
+	Triggers []ScriptTrigger `json:"triggers"`
+}
+
+type ScriptTrigger struct {
+	ID string `json:"id"`
+
+	// Repeat makes the trigger fire again after the cooldown.
+	// Non-repeating triggers fire only once.
+	Repeat   bool    `json:"repeat"`
+	Cooldown float64 `json:"cooldown"`
+
+	// All conditions should be satisfied for a trigger to fire.
+	// An empty conditions list is always satisfied.
+	Conditions []ScriptCondition `json:"conditions"`
+
+	Actions []ScriptAction `json:"actions"`
+}
+
+type ScriptConditionKind string
+
+const (
+	// timer: the scenario is running for at least Value seconds.
+	ScriptCondTimer ScriptConditionKind = "timer"
+
+	// colony_in_rect: any colony is inside the Rect.
+	ScriptCondColonyInRect ScriptConditionKind = "colony_in_rect"
+
+	// num_agents: the total number of colony drones is at least Value.
+	ScriptCondNumAgents ScriptConditionKind = "num_agents"
+
+	// num_colonies: the number of colonies is at least Value.
+	ScriptCondNumColonies ScriptConditionKind = "num_colonies"
+
+	// creeps_killed: at least Value creeps were defeated.
+	ScriptCondCreepsKilled ScriptConditionKind = "creeps_killed"
+
+	// resources: the total colonies resources amount is at least Value.
+	ScriptCondResources ScriptConditionKind = "resources"
+
+	// resources_gathered: at least Value resources were gathered (in total).
+	ScriptCondResourcesGathered ScriptConditionKind = "resources_gathered"
+
+	// trigger: the Trigger (by ID) was already fired.
+	ScriptCondTrigger ScriptConditionKind = "trigger"
+)
+
+type ScriptCondition struct {
+	Kind    ScriptConditionKind `json:"kind"`
+	Value   float64             `json:"value"`
+	Rect    ScriptRect          `json:"rect"`
+	Trigger string              `json:"trigger"`
+}
+
+type ScriptActionKind string
+
+const (
+	// spawn_creeps: create Count creeps of Creep type around Pos.
+	ScriptActSpawnCreeps ScriptActionKind = "spawn_creeps"
+
+	// message: show a Text message for Timer seconds.
+	// Text is a translation dictionary key.
+	ScriptActMessage ScriptActionKind = "message"
+
+	// add_resources: give Value resources to every player's main colony.
+	ScriptActAddResources ScriptActionKind = "add_resources"
+
+	// reveal_fog: remove the fog of war around Pos.
+	ScriptActRevealFog ScriptActionKind = "reveal_fog"
+
+	// camera_focus: center the players camera on Pos.
+	ScriptActCameraFocus ScriptActionKind = "camera_focus"
+
+	// victory: finish the scenario with a victory.
+	ScriptActVictory ScriptActionKind = "victory"
+)
+
+type ScriptAction struct {
+	Kind  ScriptActionKind `json:"kind"`
+	Value float64          `json:"value"`
+	Text  string           `json:"text"`
+	Timer float64          `json:"timer"`
+	Creep string           `json:"creep"`
+	Count int              `json:"count"`
+	Pos   ScriptPos        `json:"pos"`
+}
+
+// ScriptPos is a world position.
+// If Anchor is set, X and Y are used as an offset.
+type ScriptPos struct {
+	// Anchor is one of:
+	//	"" - absolute position
+	//	"colony" - the first player main colony position
+	//	"spawn" - the initial colony spawn position
+	//	"center" - the world center
+	Anchor string  `json:"anchor"`
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+}
+
+type ScriptRect struct {
+	X1 float64 `json:"x1"`
+	Y1 float64 `json:"y1"`
+	X2 float64 `json:"x2"`
+	Y2 float64 `json:"y2"`
+}
+
+var scriptCreepStats = map[string]*CreepStats{
+	"wanderer":        WandererCreepStats,
+	"stunner":         StunnerCreepStats,
+	"assault":         AssaultCreepStats,
+	"dominator":       DominatorCreepStats,
+	"crawler":         CrawlerCreepStats,
+	"elite_crawler":   EliteCrawlerCreepStats,
+	"heavy_crawler":   HeavyCrawlerCreepStats,
+	"stealth_crawler": StealthCrawlerCreepStats,
+	"howitzer":        HowitzerCreepStats,
+	"servant":         ServantCreepStats,
+	"templar":         TemplarCreepStats,
+}
+
+// FindScriptCreep returns the creep stats by its script name.
+// Only mobile creeps can be spawned by a script.
+func FindScriptCreep(name string) *CreepStats {
+	return scriptCreepStats[name]
+}
+
+func ParseScenarioScript(data []byte) (*ScenarioScript, error) {
+	var script ScenarioScript
+	if err := json.Unmarshal(data, &script); err != nil {
+		return nil, err
+	}
+	if err := validateScenarioScript(&script); err != nil {
+		return nil, err
+	}
+	return &script, nil
+}
+
+func validateScenarioScript(script *ScenarioScript) error {
+	ids := make(map[string]struct{}, len(script.Triggers))
+	for _, t := range script.Triggers {
+		if t.ID == "" {
+			return fmt.Errorf("trigger without an id")
+		}
+		if _, ok := ids[t.ID]; ok {
+			return fmt.Errorf("%s: duplicated trigger id", t.ID)
+		}
+		ids[t.ID] = struct{}{}
+	}
+
+	for _, t := range script.Triggers {
+		for _, cond := range t.Conditions {
+			switch cond.Kind {
+			case ScriptCondTimer, ScriptCondNumAgents, ScriptCondNumColonies, ScriptCondCreepsKilled, ScriptCondResources, ScriptCondResourcesGathered:
+				// OK.
+			case ScriptCondColonyInRect:
+				if cond.Rect.X1 > cond.Rect.X2 || cond.Rect.Y1 > cond.Rect.Y2 {
+					return fmt.Errorf("%s: invalid rect", t.ID)
+				}
+			case ScriptCondTrigger:
+				if _, ok := ids[cond.Trigger]; !ok {
+					return fmt.Errorf("%s: unknown trigger %q", t.ID, cond.Trigger)
+				}
+			default:
+				return fmt.Errorf("%s: unknown condition %q", t.ID, cond.Kind)
+			}
+		}
+		if len(t.Actions) == 0 {
+			return fmt.Errorf("%s: empty actions list", t.ID)
+		}
+		for _, act := range t.Actions {
+			switch act.Kind {
+			case ScriptActAddResources, ScriptActRevealFog, ScriptActCameraFocus, ScriptActVictory:
+				// OK.
+			case ScriptActMessage:
+				if act.Text == "" {
+					return fmt.Errorf("%s: message text is empty", t.ID)
+				}
+			case ScriptActSpawnCreeps:
+				if FindScriptCreep(act.Creep) == nil {
+					return fmt.Errorf("%s: unknown creep %q", t.ID, act.Creep)
+				}
+				if act.Count <= 0 {
+					return fmt.Errorf("%s: invalid creeps count", t.ID)
+				}
+			default:
+				return fmt.Errorf("%s: unknown action %q", t.ID, act.Kind)
+			}
+			switch act.Pos.Anchor {
+			case "", "colony", "spawn", "center":
+				// OK.
+			default:
+				return fmt.Errorf("%s: unknown pos anchor %q", t.ID, act.Pos.Anchor)
+			}
+		}
+	}
+
+	return nil
+}
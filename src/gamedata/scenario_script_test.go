@@ -0,0 +1,44 @@
+package gamedata
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseScenarioScript(t *testing.T) {
+	tests := []struct {
+		src string
+		err string
+	}{
+		{`{"triggers": []}`, ""},
+		{`{"triggers": [{"id": "a", "actions": [{"kind": "victory"}]}]}`, ""},
+		{`{"triggers": [{"id": "a", "conditions": [{"kind": "timer", "value": 10}], "actions": [{"kind": "message", "text": "x"}]}]}`, ""},
+		{`{"triggers": [{"id": "a", "actions": [{"kind": "spawn_creeps", "creep": "crawler", "count": 2, "pos": {"anchor": "colony"}}]}]}`, ""},
+		{`{"triggers": [{"id": "a", "actions": [{"kind": "victory"}]}, {"id": "b", "conditions": [{"kind": "trigger", "trigger": "a"}], "actions": [{"kind": "victory"}]}]}`, ""},
+
+		{`{"triggers": [{"actions": [{"kind": "victory"}]}]}`, "trigger without an id"},
+		{`{"triggers": [{"id": "a", "actions": [{"kind": "victory"}]}, {"id": "a", "actions": [{"kind": "victory"}]}]}`, "duplicated trigger id"},
+		{`{"triggers": [{"id": "a"}]}`, "empty actions list"},
+		{`{"triggers": [{"id": "a", "conditions": [{"kind": "foo"}], "actions": [{"kind": "victory"}]}]}`, `unknown condition "foo"`},
+		{`{"triggers": [{"id": "a", "conditions": [{"kind": "trigger", "trigger": "b"}], "actions": [{"kind": "victory"}]}]}`, `unknown trigger "b"`},
+		{`{"triggers": [{"id": "a", "conditions": [{"kind": "colony_in_rect", "rect": {"x1": 10, "x2": 5}}], "actions": [{"kind": "victory"}]}]}`, "invalid rect"},
+		{`{"triggers": [{"id": "a", "actions": [{"kind": "foo"}]}]}`, `unknown action "foo"`},
+		{`{"triggers": [{"id": "a", "actions": [{"kind": "message"}]}]}`, "message text is empty"},
+		{`{"triggers": [{"id": "a", "actions": [{"kind": "spawn_creeps", "creep": "uber_boss", "count": 1}]}]}`, `unknown creep "uber_boss"`},
+		{`{"triggers": [{"id": "a", "actions": [{"kind": "spawn_creeps", "creep": "crawler"}]}]}`, "invalid creeps count"},
+		{`{"triggers": [{"id": "a", "actions": [{"kind": "camera_focus", "pos": {"anchor": "foo"}}]}]}`, `unknown pos anchor "foo"`},
+	}
+
+	for _, test := range tests {
+		_, err := ParseScenarioScript([]byte(test.src))
+		if test.err == "" {
+			if err != nil {
+				t.Fatalf("parse(%s): unexpected error: %v", test.src, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Fatalf("parse(%s):\nhave error %v\nwant %q", test.src, err, test.err)
+		}
+	}
+}
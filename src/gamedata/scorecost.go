@@ -5,14 +5,18 @@ const (
 	ArenaModeCost    int = 2000
 	InfArenaModeCost int = 4000
 	ReverseModeCost  int = 7500
+	KothModeCost     int = 3000
+	VersusModeCost   int = 5000
 
 	SuperCreepsOptionCost       int = 5000
 	FortressOptionCost          int = 7000
 	IonMortarOptionCost         int = 10000
 	CoordinatorCreepsOptionCost int = 14000
 
-	ArkCoreCost  int = 3000
-	TankCoreCost int = 8000
+	ArkCoreCost    int = 3000
+	TankCoreCost   int = 8000
+	HiveCoreCost   int = 11000
+	BeaconCoreCost int = 13000
 
 	RoombaDroneCost        int = 1000
 	MortarDroneCost        int = 1500
@@ -26,6 +30,9 @@ const (
 	SkirmisherDroneCost    int = 12000
 	ScarabDroneCost        int = 14000
 	FirebugDroneCost       int = 16000
+	MinelayerDroneCost     int = 18000
+	ShielderDroneCost      int = 20000
+	RelayDroneCost         int = 22000
 
 	BeamTowerTurretCost int = 3000
 	HarvesterTurretCost int = 6000
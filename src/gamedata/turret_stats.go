@@ -42,8 +42,6 @@ var SiegeAgentStats = InitDroneStats(&AgentStats{
 	Image:        assets.ImageSiegeAgent,
 	PreviewImage: assets.ImageSiegeAgentIcon,
 	Size:         SizeLarge,
-	Upkeep:       15,
-	MaxHealth:    65,
 })
 
 var GunpointAgentStats = InitDroneStats(&AgentStats{
@@ -53,19 +51,9 @@ var GunpointAgentStats = InitDroneStats(&AgentStats{
 	IsBuilding: true,
 	Image:      assets.ImageGunpointAgent,
 	Size:       SizeLarge,
-	Upkeep:     12,
-	MaxHealth:  100,
 	Weapon: InitWeaponStats(&WeaponStats{
-		AttackRange:     280,
-		Reload:          2,
 		AttackSound:     assets.AudioGunpointShot,
 		ProjectileImage: assets.ImageGunpointProjectile,
-		ImpactArea:      10,
-		ProjectileSpeed: 280,
-		Damage:          DamageValue{Health: 4},
-		MaxTargets:      1,
-		BurstSize:       3,
-		BurstDelay:      0.1,
 		TargetFlags:     TargetGround,
 		FireOffsets:     []gmath.Vec{{Y: 6}},
 	}),
@@ -79,9 +67,6 @@ var HarvesterAgentStats = InitDroneStats(&AgentStats{
 	IsBuilding: false,
 	Image:      assets.ImageHarvesterAgent,
 	Size:       SizeLarge,
-	Upkeep:     14,
-	MaxHealth:  60,
-	Speed:      8,
 })
 
 var BeamTowerAgentStats = InitDroneStats(&AgentStats{
@@ -92,15 +77,8 @@ var BeamTowerAgentStats = InitDroneStats(&AgentStats{
 	IsBuilding: true,
 	Image:      assets.ImageBeamtowerAgent,
 	Size:       SizeLarge,
-	Upkeep:     18,
-	MaxHealth:  50,
 	Weapon: InitWeaponStats(&WeaponStats{
-		AttackRange: 380,
-		Reload:      3.1,
 		AttackSound: assets.AudioBeamTowerShot,
-		Damage:      DamageValue{Health: 15},
-		MaxTargets:  1,
-		BurstSize:   1,
 		TargetFlags: TargetFlying,
 	}),
 	FireOffset:     -16,
@@ -115,10 +93,6 @@ var TetherBeaconAgentStats = InitDroneStats(&AgentStats{
 	IsBuilding:     true,
 	Image:          assets.ImageTetherBeaconAgent,
 	Size:           SizeLarge,
-	Upkeep:         8,
-	MaxHealth:      75,
-	SupportReload:  10,
-	SupportRange:   450,
 	BeamSlideSpeed: 0.4,
 	HasSupport:     true,
 })
@@ -1,6 +1,7 @@
 package gamedata
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -27,11 +28,70 @@ func Validate() {
 		recipes[k1] = r.Result.Kind.String()
 		recipes[k2] = r.Result.Kind.String()
 	}
+
+	// The stats are loaded from the data file, so they need to be checked too.
+	for _, stats := range rulesetAgents {
+		if err := validateAgentStats(stats); err != nil {
+			panic(fmt.Sprintf("%s: %v", stats.Kind, err))
+		}
+	}
+	for name, stats := range rulesetCreeps {
+		if err := validateCreepStats(stats); err != nil {
+			panic(fmt.Sprintf("%s creep: %v", name, err))
+		}
+	}
+}
+
+func validateAgentStats(stats *AgentStats) error {
+	if stats.MaxHealth <= 0 {
+		return errors.New("max health should be positive")
+	}
+	if stats.Cost < 0 || stats.Upkeep < 0 || stats.Speed < 0 {
+		return errors.New("cost, upkeep and speed can't be negative")
+	}
+	if stats.Weapon != nil {
+		return validateWeaponStats(stats.Weapon)
+	}
+	return nil
+}
+
+func validateCreepStats(stats *CreepStats) error {
+	if stats.MaxHealth <= 0 {
+		return errors.New("max health should be positive")
+	}
+	if stats.Speed < 0 {
+		return errors.New("speed can't be negative")
+	}
+	for _, w := range [...]*WeaponStats{stats.Weapon, stats.SuperWeapon, stats.SpecialWeapon} {
+		if w == nil {
+			continue
+		}
+		if err := validateWeaponStats(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateWeaponStats(w *WeaponStats) error {
+	if w.Reload <= 0 {
+		return errors.New("weapon reload should be positive")
+	}
+	if w.AttackRange <= 0 {
+		return errors.New("weapon attack range should be positive")
+	}
+	if w.MaxTargets < 1 {
+		return errors.New("weapon max targets should be at least 1")
+	}
+	if w.BurstSize < 0 || w.BurstDelay < 0 || w.ImpactArea < 0 || w.ProjectileSpeed < 0 {
+		return errors.New("weapon burst and projectile values can't be negative")
+	}
+	return nil
 }
 
 func IsRunnableReplay(r serverapi.GameReplay) bool {
 	switch r.Config.RawGameMode {
-	case "classic", "arena", "inf_arena", "reverse":
+	case "classic", "arena", "inf_arena", "reverse", "koth", "versus":
 		return true
 	default:
 		return false
@@ -51,6 +111,14 @@ func IsSendableReplay(r serverapi.GameReplay) bool {
 	if r.Config.PlayersMode != serverapi.PmodeSinglePlayer {
 		return false
 	}
+	if r.Config.Mission != "" {
+		// Campaign missions have no leaderboards.
+		return false
+	}
+	if r.Mod != "" {
+		// Modded games are not comparable with the normal games.
+		return false
+	}
 	switch r.Config.RawGameMode {
 	case "classic", "arena", "reverse":
 		// There is no point in running a non-victory game replay
@@ -86,7 +154,7 @@ func IsValidReplay(replay serverapi.GameReplay) bool {
 	}
 
 	switch replay.Config.RawGameMode {
-	case "classic", "arena", "inf_arena", "reverse":
+	case "classic", "arena", "inf_arena", "reverse", "koth", "versus":
 		// OK.
 	default:
 		return false
@@ -94,6 +162,10 @@ func IsValidReplay(replay serverapi.GameReplay) bool {
 
 	cfg := &replay.Config
 
+	if cfg.Mission != "" && findCampaignMission(cfg.Mission) == nil {
+		return false
+	}
+
 	pointsAllocated := 0
 	for _, droneName := range cfg.Tier2Recipes {
 		recipe := findRecipeByName(droneName)
@@ -133,7 +205,10 @@ func IsValidReplay(replay serverapi.GameReplay) bool {
 		{cfg.OilRegenRate, 0, 3},
 		{cfg.Terrain, 0, 2},
 		{cfg.InterfaceMode, 0, 2},
-		{cfg.Environment, 0, 2},
+		{cfg.Environment, 0, 3},
+		{cfg.Symmetry, 0, 3},
+		{cfg.BotProfile, 0, len(BotProfiles) - 1},
+		{cfg.OpponentBotProfile, 0, len(BotProfiles) - 1},
 		{cfg.PlayersMode, serverapi.PmodeSinglePlayer, serverapi.PmodeTwoBots},
 	}
 	for _, o := range toValidate {
@@ -457,4 +457,51 @@ const SeasonNumber = 1
 // - Added Megaroomba relict
 // - Fixed a memory leak issue (solves a performance problem)
 // - Added screen filters
-const BuildNumber int = 21
+//
+// # Version 22
+//
+// The simulation results are different, the build 21 replays can't be reproduced.
+//
+// * New game modes:
+//   - Campaign: a sequence of authored missions with persistent unlocks
+//   - King of the hill: capture and hold the point in a two-player game
+//   - Versus: colonies fight each other
+//
+// * New features:
+//   - Event scripts for the campaign missions and the tutorial
+//   - Symmetric map generation option
+//   - Unit stats and merge recipes are loaded from a data file
+//   - Mods: a rules overlay, extra assets and translations from a mod folder
+//   - Colony tech tree unlocked with the evolution points
+//   - Turret upgrade and recycle cards
+//   - The turret upgrade track is selected by clicking the target turret; recycling refunds a half of the upgrades cost
+//   - Ice environment with frozen lakes, blizzards and frost crystals
+//   - Frozen lakes cost as much as a free land for the ground units
+//   - World events: nights, storms and meteor showers
+//   - Neutral scavenger caravans
+//   - Raid card: only an explicit raid makes the scavengers hostile
+//   - Save & Quit: an unfinished game can be continued later (it's re-simulated from the start)
+//   - The saved game resuming progress is displayed; a failed resuming is reported in the play menu
+//   - Computer player profiles, the ally and the enemy bots can have different profiles
+//   - The bot profiles affect the difficulty score
+//   - Creep commander bot for the Reverse mode
+//
+// * New content:
+//   - Minelayer, Shielder and Relay tier-2 drones
+//   - Tier-4 apex drones
+//   - Hive and Beacon colony cores
+//
+// * Performance:
+//   - Weighted A* and hierarchical pathfinding for ground and landing paths
+//   - Flow fields for the ground creep waves
+//   - A map change only rebuilds the flow fields that are affected by it
+//   - Uniform grid spatial index for creeps, agents, turrets and resources
+//
+// * Computer player (colony bots):
+//   - Relocation probes, patrol size and creep attack targets use a shared influence map
+//   - Bots attack the enemy colonies and their intruders in the Versus mode
+//
+// * Replays:
+//   - Deterministic math layer for cross-platform replays
+//   - The replay audit tool checks that the simulation arm64 code has no fused multiply-add instructions
+const BuildNumber int = 22
@@ -64,7 +64,10 @@ func GenerateRecipePreviews(scene *ge.Scene, needT3 bool) map[gamedata.RecipeSub
 		return recipeIcons
 	}
 
-	for _, recipe := range gamedata.Tier3agentMergeRecipes {
+	evoRecipes := make([]gamedata.AgentMergeRecipe, 0, len(gamedata.Tier3agentMergeRecipes)+len(gamedata.Tier4agentMergeRecipes))
+	evoRecipes = append(evoRecipes, gamedata.Tier3agentMergeRecipes...)
+	evoRecipes = append(evoRecipes, gamedata.Tier4agentMergeRecipes...)
+	for _, recipe := range evoRecipes {
 		subjects := []gamedata.RecipeSubject{
 			recipe.Drone1,
 			recipe.Drone2,
@@ -77,13 +80,16 @@ func GenerateRecipePreviews(scene *ge.Scene, needT3 bool) map[gamedata.RecipeSub
 			stats := gamedata.FindRecipeByName(s.Kind.String())
 			droneFrame := createSubImage(scene.LoadImage(stats.Result.Image))
 			frameSize := droneFrame.Bounds().Size()
+			// Tier 3 drones are too big to be scaled up.
+			scale := 2.0
+			if stats.Result.Tier == 3 {
+				scale = 1.0
+			}
 			img := ebiten.NewImage(48, 48)
 			var drawOptions ebiten.DrawImageOptions
-			drawOptions.GeoM.Scale(2, 2)
-			drawOptions.GeoM.Translate(24-float64(frameSize.X), 24-float64(frameSize.Y))
+			drawOptions.GeoM.Scale(scale, scale)
+			drawOptions.GeoM.Translate(24-float64(frameSize.X)*scale/2, 24-float64(frameSize.Y)*scale/2)
 			img.DrawImage(droneFrame, &drawOptions)
-			drawOptions.GeoM.Reset()
-			drawOptions.GeoM.Scale(2, 2)
 
 			recipeIcons[s] = img
 		}
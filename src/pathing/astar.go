@@ -0,0 +1,289 @@
+package pathing
+
+// AStar is an optimal path builder.
+//
+// Unlike GreedyBFS, it treats the layer values as the cell movement costs:
+// 0 is a blocked cell, 1 is a normal cell and the higher values
+// make the cell more expensive to walk through.
+// This makes it possible to prefer the free ground over the forests
+// while still allowing the forests to be crossed.
+//
+// The resulting path is still limited by the GridPath capacity.
+// If the optimal path is longer than that, its first steps are returned
+// and the result is marked as partial.
+type AStar struct {
+	numCols int
+	numRows int
+
+	open astarQueue
+
+	// These slices are indexed by the packed cell coord.
+	// The stamps are used to avoid the state clearing between the searches:
+	// the cell info is only valid if its stamp matches the current one.
+	costs  []uint32
+	dirs   []Direction
+	stamps []uint32
+	stamp  uint32
+
+	steps []Direction
+}
+
+// gridRect is a cells rectangle; its max bound is exclusive.
+type gridRect struct {
+	min GridCoord
+	max GridCoord
+}
+
+func (r gridRect) Contains(c GridCoord) bool {
+	return c.X >= r.min.X && c.X < r.max.X && c.Y >= r.min.Y && c.Y < r.max.Y
+}
+
+func NewAStar(numCols, numRows int) *AStar {
+	numCells := numCols * numRows
+	return &AStar{
+		numCols: numCols,
+		numRows: numRows,
+		open:    astarQueue{elems: make([]astarQueueElem, 0, 64)},
+		costs:   make([]uint32, numCells),
+		dirs:    make([]Direction, numCells),
+		stamps:  make([]uint32, numCells),
+		steps:   make([]Direction, 0, 64),
+	}
+}
+
+func (astar *AStar) BuildPath(g *Grid, from, to GridCoord, l GridLayer) BuildPathResult {
+	if from == to {
+		return BuildPathResult{}
+	}
+
+	bounds := gridRect{max: GridCoord{X: astar.numCols, Y: astar.numRows}}
+	if !bounds.Contains(from) {
+		return BuildPathResult{Finish: from, Partial: true}
+	}
+	finish, found := astar.search(g, from, to, l, bounds, layerMinCost(l))
+	astar.steps = astar.appendPath(astar.steps[:0], from, finish)
+	result := makeBuildPathResult(from, astar.steps)
+	if !found {
+		result.Partial = true
+	}
+	return result
+}
+
+// search runs the A* algorithm inside the given bounds.
+//
+// The returned coord is either the goal (then the second result is true)
+// or the closest to the goal reachable cell.
+//
+// With hscale=0 the heuristic is disabled and it becomes a Dijkstra search.
+// This is useful when the goal is not reachable (or does not exist) and
+// we're interested in the costs of all bounded reachable cells.
+func (astar *AStar) search(g *Grid, start, goal GridCoord, l GridLayer, bounds gridRect, hscale int) (GridCoord, bool) {
+	astar.nextStamp()
+	stamp := astar.stamp
+	costs := astar.costs
+	stamps := astar.stamps
+	dirs := astar.dirs
+
+	open := &astar.open
+	open.Reset()
+
+	startIndex := astar.packCoord(start)
+	stamps[startIndex] = stamp
+	costs[startIndex] = 0
+	dirs[startIndex] = DirNone
+
+	startDist := goal.Dist(start) * hscale
+	open.Push(astarQueueElem{coord: start, f: uint32(startDist), h: uint32(startDist)})
+
+	best := start
+	bestDist := startDist
+	bestCost := uint32(0)
+	for !open.IsEmpty() {
+		current := open.Pop()
+		if current.coord == goal {
+			return goal, true
+		}
+		currentCost := costs[astar.packCoord(current.coord)]
+		if current.f-current.h != currentCost {
+			// A stale queue element: this cell was already
+			// visited via a cheaper route.
+			continue
+		}
+
+		for dir, offset := range &neighborOffsets {
+			next := current.coord.Add(offset)
+			if !bounds.Contains(next) {
+				continue
+			}
+			v := g.getCellValue(uint(next.X), uint(next.Y), l)
+			if v == 0 {
+				continue
+			}
+			nextCost := currentCost + uint32(v)
+			nextIndex := astar.packCoord(next)
+			if stamps[nextIndex] == stamp && costs[nextIndex] <= nextCost {
+				continue
+			}
+			stamps[nextIndex] = stamp
+			costs[nextIndex] = nextCost
+			dirs[nextIndex] = Direction(dir)
+
+			nextDist := goal.Dist(next) * hscale
+			if nextDist < bestDist || (nextDist == bestDist && nextCost < bestCost) {
+				best = next
+				bestDist = nextDist
+				bestCost = nextCost
+			}
+			open.Push(astarQueueElem{
+				coord: next,
+				f:     nextCost + uint32(nextDist),
+				h:     uint32(nextDist),
+			})
+		}
+	}
+
+	return best, false
+}
+
+// costOf returns the cost of the cell reached by the last search.
+func (astar *AStar) costOf(c GridCoord) (uint32, bool) {
+	i := astar.packCoord(c)
+	if astar.stamps[i] != astar.stamp {
+		return 0, false
+	}
+	return astar.costs[i], true
+}
+
+// appendPath appends the steps leading from the start to the finish
+// of the last search; the steps are appended in the walking order.
+func (astar *AStar) appendPath(dst []Direction, from, to GridCoord) []Direction {
+	offset := len(dst)
+	pos := to
+	for pos != from {
+		d := astar.dirs[astar.packCoord(pos)]
+		dst = append(dst, d)
+		pos = pos.reversedMove(d)
+	}
+	// The steps were collected from the finish to the start.
+	tail := dst[offset:]
+	for i, j := 0, len(tail)-1; i < j; i, j = i+1, j-1 {
+		tail[i], tail[j] = tail[j], tail[i]
+	}
+	return dst
+}
+
+func (astar *AStar) nextStamp() {
+	astar.stamp++
+	if astar.stamp == 0 {
+		// An overflow: all old stamps can collide with the new ones.
+		for i := range astar.stamps {
+			astar.stamps[i] = 0
+		}
+		astar.stamp = 1
+	}
+}
+
+func (astar *AStar) packCoord(c GridCoord) uint {
+	return uint((c.Y * astar.numCols) + c.X)
+}
+
+// makeBuildPathResult converts the walking-order steps into a path result.
+// Only the first gridPathMaxLen steps can be stored inside the GridPath,
+// a longer path is truncated and reported as partial.
+func makeBuildPathResult(from GridCoord, steps []Direction) BuildPathResult {
+	var result BuildPathResult
+	if len(steps) > gridPathMaxLen {
+		steps = steps[:gridPathMaxLen]
+		result.Partial = true
+	}
+	// GridPath iterates its steps in reversed order.
+	for i := len(steps) - 1; i >= 0; i-- {
+		result.Steps.push(steps[i])
+	}
+	pos := from
+	for _, d := range steps {
+		pos = pos.Move(d)
+	}
+	result.Finish = pos
+	return result
+}
+
+// layerMinCost returns the cheapest non-blocked cell cost of the layer.
+// It's used to keep the heuristic admissible.
+func layerMinCost(l GridLayer) int {
+	minCost := 0
+	for tag := uint8(0); tag < MaxGridTags; tag++ {
+		v := int(l.Get(tag))
+		if v != 0 && (minCost == 0 || v < minCost) {
+			minCost = v
+		}
+	}
+	if minCost == 0 {
+		return 1
+	}
+	return minCost
+}
+
+type astarQueueElem struct {
+	coord GridCoord
+	f     uint32
+	h     uint32
+}
+
+// astarQueue is a binary min-heap ordered by the f score.
+// When scores are equal, the element that is closer to the goal goes first.
+type astarQueue struct {
+	elems []astarQueueElem
+}
+
+func (q *astarQueue) Reset() { q.elems = q.elems[:0] }
+
+func (q *astarQueue) IsEmpty() bool { return len(q.elems) == 0 }
+
+func (q *astarQueue) Push(e astarQueueElem) {
+	q.elems = append(q.elems, e)
+	elems := q.elems
+	i := len(elems) - 1
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !elems[i].less(elems[parent]) {
+			break
+		}
+		elems[i], elems[parent] = elems[parent], elems[i]
+		i = parent
+	}
+}
+
+func (q *astarQueue) Pop() astarQueueElem {
+	elems := q.elems
+	top := elems[0]
+	last := len(elems) - 1
+	elems[0] = elems[last]
+	elems = elems[:last]
+	i := 0
+	for {
+		smallest := i
+		left := 2*i + 1
+		right := left + 1
+		if left < len(elems) && elems[left].less(elems[smallest]) {
+			smallest = left
+		}
+		if right < len(elems) && elems[right].less(elems[smallest]) {
+			smallest = right
+		}
+		if smallest == i {
+			break
+		}
+		elems[i], elems[smallest] = elems[smallest], elems[i]
+		i = smallest
+	}
+	q.elems = elems
+	return top
+}
+
+func (e astarQueueElem) less(other astarQueueElem) bool {
+	if e.f != other.f {
+		return e.f < other.f
+	}
+	return e.h < other.h
+}
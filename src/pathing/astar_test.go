@@ -0,0 +1,211 @@
+package pathing_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/quasilyte/roboden-game/pathing"
+)
+
+func BenchmarkAStar(b *testing.B) {
+	l := pathing.MakeGridLayer(1, 0, 1, 1)
+	for i := range bfsTests {
+		test := bfsTests[i]
+		if !test.bench {
+			continue
+		}
+		numCols := len(test.path[0])
+		numRows := len(test.path)
+		b.Run(fmt.Sprintf("%s_%dx%d", test.name, numCols, numRows), func(b *testing.B) {
+			parseResult := testParseGrid(b, test.path)
+			astar := pathing.NewAStar(parseResult.numCols, parseResult.numRows)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				astar.BuildPath(parseResult.grid, parseResult.start, parseResult.dest, l)
+			}
+		})
+	}
+}
+
+func TestAStar(t *testing.T) {
+	l := pathing.MakeGridLayer(1, 0, 1, 1)
+	for i := range bfsTests {
+		test := bfsTests[i]
+		t.Run(test.name, func(t *testing.T) {
+			parseResult := testParseGrid(t, test.path)
+			astar := pathing.NewAStar(parseResult.numCols, parseResult.numRows)
+			grid := parseResult.grid
+
+			result := astar.BuildPath(grid, parseResult.start, parseResult.dest, l)
+			pos, pathCost := testWalkPath(t, grid, l, parseResult.start, result)
+
+			wantCost, reachable := testShortestPathCost(grid, l, parseResult.start, parseResult.dest)
+			switch {
+			case !reachable:
+				if !result.Partial {
+					t.Fatalf("%s: expected a partial result for unreachable dest", test.name)
+				}
+			case wantCost > 56:
+				if !result.Partial || result.Steps.Len() != 56 {
+					t.Fatalf("%s: expected a truncated path to be partial", test.name)
+				}
+			default:
+				if result.Partial || pos != parseResult.dest {
+					t.Fatalf("%s: expected a complete path, stopped at %v", test.name, pos)
+				}
+				if pathCost != wantCost {
+					t.Fatalf("%s: path is not optimal: have cost %d, want %d", test.name, pathCost, wantCost)
+				}
+			}
+		})
+	}
+}
+
+func TestAStarWeighted(t *testing.T) {
+	// The 'f' cells are forests: they're passable, but
+	// walking through them is 3 times more expensive.
+	// The 'F' cells are forests that are a part of the expected path.
+	tests := []struct {
+		name string
+		path []string
+	}{
+		{
+			name: "avoid_forest",
+			path: []string{
+				"AfffffB",
+				"$xxxxx$",
+				"$$$$$$$",
+			},
+		},
+		{
+			name: "cross_forest",
+			path: []string{
+				"xxxxxxxxxx",
+				"A$$F$$$$$B",
+				"xxxfxxxxxx",
+			},
+		},
+		{
+			name: "forest_shortcut",
+			path: []string{
+				"A$$F$$B",
+				".xxxxx.",
+				".......",
+			},
+		},
+	}
+
+	l := pathing.MakeGridLayer(1, 0, 3, 0)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			numCols := len(test.path[0])
+			numRows := len(test.path)
+			grid := pathing.NewGrid(pathing.CellSize*float64(numCols), pathing.CellSize*float64(numRows), 0)
+			var start, dest pathing.GridCoord
+			want := make(map[pathing.GridCoord]bool)
+			for y, row := range test.path {
+				for x, marker := range row {
+					cell := pathing.GridCoord{X: x, Y: y}
+					switch marker {
+					case 'x':
+						grid.SetCellTag(cell, 1)
+					case 'f':
+						grid.SetCellTag(cell, 2)
+					case 'F':
+						grid.SetCellTag(cell, 2)
+						want[cell] = true
+					case 'A':
+						start = cell
+					case 'B':
+						dest = cell
+					case '$':
+						want[cell] = true
+					}
+				}
+			}
+
+			astar := pathing.NewAStar(numCols, numRows)
+			result := astar.BuildPath(grid, start, dest, l)
+			pos := start
+			for result.Steps.HasNext() {
+				pos = pos.Move(result.Steps.Next())
+				if pos == dest {
+					continue
+				}
+				if !want[pos] {
+					t.Fatalf("unexpected path cell %v (path is %s)\n%s", pos, result.Steps, strings.Join(test.path, "\n"))
+				}
+			}
+			if pos != dest {
+				t.Fatalf("path ended at %v instead of %v", pos, dest)
+			}
+			if result.Steps.Len() != len(want)+1 {
+				t.Fatalf("path len mismatch: have %d, want %d", result.Steps.Len(), len(want)+1)
+			}
+		})
+	}
+}
+
+// testWalkPath follows the path and returns its final pos and cost.
+func testWalkPath(tb testing.TB, grid *pathing.Grid, l pathing.GridLayer, start pathing.GridCoord, result pathing.BuildPathResult) (pathing.GridCoord, int) {
+	tb.Helper()
+
+	pos := start
+	cost := 0
+	path := result.Steps
+	for path.HasNext() {
+		pos = pos.Move(path.Next())
+		v := grid.GetCellValue(pos, l)
+		if v == 0 {
+			tb.Fatalf("path goes through a blocked %v cell", pos)
+		}
+		cost += int(v)
+	}
+	if pos != result.Finish {
+		tb.Fatalf("path ends at %v, but the finish is %v", pos, result.Finish)
+	}
+	return pos, cost
+}
+
+// testShortestPathCost is a simple reference Dijkstra implementation.
+func testShortestPathCost(grid *pathing.Grid, l pathing.GridLayer, from, to pathing.GridCoord) (int, bool) {
+	numCols, numRows := grid.Size()
+	dist := make([]int, numCols*numRows)
+	for i := range dist {
+		dist[i] = -1
+	}
+	dist[from.Y*numCols+from.X] = 0
+	frontier := []pathing.GridCoord{from}
+	for len(frontier) != 0 {
+		// Pick the cheapest frontier cell; it's slow, but obviously correct.
+		bestIndex := 0
+		for i, c := range frontier {
+			if dist[c.Y*numCols+c.X] < dist[frontier[bestIndex].Y*numCols+frontier[bestIndex].X] {
+				bestIndex = i
+			}
+		}
+		current := frontier[bestIndex]
+		frontier[bestIndex] = frontier[len(frontier)-1]
+		frontier = frontier[:len(frontier)-1]
+		if current == to {
+			return dist[current.Y*numCols+current.X], true
+		}
+		for _, d := range []pathing.Direction{pathing.DirRight, pathing.DirDown, pathing.DirLeft, pathing.DirUp} {
+			next := current.Move(d)
+			v := grid.GetCellValue(next, l)
+			if v == 0 {
+				continue
+			}
+			nextDist := dist[current.Y*numCols+current.X] + int(v)
+			i := next.Y*numCols + next.X
+			if dist[i] == -1 {
+				frontier = append(frontier, next)
+			} else if dist[i] <= nextDist {
+				continue
+			}
+			dist[i] = nextDist
+		}
+	}
+	return 0, false
+}
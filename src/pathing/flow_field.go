@@ -0,0 +1,260 @@
+package pathing
+
+// FlowField is a direction field that leads to a single target cell.
+//
+// It's built once by a Dijkstra search that starts from the target
+// (this is an integration field that stores the cost to reach the target
+// from every cell). Then every cell gets a direction towards its cheapest
+// neighbor. After that, a path from any cell can be built by simply
+// following the directions, so many units that move to the same place
+// can share the same field.
+//
+// The layer values are interpreted as the cell costs, the same way AStar does it.
+type FlowField struct {
+	numCols int
+	numRows int
+
+	target GridCoord
+	valid  bool
+
+	costs []uint32
+	dirs  []Direction
+
+	// The layer values the field was built with, see MarkCellChanged.
+	values []uint8
+
+	// A reusable BuildPath steps buffer.
+	steps []Direction
+}
+
+const flowFieldUnvisited = ^uint32(0)
+
+func (f *FlowField) Target() GridCoord { return f.target }
+
+// BuildPath follows the field directions from the given cell.
+//
+// The result is partial if the target was not reached (the path is too long
+// or the target is unreachable from this cell). If the cell is not connected
+// to the target, an empty partial result is returned.
+func (f *FlowField) BuildPath(from GridCoord) BuildPathResult {
+	var result BuildPathResult
+	result.Finish = from
+	if from == f.target {
+		return result
+	}
+
+	steps := f.steps[:0]
+	pos := from
+	for len(steps) < gridPathMaxLen && pos != f.target {
+		d := f.Dir(pos)
+		if d == DirNone {
+			break
+		}
+		steps = append(steps, d)
+		pos = pos.Move(d)
+	}
+
+	f.steps = steps
+	result = makeBuildPathResult(from, steps)
+	if pos != f.target {
+		result.Partial = true
+	}
+	return result
+}
+
+// Dir returns the next step direction for the given cell.
+// DirNone is returned for the target cell itself and for the cells
+// that can't reach the target.
+func (f *FlowField) Dir(c GridCoord) Direction {
+	x := uint(c.X)
+	y := uint(c.Y)
+	if x >= uint(f.numCols) || y >= uint(f.numRows) {
+		return DirNone
+	}
+	return f.dirs[int(y)*f.numCols+int(x)]
+}
+
+func (f *FlowField) build(g *Grid, l GridLayer, open *astarQueue) {
+	f.valid = true
+
+	costs := f.costs
+	for i := range f.dirs {
+		f.dirs[i] = DirNone
+		costs[i] = flowFieldUnvisited
+		f.values[i] = g.getCellValue(uint(i%f.numCols), uint(i/f.numCols), l)
+	}
+	if g.GetCellValue(f.target, l) == 0 {
+		// A blocked target can't be reached.
+		return
+	}
+
+	open.Reset()
+	costs[f.target.Y*f.numCols+f.target.X] = 0
+	open.Push(astarQueueElem{coord: f.target})
+	for !open.IsEmpty() {
+		current := open.Pop()
+		currentCost := costs[current.coord.Y*f.numCols+current.coord.X]
+		if current.f != currentCost {
+			continue // A stale queue element
+		}
+		// The units are walking in the opposite direction:
+		// to get here from a neighbor, they need to pay this cell cost.
+		stepCost := currentCost + uint32(g.getCellValue(uint(current.coord.X), uint(current.coord.Y), l))
+		for dir, offset := range &neighborOffsets {
+			next := current.coord.Add(offset)
+			cx := uint(next.X)
+			cy := uint(next.Y)
+			if cx >= g.numCols || cy >= g.numRows {
+				continue
+			}
+			if g.getCellValue(cx, cy, l) == 0 {
+				continue
+			}
+			i := next.Y*f.numCols + next.X
+			if costs[i] <= stepCost {
+				continue
+			}
+			costs[i] = stepCost
+			f.dirs[i] = Direction(dir).Reversed()
+			open.Push(astarQueueElem{coord: next, f: stepCost})
+		}
+	}
+}
+
+// markCellChanged updates the field after the c cell value change.
+// It returns false if the field needs to be rebuilt.
+//
+// The field can survive most of the changes: a cell that is not connected
+// to the target doesn't matter, and a more expensive (or blocked) cell
+// doesn't matter unless some other cell directions lead through it.
+func (f *FlowField) markCellChanged(c GridCoord, v uint8) bool {
+	i := c.Y*f.numCols + c.X
+	prev := f.values[i]
+	if prev == v {
+		return true
+	}
+	f.values[i] = v
+	if c == f.target {
+		return false
+	}
+
+	if v != 0 && (prev == 0 || v < prev) {
+		// A cheaper cell may open a shortcut to the reachable cells around it.
+		if f.costs[i] != flowFieldUnvisited {
+			return false
+		}
+		for _, offset := range &neighborOffsets {
+			next := c.Add(offset)
+			if uint(next.X) < uint(f.numCols) && uint(next.Y) < uint(f.numRows) &&
+				f.costs[next.Y*f.numCols+next.X] != flowFieldUnvisited {
+				return false
+			}
+		}
+		return true
+	}
+
+	if f.costs[i] == flowFieldUnvisited {
+		return true
+	}
+	for dir, offset := range &neighborOffsets {
+		next := c.Add(offset)
+		if f.Dir(next) == Direction(dir).Reversed() {
+			// The neighbor path goes through this cell.
+			return false
+		}
+	}
+	if v == 0 {
+		f.costs[i] = flowFieldUnvisited
+		f.dirs[i] = DirNone
+	}
+	return true
+}
+
+// FlowFieldCache keeps the recently used flow fields of a single layer.
+//
+// Use MarkCellChanged after every SetCellTag call (or Invalidate after
+// many changes). Only the fields affected by the change become outdated.
+// The outdated fields are rebuilt lazily, when they're requested again.
+type FlowFieldCache struct {
+	grid  *Grid
+	layer GridLayer
+
+	// The fields are ordered from the most recently used to the least recently used.
+	fields    []*FlowField
+	maxFields int
+
+	open astarQueue
+}
+
+func NewFlowFieldCache(g *Grid, l GridLayer, maxFields int) *FlowFieldCache {
+	return &FlowFieldCache{
+		grid:      g,
+		layer:     l,
+		fields:    make([]*FlowField, 0, maxFields),
+		maxFields: maxFields,
+		open:      astarQueue{elems: make([]astarQueueElem, 0, 64)},
+	}
+}
+
+// MarkCellChanged marks the cached fields affected by the c cell as outdated.
+func (c *FlowFieldCache) MarkCellChanged(cell GridCoord) {
+	numCols, numRows := c.grid.Size()
+	if uint(cell.X) >= uint(numCols) || uint(cell.Y) >= uint(numRows) {
+		return
+	}
+	v := c.grid.GetCellValue(cell, c.layer)
+	for _, f := range c.fields {
+		if f.valid && !f.markCellChanged(cell, v) {
+			f.valid = false
+		}
+	}
+}
+
+// Invalidate marks all cached fields as outdated.
+func (c *FlowFieldCache) Invalidate() {
+	for _, f := range c.fields {
+		f.valid = false
+	}
+}
+
+// Get returns an up-to-date flow field for the given target.
+//
+// The returned field can be reused for a different target by the
+// later Get calls, so it should not be stored for a long time.
+func (c *FlowFieldCache) Get(target GridCoord) *FlowField {
+	for i, f := range c.fields {
+		if f.target != target {
+			continue
+		}
+		// Move it to the front.
+		copy(c.fields[1:i+1], c.fields[:i])
+		c.fields[0] = f
+		if !f.valid {
+			f.build(c.grid, c.layer, &c.open)
+		}
+		return f
+	}
+
+	var f *FlowField
+	if len(c.fields) < c.maxFields {
+		numCols, numRows := c.grid.Size()
+		f = &FlowField{
+			numCols: numCols,
+			numRows: numRows,
+			costs:   make([]uint32, numCols*numRows),
+			dirs:    make([]Direction, numCols*numRows),
+			values:  make([]uint8, numCols*numRows),
+			steps:   make([]Direction, 0, gridPathMaxLen),
+		}
+		c.fields = append(c.fields, nil)
+	} else {
+		// Reuse the least recently used field.
+		f = c.fields[len(c.fields)-1]
+	}
+	copy(c.fields[1:], c.fields[:len(c.fields)-1])
+	c.fields[0] = f
+
+	f.target = target
+	f.build(c.grid, c.layer, &c.open)
+	return f
+}
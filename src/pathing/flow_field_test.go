@@ -0,0 +1,178 @@
+package pathing_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/quasilyte/roboden-game/pathing"
+)
+
+func BenchmarkFlowField(b *testing.B) {
+	l := pathing.MakeGridLayer(1, 0, 2, 0)
+	grid := testObstaclesGrid(rand.New(rand.NewSource(1)), 116, 116)
+	target := pathing.GridCoord{X: 58, Y: 58}
+	grid.SetCellTag(target, 0)
+
+	b.Run("build", func(b *testing.B) {
+		cache := pathing.NewFlowFieldCache(grid, l, 4)
+		for i := 0; i < b.N; i++ {
+			cache.Invalidate()
+			cache.Get(target)
+		}
+	})
+
+	// Compare the cost of building the paths for a big wave of units.
+	const numUnits = 40
+	rng := rand.New(rand.NewSource(2))
+	starts := make([]pathing.GridCoord, numUnits)
+	for i := range starts {
+		starts[i] = pathing.GridCoord{X: 1 + rng.Intn(20), Y: 1 + rng.Intn(20)}
+		grid.SetCellTag(starts[i], 0)
+	}
+	b.Run("wave_flow_field", func(b *testing.B) {
+		cache := pathing.NewFlowFieldCache(grid, l, 4)
+		cache.Get(target)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			f := cache.Get(target)
+			for _, start := range starts {
+				f.BuildPath(start)
+			}
+		}
+	})
+	b.Run("wave_astar", func(b *testing.B) {
+		numCols, numRows := grid.Size()
+		astar := pathing.NewAStar(numCols, numRows)
+		for i := 0; i < b.N; i++ {
+			for _, start := range starts {
+				astar.BuildPath(grid, start, target, l)
+			}
+		}
+	})
+}
+
+func TestFlowField(t *testing.T) {
+	l := pathing.MakeGridLayer(1, 0, 2, 0)
+	rng := rand.New(rand.NewSource(5))
+	for i := 0; i < 20; i++ {
+		grid := testRandomGrid(rng, 20+rng.Intn(20), 20+rng.Intn(20))
+		numCols, numRows := grid.Size()
+		cache := pathing.NewFlowFieldCache(grid, l, 2)
+		target := pathing.GridCoord{X: rng.Intn(numCols), Y: rng.Intn(numRows)}
+		f := cache.Get(target)
+		if f.Target() != target {
+			t.Fatalf("field target mismatch: have %v, want %v", f.Target(), target)
+		}
+		for j := 0; j < 50; j++ {
+			from := pathing.GridCoord{X: rng.Intn(numCols), Y: rng.Intn(numRows)}
+			if from == target {
+				continue
+			}
+			result := f.BuildPath(from)
+			pos, cost := testWalkPath(t, grid, l, from, result)
+			wantCost, reachable := testShortestPathCost(grid, l, from, target)
+			switch {
+			case !reachable || grid.GetCellValue(from, l) == 0:
+				if !result.Partial || result.Steps.Len() != 0 {
+					t.Fatalf("%v->%v: expected an empty partial result", from, target)
+				}
+			case result.Steps.Len() < 56:
+				if result.Partial || pos != target {
+					t.Fatalf("%v->%v: expected a complete path, stopped at %v", from, target, pos)
+				}
+				if cost != wantCost {
+					t.Fatalf("%v->%v: path is not optimal: have cost %d, want %d", from, target, cost, wantCost)
+				}
+			}
+		}
+	}
+}
+
+func TestFlowFieldCache(t *testing.T) {
+	l := pathing.MakeGridLayer(1, 0, 1, 0)
+	grid := pathing.NewGrid(pathing.CellSize*10, pathing.CellSize*3, 0)
+	cache := pathing.NewFlowFieldCache(grid, l, 2)
+
+	from := pathing.GridCoord{X: 0, Y: 1}
+	target := pathing.GridCoord{X: 9, Y: 1}
+	if result := cache.Get(target).BuildPath(from); result.Partial || result.Steps.Len() != 9 {
+		t.Fatalf("unexpected initial path: %v (partial=%v)", result.Steps, result.Partial)
+	}
+
+	// Evict the field by requesting other targets.
+	cache.Get(pathing.GridCoord{X: 1, Y: 1})
+	cache.Get(pathing.GridCoord{X: 2, Y: 1})
+
+	// Build a wall that forces a detour.
+	grid.SetCellTag(pathing.GridCoord{X: 5, Y: 0}, 1)
+	grid.SetCellTag(pathing.GridCoord{X: 5, Y: 1}, 1)
+	cache.Invalidate()
+	if result := cache.Get(target).BuildPath(from); result.Partial || result.Steps.Len() != 11 {
+		t.Fatalf("unexpected detour path: %v (partial=%v)", result.Steps, result.Partial)
+	}
+
+	// Now the target is unreachable.
+	grid.SetCellTag(pathing.GridCoord{X: 5, Y: 2}, 1)
+	cache.Get(pathing.GridCoord{X: 2, Y: 1}) // This one should be rebuilt too
+	cache.Invalidate()
+	if result := cache.Get(target).BuildPath(from); !result.Partial || result.Steps.Len() != 0 {
+		t.Fatalf("expected an empty partial result, got %v", result.Steps)
+	}
+}
+
+func TestFlowFieldMarkCellChanged(t *testing.T) {
+	l := pathing.MakeGridLayer(1, 0, 2, 0)
+	rng := rand.New(rand.NewSource(7))
+
+	// walk follows the field directions and returns the path cost.
+	// The second result is false if the target is not reached.
+	walk := func(grid *pathing.Grid, f *pathing.FlowField, from pathing.GridCoord) (int, bool) {
+		numCols, numRows := grid.Size()
+		cost := 0
+		pos := from
+		for i := 0; i < numCols*numRows; i++ {
+			if pos == f.Target() {
+				return cost, true
+			}
+			d := f.Dir(pos)
+			if d == pathing.DirNone {
+				return cost, false
+			}
+			pos = pos.Move(d)
+			cost += int(grid.GetCellValue(pos, l))
+		}
+		return cost, false
+	}
+
+	for i := 0; i < 10; i++ {
+		grid := testRandomGrid(rng, 12+rng.Intn(10), 12+rng.Intn(10))
+		numCols, numRows := grid.Size()
+		target := pathing.GridCoord{X: rng.Intn(numCols), Y: rng.Intn(numRows)}
+		grid.SetCellTag(target, 0)
+		cache := pathing.NewFlowFieldCache(grid, l, 2)
+		cache.Get(target)
+
+		for j := 0; j < 100; j++ {
+			cell := pathing.GridCoord{X: rng.Intn(numCols), Y: rng.Intn(numRows)}
+			grid.SetCellTag(cell, uint8(rng.Intn(3)))
+			cache.MarkCellChanged(cell)
+
+			f := cache.Get(target)
+			fresh := pathing.NewFlowFieldCache(grid, l, 1).Get(target)
+			for y := 0; y < numRows; y++ {
+				for x := 0; x < numCols; x++ {
+					from := pathing.GridCoord{X: x, Y: y}
+					if grid.GetCellValue(from, l) == 0 {
+						continue
+					}
+					haveCost, haveOK := walk(grid, f, from)
+					wantCost, wantOK := walk(grid, fresh, from)
+					if haveCost != wantCost || haveOK != wantOK {
+						t.Fatalf("grid[%d] change[%d] %v->%v: have (%d, %v), want (%d, %v)",
+							i, j, from, target, haveCost, haveOK, wantCost, wantOK)
+					}
+				}
+			}
+		}
+	}
+}
@@ -8,6 +8,9 @@ const (
 	CellSize float64 = 32
 )
 
+// gridTagMask selects the cell tag bits, see MaxGridTags.
+const gridTagMask uint8 = MaxGridTags - 1
+
 type Grid struct {
 	worldWidth  float64
 	worldHeight float64
@@ -27,24 +30,17 @@ func NewGrid(worldWidth, worldHeight float64, defaultTag uint8) *Grid {
 	g.numCols = uint(g.worldWidth / CellSize)
 	g.numRows = uint(g.worldHeight / CellSize)
 
+	// Every cell tag takes 4 bits, so one byte holds two cells.
 	numCells := g.numCols * g.numRows
-	numBytes := numCells / 4
-	if numCells%4 != 0 {
+	numBytes := numCells / 2
+	if numCells%2 != 0 {
 		numBytes++
 	}
 	b := make([]byte, numBytes)
 
-	defaultTag &= 0b11
+	defaultTag &= gridTagMask
 	if defaultTag != 0 {
-		v := uint8(0)
-		switch defaultTag {
-		case 1:
-			v = 0b01010101
-		case 2:
-			v = 0b10101010
-		case 3:
-			v = 0b11111111
-		}
+		v := defaultTag | defaultTag<<4
 		for i := range b {
 			b[i] = v
 		}
@@ -61,12 +57,12 @@ func (g *Grid) Size() (numCols, numRows int) {
 
 func (g *Grid) SetCellTag(c GridCoord, tag uint8) {
 	i := uint(c.Y)*g.numCols + uint(c.X)
-	byteIndex := i / 4
+	byteIndex := i / 2
 	if byteIndex < uint(len(g.bytes)) {
-		shift := (i % 4) * 2
+		shift := (i % 2) * 4
 		b := g.bytes[byteIndex]
-		b &^= 0b11 << shift        // Clear the two data bits
-		b |= (tag & 0b11) << shift // Mix it with provided bits
+		b &^= gridTagMask << shift        // Clear the data bits
+		b |= (tag & gridTagMask) << shift // Mix it with provided bits
 		g.bytes[byteIndex] = b
 	}
 }
@@ -83,9 +79,9 @@ func (g *Grid) GetCellValue(c GridCoord, l GridLayer) uint8 {
 
 func (g *Grid) getCellValue(x, y uint, l GridLayer) uint8 {
 	i := y*g.numCols + x
-	byteIndex := i / 4
-	shift := (i % 4) * 2
-	tag := ((readByte(g.bytes, byteIndex)) >> shift) & 0b11
+	byteIndex := i / 2
+	shift := (i % 2) * 4
+	tag := ((readByte(g.bytes, byteIndex)) >> shift) & gridTagMask
 	return l.getFast(tag)
 }
 
@@ -130,7 +126,7 @@ func (g *Grid) PosToCoord(pos gmath.Vec) GridCoord {
 
 func (g *Grid) CoordToPos(cell GridCoord) gmath.Vec {
 	return gmath.Vec{
-		X: (float64(cell.X) * CellSize) + (CellSize / 2),
-		Y: (float64(cell.Y) * CellSize) + (CellSize / 2),
+		X: float64(float64(cell.X)*CellSize) + (CellSize / 2),
+		Y: float64(float64(cell.Y)*CellSize) + (CellSize / 2),
 	}
 }
@@ -2,15 +2,27 @@ package pathing
 
 import "unsafe"
 
-type GridLayer uint32
+// MaxGridTags is the number of distinct cell tags the grid can store.
+const MaxGridTags = 8
 
-func MakeGridLayer(v0, v1, v2, v3 uint8) GridLayer {
-	merged := uint32(v0) | uint32(v1)<<8 | uint32(v2)<<16 | uint32(v3)<<24
+// GridLayer maps every cell tag to its layer value (a cell cost for the path builders).
+type GridLayer uint64
+
+// MakeGridLayer creates a layer that maps the tag i to values[i].
+// The tags without a value are mapped to 0.
+func MakeGridLayer(values ...uint8) GridLayer {
+	if len(values) > MaxGridTags {
+		panic("too many grid layer values")
+	}
+	merged := uint64(0)
+	for i, v := range values {
+		merged |= uint64(v) << (uint64(i) * 8)
+	}
 	return GridLayer(merged)
 }
 
 func (l GridLayer) Get(tag uint8) uint8 {
-	return uint8(l >> (uint32(tag) * 8))
+	return uint8(l >> (uint64(tag) * 8))
 }
 
 func (l GridLayer) getFast(tag uint8) uint8 {
@@ -15,13 +15,22 @@ func TestGridLayer(t *testing.T) {
 		{100, 0xff, 0xff, 100},
 		{24, 53, 21, 99},
 		{99, 145, 9, 0},
+		{1, 0, 2, 0, 1},
+		{0, 0, 0, 0, 0, 0, 0, 1},
+		{8, 7, 6, 5, 4, 3, 2, 1},
 	}
 
 	for _, test := range tests {
-		l := MakeGridLayer(test[0], test[1], test[2], test[3])
-		for i := uint8(0); i <= 3; i++ {
-			want := test[i]
+		l := MakeGridLayer(test...)
+		for i := uint8(0); i < MaxGridTags; i++ {
+			want := uint8(0)
+			if int(i) < len(test) {
+				want = test[i]
+			}
 			have := l.Get(i)
+			if fast := l.getFast(i); fast != have {
+				t.Fatalf("(%v).getFast(%d): have %v, want %v", test, i, fast, have)
+			}
 			if want != have {
 				t.Fatalf("(%v).Get(%d): have %v, want %v", test, i, have, want)
 			}
@@ -122,14 +122,14 @@ func TestRandFillGrid(t *testing.T) {
 	for i := range layers {
 		layers[i] = make([]uint8, 10)
 		for j := range layers[i] {
-			layers[i][j] = uint8(rng.Int63n(4))
+			layers[i][j] = uint8(rng.Int63n(pathing.MaxGridTags))
 		}
 	}
 
-	values := []uint8{10, 0, 20, 30}
-	values2 := []uint8{0, 1, 2, 3}
-	l := pathing.MakeGridLayer(values[0], values[1], values[2], values[3])
-	l2 := pathing.MakeGridLayer(values2[0], values2[1], values2[2], values2[3])
+	values := []uint8{10, 0, 20, 30, 40, 50, 60, 70}
+	values2 := []uint8{0, 1, 2, 3, 4, 5, 6, 7}
+	l := pathing.MakeGridLayer(values...)
+	l2 := pathing.MakeGridLayer(values2...)
 	for y := 0; y < 10; y++ {
 		for x := 0; x < 10; x++ {
 			c := pathing.GridCoord{X: x, Y: y}
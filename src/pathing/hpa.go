@@ -0,0 +1,496 @@
+package pathing
+
+// HPAGraph implements a hierarchical path finding (HPA*) over the Grid.
+//
+// The grid is split into square clusters that are connected through
+// the entrances on their shared borders. The abstract graph nodes are
+// the entrance cells and its edges are the optimal in-cluster paths
+// between them. A long path is planned over this small graph and only
+// the first steps of it are refined into the actual grid path.
+//
+// The graph is built for a single layer. The layer values are
+// interpreted as the cell costs, the same way AStar does it.
+//
+// The graph needs to know about the grid cell tag changes:
+// use MarkCellChanged after every SetCellTag call.
+// The affected clusters are rebuilt lazily, right before the next path search.
+type HPAGraph struct {
+	grid  *Grid
+	layer GridLayer
+
+	clusterSize    int
+	numClusterCols int
+	numClusterRows int
+	clusters       []hpaCluster
+	dirtyClusters  []int
+
+	minCost int
+
+	astar *AStar
+	bfs   *GreedyBFS
+
+	// The abstract search state; the slices are indexed by the packed cell coord.
+	open    astarQueue
+	costs   []uint32
+	parents []uint32
+	stamps  []uint32
+	stamp   uint32
+
+	startEdges []hpaEdge
+	goalEdges  []hpaEdge
+	waypoints  []GridCoord
+	steps      []Direction
+}
+
+type hpaCluster struct {
+	rect  gridRect
+	dirty bool
+	nodes []hpaNode
+}
+
+type hpaNode struct {
+	coord GridCoord
+	edges []hpaEdge
+}
+
+type hpaEdge struct {
+	to   GridCoord
+	cost uint32
+}
+
+// An entrance is a pair of adjacent cells that belong to different clusters.
+type hpaEntrance struct {
+	a GridCoord
+	b GridCoord
+}
+
+const (
+	// The clusters border segments that are this long (or longer) get
+	// two entrances, one at each end of the segment.
+	// Shorter segments get a single entrance in the middle.
+	hpaWideEntranceLen = 6
+
+	// The paths that are this short are built without the abstract graph.
+	hpaMinDist = 8
+)
+
+func NewHPAGraph(g *Grid, l GridLayer, clusterSize int) *HPAGraph {
+	numCols, numRows := g.Size()
+	numClusterCols := (numCols + clusterSize - 1) / clusterSize
+	numClusterRows := (numRows + clusterSize - 1) / clusterSize
+	numCells := numCols * numRows
+
+	graph := &HPAGraph{
+		grid:           g,
+		layer:          l,
+		clusterSize:    clusterSize,
+		numClusterCols: numClusterCols,
+		numClusterRows: numClusterRows,
+		clusters:       make([]hpaCluster, numClusterCols*numClusterRows),
+		minCost:        layerMinCost(l),
+		astar:          NewAStar(numCols, numRows),
+		bfs:            NewGreedyBFS(numCols, numRows),
+		open:           astarQueue{elems: make([]astarQueueElem, 0, 64)},
+		costs:          make([]uint32, numCells),
+		parents:        make([]uint32, numCells),
+		stamps:         make([]uint32, numCells),
+	}
+
+	for i := range graph.clusters {
+		cx := i % numClusterCols
+		cy := i / numClusterCols
+		min := GridCoord{X: cx * clusterSize, Y: cy * clusterSize}
+		graph.clusters[i].rect = gridRect{
+			min: min,
+			max: GridCoord{
+				X: imin(min.X+clusterSize, numCols),
+				Y: imin(min.Y+clusterSize, numRows),
+			},
+		}
+		graph.markClusterDirty(i)
+	}
+
+	return graph
+}
+
+// MarkCellChanged schedules the rebuild of the clusters affected by the c cell.
+func (graph *HPAGraph) MarkCellChanged(c GridCoord) {
+	clusterIndex, ok := graph.clusterIndex(c)
+	if !ok {
+		return
+	}
+	graph.markClusterDirty(clusterIndex)
+
+	// The border cells also affect the entrances of the adjacent clusters.
+	r := graph.clusters[clusterIndex].rect
+	if c.X == r.min.X {
+		graph.markNeighborDirty(c.Add(GridCoord{X: -1}))
+	}
+	if c.X == r.max.X-1 {
+		graph.markNeighborDirty(c.Add(GridCoord{X: 1}))
+	}
+	if c.Y == r.min.Y {
+		graph.markNeighborDirty(c.Add(GridCoord{Y: -1}))
+	}
+	if c.Y == r.max.Y-1 {
+		graph.markNeighborDirty(c.Add(GridCoord{Y: 1}))
+	}
+}
+
+// Rebuild updates all dirty clusters.
+//
+// It's called automatically by BuildPath, but it can be
+// called explicitly to move the rebuild cost out of the search.
+func (graph *HPAGraph) Rebuild() {
+	for _, clusterIndex := range graph.dirtyClusters {
+		graph.rebuildCluster(clusterIndex)
+	}
+	graph.dirtyClusters = graph.dirtyClusters[:0]
+}
+
+func (graph *HPAGraph) BuildPath(from, to GridCoord) BuildPathResult {
+	if from == to {
+		return BuildPathResult{}
+	}
+
+	g := graph.grid
+	if _, ok := graph.clusterIndex(from); !ok {
+		// The start is outside of the grid.
+		return graph.bfs.BuildPath(g, from, to, graph.layer)
+	}
+	if from.Dist(to) < hpaMinDist {
+		return graph.astar.BuildPath(g, from, to, graph.layer)
+	}
+	if g.GetCellValue(to, graph.layer) == 0 {
+		// The goal is unreachable.
+		// Let the greedy BFS find a partial path towards it.
+		return graph.bfs.BuildPath(g, from, to, graph.layer)
+	}
+
+	graph.Rebuild()
+
+	if !graph.connectEndpoints(from, to) || !graph.searchAbstract(from, to) {
+		return graph.bfs.BuildPath(g, from, to, graph.layer)
+	}
+
+	// Refine the abstract path into the grid path.
+	// Since GridPath has a limited capacity, there is no need to
+	// refine the entire path: only the first steps are needed.
+	steps := graph.steps[:0]
+	partial := false
+	pos := from
+	for i := len(graph.waypoints) - 1; i >= 0; i-- {
+		if len(steps) >= gridPathMaxLen {
+			break
+		}
+		waypoint := graph.waypoints[i]
+		if pos.Dist(waypoint) == 1 {
+			steps = append(steps, directionTo(pos, waypoint))
+			pos = waypoint
+			continue
+		}
+		clusterIndex, _ := graph.clusterIndex(pos)
+		bounds := graph.clusters[clusterIndex].rect
+		finish, found := graph.astar.search(g, pos, waypoint, graph.layer, bounds, graph.minCost)
+		if !found {
+			partial = true
+			break
+		}
+		steps = graph.astar.appendPath(steps, pos, finish)
+		pos = finish
+	}
+	graph.steps = steps
+
+	result := makeBuildPathResult(from, steps)
+	if partial {
+		result.Partial = true
+	}
+	return result
+}
+
+// connectEndpoints computes the edges that connect the path start and goal
+// to the abstract graph nodes of their clusters.
+// It returns false if there is no way out of the start cluster.
+func (graph *HPAGraph) connectEndpoints(from, to GridCoord) bool {
+	astar := graph.astar
+	g := graph.grid
+
+	fromClusterIndex, _ := graph.clusterIndex(from)
+	toClusterIndex, _ := graph.clusterIndex(to)
+
+	graph.startEdges = graph.startEdges[:0]
+	fromCluster := &graph.clusters[fromClusterIndex]
+	astar.search(g, from, GridCoord{X: -1, Y: -1}, graph.layer, fromCluster.rect, 0)
+	for _, n := range fromCluster.nodes {
+		if cost, ok := astar.costOf(n.coord); ok {
+			graph.startEdges = append(graph.startEdges, hpaEdge{to: n.coord, cost: cost})
+		}
+	}
+	if fromClusterIndex == toClusterIndex {
+		if cost, ok := astar.costOf(to); ok {
+			graph.startEdges = append(graph.startEdges, hpaEdge{to: to, cost: cost})
+		}
+	}
+	if len(graph.startEdges) == 0 {
+		return false
+	}
+
+	// The goal edges are computed by walking from the goal.
+	// Since the cost is paid when entering the cell, the reversed walk
+	// cost needs to be adjusted: it includes the node cell cost
+	// instead of the goal cell cost.
+	graph.goalEdges = graph.goalEdges[:0]
+	toCluster := &graph.clusters[toClusterIndex]
+	astar.search(g, to, GridCoord{X: -1, Y: -1}, graph.layer, toCluster.rect, 0)
+	goalCost := uint32(g.GetCellValue(to, graph.layer))
+	for _, n := range toCluster.nodes {
+		if cost, ok := astar.costOf(n.coord); ok {
+			nodeCost := uint32(g.GetCellValue(n.coord, graph.layer))
+			graph.goalEdges = append(graph.goalEdges, hpaEdge{to: n.coord, cost: cost + goalCost - nodeCost})
+		}
+	}
+
+	return true
+}
+
+// searchAbstract finds the path over the abstract graph.
+// The found path waypoints are stored in the reversed order, the start is excluded.
+func (graph *HPAGraph) searchAbstract(from, to GridCoord) bool {
+	graph.stamp++
+	if graph.stamp == 0 {
+		for i := range graph.stamps {
+			graph.stamps[i] = 0
+		}
+		graph.stamp = 1
+	}
+
+	open := &graph.open
+	open.Reset()
+
+	fromIndex := graph.astar.packCoord(from)
+	graph.stamps[fromIndex] = graph.stamp
+	graph.costs[fromIndex] = 0
+	graph.parents[fromIndex] = uint32(fromIndex)
+	h := uint32(from.Dist(to) * graph.minCost)
+	open.Push(astarQueueElem{coord: from, f: h, h: h})
+
+	for !open.IsEmpty() {
+		current := open.Pop()
+		if current.coord == to {
+			graph.waypoints = graph.waypoints[:0]
+			pos := to
+			for pos != from {
+				graph.waypoints = append(graph.waypoints, pos)
+				pos = graph.unpackCoord(graph.parents[graph.astar.packCoord(pos)])
+			}
+			return true
+		}
+		currentIndex := graph.astar.packCoord(current.coord)
+		currentCost := graph.costs[currentIndex]
+		if current.f-current.h != currentCost {
+			continue // A stale queue element
+		}
+
+		if current.coord == from {
+			for _, e := range graph.startEdges {
+				graph.relaxEdge(current.coord, currentCost, to, e)
+			}
+		}
+		if n := graph.findNode(current.coord); n != nil {
+			for _, e := range n.edges {
+				graph.relaxEdge(current.coord, currentCost, to, e)
+			}
+		}
+		for _, e := range graph.goalEdges {
+			if e.to == current.coord {
+				graph.relaxEdge(current.coord, currentCost, to, hpaEdge{to: to, cost: e.cost})
+				break
+			}
+		}
+	}
+
+	return false
+}
+
+func (graph *HPAGraph) relaxEdge(pos GridCoord, posCost uint32, goal GridCoord, e hpaEdge) {
+	nextCost := posCost + e.cost
+	nextIndex := graph.astar.packCoord(e.to)
+	if graph.stamps[nextIndex] == graph.stamp && graph.costs[nextIndex] <= nextCost {
+		return
+	}
+	graph.stamps[nextIndex] = graph.stamp
+	graph.costs[nextIndex] = nextCost
+	graph.parents[nextIndex] = uint32(graph.astar.packCoord(pos))
+	h := uint32(e.to.Dist(goal) * graph.minCost)
+	graph.open.Push(astarQueueElem{coord: e.to, f: nextCost + h, h: h})
+}
+
+func (graph *HPAGraph) findNode(c GridCoord) *hpaNode {
+	clusterIndex, ok := graph.clusterIndex(c)
+	if !ok {
+		return nil
+	}
+	nodes := graph.clusters[clusterIndex].nodes
+	for i := range nodes {
+		if nodes[i].coord == c {
+			return &nodes[i]
+		}
+	}
+	return nil
+}
+
+func (graph *HPAGraph) rebuildCluster(clusterIndex int) {
+	cluster := &graph.clusters[clusterIndex]
+	cluster.dirty = false
+	cluster.nodes = cluster.nodes[:0]
+
+	// Collect the entrances on all four sides of the cluster.
+	// The entrances are always computed in the same (left-to-right, top-to-bottom)
+	// order, so both clusters that share a border agree on its entrances.
+	r := cluster.rect
+	cx := clusterIndex % graph.numClusterCols
+	cy := clusterIndex / graph.numClusterCols
+	if cx > 0 {
+		graph.walkEntrances(GridCoord{X: r.min.X - 1, Y: r.min.Y}, GridCoord{Y: 1}, GridCoord{X: 1}, r.max.Y-r.min.Y, func(e hpaEntrance) {
+			graph.addEntranceEdge(cluster, e.b, e.a)
+		})
+	}
+	if cx < graph.numClusterCols-1 {
+		graph.walkEntrances(GridCoord{X: r.max.X - 1, Y: r.min.Y}, GridCoord{Y: 1}, GridCoord{X: 1}, r.max.Y-r.min.Y, func(e hpaEntrance) {
+			graph.addEntranceEdge(cluster, e.a, e.b)
+		})
+	}
+	if cy > 0 {
+		graph.walkEntrances(GridCoord{X: r.min.X, Y: r.min.Y - 1}, GridCoord{X: 1}, GridCoord{Y: 1}, r.max.X-r.min.X, func(e hpaEntrance) {
+			graph.addEntranceEdge(cluster, e.b, e.a)
+		})
+	}
+	if cy < graph.numClusterRows-1 {
+		graph.walkEntrances(GridCoord{X: r.min.X, Y: r.max.Y - 1}, GridCoord{X: 1}, GridCoord{Y: 1}, r.max.X-r.min.X, func(e hpaEntrance) {
+			graph.addEntranceEdge(cluster, e.a, e.b)
+		})
+	}
+
+	// Connect the cluster nodes with each other.
+	for i := range cluster.nodes {
+		n := &cluster.nodes[i]
+		graph.astar.search(graph.grid, n.coord, GridCoord{X: -1, Y: -1}, graph.layer, r, 0)
+		for j := range cluster.nodes {
+			if i == j {
+				continue
+			}
+			other := cluster.nodes[j].coord
+			if cost, ok := graph.astar.costOf(other); ok {
+				n.edges = append(n.edges, hpaEdge{to: other, cost: cost})
+			}
+		}
+	}
+}
+
+// walkEntrances finds the entrances of the border that starts at pos.
+// The border cells on the first side are pos+step*i, the cells
+// on the other side are shifted by the across offset.
+func (graph *HPAGraph) walkEntrances(pos, step, across GridCoord, length int, f func(hpaEntrance)) {
+	g := graph.grid
+	segmentStart := -1
+	for i := 0; i <= length; i++ {
+		passable := false
+		if i < length {
+			a := GridCoord{X: pos.X + step.X*i, Y: pos.Y + step.Y*i}
+			passable = g.GetCellValue(a, graph.layer) != 0 && g.GetCellValue(a.Add(across), graph.layer) != 0
+		}
+		if passable {
+			if segmentStart == -1 {
+				segmentStart = i
+			}
+			continue
+		}
+		if segmentStart == -1 {
+			continue
+		}
+		segmentLen := i - segmentStart
+		emit := func(offset int) {
+			a := GridCoord{X: pos.X + step.X*offset, Y: pos.Y + step.Y*offset}
+			f(hpaEntrance{a: a, b: a.Add(across)})
+		}
+		if segmentLen >= hpaWideEntranceLen {
+			emit(segmentStart)
+			emit(i - 1)
+		} else {
+			emit(segmentStart + segmentLen/2)
+		}
+		segmentStart = -1
+	}
+}
+
+func (graph *HPAGraph) addEntranceEdge(cluster *hpaCluster, own, other GridCoord) {
+	edge := hpaEdge{to: other, cost: uint32(graph.grid.GetCellValue(other, graph.layer))}
+	for i := range cluster.nodes {
+		if cluster.nodes[i].coord == own {
+			cluster.nodes[i].edges = append(cluster.nodes[i].edges, edge)
+			return
+		}
+	}
+	// Try to reuse the previously allocated edges slice.
+	if len(cluster.nodes) < cap(cluster.nodes) {
+		cluster.nodes = cluster.nodes[:len(cluster.nodes)+1]
+		n := &cluster.nodes[len(cluster.nodes)-1]
+		n.coord = own
+		n.edges = append(n.edges[:0], edge)
+		return
+	}
+	cluster.nodes = append(cluster.nodes, hpaNode{
+		coord: own,
+		edges: []hpaEdge{edge},
+	})
+}
+
+func (graph *HPAGraph) markNeighborDirty(c GridCoord) {
+	if clusterIndex, ok := graph.clusterIndex(c); ok {
+		graph.markClusterDirty(clusterIndex)
+	}
+}
+
+func (graph *HPAGraph) markClusterDirty(clusterIndex int) {
+	cluster := &graph.clusters[clusterIndex]
+	if cluster.dirty {
+		return
+	}
+	cluster.dirty = true
+	graph.dirtyClusters = append(graph.dirtyClusters, clusterIndex)
+}
+
+func (graph *HPAGraph) clusterIndex(c GridCoord) (int, bool) {
+	numCols, numRows := graph.grid.Size()
+	if c.X < 0 || c.Y < 0 || c.X >= numCols || c.Y >= numRows {
+		return 0, false
+	}
+	cx := c.X / graph.clusterSize
+	cy := c.Y / graph.clusterSize
+	return cy*graph.numClusterCols + cx, true
+}
+
+func (graph *HPAGraph) unpackCoord(i uint32) GridCoord {
+	numCols := uint32(graph.astar.numCols)
+	return GridCoord{X: int(i % numCols), Y: int(i / numCols)}
+}
+
+func directionTo(from, to GridCoord) Direction {
+	switch {
+	case to.X > from.X:
+		return DirRight
+	case to.Y > from.Y:
+		return DirDown
+	case to.X < from.X:
+		return DirLeft
+	default:
+		return DirUp
+	}
+}
+
+func imin(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
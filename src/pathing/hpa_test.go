@@ -0,0 +1,171 @@
+package pathing_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/quasilyte/roboden-game/pathing"
+)
+
+func BenchmarkHPAGraph(b *testing.B) {
+	// Compare the path builders on a huge level-like grid.
+	// The GreedyBFS and AStar can only build the first 56 steps of this path,
+	// but they still need to explore the grid almost up to the goal.
+	l := pathing.MakeGridLayer(1, 0, 2, 0)
+	grid := testObstaclesGrid(rand.New(rand.NewSource(1)), 116, 116)
+	from := pathing.GridCoord{X: 2, Y: 2}
+	to := pathing.GridCoord{X: 110, Y: 105}
+	grid.SetCellTag(from, 0)
+	grid.SetCellTag(to, 0)
+	numCols, numRows := grid.Size()
+
+	b.Run("greedy_bfs", func(b *testing.B) {
+		bfs := pathing.NewGreedyBFS(numCols, numRows)
+		for i := 0; i < b.N; i++ {
+			bfs.BuildPath(grid, from, to, l)
+		}
+	})
+	b.Run("astar", func(b *testing.B) {
+		astar := pathing.NewAStar(numCols, numRows)
+		for i := 0; i < b.N; i++ {
+			astar.BuildPath(grid, from, to, l)
+		}
+	})
+	b.Run("hpa", func(b *testing.B) {
+		graph := pathing.NewHPAGraph(grid, l, 8)
+		graph.Rebuild()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			graph.BuildPath(from, to)
+		}
+	})
+}
+
+func BenchmarkHPAGraphRebuild(b *testing.B) {
+	l := pathing.MakeGridLayer(1, 0, 2, 0)
+	grid := testObstaclesGrid(rand.New(rand.NewSource(1)), 116, 116)
+	graph := pathing.NewHPAGraph(grid, l, 8)
+	graph.Rebuild()
+	b.Run("full", func(b *testing.B) {
+		numCols, numRows := grid.Size()
+		for i := 0; i < b.N; i++ {
+			for y := 0; y < numRows; y += 8 {
+				for x := 0; x < numCols; x += 8 {
+					graph.MarkCellChanged(pathing.GridCoord{X: x + 1, Y: y + 1})
+				}
+			}
+			graph.Rebuild()
+		}
+	})
+	b.Run("one_cell", func(b *testing.B) {
+		cell := pathing.GridCoord{X: 43, Y: 43}
+		for i := 0; i < b.N; i++ {
+			grid.SetCellTag(cell, uint8(i%2))
+			graph.MarkCellChanged(cell)
+			graph.Rebuild()
+		}
+	})
+}
+
+func TestHPAGraph(t *testing.T) {
+	l := pathing.MakeGridLayer(1, 0, 2, 0)
+	rng := rand.New(rand.NewSource(4))
+	for i := 0; i < 20; i++ {
+		grid := testRandomGrid(rng, 40+rng.Intn(30), 40+rng.Intn(30))
+		numCols, numRows := grid.Size()
+		graph := pathing.NewHPAGraph(grid, l, 8)
+		for j := 0; j < 50; j++ {
+			from := pathing.GridCoord{X: rng.Intn(numCols), Y: rng.Intn(numRows)}
+			to := pathing.GridCoord{X: rng.Intn(numCols), Y: rng.Intn(numRows)}
+			result := graph.BuildPath(from, to)
+			pos, _ := testWalkPath(t, grid, l, from, result)
+			if from == to {
+				continue
+			}
+			wantCost, reachable := testShortestPathCost(grid, l, from, to)
+			if !reachable {
+				if !result.Partial {
+					t.Fatalf("expected %v->%v to be partial", from, to)
+				}
+				continue
+			}
+			if wantCost <= 56 && result.Steps.Len() < 56 && pos != to {
+				t.Fatalf("%v->%v: expected a complete path, stopped at %v", from, to, pos)
+			}
+			if result.Steps.Len() == 0 {
+				t.Fatalf("%v->%v: no progress for a reachable dest", from, to)
+			}
+		}
+	}
+}
+
+func TestHPAGraphIncremental(t *testing.T) {
+	// After any sequence of the cell changes, the incrementally updated
+	// graph should give the same results as the graph built from scratch.
+	l := pathing.MakeGridLayer(1, 0, 2, 0)
+	rng := rand.New(rand.NewSource(9))
+	grid := testRandomGrid(rng, 64, 48)
+	numCols, numRows := grid.Size()
+	graph := pathing.NewHPAGraph(grid, l, 8)
+	graph.Rebuild()
+
+	for i := 0; i < 30; i++ {
+		for j := 0; j < 10; j++ {
+			cell := pathing.GridCoord{X: rng.Intn(numCols), Y: rng.Intn(numRows)}
+			grid.SetCellTag(cell, uint8(rng.Intn(3)))
+			graph.MarkCellChanged(cell)
+		}
+
+		freshGraph := pathing.NewHPAGraph(grid, l, 8)
+		for j := 0; j < 10; j++ {
+			from := pathing.GridCoord{X: rng.Intn(numCols), Y: rng.Intn(numRows)}
+			to := pathing.GridCoord{X: rng.Intn(numCols), Y: rng.Intn(numRows)}
+			have := graph.BuildPath(from, to)
+			want := freshGraph.BuildPath(from, to)
+			if have != want {
+				t.Fatalf("%v->%v results mismatch:\nhave: %v %v\nwant: %v %v",
+					from, to, have.Finish, have.Steps, want.Finish, want.Steps)
+			}
+		}
+	}
+}
+
+// testRandomGrid creates a grid with some walls and forests.
+func testRandomGrid(rng *rand.Rand, numCols, numRows int) *pathing.Grid {
+	grid := pathing.NewGrid(pathing.CellSize*float64(numCols), pathing.CellSize*float64(numRows), 0)
+	for y := 0; y < numRows; y++ {
+		for x := 0; x < numCols; x++ {
+			roll := rng.Float64()
+			switch {
+			case roll < 0.2:
+				grid.SetCellTag(pathing.GridCoord{X: x, Y: y}, 1)
+			case roll < 0.3:
+				grid.SetCellTag(pathing.GridCoord{X: x, Y: y}, 2)
+			}
+		}
+	}
+	return grid
+}
+
+// testObstaclesGrid creates a grid with rectangular walls and forests,
+// the way a level generator would do it.
+func testObstaclesGrid(rng *rand.Rand, numCols, numRows int) *pathing.Grid {
+	grid := pathing.NewGrid(pathing.CellSize*float64(numCols), pathing.CellSize*float64(numRows), 0)
+	numObstacles := (numCols * numRows) / 80
+	for i := 0; i < numObstacles; i++ {
+		tag := uint8(1)
+		if rng.Float64() < 0.3 {
+			tag = 2
+		}
+		x0 := rng.Intn(numCols)
+		y0 := rng.Intn(numRows)
+		w := 1 + rng.Intn(6)
+		h := 1 + rng.Intn(6)
+		for y := y0; y < y0+h && y < numRows; y++ {
+			for x := x0; x < x0+w && x < numCols; x++ {
+				grid.SetCellTag(pathing.GridCoord{X: x, Y: y}, tag)
+			}
+		}
+	}
+	return grid
+}
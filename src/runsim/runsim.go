@@ -48,6 +48,12 @@ func PrepareAssets(ctx *ge.Context) {
 }
 
 func Run(state *session.State, levelGenChecksum, timeoutSeconds int, controller *staging.Controller) (serverapi.GameResults, error) {
+	return RunWithTickFunc(state, levelGenChecksum, timeoutSeconds, controller, nil)
+}
+
+// RunWithTickFunc is like Run, but it calls onTick after every simulation tick.
+// The onTick func can be used to collect the controller state hashes.
+func RunWithTickFunc(state *session.State, levelGenChecksum, timeoutSeconds int, controller *staging.Controller, onTick func(tick int)) (serverapi.GameResults, error) {
 	var simResult serverapi.GameResults
 
 	runner, scene := ge.NewSimulatedScene(state.Context, controller)
@@ -62,10 +68,15 @@ func Run(state *session.State, levelGenChecksum, timeoutSeconds int, controller
 	timeout := (time.Duration(timeoutSeconds) * time.Second)
 
 	start := time.Now()
+	tick := 0
 OuterLoop:
 	for {
 		for i := 0; i < 60*60; i++ {
 			runner.Update(1.0 / 60.0)
+			if onTick != nil {
+				onTick(tick)
+			}
+			tick++
 			var stop bool
 			simResult, stop = controller.GetSimulationResult()
 			if stop {
@@ -0,0 +1,91 @@
+package runsim_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/ge/langs"
+	"github.com/quasilyte/roboden-game/assets"
+	"github.com/quasilyte/roboden-game/gamedata"
+	"github.com/quasilyte/roboden-game/runsim"
+	"github.com/quasilyte/roboden-game/scenes/staging"
+	"github.com/quasilyte/roboden-game/serverapi"
+)
+
+func TestActionLogRoundTrip(t *testing.T) {
+	if testing.Short() {
+		t.Skip("the full game simulation is slow")
+	}
+
+	ctx := ge.NewContext(ge.ContextConfig{
+		Mute:       true,
+		FixedDelta: true,
+	})
+	ctx.Loader.OpenAssetFunc = assets.MakeOpenAssetFunc(ctx, "", "")
+	ctx.Dict = langs.NewDictionary("en", 2)
+	runsim.PrepareAssets(ctx)
+	state := runsim.NewState(ctx)
+
+	replayConfig := serverapi.ReplayLevelConfig{
+		RawGameMode:     "classic",
+		Seed:            2,
+		Tier2Recipes:    []string{"Fighter", "Skirmisher", "Defender"},
+		TurretDesign:    "Gunpoint",
+		CoreDesign:      "den",
+		PlayersMode:     serverapi.PmodeSingleBot,
+		DronesPower:     1,
+		Teleporters:     1,
+		OilRegenRate:    2,
+		Terrain:         1,
+		Resources:       2,
+		CreepDifficulty: 3,
+		InitialCreeps:   1,
+		NumCreepBases:   2,
+		CreepSpawnRate:  1,
+		BossDifficulty:  1,
+		GameSpeed:       3,
+		WorldEvents:     true,
+		Scavengers:      true,
+	}
+	newController := func() *staging.Controller {
+		config := gamedata.MakeLevelConfig(gamedata.ExecuteSimulation, replayConfig)
+		config.Finalize()
+		return staging.NewController(state, config, nil)
+	}
+
+	want, err := runsim.Run(state, 0, 120, newController())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, numSteps := range []int{1, 777, 20000} {
+		controller := newController()
+		runner, scene := ge.NewSimulatedScene(state.Context, controller)
+		controller.Init(scene)
+		for i := 0; i < numSteps; i++ {
+			runner.Update(1.0 / 60.0)
+		}
+
+		data, err := json.Marshal(controller.RecordActionLog())
+		if err != nil {
+			t.Fatal(err)
+		}
+		var log staging.ActionLog
+		if err := json.Unmarshal(data, &log); err != nil {
+			t.Fatal(err)
+		}
+
+		resumed, err := staging.NewResumedController(state, gamedata.ExecuteSimulation, &log, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		have, err := runsim.Run(state, 0, 120, resumed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have != want {
+			t.Fatalf("action log at step %d:\nhave: %+v\nwant: %+v", numSteps, have, want)
+		}
+	}
+}
@@ -200,6 +200,7 @@ func (c *BootloadController) loadExtra(ctx *ge.Context, config *assets.Config, p
 		{&gamedata.CourierAgentStats.BeamTexture, assets.ImageCourierLine, 120},
 		{&gamedata.RepairAgentStats.BeamTexture, assets.ImageRepairLine, gamedata.RepairAgentStats.SupportRange * 1.4},
 		{&gamedata.RechargerAgentStats.BeamTexture, assets.ImageRechargerLine, gamedata.RepairAgentStats.SupportRange * 1.4},
+		{&gamedata.ShielderAgentStats.BeamTexture, assets.ImageRechargerLine, gamedata.ShielderAgentStats.SupportRange * 1.4},
 		{&gamedata.DefenderAgentStats.BeamTexture, assets.ImageDefenderLine, gamedata.DefenderAgentStats.Weapon.AttackRange * 1.05},
 		{&gamedata.GuardianAgentStats.BeamTexture, assets.ImageDefenderLine, gamedata.GuardianAgentStats.Weapon.AttackRange * 1.05},
 		{&gamedata.BeamTowerAgentStats.BeamTexture, assets.ImageBeamtowerLine, gamedata.BeamTowerAgentStats.Weapon.AttackRange * 1.1},
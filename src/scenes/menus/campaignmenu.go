@@ -0,0 +1,192 @@
+package menus
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ebitenui/ebitenui/widget"
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/ge/xslices"
+	"github.com/quasilyte/roboden-game/assets"
+	"github.com/quasilyte/roboden-game/controls"
+	"github.com/quasilyte/roboden-game/gamedata"
+	"github.com/quasilyte/roboden-game/gameui/eui"
+	"github.com/quasilyte/roboden-game/scenes/staging"
+	"github.com/quasilyte/roboden-game/session"
+)
+
+type CampaignMenuController struct {
+	state *session.State
+
+	scene *ge.Scene
+
+	helpLabel *widget.Text
+}
+
+func NewCampaignMenuController(state *session.State) *CampaignMenuController {
+	return &CampaignMenuController{state: state}
+}
+
+func (c *CampaignMenuController) Init(scene *ge.Scene) {
+	c.scene = scene
+	c.initUI()
+}
+
+func (c *CampaignMenuController) Update(delta float64) {
+	if c.state.CombinedInput.ActionIsJustPressed(controls.ActionMenuBack) {
+		c.back()
+		return
+	}
+}
+
+func (c *CampaignMenuController) missionCompleted(m *gamedata.CampaignMission) bool {
+	return xslices.Contains(c.state.Persistent.PlayerStats.CampaignMissionsCompleted, m.ID)
+}
+
+func (c *CampaignMenuController) missionUnlocked(i int) bool {
+	if i == 0 {
+		return true
+	}
+	return c.missionCompleted(gamedata.CampaignMissionList[i-1])
+}
+
+func (c *CampaignMenuController) missionDescriptionText(m *gamedata.CampaignMission) string {
+	d := c.scene.Dict()
+
+	var buf strings.Builder
+	buf.WriteString(d.Get("campaign.mission", m.ID, "description"))
+	buf.WriteString("\n\n")
+
+	buf.WriteString(d.Get("menu.campaign.objective"))
+	buf.WriteString(": ")
+	if m.Objective == gamedata.ObjectiveTrigger {
+		buf.WriteString(d.Get("campaign.objective.tutorial"))
+	} else {
+		buf.WriteString(d.Get("campaign.objective", m.Objective.String()))
+	}
+	buf.WriteString("\n")
+
+	var rewards []string
+	if m.ScoreReward != 0 {
+		rewards = append(rewards, fmt.Sprintf("%d %s", m.ScoreReward, d.Get("menu.campaign.score")))
+	}
+	for _, core := range m.Rewards.Cores {
+		rewards = append(rewards, d.Get("core", core.Name))
+	}
+	for _, drone := range m.Rewards.Drones {
+		rewards = append(rewards, d.Get("drone", strings.ToLower(drone.Kind.String())))
+	}
+	for _, turret := range m.Rewards.Turrets {
+		rewards = append(rewards, d.Get("turret", strings.ToLower(turret.Kind.String())))
+	}
+	for _, mode := range m.Rewards.Modes {
+		rewards = append(rewards, d.Get("menu.leaderboard", mode))
+	}
+	if len(rewards) != 0 {
+		buf.WriteString(d.Get("menu.campaign.rewards"))
+		buf.WriteString(": ")
+		buf.WriteString(strings.Join(rewards, ", "))
+		buf.WriteString("\n")
+	}
+
+	if c.missionCompleted(m) {
+		buf.WriteString("\n")
+		buf.WriteString(d.Get("menu.campaign.completed"))
+	}
+
+	return buf.String()
+}
+
+func (c *CampaignMenuController) setHelpText(s string) {
+	c.helpLabel.Label = s
+}
+
+func (c *CampaignMenuController) initUI() {
+	eui.AddBackground(c.state.BackgroundImage, c.scene)
+	uiResources := c.state.Resources.UI
+
+	root := eui.NewAnchorContainer()
+	rowContainer := eui.NewRowLayoutContainerWithMinWidth(440, 10, nil)
+	root.AddChild(rowContainer)
+
+	d := c.scene.Dict()
+
+	titleLabel := eui.NewCenteredLabel(d.Get("menu.play.campaign"), assets.BitmapFont3)
+	rowContainer.AddChild(titleLabel)
+
+	rootGrid := widget.NewContainer(
+		widget.ContainerOpts.WidgetOpts(widget.WidgetOpts.LayoutData(widget.RowLayoutData{
+			Stretch: true,
+		})),
+		widget.ContainerOpts.Layout(widget.NewGridLayout(
+			widget.GridLayoutOpts.Columns(2),
+			widget.GridLayoutOpts.Stretch([]bool{false, true}, nil),
+			widget.GridLayoutOpts.Spacing(4, 4))))
+	rowContainer.AddChild(rootGrid)
+
+	buttonsContainer := widget.NewContainer(
+		widget.ContainerOpts.WidgetOpts(
+			widget.WidgetOpts.LayoutData(widget.RowLayoutData{
+				Stretch: true,
+			}),
+		),
+		widget.ContainerOpts.Layout(widget.NewGridLayout(
+			widget.GridLayoutOpts.Columns(1),
+			widget.GridLayoutOpts.Stretch([]bool{true}, nil),
+			widget.GridLayoutOpts.Spacing(4, 4),
+		)),
+	)
+
+	leftPanel := eui.NewPanel(uiResources, 360, 0)
+	leftPanel.AddChild(buttonsContainer)
+	rootGrid.AddChild(leftPanel)
+
+	helpLabel := eui.NewLabel(d.Get("menu.overview.campaign"), assets.BitmapFont1)
+	helpLabel.MaxWidth = 320
+	c.helpLabel = helpLabel
+
+	rightPanel := eui.NewTextPanel(uiResources, 360, 0)
+	rightPanel.AddChild(helpLabel)
+	rootGrid.AddChild(rightPanel)
+
+	for i, m := range gamedata.CampaignMissionList {
+		m := m
+		label := fmt.Sprintf("%d. %s", i+1, d.Get("campaign.mission", m.ID))
+		b := eui.NewButtonWithConfig(uiResources, eui.ButtonConfig{
+			Scene: c.scene,
+			Text:  label,
+			OnPressed: func() {
+				c.startMission(m)
+			},
+			OnHover: func() { c.setHelpText(c.missionDescriptionText(m)) },
+		})
+		b.GetWidget().Disabled = !c.missionUnlocked(i)
+		buttonsContainer.AddChild(b)
+	}
+
+	rowContainer.AddChild(eui.NewButton(uiResources, c.scene, d.Get("menu.back"), func() {
+		c.back()
+	}))
+
+	uiObject := eui.NewSceneObject(root)
+	c.scene.AddGraphics(uiObject)
+	c.scene.AddObject(uiObject)
+}
+
+func (c *CampaignMenuController) startMission(m *gamedata.CampaignMission) {
+	var config gamedata.LevelConfig
+	if m.Mode == "tutorial" {
+		config = newIntroLevelConfig(c.state, c.scene)
+	} else {
+		config = m.MakeLevelConfig()
+		if config.Seed == 0 {
+			config.Seed = c.scene.Rand().PositiveInt64()
+		}
+	}
+	back := NewCampaignMenuController(c.state)
+	c.scene.Context().ChangeScene(staging.NewController(c.state, config, back))
+}
+
+func (c *CampaignMenuController) back() {
+	c.scene.Context().ChangeScene(NewPlayMenuController(c.state))
+}
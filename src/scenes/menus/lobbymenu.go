@@ -176,6 +176,10 @@ func (c *LobbyMenuController) getConfigForMode() *gamedata.LevelConfig {
 		return c.state.ClassicLevelConfig
 	case gamedata.ModeReverse:
 		return c.state.ReverseLevelConfig
+	case gamedata.ModeKingOfTheHill:
+		return c.state.KothLevelConfig
+	case gamedata.ModeVersus:
+		return c.state.VersusLevelConfig
 	case gamedata.ModeTutorial:
 		return c.state.TutorialLevelConfig
 	default:
@@ -200,7 +204,7 @@ func (c *LobbyMenuController) createButtonsPanel(uiResources *eui.Resources) *wi
 	panel.AddChild(eui.NewButton(uiResources, c.scene, d.Get("menu.lobby.go"), func() {
 		c.saveConfig()
 
-		if c.config.PlayersMode == serverapi.PmodeSinglePlayer && c.mode == gamedata.ModeReverse {
+		if c.mode == gamedata.ModeReverse && c.isColonyBot() {
 			c.config.CoreDesign = gamedata.PickColonyDesign(c.state.Persistent.PlayerStats.CoresUnlocked, c.scene.Rand())
 			c.config.TurretDesign = gamedata.PickTurretDesign(c.scene.Rand())
 			c.config.Tier2Recipes = gamedata.CreateDroneBuild(c.scene.Rand())
@@ -239,9 +243,7 @@ func (c *LobbyMenuController) createTabs(uiResources *eui.Resources) *widget.Tab
 	tabs = append(tabs, c.createDifficultyTab(uiResources))
 	tabs = append(tabs, c.createExtraTab(uiResources))
 
-	if c.config.RawGameMode == "reverse" {
-		c.maybeDisableColonyTab(c.config.PlayersMode != serverapi.PmodeTwoPlayers)
-	}
+	c.maybeDisableColonyTab(c.isColonyBot())
 
 	t := widget.NewTabBook(
 		// widget.TabBookOpts.InitialTab(worldTab),
@@ -262,6 +264,17 @@ func (c *LobbyMenuController) createTabs(uiResources *eui.Resources) *widget.Tab
 	return t
 }
 
+// isColonyBot reports whether the colony is controlled by a computer in the reverse mode.
+// The first player commands the creeps there, the second one controls the colony.
+func (c *LobbyMenuController) isColonyBot() bool {
+	switch c.config.PlayersMode {
+	case serverapi.PmodeSinglePlayer, serverapi.PmodeTwoBots:
+		return true
+	default:
+		return false
+	}
+}
+
 func (c *LobbyMenuController) maybeDisableColonyTab(disable bool) {
 	if c.config.RawGameMode != "reverse" {
 		return
@@ -283,7 +296,10 @@ func (c *LobbyMenuController) createExtraTab(uiResources *eui.Resources) *widget
 	{
 		disabled := []int{}
 		if c.config.RawGameMode == "reverse" {
-			disabled = append(disabled, 1, 2, 4) // These combinations are not supported for this mode
+			disabled = append(disabled, 1) // There is only one colony player in this mode
+		}
+		if c.config.RawGameMode == "koth" || c.config.RawGameMode == "versus" {
+			disabled = append(disabled, 0, 1) // This mode requires two sides
 		}
 		if c.state.Device.IsMobile() {
 			disabled = append(disabled, 3) // Two players are not available on mobiles
@@ -300,12 +316,32 @@ func (c *LobbyMenuController) createExtraTab(uiResources *eui.Resources) *widget
 			d.Get("menu.lobby.player_mode.two_bots"),
 		})
 		tab.AddChild(b)
-		b.PressedEvent.AddHandler(func(args interface{}) {
-			// This handler is called before the config value is changed.
-			c.maybeDisableColonyTab(c.config.PlayersMode == serverapi.PmodeTwoPlayers)
+		b.ClickedEvent.AddHandler(func(args interface{}) {
+			// This handler is called after the config value is changed.
+			c.maybeDisableColonyTab(c.isColonyBot())
 		})
 	}
 
+	{
+		profileNames := []string{
+			d.Get("menu.lobby.bot_profile.normal"),
+			d.Get("menu.lobby.bot_profile.easy"),
+			d.Get("menu.lobby.bot_profile.hard"),
+			d.Get("menu.lobby.bot_profile.turtle"),
+			d.Get("menu.lobby.bot_profile.rusher"),
+			d.Get("menu.lobby.bot_profile.economist"),
+		}
+		switch c.config.RawGameMode {
+		case "reverse", "koth", "versus":
+			// These modes have two rival sides, the bots of each side
+			// can have their own profile.
+			tab.AddChild(c.newOptionButton(&c.config.BotProfile, "menu.lobby.ally_bot_profile", profileNames))
+			tab.AddChild(c.newOptionButton(&c.config.OpponentBotProfile, "menu.lobby.opponent_bot_profile", profileNames))
+		default:
+			tab.AddChild(c.newOptionButton(&c.config.BotProfile, "menu.lobby.bot_profile", profileNames))
+		}
+	}
+
 	if c.config.RawGameMode != "reverse" {
 		disabled := []int{}
 		if c.config.RawGameMode == "arena" || c.config.RawGameMode == "inf_arena" {
@@ -356,6 +392,7 @@ func (c *LobbyMenuController) createExtraTab(uiResources *eui.Resources) *widget
 	if c.config.RawGameMode != "reverse" {
 		toggleButtons = append(toggleButtons, c.newToggleItemButton(&c.config.FogOfWar, "fog_of_war", assets.ImageItemFogOfWar))
 	}
+	toggleButtons = append(toggleButtons, c.newToggleItemButton(&c.config.WorldEvents, "world_events", assets.ImageItemWorldEvents))
 
 	for _, b := range toggleButtons {
 		grid.AddChild(b)
@@ -516,6 +553,11 @@ func (c *LobbyMenuController) createDifficultyTab(uiResources *eui.Resources) *w
 	if c.mode == gamedata.ModeClassic {
 		toggleButtons = append(toggleButtons, c.newToggleItemButton(&c.config.CoordinatorCreeps, "coordinator_creeps", assets.ImageCreepCenturion))
 	}
+	if c.mode != gamedata.ModeReverse {
+		toggleButtons = append(toggleButtons, c.newToggleItemButton(&c.config.TechTree, "tech_tree", assets.ImageItemTechTree))
+		toggleButtons = append(toggleButtons, c.newToggleItemButton(&c.config.TurretUpgrades, "turret_upgrades", assets.ImageActionUpgradeTurret))
+	}
+	toggleButtons = append(toggleButtons, c.newToggleItemButton(&c.config.Scavengers, "scavengers", assets.ImageItemScavengers))
 
 	for _, b := range toggleButtons {
 		grid.AddChild(b)
@@ -685,11 +727,22 @@ func (c *LobbyMenuController) createWorldTab(uiResources *eui.Resources) *widget
 		tab.AddChild(b)
 	}
 
+	{
+		b := c.newOptionButton(&c.config.Symmetry, "menu.lobby.symmetry", []string{
+			d.Get("menu.option.off"),
+			d.Get("menu.lobby.symmetry.mirror_x"),
+			d.Get("menu.lobby.symmetry.mirror_y"),
+			d.Get("menu.lobby.symmetry.rotational"),
+		})
+		tab.AddChild(b)
+	}
+
 	{
 		b := c.newOptionButton(&c.config.Environment, "menu.lobby.environment", []string{
 			d.Get("menu.lobby.forest"),
 			d.Get("menu.lobby.inferno"),
 			d.Get("menu.lobby.moon"),
+			d.Get("menu.lobby.ice"),
 		})
 		tab.AddChild(b)
 	}
@@ -1021,7 +1074,7 @@ func (c *LobbyMenuController) createDronesPanel(uiResources *eui.Resources) *wid
 			widget.GridLayoutOpts.Columns(8),
 			widget.GridLayoutOpts.Spacing(4, 4))))
 
-	maxNumDrones := 8 * 3
+	maxNumDrones := 8 * 4
 	for i := range gamedata.Tier2agentMergeRecipes {
 		recipe := gamedata.Tier2agentMergeRecipes[i]
 		drone := recipe.Result
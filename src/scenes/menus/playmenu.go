@@ -12,6 +12,7 @@ import (
 	"github.com/quasilyte/roboden-game/gameui/eui"
 	"github.com/quasilyte/roboden-game/scenes/staging"
 	"github.com/quasilyte/roboden-game/session"
+	"github.com/quasilyte/roboden-game/timeutil"
 )
 
 type PlayMenuController struct {
@@ -20,6 +21,10 @@ type PlayMenuController struct {
 	scene *ge.Scene
 
 	helpLabel *widget.Text
+
+	// resumeFailed is set when this menu is returned to
+	// after the saved game resuming failure.
+	resumeFailed bool
 }
 
 func NewPlayMenuController(state *session.State) *PlayMenuController {
@@ -29,6 +34,10 @@ func NewPlayMenuController(state *session.State) *PlayMenuController {
 func (c *PlayMenuController) Init(scene *ge.Scene) {
 	c.scene = scene
 	c.initUI()
+
+	if c.resumeFailed {
+		c.setHelpText(scene.Dict().Get("menu.play.continue_failed"))
+	}
 }
 
 func (c *PlayMenuController) Update(delta float64) {
@@ -102,16 +111,43 @@ func (c *PlayMenuController) initUI() {
 	rightPanel.AddChild(helpLabel)
 	rootGrid.AddChild(rightPanel)
 
+	if saved := staging.LoadSavedGame(c.state); saved != nil {
+		b := eui.NewButtonWithConfig(uiResources, eui.ButtonConfig{
+			Scene: c.scene,
+			Text:  d.Get("menu.play.continue"),
+			OnPressed: func() {
+				back := NewPlayMenuController(c.state)
+				controller, err := staging.NewResumedController(c.state, gamedata.ExecuteNormal, saved.Log, back)
+				if err != nil {
+					c.state.Logf("continue the saved game: %v", err)
+					c.setHelpText(d.Get("menu.play.continue_failed"))
+					return
+				}
+				controller.EventResumed.Connect(nil, func(err error) {
+					if err != nil {
+						back.resumeFailed = true
+						return
+					}
+					// The saved game slot is cleared only after it's resumed,
+					// so it's not lost if the resuming is interrupted.
+					staging.ClearSavedGame(c.state)
+				})
+				c.scene.Context().ChangeScene(controller)
+			},
+			OnHover: func() {
+				c.setHelpText(d.Get("menu.overview.continue") + "\n\n" + timeutil.FormatDateISO8601(saved.Date, true))
+			},
+		})
+		buttonsContainer.AddChild(b)
+	}
+
 	{
 		b := eui.NewButtonWithConfig(uiResources, eui.ButtonConfig{
 			Scene: c.scene,
 			Text:  d.Get("menu.play.intro_mission"),
 			OnPressed: func() {
 				back := NewPlayMenuController(c.state)
-				config := c.state.TutorialLevelConfig.Clone()
-				config.Seed = c.scene.Rand().PositiveInt64()
-				config.CreepDifficulty = c.state.Persistent.Settings.IntroDifficulty
-				config.GameSpeed = c.state.Persistent.Settings.IntroSpeed
+				config := newIntroLevelConfig(c.state, c.scene)
 				c.scene.Context().ChangeScene(staging.NewController(c.state, config, back))
 			},
 			OnHover: func() { c.setHelpText(d.Get("menu.overview.intro_mission")) },
@@ -121,6 +157,19 @@ func (c *PlayMenuController) initUI() {
 
 	playerStats := &c.state.Persistent.PlayerStats
 
+	{
+		b := eui.NewButtonWithConfig(uiResources, eui.ButtonConfig{
+			Scene: c.scene,
+			Text:  d.Get("menu.play.campaign"),
+			OnPressed: func() {
+				c.scene.Context().ChangeScene(NewCampaignMenuController(c.state))
+			},
+			OnHover: func() { c.setHelpText(d.Get("menu.overview.campaign")) },
+		})
+		b.GetWidget().Disabled = !playerStats.TutorialCompleted
+		buttonsContainer.AddChild(b)
+	}
+
 	{
 		label := d.Get("menu.play.classic")
 		b := eui.NewButtonWithConfig(uiResources, eui.ButtonConfig{
@@ -177,6 +226,34 @@ func (c *PlayMenuController) initUI() {
 		buttonsContainer.AddChild(b)
 	}
 
+	{
+		label := d.Get("menu.play.koth")
+		b := eui.NewButtonWithConfig(uiResources, eui.ButtonConfig{
+			Scene: c.scene,
+			Text:  label,
+			OnPressed: func() {
+				c.scene.Context().ChangeScene(NewLobbyMenuController(c.state, gamedata.ModeKingOfTheHill))
+			},
+			OnHover: func() { c.setHelpText(c.modeDescriptionText("koth", gamedata.KothModeCost)) },
+		})
+		b.GetWidget().Disabled = !xslices.Contains(playerStats.ModesUnlocked, "koth")
+		buttonsContainer.AddChild(b)
+	}
+
+	{
+		label := d.Get("menu.play.versus")
+		b := eui.NewButtonWithConfig(uiResources, eui.ButtonConfig{
+			Scene: c.scene,
+			Text:  label,
+			OnPressed: func() {
+				c.scene.Context().ChangeScene(NewLobbyMenuController(c.state, gamedata.ModeVersus))
+			},
+			OnHover: func() { c.setHelpText(c.modeDescriptionText("versus", gamedata.VersusModeCost)) },
+		})
+		b.GetWidget().Disabled = !xslices.Contains(playerStats.ModesUnlocked, "versus")
+		buttonsContainer.AddChild(b)
+	}
+
 	rowContainer.AddChild(eui.NewButton(uiResources, c.scene, d.Get("menu.back"), func() {
 		c.back()
 	}))
@@ -189,3 +266,11 @@ func (c *PlayMenuController) initUI() {
 func (c *PlayMenuController) back() {
 	c.scene.Context().ChangeScene(NewMainMenuController(c.state))
 }
+
+func newIntroLevelConfig(state *session.State, scene *ge.Scene) gamedata.LevelConfig {
+	config := state.TutorialLevelConfig.Clone()
+	config.Seed = scene.Rand().PositiveInt64()
+	config.CreepDifficulty = state.Persistent.Settings.IntroDifficulty
+	config.GameSpeed = state.Persistent.Settings.IntroSpeed
+	return config
+}
@@ -95,6 +95,8 @@ func (c *ProfileDroneCollectionMenuController) initUI() {
 			return xslices.Contains(stats.DronesUnlocked, d.Kind.String())
 		case 3:
 			return xslices.Contains(stats.Tier3DronesSeen, d.Kind.String())
+		case 4:
+			return xslices.Contains(stats.Tier4DronesSeen, d.Kind.String())
 		default:
 			return true
 		}
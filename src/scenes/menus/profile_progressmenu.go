@@ -69,6 +69,7 @@ func (c *ProfileProgressMenuController) initUI() {
 		{d.Get("menu.profile.progress.turrets_unlocked"), fmt.Sprintf("%d/%d", len(stats.TurretsUnlocked), len(gamedata.TurretStatsList))},
 		{d.Get("menu.profile.progress.drones_unlocked"), fmt.Sprintf("%d/%d", len(stats.DronesUnlocked), numDrones)},
 		{d.Get("menu.profile.progress.t3drones_seen"), fmt.Sprintf("%d/%d", len(stats.Tier3DronesSeen), len(gamedata.Tier3agentMergeRecipes))},
+		{d.Get("menu.profile.progress.t4drones_seen"), fmt.Sprintf("%d/%d", len(stats.Tier4DronesSeen), len(gamedata.Tier4agentMergeRecipes))},
 		{d.Get("menu.profile.progress.modes_unlocked"), fmt.Sprintf("%d/%d", len(stats.ModesUnlocked), len(gamedata.GameModeInfoMap))},
 		{d.Get("menu.profile.progress.extra_options_unlocked"), fmt.Sprintf("%d/%d", len(stats.OptionsUnlocked), len(gamedata.LobbyOptionMap))},
 	}
@@ -51,8 +51,10 @@ func (c *SplashScreenController) Init(scene *ge.Scene) {
 	switch envRoll := scene.Rand().Float(); {
 	case envRoll < 0.4:
 		config.Environment = int(gamedata.EnvForest)
-	case envRoll < 0.75:
+	case envRoll < 0.7:
 		config.Environment = int(gamedata.EnvInferno)
+	case envRoll < 0.85:
+		config.Environment = int(gamedata.EnvIce)
 	default:
 		config.Environment = int(gamedata.EnvMoon)
 	}
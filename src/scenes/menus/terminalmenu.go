@@ -460,20 +460,26 @@ func (c *TerminalMenu) calcDroneScore(drone *gamedata.AgentStats) droneScore {
 
 	// Rate the special abilities.
 	switch drone.Kind {
+	case gamedata.AgentHive:
+		score += 20
 	case gamedata.AgentCloner, gamedata.AgentRepair:
 		score += 18
+	case gamedata.AgentTitan:
+		score += 14
 	case gamedata.AgentRedminer:
 		score += 14
 	case gamedata.AgentServo:
 		score += 12
-	case gamedata.AgentCourier, gamedata.AgentTrucker, gamedata.AgentRecharger:
+	case gamedata.AgentShielder:
+		score += 12
+	case gamedata.AgentCourier, gamedata.AgentTrucker, gamedata.AgentRecharger, gamedata.AgentRelay:
 		score += 8
 	case gamedata.AgentScarab:
 		// Becomes much better if kept safe.
 		score += 8
 	case gamedata.AgentKamikaze:
 		score += 6
-	case gamedata.AgentGenerator, gamedata.AgentScavenger, gamedata.AgentMarauder:
+	case gamedata.AgentGenerator, gamedata.AgentScavenger, gamedata.AgentMarauder, gamedata.AgentMinelayer:
 		score += 4
 	}
 
@@ -593,6 +599,13 @@ func (c *TerminalMenu) onBalanceDronesCalc(*terminalCommandContext) (string, err
 	c.sortDroneScoreList(tier3)
 	c.dumpList("tier 3", tier3)
 
+	var tier4 []droneScore
+	for _, recipe := range gamedata.Tier4agentMergeRecipes {
+		tier4 = append(tier4, c.calcDroneScore(recipe.Result))
+	}
+	c.sortDroneScoreList(tier4)
+	c.dumpList("tier 4", tier4)
+
 	return "Drones balance report is dumped to the console", nil
 }
 
@@ -4,6 +4,8 @@ import (
 	resource "github.com/quasilyte/ebitengine-resource"
 	"github.com/quasilyte/ge"
 	"github.com/quasilyte/gmath"
+
+	"github.com/quasilyte/roboden-game/detmath"
 )
 
 type attachedSpriteNode struct {
@@ -35,7 +37,7 @@ func (s *attachedSpriteNode) Init(scene *ge.Scene) {
 	s.world.stage.AddSpriteAbove(s.sprite)
 
 	if s.rotates {
-		s.rotation = s.world.localRand.Rad()
+		s.rotation = detmath.RandRad(s.world.localRand)
 	}
 }
 
@@ -99,7 +99,7 @@ func (b *beamNode) IsDisposed() bool {
 
 func (b *beamNode) Update(delta float64) {
 	if b.texLine != nil && !b.texLine.Shader.IsNil() {
-		b.shaderTime += delta * b.beamSlideSpeed
+		b.shaderTime += float64(delta * b.beamSlideSpeed)
 		b.texLine.Shader.SetFloatValue("Time", b.shaderTime)
 	}
 	if b.opaqueTime > 0 {
@@ -0,0 +1,94 @@
+package staging
+
+import (
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/gmath"
+
+	"github.com/quasilyte/roboden-game/detmath"
+)
+
+const (
+	// The fog of war reveal radius multiplier during the blizzard.
+	blizzardVisionMultiplier = 0.6
+
+	// The radar can't detect anything beyond this range during the blizzard.
+	blizzardRadarRange float64 = 640
+
+	blizzardOverlayAlpha = 0.3
+)
+
+// blizzardNode is an ice environment weather event.
+// While the blizzard is active, the radar range and the
+// fog of war reveal radius are reduced.
+type blizzardNode struct {
+	world *worldState
+
+	duration float64
+	delay    float64
+
+	alpha    float64
+	overlays []*ge.Rect
+}
+
+func newBlizzardNode(world *worldState) *blizzardNode {
+	return &blizzardNode{world: world}
+}
+
+func (b *blizzardNode) Init(scene *ge.Scene) {
+	b.delay = detmath.FloatRange(b.world.rand, 90, 180)
+
+	if b.world.simulation {
+		return
+	}
+	for _, cam := range b.world.cameras {
+		overlay := ge.NewRect(scene.Context(), cam.Rect.Width(), cam.Rect.Height())
+		overlay.Centered = false
+		overlay.Visible = false
+		cam.UI.AddGraphicsBelow(overlay)
+		b.overlays = append(b.overlays, overlay)
+	}
+}
+
+func (b *blizzardNode) IsDisposed() bool { return false }
+
+func (b *blizzardNode) IsActive() bool { return b.duration > 0 }
+
+func (b *blizzardNode) Update(delta float64) {
+	b.updateOverlays(delta)
+
+	if b.duration > 0 {
+		b.duration = gmath.ClampMin(b.duration-delta, 0)
+		if b.duration == 0 {
+			b.delay = detmath.FloatRange(b.world.rand, 90, 180)
+		}
+		return
+	}
+
+	b.delay = gmath.ClampMin(b.delay-delta, 0)
+	if b.delay == 0 {
+		b.duration = detmath.FloatRange(b.world.rand, 20, 35)
+	}
+}
+
+func (b *blizzardNode) updateOverlays(delta float64) {
+	if len(b.overlays) == 0 {
+		return
+	}
+
+	targetAlpha := 0.0
+	if b.IsActive() {
+		targetAlpha = blizzardOverlayAlpha
+	}
+	if b.alpha == targetAlpha {
+		return
+	}
+	if b.alpha < targetAlpha {
+		b.alpha = gmath.ClampMax(b.alpha+float64(delta*0.1), targetAlpha)
+	} else {
+		b.alpha = gmath.ClampMin(b.alpha-float64(delta*0.1), targetAlpha)
+	}
+	for _, overlay := range b.overlays {
+		overlay.Visible = b.alpha != 0
+		overlay.FillColorScale.SetRGBA(0xe0, 0xf0, 0xff, uint8(b.alpha*255))
+	}
+}
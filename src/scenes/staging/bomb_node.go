@@ -6,6 +6,7 @@ import (
 	"github.com/quasilyte/ge"
 	"github.com/quasilyte/gmath"
 	"github.com/quasilyte/roboden-game/assets"
+	"github.com/quasilyte/roboden-game/detmath"
 	"github.com/quasilyte/roboden-game/gamedata"
 )
 
@@ -57,7 +58,7 @@ func (b *bombNode) explode() {
 	const maxRadius = 56
 	const maxRadiusSqr = maxRadius * maxRadius
 	b.world.WalkCreeps(b.pos, 40, func(creep *creepNode) bool {
-		distSqr := b.pos.DistanceSquaredTo(creep.pos)
+		distSqr := detmath.DistanceSquaredTo(b.pos, creep.pos)
 		if distSqr <= maxRadiusSqr {
 			// 40 => 0.5
 			// 20 => 0.75
@@ -80,7 +81,7 @@ func (b *bombNode) dispose() {
 }
 
 func (b *bombNode) Update(delta float64) {
-	travelled := delta * 200
+	travelled := float64(delta * 200)
 	b.height -= travelled
 	b.pos.Y += travelled
 	if b.height <= 0 {
@@ -4,6 +4,8 @@ import (
 	"github.com/quasilyte/ge"
 	"github.com/quasilyte/gmath"
 	"github.com/quasilyte/gsignal"
+
+	"github.com/quasilyte/roboden-game/detmath"
 )
 
 type builderLaserNode struct {
@@ -69,9 +71,9 @@ func (laser *builderLaserNode) OnBuildingStop(gsignal.Void) {
 func (laser *builderLaserNode) Update(delta float64) {
 	laser.armMoveDelay = gmath.ClampMin(laser.armMoveDelay-delta, 0)
 	if laser.armMoveDelay == 0 {
-		laser.armMoveDelay = laser.world.localRand.FloatRange(0.05, 0.1)
+		laser.armMoveDelay = detmath.FloatRange(laser.world.localRand, 0.05, 0.1)
 		armMoved := laser.world.localRand.IntRange(0, 4)
 
-		laser.arms[armMoved].EndPos.Offset = laser.world.localRand.Offset(-2, 2)
+		laser.arms[armMoved].EndPos.Offset = detmath.Offset(laser.world.localRand, -2, 2)
 	}
 }
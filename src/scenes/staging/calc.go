@@ -4,6 +4,7 @@ import (
 	"math"
 
 	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/roboden-game/detmath"
 	"github.com/quasilyte/roboden-game/gamedata"
 )
 
@@ -73,14 +74,14 @@ func calcPosDanger(world *worldState, pstate *playerState, pos gmath.Vec, r floa
 		if power == 0 {
 			continue
 		}
-		if turret.pos.DistanceSquaredTo(pos) < rSqr {
+		if detmath.DistanceSquaredTo(turret.pos, pos) < rSqr {
 			dangerDecrease += turretPower
 		}
 	}
 	if pstate.hasRoombas {
 		for _, c := range pstate.colonies {
 			for _, roomba := range c.roombas {
-				if roomba.pos.DistanceSquaredTo(pos) < rSqr {
+				if detmath.DistanceSquaredTo(roomba.pos, pos) < rSqr {
 					dangerDecrease += int(gamedata.RoombaAgentStats.Cost)
 				}
 			}
@@ -178,6 +179,10 @@ func creepFragScore(stats *gamedata.CreepStats) int {
 }
 
 func calcScore(world *worldState) int {
+	if world.config.Mission != "" {
+		return gamedata.FindCampaignMission(world.config.Mission).ScoreReward
+	}
+
 	switch world.config.GameMode {
 	case gamedata.ModeTutorial:
 		return 500
@@ -208,7 +213,7 @@ func calcScore(world *worldState) int {
 		if world.boss != nil {
 			score += int((world.boss.health / world.boss.maxHealth) * 500.0)
 		}
-		multiplier := 1.0 - (0.000347222 * (world.result.TimePlayed.Seconds() / 5))
+		multiplier := 1.0 - float64(0.000347222*(world.result.TimePlayed.Seconds()/5))
 		if multiplier < 0 {
 			multiplier = 0.001
 		}
@@ -218,7 +223,7 @@ func calcScore(world *worldState) int {
 		score := world.config.DifficultyScore * 10
 		crystalsCollected := gmath.Percentage(world.result.RedCrystalsCollected, world.numRedCrystals)
 		score += crystalsCollected * 3
-		multiplier := 1.0 - (0.000347222 * (world.result.TimePlayed.Seconds() / 5))
+		multiplier := 1.0 - float64(0.000347222*(world.result.TimePlayed.Seconds()/5))
 		if multiplier < 0 {
 			multiplier = 0.001
 		}
@@ -231,8 +236,11 @@ func calcScore(world *worldState) int {
 
 func mergeAgents(world *worldState, x, y *colonyAgentNode) *gamedata.AgentStats {
 	list := world.tier2recipes
-	if x.stats.Tier == 2 {
+	switch x.stats.Tier {
+	case 2:
 		list = gamedata.Tier3agentMergeRecipes
+	case 3:
+		list = gamedata.Tier4agentMergeRecipes
 	}
 	for _, recipe := range list {
 		if recipe.Match(x.AsRecipeSubject(), y.AsRecipeSubject()) {
@@ -248,7 +256,7 @@ func agentCloningEnergyCost() float64 {
 
 func agentCloningCost(core *colonyCoreNode, cloner, a *colonyAgentNode) float64 {
 	multiplier := 0.85
-	return a.stats.Cost * multiplier
+	return float64(a.stats.Cost * multiplier)
 }
 
 func resourceScore(core *colonyCoreNode, source *essenceSourceNode) float64 {
@@ -258,13 +266,13 @@ func resourceScore(core *colonyCoreNode, source *essenceSourceNode) float64 {
 	if core.failedResource == source {
 		return 0
 	}
-	dist := core.pos.DistanceTo(source.pos)
-	maxDist := 1.5 + (core.GetResourcePriority() * 0.5)
+	dist := detmath.DistanceTo(core.pos, source.pos)
+	maxDist := 1.5 + float64(core.GetResourcePriority()*0.5)
 	if dist > core.realRadius*maxDist || source.resource == 0 {
 		return 0
 	}
 	distScore := 8.0 - gmath.ClampMax(dist/120, 8.0)
-	multiplier := 1.0 + (source.stats.value * 0.1)
+	multiplier := 1.0 + float64(source.stats.value*0.1)
 	if source.stats.regenDelay == 0 {
 		multiplier += 0.3
 	}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/quasilyte/gmath"
 	"github.com/quasilyte/roboden-game/controls"
+	"github.com/quasilyte/roboden-game/detmath"
 	"github.com/quasilyte/roboden-game/gamedata"
 	"github.com/quasilyte/roboden-game/gameinput"
 	"github.com/quasilyte/roboden-game/viewport"
@@ -124,14 +125,13 @@ func (m *cameraManager) HandleInput() {
 					m.cameraPanStartPos = info.Pos
 				} else if info, ok := m.input.PressedActionInfo(controls.ActionPanAlt); ok {
 					m.cameraToggleTarget = gmath.Vec{}
-					posDelta := m.cameraPanStartPos.Sub(info.Pos).Mulf(m.cameraDragSpeed)
-					newPos := m.cameraPanDragPos.Add(posDelta)
+					newPos := detmath.AddScaled(m.cameraPanDragPos, m.cameraPanStartPos.Sub(info.Pos), m.cameraDragSpeed)
 					m.SetOffset(newPos)
 				}
 			} else {
 				if info, ok := m.input.PressedActionInfo(controls.ActionPanAlt); ok {
 					cameraCenter := m.Rect.Center()
-					cameraPan = gmath.RadToVec(cameraCenter.AngleToPoint(info.Pos)).Mulf(m.cameraPanSpeed * 0.8)
+					cameraPan = detmath.RadToVec(detmath.AngleToPoint(cameraCenter, info.Pos)).Mulf(m.cameraPanSpeed * 0.8)
 				}
 			}
 		}
@@ -163,8 +163,7 @@ func (m *cameraManager) HandleInput() {
 		}
 		if info, ok := m.input.PressedActionInfo(controls.ActionPanDrag); ok {
 			m.cameraToggleTarget = gmath.Vec{}
-			posDelta := info.StartPos.Sub(info.Pos).Mulf(m.cameraDragSpeed)
-			newPos := m.cameraPanDragPos.Add(posDelta)
+			newPos := detmath.AddScaled(m.cameraPanDragPos, info.StartPos.Sub(info.Pos), m.cameraDragSpeed)
 			m.SetOffset(newPos)
 		}
 	}
@@ -176,7 +175,7 @@ func (m *cameraManager) findTwoColonies(pstate *playerState, dist float64) [2]*c
 			if c1 == c2 {
 				continue
 			}
-			if c1.pos.DistanceTo(c2.pos) <= dist {
+			if detmath.DistanceTo(c1.pos, c2.pos) <= dist {
 				return [2]*colonyCoreNode{c1, c2}
 			}
 		}
@@ -186,9 +185,9 @@ func (m *cameraManager) findTwoColonies(pstate *playerState, dist float64) [2]*c
 
 func (m *cameraManager) Update(delta float64) {
 	if !m.cameraToggleTarget.IsZero() {
-		m.cameraToggleProgress = gmath.ClampMax(m.cameraToggleProgress+(delta*m.cameraToggleSpeed), 1)
-		m.CenterOn(m.CenterPos().LinearInterpolate(m.cameraToggleTarget, m.cameraToggleProgress))
-		if m.cameraToggleProgress >= m.cameraToggleSnapProgress || m.CenterPos().DistanceSquaredTo(m.cameraToggleTarget) < m.cameraToggleSnapDistSqr {
+		m.cameraToggleProgress = gmath.ClampMax(m.cameraToggleProgress+float64(delta*m.cameraToggleSpeed), 1)
+		m.CenterOn(detmath.LinearInterpolate(m.CenterPos(), m.cameraToggleTarget, m.cameraToggleProgress))
+		if m.cameraToggleProgress >= m.cameraToggleSnapProgress || detmath.DistanceSquaredTo(m.CenterPos(), m.cameraToggleTarget) < m.cameraToggleSnapDistSqr {
 			m.CenterOn(m.cameraToggleTarget)
 			m.cameraToggleTarget = gmath.Vec{}
 		}
@@ -200,9 +199,9 @@ func (m *cameraManager) Update(delta float64) {
 			pstate := gmath.RandElem(m.world.localRand, m.world.players).GetState()
 			if m.world.boss != nil && m.world.localRand.Chance(0.6) {
 				for _, c := range pstate.colonies {
-					if c.pos.DistanceTo(m.world.boss.pos) < 500 {
+					if detmath.DistanceTo(c.pos, m.world.boss.pos) < 500 {
 						if m.world.localRand.Chance(0.85) {
-							m.cinematicSwitchDelay = m.world.localRand.FloatRange(10, 14)
+							m.cinematicSwitchDelay = detmath.FloatRange(m.world.localRand, 10, 14)
 							m.ToggleCamera(midpoint(m.world.boss.pos, c.pos))
 							return
 						}
@@ -210,15 +209,15 @@ func (m *cameraManager) Update(delta float64) {
 				}
 			}
 			if m.world.boss != nil && !m.world.boss.IsFlying() && m.world.localRand.Chance(0.9) {
-				m.cinematicSwitchDelay = m.world.localRand.FloatRange(8, 12)
-				m.ToggleCamera(m.world.boss.pos.Add(m.world.localRand.Offset(-40, 40)))
+				m.cinematicSwitchDelay = detmath.FloatRange(m.world.localRand, 8, 12)
+				m.ToggleCamera(m.world.boss.pos.Add(detmath.Offset(m.world.localRand, -40, 40)))
 				return
 			}
 			roll := m.world.localRand.Float()
 			switch {
 			case roll < 0.55: // 55%
 				// Show one of the colonies.
-				m.cinematicSwitchDelay = m.world.localRand.FloatRange(15, 25)
+				m.cinematicSwitchDelay = detmath.FloatRange(m.world.localRand, 15, 25)
 				if len(pstate.colonies) > 0 {
 					pos := gmath.RandElem(m.world.localRand, pstate.colonies).pos
 					if len(pstate.colonies) > 1 && m.world.localRand.Chance(0.4) {
@@ -228,17 +227,17 @@ func (m *cameraManager) Update(delta float64) {
 						}
 					} else {
 						if m.world.localRand.Chance(0.3) {
-							pos.X += m.world.localRand.FloatRange(-40, 40)
+							pos.X += detmath.FloatRange(m.world.localRand, -40, 40)
 						}
 					}
 					if m.world.localRand.Bool() {
-						pos = pos.Add(m.world.localRand.Offset(-32, 32))
+						pos = pos.Add(detmath.Offset(m.world.localRand, -32, 32))
 					}
 					m.ToggleCamera(pos)
 				}
 			case roll < 0.7: // 15%
 				// Show enemy colony.
-				m.cinematicSwitchDelay = m.world.localRand.FloatRange(7, 10)
+				m.cinematicSwitchDelay = detmath.FloatRange(m.world.localRand, 7, 10)
 				if m.world.boss != nil {
 					m.ToggleCamera(m.world.boss.pos)
 				}
@@ -264,18 +263,18 @@ func (m *cameraManager) Update(delta float64) {
 					return false
 				})
 				if creep != nil {
-					m.cinematicSwitchDelay = m.world.localRand.FloatRange(8, 10)
+					m.cinematicSwitchDelay = detmath.FloatRange(m.world.localRand, 8, 10)
 					m.ToggleCamera(creep.pos)
 				}
 			case roll < 0.95: // 5%
 				// Show some random creep.
-				m.cinematicSwitchDelay = m.world.localRand.FloatRange(6, 8)
+				m.cinematicSwitchDelay = detmath.FloatRange(m.world.localRand, 6, 8)
 				if len(m.world.creeps) != 0 {
 					m.ToggleCamera(gmath.RandElem(m.world.localRand, m.world.creeps).pos)
 				}
 			default: // 5%
 				// Show a random pos on a map.
-				m.cinematicSwitchDelay = m.world.localRand.FloatRange(6, 8)
+				m.cinematicSwitchDelay = detmath.FloatRange(m.world.localRand, 6, 8)
 				m.ToggleCamera(randomSectorPos(m.world.localRand, m.world.innerRect))
 			}
 		}
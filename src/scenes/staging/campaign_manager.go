@@ -0,0 +1,113 @@
+package staging
+
+import (
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/gsignal"
+	"github.com/quasilyte/roboden-game/gamedata"
+)
+
+// campaignManager tracks the mission objective progress.
+//
+// The tutorial (intro) mission is handled by the tutorialManager instead.
+type campaignManager struct {
+	world *worldState
+
+	mission *gamedata.CampaignMission
+
+	messageManager *messageManager
+
+	scene *ge.Scene
+
+	checkDelay float64
+	completed  bool
+
+	EventVictory gsignal.Event[gsignal.Void]
+}
+
+func newCampaignManager(world *worldState, mission *gamedata.CampaignMission, messageManager *messageManager) *campaignManager {
+	return &campaignManager{
+		world:          world,
+		mission:        mission,
+		messageManager: messageManager,
+		checkDelay:     5,
+	}
+}
+
+func (m *campaignManager) Init(scene *ge.Scene) {
+	m.scene = scene
+
+	if m.messageManager != nil {
+		d := scene.Dict()
+		m.messageManager.AddMessage(queuedMessageInfo{
+			text:  d.Get("campaign.mission", m.mission.ID, "intro"),
+			timer: 20,
+		})
+		m.messageManager.AddMessage(queuedMessageInfo{
+			text:  d.Get("menu.campaign.objective") + ": " + d.Get("campaign.objective", m.mission.Objective.String()),
+			timer: 10,
+		})
+	}
+}
+
+func (m *campaignManager) IsDisposed() bool {
+	return false
+}
+
+func (m *campaignManager) Update(delta float64) {
+	if m.completed {
+		return
+	}
+
+	m.checkDelay = gmath.ClampMin(m.checkDelay-delta, 0)
+	if m.checkDelay != 0 {
+		return
+	}
+	m.checkDelay = 2
+
+	if m.objectiveCompleted() {
+		m.completed = true
+		m.EventVictory.Emit(gsignal.Void{})
+	}
+}
+
+func (m *campaignManager) objectiveCompleted() bool {
+	switch m.mission.Objective {
+	case gamedata.ObjectiveBoss:
+		return m.world.boss == nil
+
+	case gamedata.ObjectiveBuildBase:
+		for _, p := range m.world.players {
+			if len(p.GetState().colonies) >= m.mission.ObjectiveValue {
+				return true
+			}
+		}
+		return false
+
+	case gamedata.ObjectiveDestroyCreepBases:
+		for _, c := range m.world.creeps {
+			switch c.stats.Kind {
+			case gamedata.CreepBase, gamedata.CreepCrawlerBase:
+				return false
+			}
+		}
+		return true
+
+	case gamedata.ObjectiveAcquireSuperElite:
+		for _, colony := range m.world.allColonies {
+			found := false
+			colony.agents.Each(func(a *colonyAgentNode) {
+				if a.rank == 2 {
+					found = true
+				}
+			})
+			if found {
+				return true
+			}
+		}
+		return false
+
+	default:
+		return false
+	}
+}
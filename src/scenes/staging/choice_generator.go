@@ -20,6 +20,12 @@ const (
 	specialBuildColony
 	specialAttack
 	specialChoiceMoveColony
+	specialResearch
+	specialBurrow
+	specialRecall
+	specialUpgradeTurret
+	specialRecycleTurret
+	specialRaid
 
 	// These are the actions for the creeps.
 	specialSendCreeps
@@ -56,6 +62,9 @@ type selectedChoice struct {
 	Pos      gmath.Vec
 	Player   player
 	Colony   *colonyCoreNode
+
+	// Param is an extra card argument, like the turret upgrade track.
+	Param int
 }
 
 type choiceOption struct {
@@ -64,6 +73,9 @@ type choiceOption struct {
 	direction int
 	icon      resource.ImageID
 	cost      float64
+
+	// Only for the research options, see choiceGenerator.researchColony.
+	tech colonyTech
 }
 
 type choiceOptionEffect struct {
@@ -83,6 +95,12 @@ var specialChoicesTable = [...]choiceOption{
 		icon:    assets.ImageActionAttack,
 	},
 
+	specialRaid: {
+		special: specialRaid,
+		cost:    5,
+		icon:    assets.ImageActionRaid,
+	},
+
 	specialBuildColony: {
 		special: specialBuildColony,
 		cost:    25,
@@ -94,6 +112,34 @@ var specialChoicesTable = [...]choiceOption{
 		icon:    assets.ImageActionBuildTurret,
 	},
 
+	specialResearch: {
+		special: specialResearch,
+		cost:    10,
+		icon:    assets.ImageActionResearch,
+	},
+
+	specialBurrow: {
+		special: specialBurrow,
+		cost:    30,
+		icon:    assets.ImageActionBurrow,
+	},
+	specialRecall: {
+		special: specialRecall,
+		cost:    25,
+		icon:    assets.ImageActionRecall,
+	},
+
+	specialUpgradeTurret: {
+		special: specialUpgradeTurret,
+		cost:    10,
+		icon:    assets.ImageActionUpgradeTurret,
+	},
+	specialRecycleTurret: {
+		special: specialRecycleTurret,
+		cost:    5,
+		icon:    assets.ImageActionRecycleTurret,
+	},
+
 	specialIncreaseRadius: {
 		special: specialIncreaseRadius,
 		cost:    15,
@@ -340,12 +386,21 @@ type choiceGenerator struct {
 	specialOptionIndex   int
 	buildTurret          bool
 	increaseRadius       bool
+	recycleTurret        bool
+	raid                 bool
 	spawnCrawlers        bool
 	doubleTech           bool
 	specialChoiceKinds   []specialChoiceKind
 
 	forcedSpecialChoice specialChoiceKind
 
+	// The research card replaces the regular cards with the tech options.
+	// researchColony is non-nil while these options are shown;
+	// the selected tech is researched by this colony.
+	// Activating the research card again brings the regular cards back.
+	researchColony  *colonyCoreNode
+	researchOptions []choiceOption
+
 	creepsState *creepsPlayerState
 
 	EventChoiceReady    gsignal.Event[choiceSelection]
@@ -376,6 +431,18 @@ func newChoiceGenerator(world *worldState, creepsState *creepsPlayerState) *choi
 			specialAttack,
 			specialDecreaseRadius,
 		}
+		if world.config.TurretUpgrades {
+			g.specialChoiceKinds = append(g.specialChoiceKinds, specialUpgradeTurret)
+		}
+		if world.config.TechTree {
+			g.specialChoiceKinds = append(g.specialChoiceKinds, specialResearch)
+		}
+		switch world.coreDesign.Ability {
+		case gamedata.CoreAbilityBurrow:
+			g.specialChoiceKinds = append(g.specialChoiceKinds, specialBurrow)
+		case gamedata.CoreAbilityRecall:
+			g.specialChoiceKinds = append(g.specialChoiceKinds, specialRecall)
+		}
 	}
 
 	return g
@@ -402,18 +469,24 @@ func (g *choiceGenerator) Update(delta float64) {
 }
 
 func (g *choiceGenerator) TryExecute(colony *colonyCoreNode, cardIndex int, pos gmath.Vec) bool {
+	return g.TryExecuteWithParam(colony, cardIndex, pos, 0)
+}
+
+// TryExecuteWithParam is like TryExecute, but it also passes
+// the param to the card that accepts it (see selectedChoice.Param).
+func (g *choiceGenerator) TryExecuteWithParam(colony *colonyCoreNode, cardIndex int, pos gmath.Vec, param int) bool {
 	if g.creepsState != nil {
 		if cardIndex == -1 {
 			return g.activateCenturionsMoveChoice(pos)
 		}
-		return g.activateChoice(colony, cardIndex)
+		return g.activateChoice(colony, cardIndex, pos, 0)
 	}
 
 	if colony.mode != colonyModeNormal {
 		return false
 	}
 	if cardIndex != -1 {
-		return g.activateChoice(colony, cardIndex)
+		return g.activateChoice(colony, cardIndex, pos, param)
 	}
 	return g.activateMoveChoice(colony, pos)
 }
@@ -442,15 +515,25 @@ func (g *choiceGenerator) activateMoveChoice(colony *colonyCoreNode, pos gmath.V
 	return true
 }
 
-func (g *choiceGenerator) activateChoice(colony *colonyCoreNode, i int) bool {
+func (g *choiceGenerator) activateChoice(colony *colonyCoreNode, i int, pos gmath.Vec, param int) bool {
 	if g.state != choiceReady {
 		return false
 	}
 
+	if g.creepsState == nil {
+		if i == 4 && specialChoicesTable[g.specialOptionIndex].special == specialResearch {
+			return g.toggleResearchOptions(colony)
+		}
+		if g.researchColony != nil {
+			return g.activateResearchChoice(i)
+		}
+	}
+
 	choice := selectedChoice{
 		Colony:  colony,
 		Faction: gamedata.FactionTag(i + 1),
 		Index:   i,
+		Pos:     pos,
 		Player:  g.player,
 	}
 	cooldown := 10.0
@@ -460,17 +543,21 @@ func (g *choiceGenerator) activateChoice(colony *colonyCoreNode, i int) bool {
 		choice.Option = specialChoicesTable[g.specialOptionIndex]
 		cooldown = choice.Option.cost
 		if choice.Option.special == specialIncreaseTech || choice.Option.special == specialIncreaseTechX2 {
-			div := 0.6 + (0.1 * float64(g.world.config.TechProgressRate))
-			cooldown *= (1.0 + 1.85*g.world.creepsPlayerState.techLevel)
+			div := 0.6 + float64(0.1*float64(g.world.config.TechProgressRate))
+			cooldown *= (1.0 + float64(1.85*g.world.creepsPlayerState.techLevel))
 			cooldown /= div
 		}
+		if choice.Option.special == specialUpgradeTurret {
+			// Only the turret upgrade card accepts a param so far.
+			choice.Param = param
+		}
 	} else {
 		if g.creepsState != nil {
 			info := creepOptionInfoList[creepCardID(g.shuffledOptions[i].special)]
 			extraTech := g.world.creepsPlayerState.techLevel - info.minTechLevel
 			multiplier := 1.0
 			if extraTech > 0 {
-				multiplier = gmath.ClampMin(1.0-(extraTech*0.25), 0.75)
+				multiplier = gmath.ClampMin(1.0-float64(extraTech*0.25), 0.75)
 			}
 			cooldown = (g.shuffledOptions[i].cost * multiplier)
 		}
@@ -484,6 +571,63 @@ func (g *choiceGenerator) activateChoice(colony *colonyCoreNode, i int) bool {
 	return true
 }
 
+func (g *choiceGenerator) toggleResearchOptions(colony *colonyCoreNode) bool {
+	choice := selectedChoice{
+		Colony: colony,
+		Index:  4,
+		Option: specialChoicesTable[specialResearch],
+		Player: g.player,
+	}
+
+	if g.researchColony != nil {
+		g.researchColony = nil
+		g.EventChoiceSelected.Emit(choice)
+		g.EventChoiceReady.Emit(g.GetChoices())
+		return true
+	}
+
+	techs := colony.ResearchOptions()
+	if len(techs) == 0 {
+		return false
+	}
+	g.researchColony = colony
+	g.researchOptions = g.researchOptions[:0]
+	for _, tech := range techs {
+		o := specialChoicesTable[specialResearch]
+		o.tech = tech
+		o.icon = colonyTechTable[tech].icon
+		g.researchOptions = append(g.researchOptions, o)
+	}
+	for len(g.researchOptions) < maxResearchOptions {
+		g.researchOptions = append(g.researchOptions, choiceOption{special: specialResearch})
+	}
+	g.EventChoiceSelected.Emit(choice)
+	g.EventChoiceReady.Emit(g.GetChoices())
+	return true
+}
+
+func (g *choiceGenerator) activateResearchChoice(i int) bool {
+	option := g.researchOptions[i]
+	colony := g.researchColony
+	if option.tech == techNone || colony.IsDisposed() {
+		return false
+	}
+	g.researchColony = nil
+	g.forcedSpecialChoice = specialChoiceNone
+
+	choice := selectedChoice{
+		Colony:   colony,
+		Faction:  gamedata.FactionTag(i + 1),
+		Index:    i,
+		Option:   option,
+		Player:   g.player,
+		Cooldown: option.cost,
+	}
+	g.startCharging(option.cost)
+	g.EventChoiceSelected.Emit(choice)
+	return true
+}
+
 func (g *choiceGenerator) startCharging(targetValue float64) {
 	g.value = 0
 	g.targetValue = targetValue
@@ -524,6 +668,12 @@ func (g *choiceGenerator) generateChoicesForCreeps() {
 }
 
 func (g *choiceGenerator) GetChoices() choiceSelection {
+	if g.researchColony != nil {
+		return choiceSelection{
+			cards:   g.researchOptions,
+			special: specialChoicesTable[specialResearch],
+		}
+	}
 	return choiceSelection{
 		cards:   g.shuffledOptions[:4],
 		special: specialChoicesTable[g.specialOptionIndex],
@@ -549,6 +699,8 @@ func (g *choiceGenerator) prepareChoiceOptions() {
 		g.doubleTech = !g.doubleTech
 		g.buildTurret = !g.buildTurret
 		g.increaseRadius = !g.increaseRadius
+		g.recycleTurret = !g.recycleTurret
+		g.raid = !g.raid
 		gmath.Shuffle(g.world.rand, g.specialChoiceKinds)
 		g.beforeSpecialShuffle = len(g.specialChoiceKinds)
 	}
@@ -573,6 +725,10 @@ func (g *choiceGenerator) prepareChoiceOptions() {
 		if g.doubleTech {
 			specialOptionKind = specialIncreaseTechX2
 		}
+	case specialAttack:
+		if g.raid && g.world.config.Scavengers {
+			specialOptionKind = specialRaid
+		}
 	case specialBuildColony:
 		if g.buildTurret {
 			specialOptionKind = specialBuildGunpoint
@@ -581,12 +737,17 @@ func (g *choiceGenerator) prepareChoiceOptions() {
 		if g.increaseRadius {
 			specialOptionKind = specialIncreaseRadius
 		}
+	case specialUpgradeTurret:
+		if g.recycleTurret {
+			specialOptionKind = specialRecycleTurret
+		}
 	}
 	g.specialOptionIndex = int(specialOptionKind)
 }
 
 func (g *choiceGenerator) generateChoices() {
 	g.state = choiceReady
+	g.researchColony = nil
 	g.prepareChoiceOptions()
 	g.EventChoiceReady.Emit(g.GetChoices())
 }
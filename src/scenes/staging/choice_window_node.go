@@ -208,6 +208,12 @@ func (w *choiceWindowNode) RevealChoices(selection choiceSelection) {
 			faction := gamedata.FactionTag(i + 1)
 			choice := w.choices[i]
 			choice.option = o
+			if o.special == specialResearch {
+				w.revealResearchChoice(choice)
+				continue
+			}
+			choice.label1.SetImage(w.scene.LoadImage(assets.ImagePriorityIcons))
+			choice.label1.SetColorScaleRGBA(255, 255, 255, 255)
 			if len(o.effects) == 1 {
 				choice.label1.Visible = true
 				choice.label1.Pos.Offset = gmath.Vec{X: 55, Y: 32}
@@ -229,6 +235,25 @@ func (w *choiceWindowNode) RevealChoices(selection choiceSelection) {
 	w.scene.Audio().PlaySound(assets.AudioChoiceReady)
 }
 
+func (w *choiceWindowNode) revealResearchChoice(choice *choiceOptionSlot) {
+	if choice.option.tech == techNone {
+		// An empty slot: there are less techs than cards.
+		return
+	}
+	info := colonyTechTable[choice.option.tech]
+	choice.label1.Visible = true
+	choice.label1.Pos.Offset = gmath.Vec{X: 48, Y: 24}
+	choice.label1.SetImage(w.scene.LoadImage(info.icon))
+	choice.label1.FrameOffset.X = 0
+	// Armor techs share the icon, the faction color tells them apart.
+	if info.faction != gamedata.NeutralFactionTag {
+		clr := gamedata.FactionByTag(info.faction).Color
+		choice.label1.SetColorScaleRGBA(clr.R, clr.G, clr.B, 255)
+	} else {
+		choice.label1.SetColorScaleRGBA(255, 255, 255, 255)
+	}
+}
+
 func (w *choiceWindowNode) StartCharging(targetValue float64, cardIndex int) {
 	w.charging = true
 	w.targetValue = targetValue
@@ -260,7 +285,7 @@ func (w *choiceWindowNode) Update(delta float64) {
 	const maxSlideOffset float64 = 86 + 8
 	for i, o := range w.choices {
 		if i == w.selectedIndex {
-			o.floppy.Pos.Offset.X = math.Round(w.floppyOffsetX + maxSlideOffset*(1.05*percentage))
+			o.floppy.Pos.Offset.X = math.Round(w.floppyOffsetX + float64(maxSlideOffset*(1.05*percentage)))
 			continue
 		}
 
@@ -3,6 +3,7 @@ package staging
 import (
 	"github.com/quasilyte/ge"
 	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/roboden-game/detmath"
 	"github.com/quasilyte/roboden-game/gamedata"
 )
 
@@ -33,18 +34,18 @@ func newClassicManager(world *worldState) *classicManager {
 func (m *classicManager) Init(scene *ge.Scene) {
 	m.scene = scene
 
-	m.spawnDelayMultiplier = 1.0 / (0.75 + (0.25 * float64(m.world.config.CreepSpawnRate)))
+	m.spawnDelayMultiplier = 1.0 / (0.75 + float64(0.25*float64(m.world.config.CreepSpawnRate)))
 
 	// 1.1, 1.0, 0.9, 0.8, 0.7, 0.6
-	firstSpawnDelayMultiplier := 1.1 - (0.1 * float64(m.world.config.CreepSpawnRate))
+	firstSpawnDelayMultiplier := 1.1 - float64(0.1*float64(m.world.config.CreepSpawnRate))
 
 	// Start launching tier3 creeps after ~15 minutes.
-	m.tier3spawnDelay = m.world.rand.FloatRange(15*60.0, 18*60.0) * firstSpawnDelayMultiplier
+	m.tier3spawnDelay = detmath.FloatRange(m.world.rand, 15*60.0, 18*60.0) * firstSpawnDelayMultiplier
 
 	// Extra crawlers show up around the 10th minute.
-	m.crawlersDelay = m.world.rand.FloatRange(10*60.0, 14*60.0) * firstSpawnDelayMultiplier
+	m.crawlersDelay = detmath.FloatRange(m.world.rand, 10*60.0, 14*60.0) * firstSpawnDelayMultiplier
 
-	m.coordinatorsDelay = m.world.rand.FloatRange(5, 20)
+	m.coordinatorsDelay = detmath.FloatRange(m.world.rand, 5, 20)
 }
 
 func (m *classicManager) IsDisposed() bool {
@@ -80,17 +81,17 @@ func (m *classicManager) maybeSendCoordinators() {
 	stationed := m.world.centurionRallyPointPtr == &m.world.boss.pos
 	if stationed {
 		if len(m.world.centurions) == 0 {
-			m.coordinatorsDelay = m.world.rand.FloatRange(30, 60)
+			m.coordinatorsDelay = detmath.FloatRange(m.world.rand, 30, 60)
 			return
 		}
 		if len(m.world.centurions) < 3 {
-			m.coordinatorsDelay = m.world.rand.FloatRange(20, 40)
+			m.coordinatorsDelay = detmath.FloatRange(m.world.rand, 20, 40)
 			return
 		}
 	} else {
 		if len(m.world.centurions) < 3 {
 			m.world.centurionRallyPointPtr = &m.world.boss.pos
-			m.coordinatorsDelay = m.world.rand.FloatRange(60, 100)
+			m.coordinatorsDelay = detmath.FloatRange(m.world.rand, 60, 100)
 			return
 		}
 	}
@@ -99,24 +100,24 @@ func (m *classicManager) maybeSendCoordinators() {
 	if stationed {
 		// Keep Coordinators around if it's wounded.
 		if bossHealthPercentage < 0.8 {
-			m.coordinatorsDelay = m.world.rand.FloatRange(10, 15)
+			m.coordinatorsDelay = detmath.FloatRange(m.world.rand, 10, 15)
 			return
 		}
 		if m.world.rand.Chance(0.4) {
 			// Send Coordinators out for some time.
-			m.coordinatorsDelay = m.world.rand.FloatRange(30, 50)
-			m.world.centurionRallyPoint = correctedPos(m.world.rect, m.world.boss.pos.Add(m.world.rand.Offset(-300, 300)), 128)
+			m.coordinatorsDelay = detmath.FloatRange(m.world.rand, 30, 50)
+			m.world.centurionRallyPoint = correctedPos(m.world.rect, m.world.boss.pos.Add(detmath.Offset(m.world.rand, -300, 300)), 128)
 			m.world.centurionRallyPointPtr = &m.world.centurionRallyPoint
 			return
 		}
 		// Keep them around for a bit longer
-		m.coordinatorsDelay = m.world.rand.FloatRange(20, 30)
+		m.coordinatorsDelay = detmath.FloatRange(m.world.rand, 20, 30)
 		return
 	}
 
 	// Send them back.
 	m.world.centurionRallyPointPtr = &m.world.boss.pos
-	m.coordinatorsDelay = m.world.rand.FloatRange(50, 80)
+	m.coordinatorsDelay = detmath.FloatRange(m.world.rand, 50, 80)
 }
 
 func (m *classicManager) spawnCrawlers() {
@@ -124,7 +125,7 @@ func (m *classicManager) spawnCrawlers() {
 
 	nextAttackDelay := 0.0
 	if m.world.rand.Chance(0.75) {
-		nextAttackDelay = m.world.rand.FloatRange(80, 140)
+		nextAttackDelay = detmath.FloatRange(m.world.rand, 80, 140)
 		numCreeps := m.world.rand.IntRange(1, 5) + m.world.config.CreepSpawnRate
 		for i := 0; i < numCreeps; i++ {
 			units = append(units, arenaWaveUnit{
@@ -133,7 +134,7 @@ func (m *classicManager) spawnCrawlers() {
 			})
 		}
 	} else {
-		nextAttackDelay = m.world.rand.FloatRange(210, 250)
+		nextAttackDelay = detmath.FloatRange(m.world.rand, 210, 250)
 		units = append(units, arenaWaveUnit{
 			stats: gamedata.HowitzerCreepStats,
 			super: m.world.config.SuperCreeps && m.world.rand.Chance(0.3),
@@ -149,21 +150,21 @@ func (m *classicManager) spawnCrawlers() {
 func (m *classicManager) spawnTier3Creep() {
 	superChance := (1.0 - m.tier3spawnRate) * 0.5
 	m.tier3spawnRate = gmath.ClampMin(m.tier3spawnRate-0.02, 0.35)
-	m.tier3spawnDelay = (m.world.rand.FloatRange(60, 90) * m.tier3spawnRate) * m.spawnDelayMultiplier
+	m.tier3spawnDelay = (detmath.FloatRange(m.world.rand, 60, 90) * m.tier3spawnRate) * m.spawnDelayMultiplier
 
 	var spawnPos gmath.Vec
 	roll := m.world.rand.Float()
 	if roll < 0.25 {
 		spawnPos.X = m.world.width - 4
-		spawnPos.Y = m.world.rand.FloatRange(0, m.world.height)
+		spawnPos.Y = detmath.FloatRange(m.world.rand, 0, m.world.height)
 	} else if roll < 0.5 {
-		spawnPos.X = m.world.rand.FloatRange(0, m.world.width)
+		spawnPos.X = detmath.FloatRange(m.world.rand, 0, m.world.width)
 		spawnPos.Y = m.world.height - 4
 	} else if roll < 0.75 {
 		spawnPos.X = 4
-		spawnPos.Y = m.world.rand.FloatRange(0, m.world.height)
+		spawnPos.Y = detmath.FloatRange(m.world.rand, 0, m.world.height)
 	} else {
-		spawnPos.X = m.world.rand.FloatRange(0, m.world.width)
+		spawnPos.X = detmath.FloatRange(m.world.rand, 0, m.world.width)
 		spawnPos.Y = 4
 	}
 	spawnPos = roundedPos(spawnPos)
@@ -4,6 +4,7 @@ import (
 	"github.com/quasilyte/ge"
 	"github.com/quasilyte/gmath"
 	"github.com/quasilyte/roboden-game/assets"
+	"github.com/quasilyte/roboden-game/detmath"
 )
 
 type activeBeamKind int
@@ -60,8 +61,8 @@ func (b *cloningBeamNode) Update(delta float64) {
 		} else {
 			b.delay = 0.06
 		}
-		offset1 := b.world.localRand.Offset(-6, 6)
-		offset2 := b.world.localRand.Offset(-6, 6)
+		offset1 := detmath.Offset(b.world.localRand, -6, 6)
+		offset2 := detmath.Offset(b.world.localRand, -6, 6)
 		b.lines[0].EndPos.Offset = b.to.Offset.Add(offset1)
 		b.lines[1].EndPos.Offset = b.to.Offset.Add(offset2)
 		b.lines[2].BeginPos.Offset = b.lines[0].EndPos.Resolve()
@@ -72,18 +73,18 @@ func (b *cloningBeamNode) Update(delta float64) {
 		switch b.kind {
 		case abeamMerging:
 			if b.world.localRand.Bool() {
-				b.soundDelay = b.world.localRand.FloatRange(0.5, 0.75)
+				b.soundDelay = detmath.FloatRange(b.world.localRand, 0.5, 0.75)
 				playSound(b.world, assets.AudioMerging1, *b.from)
 			} else {
-				b.soundDelay = b.world.localRand.FloatRange(0.55, 0.9)
+				b.soundDelay = detmath.FloatRange(b.world.localRand, 0.55, 0.9)
 				playSound(b.world, assets.AudioMerging2, *b.from)
 			}
 		case abeamCloning:
 			if b.world.localRand.Bool() {
-				b.soundDelay = b.world.localRand.FloatRange(0.3, 0.7)
+				b.soundDelay = detmath.FloatRange(b.world.localRand, 0.3, 0.7)
 				playSound(b.world, assets.AudioCloning1, *b.from)
 			} else {
-				b.soundDelay = b.world.localRand.FloatRange(0.25, 0.6)
+				b.soundDelay = detmath.FloatRange(b.world.localRand, 0.25, 0.6)
 				playSound(b.world, assets.AudioCloning2, *b.from)
 			}
 		}
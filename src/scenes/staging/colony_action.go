@@ -42,4 +42,8 @@ const (
 	actionBuildBuilding
 	actionGetReinforcements
 	actionCaptureBuilding
+	actionLayMine
+	actionDeployRelay
+	actionSendShielder
+	actionCoreAbility
 )
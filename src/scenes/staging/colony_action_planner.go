@@ -4,6 +4,7 @@ import (
 	"math"
 
 	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/roboden-game/detmath"
 	"github.com/quasilyte/roboden-game/gamedata"
 )
 
@@ -119,7 +120,7 @@ func (p *colonyActionPlanner) PickAction() colonyAction {
 
 func (p *colonyActionPlanner) trySendingCourier() colonyAction {
 	// Try to find a colony for a trading route.
-	maxTradingDist := (p.colony.PatrolRadius() * 1.75) + 200
+	maxTradingDist := float64(p.colony.PatrolRadius()*1.75) + 200
 	potentialTargets := &p.world.tmpColonySlice
 	(*potentialTargets) = (*potentialTargets)[:0]
 	for _, colony := range p.colony.player.GetState().colonies {
@@ -129,7 +130,7 @@ func (p *colonyActionPlanner) trySendingCourier() colonyAction {
 		if colony.mode != colonyModeNormal {
 			continue
 		}
-		if colony.pos.DistanceTo(p.colony.pos) > maxTradingDist {
+		if detmath.DistanceTo(colony.pos, p.colony.pos) > maxTradingDist {
 			continue
 		}
 		*potentialTargets = append(*potentialTargets, colony)
@@ -162,6 +163,25 @@ func (p *colonyActionPlanner) trySendingCourier() colonyAction {
 	}
 }
 
+func (p *colonyActionPlanner) trySendingRelay(source *essenceSourceNode) colonyAction {
+	// A relay is only worth it when the resource is far away from the colony.
+	if detmath.DistanceTo(source.pos, p.colony.pos) < p.colony.realRadius*1.5 {
+		return colonyAction{}
+	}
+	relay := p.colony.agents.Find(searchWorkers|searchOnlyAvailable|searchRandomized, func(a *colonyAgentNode) bool {
+		return a.stats.Kind == gamedata.AgentRelay && a.energy >= 60
+	})
+	if relay == nil {
+		return colonyAction{}
+	}
+	return colonyAction{
+		Kind:     actionDeployRelay,
+		Value:    source,
+		Value2:   relay,
+		TimeCost: 0.2,
+	}
+}
+
 func (p *colonyActionPlanner) pickResourcesAction() colonyAction {
 	if p.colony.failedResource != nil {
 		p.colony.failedResourceTick++
@@ -219,7 +239,7 @@ func (p *colonyActionPlanner) pickResourcesAction() colonyAction {
 		if colonyRect.Contains(source.pos) {
 			continue
 		}
-		score := resourceScore(p.colony, source) * p.world.rand.FloatRange(0.65, 1.5)
+		score := resourceScore(p.colony, source) * detmath.FloatRange(p.world.rand, 0.65, 1.5)
 		if source.stats == redOilSource {
 			if !p.colony.agents.hasRedMiner {
 				continue
@@ -244,6 +264,13 @@ func (p *colonyActionPlanner) pickResourcesAction() colonyAction {
 		}
 	}
 
+	if bestSource != nil && p.colony.agents.hasRelay && p.world.rand.Chance(0.2) {
+		a := p.trySendingRelay(bestSource)
+		if a.Kind != actionNone {
+			return a
+		}
+	}
+
 	if bestSource != nil {
 		if bestSource.stats == sulfurSource {
 			return colonyAction{
@@ -306,6 +333,8 @@ func (p *colonyActionPlanner) maxUnitCountForCloning(stats *gamedata.AgentStats)
 		return 10
 	case 3:
 		return 5
+	case 4:
+		return 2
 	default:
 		panic("unreachable")
 	}
@@ -338,7 +367,7 @@ func (p *colonyActionPlanner) pickUnitToClone(cloner *colonyAgentNode, combat bo
 		// Higher tiers are good, but it's also good to clone the units that
 		// are not as numerous as some others.
 		scoreMultiplier := gmath.ClampMin(1.0/float64(p.agentCountTable[a.stats.Kind]), 0.1)
-		score := ((1.25 * float64(a.stats.Tier)) * scoreMultiplier) * p.world.rand.FloatRange(0.7, 1.3)
+		score := ((1.25 * float64(a.stats.Tier)) * scoreMultiplier) * detmath.FloatRange(p.world.rand, 0.7, 1.3)
 		if a.faction != gamedata.NeutralFactionTag {
 			score *= 1.1
 		}
@@ -382,9 +411,9 @@ func (p *colonyActionPlanner) pickGrowthAction() colonyAction {
 	}
 
 	if p.colony.captureDelay == 0 && p.colony.GetGrowthPriority() > 0.2 && p.colony.resources > 60 {
-		p.colony.captureDelay = p.world.rand.FloatRange(10, 20)
+		p.colony.captureDelay = detmath.FloatRange(p.world.rand, 10, 20)
 		b := randIterate(p.world.rand, p.world.neutralBuildings, func(b *neutralBuildingNode) bool {
-			return b.agent == nil && b.pos.DistanceSquaredTo(p.colony.pos) <= p.colony.realRadiusSqr
+			return b.CanBeCapturedBy(p.colony) && detmath.DistanceSquaredTo(b.pos, p.colony.pos) <= p.colony.realRadiusSqr
 		})
 		if b != nil {
 			return colonyAction{
@@ -412,7 +441,7 @@ func (p *colonyActionPlanner) pickGrowthAction() colonyAction {
 			if turret.health >= turret.maxHealth*0.9 {
 				continue
 			}
-			distSqr := turret.pos.DistanceSquaredTo(p.colony.pos)
+			distSqr := detmath.DistanceSquaredTo(turret.pos, p.colony.pos)
 			if distSqr > p.colony.realRadiusSqr*1.8 {
 				continue
 			}
@@ -434,7 +463,7 @@ func (p *colonyActionPlanner) pickGrowthAction() colonyAction {
 			if c.attention > 2 {
 				continue
 			}
-			dist := c.pos.DistanceTo(p.colony.pos)
+			dist := detmath.DistanceTo(c.pos, p.colony.pos)
 			if dist > p.colony.realRadius*1.75 {
 				continue
 			}
@@ -507,7 +536,7 @@ func (p *colonyActionPlanner) pickSecurityAction() colonyAction {
 				if c.NumAgents() < 10 || c.agents.NumAvailableWorkers() < 6 || c.agents.NumAvailableFighters() < 2 {
 					continue
 				}
-				dist := c.pos.DistanceTo(p.colony.pos)
+				dist := detmath.DistanceTo(c.pos, p.colony.pos)
 				if dist > c.realRadius*3 {
 					continue
 				}
@@ -523,13 +552,13 @@ func (p *colonyActionPlanner) pickSecurityAction() colonyAction {
 	// Are there any intruders?
 	intrusionDist := p.colony.PatrolRadius() * 0.85
 	numAttackers := 0
-	var closestAttacker *creepNode
+	var closestAttacker targetable
 	closestAttackerDist := float64(math.MaxFloat64)
 	for _, creep := range p.world.creeps {
 		if !creep.CanBeTargeted() {
 			continue
 		}
-		dist := creep.pos.DistanceTo(p.colony.pos)
+		dist := detmath.DistanceTo(creep.pos, p.colony.pos)
 		if dist >= intrusionDist {
 			continue
 		}
@@ -542,8 +571,30 @@ func (p *colonyActionPlanner) pickSecurityAction() colonyAction {
 			break
 		}
 	}
+	if numAttackers <= 5 && p.world.hostileColonies {
+		// The rival colony drones and turrets are intruders too.
+		p.world.FindTargetableAgents(p.colony.pos, false, intrusionDist, func(a *colonyAgentNode) bool {
+			if !p.world.AreEnemies(p.colony, a.colonyCore) || a.insideForest {
+				return false
+			}
+			dist := detmath.DistanceTo(a.pos, p.colony.pos)
+			if dist < closestAttackerDist {
+				closestAttackerDist = dist
+				closestAttacker = a
+			}
+			numAttackers++
+			return numAttackers > 5
+		})
+	}
 
 	if numAttackers == 0 {
+		if p.colony.agents.hasMinelayer && p.world.rand.Chance(0.15) {
+			// Plant the mines around the colony in advance.
+			a := p.tryLayingMine(nil)
+			if a.Kind != actionNone {
+				return a
+			}
+		}
 		if p.agentCountTable[gamedata.AgentCommander] != 0 && p.world.rand.Chance(0.6) {
 			commander, follower := p.maybeAttachToCommander()
 			if commander != nil {
@@ -556,12 +607,29 @@ func (p *colonyActionPlanner) pickSecurityAction() colonyAction {
 			}
 		}
 		numPatrolWanted := int(p.colony.PatrolRadius() / 40)
+		if p.world.influenceMap.Threat(p.colony.pos, p.colony.PatrolRadius()*1.5) != 0 {
+			// There are no intruders yet, but some creeps are nearby.
+			numPatrolWanted += numPatrolWanted / 2
+		}
 		if p.numGarrisonAgents != 0 && p.numPatrolAgents < numPatrolWanted {
 			return colonyAction{Kind: actionSetPatrol, TimeCost: 0.25}
 		}
 		return colonyAction{}
 	}
 
+	if p.colony.agents.hasShielder && p.world.rand.Chance(0.3) {
+		a := p.trySendingShielder()
+		if a.Kind != actionNone {
+			return a
+		}
+	}
+	if p.colony.agents.hasMinelayer && !closestAttacker.IsFlying() && p.world.rand.Chance(0.25) {
+		a := p.tryLayingMine(closestAttacker)
+		if a.Kind != actionNone {
+			return a
+		}
+	}
+
 	if numAttackers <= 5 {
 		if numAttackers*3 < p.numGarrisonAgents {
 			return colonyAction{Kind: actionDefenceGarrison, Value: closestAttacker, TimeCost: 0.5}
@@ -570,6 +638,51 @@ func (p *colonyActionPlanner) pickSecurityAction() colonyAction {
 	return colonyAction{Kind: actionDefencePatrol, Value: closestAttacker, TimeCost: 0.5}
 }
 
+func (p *colonyActionPlanner) tryLayingMine(attacker targetable) colonyAction {
+	minelayer := p.colony.agents.Find(searchWorkers|searchOnlyAvailable|searchRandomized, func(a *colonyAgentNode) bool {
+		return a.stats.Kind == gamedata.AgentMinelayer && a.energy >= 40
+	})
+	if minelayer == nil {
+		return colonyAction{}
+	}
+	var pos gmath.Vec
+	if attacker != nil {
+		// Put the mine on the attacker's way to the colony.
+		pos = midpoint(p.colony.pos, *attacker.GetPos()).Add(detmath.Offset(p.world.rand, -24, 24))
+	} else {
+		dist := p.colony.PatrolRadius() * detmath.FloatRange(p.world.rand, 0.6, 0.9)
+		pos = detmath.AddScaled(p.colony.pos, detmath.RadToVec(detmath.RandRad(p.world.rand)), dist)
+	}
+	return colonyAction{
+		Kind:     actionLayMine,
+		Value:    correctedPos(p.world.rect, pos, 64),
+		Value2:   minelayer,
+		TimeCost: 0.3,
+	}
+}
+
+func (p *colonyActionPlanner) trySendingShielder() colonyAction {
+	// Shielders escort the drones that are already fighting.
+	escorted := p.colony.agents.Find(searchFighters|searchRandomized, func(a *colonyAgentNode) bool {
+		return a.mode == agentModeFollow
+	})
+	if escorted == nil {
+		return colonyAction{}
+	}
+	shielder := p.colony.agents.Find(searchWorkers|searchOnlyAvailable|searchRandomized, func(a *colonyAgentNode) bool {
+		return a.stats.Kind == gamedata.AgentShielder
+	})
+	if shielder == nil {
+		return colonyAction{}
+	}
+	return colonyAction{
+		Kind:     actionSendShielder,
+		Value:    escorted,
+		Value2:   shielder,
+		TimeCost: 0.2,
+	}
+}
+
 func (p *colonyActionPlanner) maybeAttachToCommander() (commander, follower *colonyAgentNode) {
 	// Calculate how many units each commander has right now.
 	for _, u := range p.colony.agents.fighters {
@@ -610,10 +723,15 @@ func (p *colonyActionPlanner) maybeAttachToCommander() (commander, follower *col
 }
 
 func (p *colonyActionPlanner) pickMergeRecipe(list []gamedata.AgentMergeRecipe, tier int) gamedata.AgentMergeRecipe {
-	if tier == 3 {
+	switch tier {
+	case 3:
 		return randIterate(p.world.rand, list, func(recipe gamedata.AgentMergeRecipe) bool {
 			return p.agentCountTable[recipe.Result.Kind] < 10
 		})
+	case 4:
+		return randIterate(p.world.rand, list, func(recipe gamedata.AgentMergeRecipe) bool {
+			return p.agentCountTable[recipe.Result.Kind] < 3
+		})
 	}
 
 	bestScore := 0.0
@@ -628,7 +746,7 @@ func (p *colonyActionPlanner) pickMergeRecipe(list []gamedata.AgentMergeRecipe,
 		}
 		count := p.agentCountTable[recipe.Result.Kind]
 		score := 12.0 - float64(count)
-		score *= p.world.rand.FloatRange(0.7, 1.4)
+		score *= detmath.FloatRange(p.world.rand, 0.7, 1.4)
 		if count == 0 {
 			score *= 1.2
 		}
@@ -652,7 +770,10 @@ func (p *colonyActionPlanner) pickMergeRecipe(list []gamedata.AgentMergeRecipe,
 func (p *colonyActionPlanner) tryMergingAction() colonyAction {
 	var list []gamedata.AgentMergeRecipe
 	tier := 0
-	if p.colony.evoPoints >= blueEvoThreshold && p.colony.agents.tier2Num >= 2 && (p.numTier1Agents < 2 || p.world.rand.Bool()) {
+	if p.colony.evoPoints >= maxEvoPoints && p.colony.agents.tier3Num >= 2 && p.world.rand.Chance(0.4) {
+		list = gamedata.Tier4agentMergeRecipes
+		tier = 4
+	} else if p.colony.evoPoints >= blueEvoThreshold && p.colony.agents.tier2Num >= 2 && (p.numTier1Agents < 2 || p.world.rand.Bool()) {
 		list = gamedata.Tier3agentMergeRecipes
 		tier = 3
 	} else {
@@ -666,7 +787,7 @@ func (p *colonyActionPlanner) tryMergingAction() colonyAction {
 		return colonyAction{}
 	}
 	if recipe.EvoCost > p.colony.evoPoints {
-		// This happens only when tier3 can't be produced due to the evo points shortage.
+		// This happens only when tier3/tier4 can't be produced due to the evo points shortage.
 		list = p.world.tier2recipes
 		tier = 2
 		p.mergetab.Update(p.colony.agents)
@@ -758,7 +879,7 @@ func (p *colonyActionPlanner) pickEvolutionAction() colonyAction {
 		// evolution=40% => ~21%
 		// evolution=60% => ~34%
 		// evolution=75% => ~44%
-		evoPointsChance := gmath.Clamp((p.colony.GetEvolutionPriority()*0.65)-0.05, 0, 0.5)
+		evoPointsChance := gmath.Clamp(float64(p.colony.GetEvolutionPriority()*0.65)-0.05, 0, 0.5)
 		if p.world.rand.Chance(evoPointsChance) {
 			if p.colony.resources < p.colony.maxVisualResources() && p.colony.evoPoints > blueEvoThreshold && len(p.world.neutralBuildings) != 0 {
 				var powerPlant *neutralBuildingNode
@@ -769,7 +890,7 @@ func (p *colonyActionPlanner) pickEvolutionAction() colonyAction {
 					if b.agent.health < b.agent.maxHealth*0.3 || b.agent.specialDelay != 0 {
 						continue
 					}
-					if b.pos.DistanceSquaredTo(p.colony.pos) < (256 * 256) {
+					if detmath.DistanceSquaredTo(b.pos, p.colony.pos) < (256 * 256) {
 						powerPlant = b
 						break
 					}
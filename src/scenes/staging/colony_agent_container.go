@@ -61,13 +61,16 @@ type colonyAgentContainer struct {
 
 	sortTmp [3][]*colonyAgentNode
 
-	hasGatherer bool
-	hasRedMiner bool
-	hasCloner   bool
-	hasCourier  bool
-	servoNum    int
-	tier2Num    int
-	tier3Num    int
+	hasGatherer  bool
+	hasRedMiner  bool
+	hasCloner    bool
+	hasCourier   bool
+	hasMinelayer bool
+	hasShielder  bool
+	hasRelay     bool
+	servoNum     int
+	tier2Num     int
+	tier3Num     int
 }
 
 func newColonyAgentContainer(rand *gmath.Rand) *colonyAgentContainer {
@@ -86,6 +89,9 @@ func (c *colonyAgentContainer) Update() {
 	c.hasRedMiner = false
 	c.hasCloner = false
 	c.hasGatherer = false
+	c.hasMinelayer = false
+	c.hasShielder = false
+	c.hasRelay = false
 	c.servoNum = 0
 	c.tier2Num = 0
 	c.tier3Num = 0
@@ -115,6 +121,12 @@ func (c *colonyAgentContainer) Update() {
 				c.hasCloner = true
 			case gamedata.AgentCourier, gamedata.AgentTrucker:
 				c.hasCourier = true
+			case gamedata.AgentMinelayer:
+				c.hasMinelayer = true
+			case gamedata.AgentShielder:
+				c.hasShielder = true
+			case gamedata.AgentRelay:
+				c.hasRelay = true
 			}
 		}
 	}
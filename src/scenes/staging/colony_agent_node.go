@@ -11,8 +11,10 @@ import (
 	"github.com/quasilyte/gsignal"
 
 	"github.com/quasilyte/roboden-game/assets"
+	"github.com/quasilyte/roboden-game/detmath"
 	"github.com/quasilyte/roboden-game/gamedata"
 	"github.com/quasilyte/roboden-game/pathing"
+	"github.com/quasilyte/roboden-game/spatial"
 )
 
 const (
@@ -42,6 +44,9 @@ var stunnableModes = [256]bool{
 	agentModePanic:           true,
 	agentModeRecycleReturn:   true,
 	agentModeBuildBuilding:   true,
+	agentModeLayMine:         true,
+	agentModeRelay:           true,
+	agentModeShieldEscort:    true,
 }
 
 type colonyAgentMode uint8
@@ -88,6 +93,9 @@ const (
 	agentModeConsumeDrone
 	agentModeFollowCommander
 	agentModeBomberAttack
+	agentModeLayMine
+	agentModeRelay
+	agentModeShieldEscort
 
 	agentModeRelictDroneFactory
 	agentModeRelictPatrol
@@ -127,6 +135,11 @@ type colonyAgentNode struct {
 
 	pos gmath.Vec
 
+	// The agents are indexed while they belong to a colony.
+	// The turrets and the normal drones use different indexes.
+	spatialIndex  *spatial.Index[*colonyAgentNode]
+	spatialHandle spatial.Handle
+
 	traits agentTraitBits
 	path   pathing.GridPath
 
@@ -146,6 +159,8 @@ type colonyAgentNode struct {
 	reloadRate      float64
 	healthRegen     float64
 
+	upgrades turretUpgradeState
+
 	attackDelay  float64
 	supportDelay float64
 	specialDelay float64
@@ -158,6 +173,7 @@ type colonyAgentNode struct {
 	energyBill      float64
 	energyRegenRate float64
 	slow            float64
+	shield          float64 // Shield time (in seconds)
 	lifetime        float64
 	energyTarget    float64
 
@@ -213,7 +229,7 @@ func (a *colonyAgentNode) Init(scene *ge.Scene) {
 	a.scene = scene
 
 	if a.stats.Tier == 1 {
-		a.lifetime = scene.Rand().FloatRange(1.5*60, 3*60)
+		a.lifetime = detmath.FloatRange(scene.Rand(), 1.5*60, 3*60)
 		// If it's a neutral drone, don't hurry to recycle it.
 		// It's probably a new base and it may need drones to live for longer.
 		// If evolution priority is high, neutral drones will be recycled anyway.
@@ -227,10 +243,10 @@ func (a *colonyAgentNode) Init(scene *ge.Scene) {
 		a.healthRegen = a.stats.SelfRepair
 		a.maxHealth = a.stats.MaxHealth * a.world().droneHealthMultiplier
 		if !a.IsTurret() {
-			a.maxHealth *= scene.Rand().FloatRange(0.9, 1.1)
+			a.maxHealth *= detmath.FloatRange(scene.Rand(), 0.9, 1.1)
 		}
-		a.maxEnergy = scene.Rand().FloatRange(120, 200)
-		a.speed = a.stats.Speed * scene.Rand().FloatRange(0.8, 1.1)
+		a.maxEnergy = detmath.FloatRange(scene.Rand(), 120, 200)
+		a.speed = a.stats.Speed * detmath.FloatRange(scene.Rand(), 0.8, 1.1)
 
 		switch a.faction {
 		case gamedata.RedFactionTag:
@@ -243,6 +259,9 @@ func (a *colonyAgentNode) Init(scene *ge.Scene) {
 		case gamedata.YellowFactionTag:
 			a.energyRegenRate += 0.5
 		}
+		if !a.IsTurret() && a.colonyCore != nil && a.colonyCore.HasTech(armorTechForFaction(a.faction)) {
+			a.maxHealth *= techArmorHealthMultiplier
+		}
 	}
 
 	if a.cloneGen == 0 && !a.IsTurret() {
@@ -368,12 +387,12 @@ func (a *colonyAgentNode) Init(scene *ge.Scene) {
 			if a.stats.AnimSpeed != 0 {
 				a.anim.SetSecondsPerFrame(a.stats.AnimSpeed)
 			}
-			a.anim.Tick(a.world().localRand.FloatRange(0, 0.7))
+			a.anim.Tick(detmath.FloatRange(a.world().localRand, 0, 0.7))
 			a.anim.SetOffsetY(float64(a.rank) * a.sprite.FrameHeight)
 		}
 	}
 
-	a.supportDelay = scene.Rand().FloatRange(0.8, 2)
+	a.supportDelay = detmath.FloatRange(scene.Rand(), 0.8, 2)
 
 	if a.world().droneLabels && isHumanPlayer(a.colonyCore.player) {
 		l := newDebugDroneLabelNode(a.colonyCore.player.GetState(), a)
@@ -394,7 +413,7 @@ func (a *colonyAgentNode) initExtra() {
 			turret.Dispose()
 		})
 		a.target = turret
-		a.specialDelay = a.world().rand.FloatRange(3, 6)
+		a.specialDelay = detmath.FloatRange(a.world().rand, 3, 6)
 
 	case gamedata.DroneFactoryAgentStats:
 		hatch := a.scene.NewSprite(assets.ImageRelictFactoryHatch)
@@ -407,7 +426,7 @@ func (a *colonyAgentNode) initExtra() {
 			hatch.Dispose()
 		})
 		a.target = hatch
-		a.specialDelay = a.scene.Rand().FloatRange(10, 15)
+		a.specialDelay = detmath.FloatRange(a.scene.Rand(), 10, 15)
 	}
 }
 
@@ -461,7 +480,7 @@ func (a *colonyAgentNode) AssignMode(mode colonyAgentMode, pos gmath.Vec, target
 	switch mode {
 	case agentModeReturn:
 		entranceNum := a.scene.Rand().IntRange(0, 2)
-		a.setWaypoint(a.colonyCore.GetStoragePos().Add(gmath.Vec{Y: float64(entranceNum) * 8}))
+		a.setWaypoint(a.colonyCore.GetStoragePos().Add(gmath.Vec{Y: float64(float64(entranceNum) * 8)}))
 		a.mode = mode
 		return true
 
@@ -481,16 +500,16 @@ func (a *colonyAgentNode) AssignMode(mode colonyAgentMode, pos gmath.Vec, target
 	case agentModeMakeClone:
 		a.mode = mode
 		a.target = target
-		a.dist = a.scene.Rand().FloatRange(1.2, 2) // cloning time
+		a.dist = detmath.FloatRange(a.scene.Rand(), 1.2, 2) // cloning time
 		a.energyBill += 20
 		targetPos := target.(*colonyAgentNode).pos
-		a.setWaypoint(a.pos.DirectionTo(targetPos).Mulf(110).Add(targetPos).Add(a.scene.Rand().Offset(-20, 20)))
+		a.setWaypoint(detmath.AddScaled(targetPos, detmath.DirectionTo(a.pos, targetPos), 110).Add(detmath.Offset(a.scene.Rand(), -20, 20)))
 		return true
 
 	case agentModeMerging, agentModeMergingRoomba:
 		a.mode = mode
 		a.target = target
-		a.dist = a.scene.Rand().FloatRange(8, 10) // merging time
+		a.dist = detmath.FloatRange(a.scene.Rand(), 8, 10) // merging time
 		a.setWaypoint(pos)
 		if mode == agentModeMergingRoomba {
 			a.dist *= 1.5
@@ -532,7 +551,7 @@ func (a *colonyAgentNode) AssignMode(mode colonyAgentMode, pos gmath.Vec, target
 		if !a.stats.CanPatrol {
 			maxDist *= 0.65
 		}
-		a.dist = a.scene.Rand().FloatRange(40, maxDist)
+		a.dist = detmath.FloatRange(a.scene.Rand(), 40, maxDist)
 		a.setWaypoint(a.orbitingWaypoint(a.colonyCore.pos, a.dist))
 		a.waypointsLeft = 0
 		return true
@@ -548,7 +567,7 @@ func (a *colonyAgentNode) AssignMode(mode colonyAgentMode, pos gmath.Vec, target
 		isPatrol := a.mode == agentModePatrol
 		a.mode = agentModeFollow // attack is a long-range follow
 		a.target = target
-		a.setWaypoint(a.followWaypoint(target.(*creepNode).pos))
+		a.setWaypoint(a.followWaypoint(*target.(targetable).GetPos()))
 		if isPatrol {
 			a.waypointsLeft = a.scene.Rand().IntRange(4, 6)
 		} else {
@@ -580,7 +599,7 @@ func (a *colonyAgentNode) AssignMode(mode colonyAgentMode, pos gmath.Vec, target
 
 	case agentModeCourierFlight:
 		colony := target.(*colonyCoreNode)
-		energyCost := gmath.ClampMax(colony.pos.DistanceTo(a.pos)*0.33, 100)
+		energyCost := gmath.ClampMax(detmath.DistanceTo(colony.pos, a.pos)*0.33, 100)
 		if a.tether {
 			energyCost *= 0.5
 		}
@@ -596,7 +615,7 @@ func (a *colonyAgentNode) AssignMode(mode colonyAgentMode, pos gmath.Vec, target
 
 	case agentModeScavenge:
 		source := target.(*essenceSourceNode)
-		energyCost := source.pos.DistanceTo(a.pos) * 0.33
+		energyCost := detmath.DistanceTo(source.pos, a.pos) * 0.33
 		if a.tether {
 			energyCost *= 0.5
 		}
@@ -609,7 +628,7 @@ func (a *colonyAgentNode) AssignMode(mode colonyAgentMode, pos gmath.Vec, target
 		}
 		a.energyBill += energyCost
 		a.mode = agentModeMineEssence
-		a.setWaypoint(roundedPos(source.pos.Sub(gmath.Vec{Y: agentFlightHeight}).Add(a.scene.Rand().Offset(-8, 8))))
+		a.setWaypoint(roundedPos(source.pos.Sub(gmath.Vec{Y: agentFlightHeight}).Add(detmath.Offset(a.scene.Rand(), -8, 8))))
 		a.target = target
 		return true
 
@@ -622,17 +641,17 @@ func (a *colonyAgentNode) AssignMode(mode colonyAgentMode, pos gmath.Vec, target
 			return false
 		}
 		source := target.(*essenceSourceNode)
-		energyCost := (source.pos.DistanceTo(a.pos) * 0.3) + 20
+		energyCost := float64(detmath.DistanceTo(source.pos, a.pos)*0.3) + 20
 		if a.tether {
 			energyCost *= 0.5
 		}
 		if energyCost > a.energy && !a.hasTrait(traitWorkaholic) {
 			return false
 		}
-		a.dist = a.scene.Rand().FloatRange(20, 25) // time to harvest
+		a.dist = detmath.FloatRange(a.scene.Rand(), 20, 25) // time to harvest
 		a.energyBill += energyCost
 		a.mode = mode
-		a.setWaypoint(roundedPos(source.pos.Sub(gmath.Vec{Y: agentFlightHeight}).Add(a.scene.Rand().Offset(-10, 10))))
+		a.setWaypoint(roundedPos(source.pos.Sub(gmath.Vec{Y: agentFlightHeight}).Add(detmath.Offset(a.scene.Rand(), -10, 10))))
 		a.target = target
 		return true
 
@@ -651,7 +670,7 @@ func (a *colonyAgentNode) AssignMode(mode colonyAgentMode, pos gmath.Vec, target
 		if source.stats == redOilSource && a.stats.Kind != gamedata.AgentRedminer {
 			return false
 		}
-		energyCost := source.pos.DistanceTo(a.pos) * 0.5
+		energyCost := detmath.DistanceTo(source.pos, a.pos) * 0.5
 		if a.tether {
 			energyCost *= 0.5
 		}
@@ -660,7 +679,7 @@ func (a *colonyAgentNode) AssignMode(mode colonyAgentMode, pos gmath.Vec, target
 		}
 		a.energyBill += energyCost
 		a.mode = mode
-		a.setWaypoint(roundedPos(source.pos.Sub(gmath.Vec{Y: agentFlightHeight}).Add(a.scene.Rand().Offset(-8, 8))))
+		a.setWaypoint(roundedPos(source.pos.Sub(gmath.Vec{Y: agentFlightHeight}).Add(detmath.Offset(a.scene.Rand(), -8, 8))))
 		a.target = target
 		return true
 
@@ -695,8 +714,8 @@ func (a *colonyAgentNode) AssignMode(mode colonyAgentMode, pos gmath.Vec, target
 		a.target = target
 		a.mode = mode
 		a.energyBill += energyCost
-		a.dist = a.scene.Rand().FloatRange(5, 7) // working time
-		a.setWaypoint(gmath.RadToVec(a.scene.Rand().Rad()).Mulf(64.0).Add(target.(*neutralBuildingNode).pos))
+		a.dist = detmath.FloatRange(a.scene.Rand(), 5, 7) // working time
+		a.setWaypoint(detmath.AddScaled(target.(*neutralBuildingNode).pos, detmath.RadToVec(detmath.RandRad(a.scene.Rand())), 64.0))
 		return true
 
 	case agentModeRepairTurret:
@@ -707,8 +726,8 @@ func (a *colonyAgentNode) AssignMode(mode colonyAgentMode, pos gmath.Vec, target
 		a.target = target
 		a.mode = mode
 		a.energyBill += energyCost
-		a.dist = a.scene.Rand().FloatRange(3, 4) // repair time
-		a.setWaypoint(gmath.RadToVec(a.scene.Rand().Rad()).Mulf(64.0).Add(target.(*colonyAgentNode).pos))
+		a.dist = detmath.FloatRange(a.scene.Rand(), 3, 4) // repair time
+		a.setWaypoint(detmath.AddScaled(target.(*colonyAgentNode).pos, detmath.RadToVec(detmath.RandRad(a.scene.Rand())), 64.0))
 		return true
 
 	case agentModeRepairBase:
@@ -718,21 +737,51 @@ func (a *colonyAgentNode) AssignMode(mode colonyAgentMode, pos gmath.Vec, target
 		}
 		a.mode = mode
 		a.energyBill += energyCost
-		a.dist = a.scene.Rand().FloatRange(3, 4) // repair time
-		a.setWaypoint(gmath.RadToVec(a.scene.Rand().Rad()).Mulf(64.0).Add(a.colonyCore.pos))
+		a.dist = detmath.FloatRange(a.scene.Rand(), 3, 4) // repair time
+		a.setWaypoint(detmath.AddScaled(a.colonyCore.pos, detmath.RadToVec(detmath.RandRad(a.scene.Rand())), 64.0))
 		return true
 
 	case agentModeBuildBuilding:
 		construction := target.(*constructionNode)
-		energyCost := construction.pos.DistanceTo(a.pos) * 0.6
+		energyCost := float64(detmath.DistanceTo(construction.pos, a.pos) * 0.6)
+		if energyCost > a.energy && !a.hasTrait(traitWorkaholic) {
+			return false
+		}
+		a.mode = mode
+		a.energyBill += energyCost
+		a.dist = detmath.FloatRange(a.scene.Rand(), 5, 7) // build time
+		a.target = target
+		a.setWaypoint(detmath.AddScaled(construction.pos, detmath.RadToVec(detmath.RandRad(a.scene.Rand())), 64.0))
+		return true
+
+	case agentModeLayMine:
+		energyCost := float64(detmath.DistanceTo(pos, a.pos)*0.3) + 10
 		if energyCost > a.energy && !a.hasTrait(traitWorkaholic) {
 			return false
 		}
+		a.energyBill += energyCost
 		a.mode = mode
+		a.setWaypoint(pos.Sub(gmath.Vec{Y: agentFlightHeight}))
+		return true
+
+	case agentModeRelay:
+		source := target.(*essenceSourceNode)
+		energyCost := float64(detmath.DistanceTo(source.pos, a.pos) * 0.3)
+		if energyCost > a.energy && !a.hasTrait(traitWorkaholic) {
+			return false
+		}
 		a.energyBill += energyCost
-		a.dist = a.scene.Rand().FloatRange(5, 7) // build time
+		a.mode = mode
+		a.target = target
+		a.dist = detmath.FloatRange(a.scene.Rand(), 25, 40) // relay time
+		a.setWaypoint(roundedPos(source.pos.Sub(gmath.Vec{Y: agentFlightHeight}).Add(detmath.Offset(a.scene.Rand(), -40, 40))))
+		return true
+
+	case agentModeShieldEscort:
+		a.mode = mode
 		a.target = target
-		a.setWaypoint(gmath.RadToVec(a.scene.Rand().Rad()).Mulf(64.0).Add(construction.pos))
+		a.setWaypoint(target.(*colonyAgentNode).pos.Add(detmath.Offset(a.scene.Rand(), -40, 40)))
+		a.waypointsLeft = a.scene.Rand().IntRange(8, 12)
 		return true
 
 	case agentModeKamikazeAttack:
@@ -742,7 +791,7 @@ func (a *colonyAgentNode) AssignMode(mode colonyAgentMode, pos gmath.Vec, target
 		return true
 
 	case agentModeConsumeDrone:
-		a.setWaypoint(target.(*colonyAgentNode).pos.Add(a.scene.Rand().Offset(-4, 4)))
+		a.setWaypoint(target.(*colonyAgentNode).pos.Add(detmath.Offset(a.scene.Rand(), -4, 4)))
 		a.mode = mode
 		a.target = target
 		return true
@@ -750,7 +799,7 @@ func (a *colonyAgentNode) AssignMode(mode colonyAgentMode, pos gmath.Vec, target
 	case agentModeFollowCommander:
 		a.mode = mode
 		a.target = target
-		a.setWaypoint(target.(*colonyAgentNode).pos.Add(a.scene.Rand().Offset(-16, 16)))
+		a.setWaypoint(target.(*colonyAgentNode).pos.Add(detmath.Offset(a.scene.Rand(), -16, 16)))
 		a.waypointsLeft = a.scene.Rand().IntRange(60, 100)
 		weaponRange := a.stats.Weapon.AttackRange
 		switch {
@@ -772,6 +821,13 @@ func (a *colonyAgentNode) orbitingWaypoint(center gmath.Vec, dist float64) gmath
 }
 
 func (a *colonyAgentNode) Update(delta float64) {
+	a.update(delta)
+	if a.spatialIndex != nil && !a.IsDisposed() {
+		a.spatialIndex.Move(a.spatialHandle, a.pos)
+	}
+}
+
+func (a *colonyAgentNode) update(delta float64) {
 	if a.anim != nil {
 		a.anim.Tick(delta)
 	}
@@ -787,7 +843,7 @@ func (a *colonyAgentNode) Update(delta float64) {
 	}
 
 	if a.resting {
-		a.energy = gmath.ClampMax(a.energy+(delta*0.5), a.maxEnergy)
+		a.energy = gmath.ClampMax(a.energy+float64(delta*0.5), a.maxEnergy)
 		if a.energy > a.maxEnergy*0.6 {
 			a.resting = false
 		}
@@ -798,6 +854,7 @@ func (a *colonyAgentNode) Update(delta float64) {
 	}
 
 	a.slow = gmath.ClampMin(a.slow-delta, 0)
+	a.shield = gmath.ClampMin(a.shield-delta, 0)
 	a.specialDelay = gmath.ClampMin(a.specialDelay-delta, 0)
 
 	if a.cloaking > 0 {
@@ -871,6 +928,12 @@ func (a *colonyAgentNode) Update(delta float64) {
 		a.updateBomberAttack(delta)
 	case agentModeConsumeDrone:
 		a.updateConsumeDrone(delta)
+	case agentModeLayMine:
+		a.updateLayMine(delta)
+	case agentModeRelay:
+		a.updateRelay(delta)
+	case agentModeShieldEscort:
+		a.updateShieldEscort(delta)
 	case agentModeRoombaPatrol, agentModeRoombaGuard, agentModeRoombaAttack:
 		a.updateRoombaPatrol(delta)
 	case agentModeRoombaWait, agentModeRoombaCombatWait:
@@ -1017,7 +1080,7 @@ func (a *colonyAgentNode) onLowHealthDamage(source targetable) {
 		case agentModePatrol, agentModeStandby, agentModeMineEssence, agentModeReturn, agentModeFollow:
 			if creep, ok := source.(*creepNode); ok {
 				a.health = gmath.ClampMax(a.health+10, a.maxHealth)
-				a.dist = 0.1 + a.scene.Rand().FloatRange(0.05, 0.25)
+				a.dist = 0.1 + detmath.FloatRange(a.scene.Rand(), 0.05, 0.25)
 				a.AssignMode(agentModeKamikazeAttack, gmath.Vec{}, creep)
 				return
 			}
@@ -1026,7 +1089,7 @@ func (a *colonyAgentNode) onLowHealthDamage(source targetable) {
 
 	if a.mode == agentModeRoombaCombatWait && a.scene.Rand().Chance(0.4) {
 		a.mode = agentModeRoombaGuard
-		a.sendTo(a.colonyCore.pos.Add(a.scene.Rand().Offset(-180, 180)), layerNormal)
+		a.sendTo(a.colonyCore.pos.Add(detmath.Offset(a.scene.Rand(), -180, 180)), layerNormal)
 		if a.specialDelay == 0 {
 			a.energy = gmath.ClampMax(a.energy+50, a.maxEnergy)
 			a.specialDelay = 20
@@ -1050,15 +1113,15 @@ func (a *colonyAgentNode) onLowHealthDamage(source targetable) {
 
 	if a.stats.CanCloak && !a.IsCloaked() && a.specialDelay == 0 {
 		a.AssignMode(agentModeCloakHide, gmath.Vec{}, nil)
-		a.doCloak(a.scene.Rand().FloatRange(6, 10))
-		a.specialDelay = a.scene.Rand().FloatRange(6, 10)
+		a.doCloak(detmath.FloatRange(a.scene.Rand(), 6, 10))
+		a.specialDelay = detmath.FloatRange(a.scene.Rand(), 6, 10)
 		return
 	}
 
 	switch {
 	case a.hasTrait(traitLowHPBerserk):
 		// Berserks go straight into the danger when low on health.
-		a.AssignMode(agentModeMove, source.GetPos().Add(a.scene.Rand().Offset(-20, 20)), nil)
+		a.AssignMode(agentModeMove, source.GetPos().Add(detmath.Offset(a.scene.Rand(), -20, 20)), nil)
 	case a.hasTrait(traitLowHPRecycle):
 		// Recycle agents may go to recycle themselves on low health.
 		if a.scene.Rand().Chance(0.8) {
@@ -1066,7 +1129,7 @@ func (a *colonyAgentNode) onLowHealthDamage(source targetable) {
 		}
 	case a.hasTrait(traitLowHPRetreat):
 		// Agents with retreat trait will try to fly away from a threat on low health.
-		pos := retreatPos(a.scene.Rand(), a.scene.Rand().FloatRange(80, 140), a.pos, *source.GetPos())
+		pos := retreatPos(a.scene.Rand(), detmath.FloatRange(a.scene.Rand(), 80, 140), a.pos, *source.GetPos())
 		a.AssignMode(agentModeMove, pos, nil)
 	case a.hasTrait(traitLowHPPanic):
 		// Agents with panic trait will stop what they're doing and fly like crazy.
@@ -1079,6 +1142,11 @@ func (a *colonyAgentNode) OnDamage(damage gamedata.DamageValue, source targetabl
 		return
 	}
 
+	if a.shield > 0 {
+		// The shielder's projection absorbs a half of the incoming damage.
+		damage.Health *= 0.5
+	}
+
 	a.health -= damage.Health
 
 	if a.health < 0 {
@@ -1098,7 +1166,7 @@ func (a *colonyAgentNode) OnDamage(damage gamedata.DamageValue, source targetabl
 
 	if damage.Health != 0 {
 		if !damage.HasFlag(gamedata.DmgflagNoFlash) {
-			a.flashComponent.SetFlash(a.world().localRand.FloatRange(0.07, 0.14))
+			a.flashComponent.SetFlash(detmath.FloatRange(a.world().localRand, 0.07, 0.14))
 		}
 		if a.IsTurret() {
 			a.updateHealthShader()
@@ -1106,14 +1174,15 @@ func (a *colonyAgentNode) OnDamage(damage gamedata.DamageValue, source targetabl
 		if a.stats.Kind == gamedata.AgentRoomba && !source.IsFlying() {
 			switch a.mode {
 			case agentModeRoombaPatrol:
-				if source.GetPos().DistanceSquaredTo(a.pos) < a.stats.Weapon.AttackRangeSqr && a.scene.Rand().Chance(0.2) {
+				if detmath.DistanceSquaredTo(*source.GetPos(), a.pos) < a.stats.Weapon.AttackRangeSqr && a.scene.Rand().Chance(0.2) {
 					a.mode = agentModeRoombaCombatWait
-					a.dist = a.scene.Rand().FloatRange(6, 10)
+					a.dist = detmath.FloatRange(a.scene.Rand(), 6, 10)
 					a.clearWaypoint()
 					return
 				}
 			case agentModeRoombaWait:
-				if source != a.target && source.GetPos().DistanceSquaredTo(a.pos) > a.stats.Weapon.AttackRangeSqr && a.scene.Rand().Chance(0.45) {
+				_, isCreep := source.(*creepNode)
+				if isCreep && source != a.target && detmath.DistanceSquaredTo(*source.GetPos(), a.pos) > a.stats.Weapon.AttackRangeSqr && a.scene.Rand().Chance(0.45) {
 					a.mode = agentModeRoombaAttack
 					a.target = source
 					a.sendTo(midpoint(a.pos, *source.GetPos()), layerNormal)
@@ -1142,7 +1211,7 @@ func (a *colonyAgentNode) OnDamage(damage gamedata.DamageValue, source targetabl
 			if stunnableModes[a.mode] && a.scene.Rand().Chance(0.9) {
 				a.clearCargo()
 				a.AssignMode(agentModeForcedCharging, gmath.Vec{}, nil)
-				a.energyTarget = gmath.ClampMax(a.energy+a.scene.Rand().FloatRange(4, 8), a.maxEnergy-0.01)
+				a.energyTarget = gmath.ClampMax(a.energy+detmath.FloatRange(a.scene.Rand(), 4, 8), a.maxEnergy-0.01)
 				return
 			} else {
 				a.energy = gmath.ClampMin(a.energy-energyDamage, 0)
@@ -1166,7 +1235,7 @@ func (a *colonyAgentNode) OnDamage(damage gamedata.DamageValue, source targetabl
 			if effectRoll < 0.4 {
 				a.AssignMode(agentModePanic, gmath.Vec{}, nil)
 			} else {
-				pos := retreatPos(a.scene.Rand(), a.scene.Rand().FloatRange(80, 140), a.pos, *source.GetPos())
+				pos := retreatPos(a.scene.Rand(), detmath.FloatRange(a.scene.Rand(), 80, 140), a.pos, *source.GetPos())
 				a.AssignMode(agentModeMove, pos, nil)
 			}
 		}
@@ -1187,7 +1256,7 @@ func (a *colonyAgentNode) processSupport(delta float64) {
 		return
 	}
 
-	a.supportDelay = gmath.ClampMin(a.supportDelay-(delta*a.reloadRate), 0)
+	a.supportDelay = gmath.ClampMin(a.supportDelay-float64(delta*a.reloadRate), 0)
 
 	if a.supportDelay != 0 {
 		return
@@ -1199,6 +1268,14 @@ func (a *colonyAgentNode) processSupport(delta float64) {
 		a.doRecharge()
 	case gamedata.AgentRepair:
 		a.doRepair()
+	case gamedata.AgentShielder:
+		a.doShield()
+	case gamedata.AgentRelay:
+		a.doRelay()
+	case gamedata.AgentTitan:
+		a.doShockwave()
+	case gamedata.AgentHive:
+		a.doSpawnWorker()
 	case gamedata.AgentScavenger, gamedata.AgentMarauder:
 		a.doScavenge()
 	case gamedata.AgentDisintegrator:
@@ -1212,7 +1289,7 @@ func (a *colonyAgentNode) processSupport(delta float64) {
 		a.doTether()
 	}
 	if setDelay {
-		a.supportDelay = a.stats.SupportReload * a.scene.Rand().FloatRange(0.7, 1.4)
+		a.supportDelay = a.stats.SupportReload * detmath.FloatRange(a.scene.Rand(), 0.7, 1.4)
 	}
 }
 
@@ -1272,7 +1349,7 @@ func (a *colonyAgentNode) doConsumeDrone() {
 			score += 0.5
 		}
 		if a.rank != 0 {
-			score -= float64(a.rank) * 0.5
+			score -= float64(float64(a.rank) * 0.5)
 		}
 		multiplier := (2.0 - (x.health / x.maxHealth)) + (1.2 - (x.energy / x.maxEnergy))
 		score *= multiplier
@@ -1311,7 +1388,7 @@ func (a *colonyAgentNode) doTether() {
 	// If it's connected to the colony, it can't boost anyone else.
 	if a.target != nil {
 		if tether, ok := a.target.(*tetherNode); ok {
-			a.supportDelay = a.scene.Rand().FloatRange(0.5, 2)
+			a.supportDelay = detmath.FloatRange(a.scene.Rand(), 0.5, 2)
 			if !tether.IsDisposed() {
 				return
 			}
@@ -1323,7 +1400,7 @@ func (a *colonyAgentNode) doTether() {
 		if colony.waypoint.IsZero() {
 			return false
 		}
-		return colony.pos.DistanceSquaredTo(a.pos) <= a.stats.SupportRangeSqr
+		return detmath.DistanceSquaredTo(colony.pos, a.pos) <= a.stats.SupportRangeSqr
 	})
 	if colonyTarget != nil && a.target != colonyTarget {
 		tether := newTetherNode(a.world(), a, colonyTarget)
@@ -1331,7 +1408,7 @@ func (a *colonyAgentNode) doTether() {
 		colonyTarget.tether++
 		a.world().nodeRunner.AddObject(tether)
 		playSound(a.world(), assets.AudioTetherShot, a.pos)
-		a.supportDelay = a.stats.SupportReload * a.scene.Rand().FloatRange(0.95, 1.35)
+		a.supportDelay = a.stats.SupportReload * detmath.FloatRange(a.scene.Rand(), 0.95, 1.35)
 		return
 	}
 
@@ -1345,7 +1422,7 @@ func (a *colonyAgentNode) doTether() {
 			if actionsLeft <= 0 {
 				return true
 			}
-			if colony == a.colonyCore || a.pos.DistanceSquaredTo(colony.pos) > (a.stats.SupportRangeSqr*1.2) {
+			if colony == a.colonyCore || detmath.DistanceSquaredTo(a.pos, colony.pos) > (a.stats.SupportRangeSqr*1.2) {
 				return false
 			}
 			actionsLeft -= a.walkTetherTargets(colony, actionsLeft, func(x *colonyAgentNode) {
@@ -1356,10 +1433,10 @@ func (a *colonyAgentNode) doTether() {
 	}
 
 	if actionsLeft != maxNumberOfTargets {
-		a.supportDelay = a.stats.SupportReload * a.scene.Rand().FloatRange(0.95, 1.35)
+		a.supportDelay = a.stats.SupportReload * detmath.FloatRange(a.scene.Rand(), 0.95, 1.35)
 		return
 	}
-	a.supportDelay = a.scene.Rand().FloatRange(0.5, 2)
+	a.supportDelay = detmath.FloatRange(a.scene.Rand(), 0.5, 2)
 }
 
 func (a *colonyAgentNode) doDisintegratorAttack() {
@@ -1376,11 +1453,11 @@ func (a *colonyAgentNode) doDisintegratorAttack() {
 	}
 	targets := a.findAttackTargets()
 	if len(targets) == 0 {
-		a.supportDelay = a.scene.Rand().FloatRange(0.15, 1.2)
+		a.supportDelay = detmath.FloatRange(a.scene.Rand(), 0.15, 1.2)
 		return
 	}
 	a.energy -= attackEnergyCost
-	a.supportDelay = a.stats.SupportReload * a.scene.Rand().FloatRange(0.8, 1.2)
+	a.supportDelay = a.stats.SupportReload * detmath.FloatRange(a.scene.Rand(), 0.8, 1.2)
 	target := targets[0]
 	toPos := snipePos(a.stats.Weapon.ProjectileSpeed, a.pos, *target.GetPos(), target.GetVelocity())
 	p := a.world().newProjectileNode(projectileConfig{
@@ -1394,7 +1471,7 @@ func (a *colonyAgentNode) doDisintegratorAttack() {
 	a.AssignMode(agentModeForcedCharging, gmath.Vec{}, nil)
 	playSound(a.world(), a.stats.Weapon.AttackSound, a.pos)
 	a.world().nodeRunner.AddObject(newEffectNode(a.world(), a.pos, aboveEffectLayer, assets.ImagePurpleIonZap))
-	a.energyTarget = a.energy + a.scene.Rand().FloatRange(22, 35)
+	a.energyTarget = a.energy + detmath.FloatRange(a.scene.Rand(), 22, 35)
 }
 
 func (a *colonyAgentNode) doScavenge() {
@@ -1414,27 +1491,25 @@ func (a *colonyAgentNode) doScavenge() {
 		return
 	}
 
-	maxDistSqr := 256.0 * 256.0
+	maxDist := 256.0
 	if a.stats.Kind == gamedata.AgentMarauder {
-		maxDistSqr = 300.0 * 300.0
+		maxDist = 300.0
 	}
 
 	var bestSource *essenceSourceNode
 	bestScore := 0.0
-	for _, source := range a.world().essenceSources {
+	a.world().essenceIndex.WalkRadius(a.pos, maxDist, func(source *essenceSourceNode) bool {
 		if !source.stats.scrap {
-			continue
-		}
-		distSqr := a.pos.DistanceSquaredTo(source.pos)
-		if distSqr > maxDistSqr {
-			continue
+			return false
 		}
-		score := distSqr * a.scene.Rand().FloatRange(0.6, 1.6)
+		distSqr := detmath.DistanceSquaredTo(a.pos, source.pos)
+		score := distSqr * detmath.FloatRange(a.scene.Rand(), 0.6, 1.6)
 		if score != 0 && score > bestScore {
 			bestScore = score
 			bestSource = source
 		}
-	}
+		return false
+	})
 	if bestSource != nil {
 		a.AssignMode(agentModeScavenge, gmath.Vec{}, bestSource)
 	}
@@ -1446,7 +1521,7 @@ func (a *colonyAgentNode) doRecharge() {
 		return x != a &&
 			x.mode != agentModeKamikazeAttack &&
 			(x.energy+rechargerEnergyRecorery) < x.maxEnergy &&
-			x.pos.DistanceSquaredTo(a.pos) < gamedata.RechargerAgentStats.SupportRangeSqr
+			detmath.DistanceSquaredTo(x.pos, a.pos) < gamedata.RechargerAgentStats.SupportRangeSqr
 	})
 	if target != nil {
 		if !a.world().simulation {
@@ -1461,8 +1536,7 @@ func (a *colonyAgentNode) createBeam(target targetable, beamStats *gamedata.Agen
 	offset := gmath.Vec{Y: a.stats.FireOffset}
 	targetPos := target.GetPos()
 	if a.stats.BeamShift != 0 {
-		shift := targetPos.DirectionTo(a.pos).Mulf(a.stats.BeamShift)
-		offset = offset.Add(shift)
+		offset = detmath.AddScaled(offset, detmath.DirectionTo(*targetPos, a.pos), a.stats.BeamShift)
 	}
 	from := ge.Pos{Base: &a.pos, Offset: offset}
 	to := ge.Pos{Base: targetPos, Offset: gmath.Vec{Y: -2}}
@@ -1476,7 +1550,7 @@ func (a *colonyAgentNode) createBeam(target targetable, beamStats *gamedata.Agen
 	}
 	if a.stats.BeamExplosion != assets.ImageNone {
 		createEffect(a.world(), effectConfig{
-			Pos:   target.GetPos().Add(a.world().localRand.Offset(-6, 6)),
+			Pos:   target.GetPos().Add(detmath.Offset(a.world().localRand, -6, 6)),
 			Layer: effectLayerFromBool(target.IsFlying()),
 			Image: a.stats.BeamExplosion,
 		})
@@ -1488,7 +1562,7 @@ func (a *colonyAgentNode) doRepair() {
 		return x != a &&
 			x.mode != agentModeKamikazeAttack &&
 			x.health < x.maxHealth &&
-			x.pos.DistanceSquaredTo(a.pos) < gamedata.RepairAgentStats.SupportRangeSqr
+			detmath.DistanceSquaredTo(x.pos, a.pos) < gamedata.RepairAgentStats.SupportRangeSqr
 	})
 	if target != nil {
 		if !a.world().simulation {
@@ -1499,6 +1573,99 @@ func (a *colonyAgentNode) doRepair() {
 	}
 }
 
+func (a *colonyAgentNode) doShield() {
+	const shieldDuration float64 = 6.0
+	target := a.colonyCore.agents.Find(searchFighters|searchRandomized, func(x *colonyAgentNode) bool {
+		return x.shield == 0 &&
+			x.mode != agentModeKamikazeAttack &&
+			detmath.DistanceSquaredTo(x.pos, a.pos) < gamedata.ShielderAgentStats.SupportRangeSqr
+	})
+	if target == nil {
+		return
+	}
+	target.shield = shieldDuration
+	if !a.world().simulation {
+		a.createBeam(target, gamedata.ShielderAgentStats)
+		effect := newAttachedSpriteNode(a.world(), target, shieldDuration, ge.Pos{Base: &target.pos}, true, assets.ImageAssaultShield)
+		a.world().nodeRunner.AddObject(effect)
+	}
+	playSound(a.world(), assets.AudioAssaultShield, a.pos)
+}
+
+func (a *colonyAgentNode) doRelay() {
+	// Teleport the cargo of a drone that is flying back to the colony.
+	target := a.colonyCore.agents.Find(searchWorkers|searchFighters|searchRandomized, func(x *colonyAgentNode) bool {
+		return x != a &&
+			x.mode == agentModeReturn &&
+			x.payload != 0 &&
+			detmath.DistanceSquaredTo(x.pos, a.pos) < gamedata.RelayAgentStats.SupportRangeSqr
+	})
+	if target == nil {
+		return
+	}
+	createEffect(a.world(), effectConfig{
+		Pos:   target.pos,
+		Layer: aboveEffectLayer,
+		Image: assets.ImageTeleportEffectSmall,
+	})
+	target.unloadCargo()
+	target.AssignMode(agentModeStandby, gmath.Vec{}, nil)
+	playSound(a.world(), assets.AudioTeleportDone, a.pos)
+}
+
+func (a *colonyAgentNode) doShockwave() {
+	// The shockwave hits several nearby enemies at once.
+	const maxTargets = 6
+	targets := a.world().tmpTargetSlice[:0]
+	a.world().WalkCreeps(a.pos, a.stats.SupportRange, func(creep *creepNode) bool {
+		if !creep.CanBeTargeted() || detmath.DistanceSquaredTo(creep.pos, a.pos) > a.stats.SupportRangeSqr {
+			return false
+		}
+		targets = append(targets, creep)
+		return len(targets) >= maxTargets
+	})
+	if len(targets) == 0 {
+		return
+	}
+	for _, target := range targets {
+		target.OnDamage(gamedata.DamageValue{Health: 4, Slow: 1, Flags: gamedata.DmgflagNoFlash}, a)
+	}
+	createEffect(a.world(), effectConfig{
+		Pos:   a.pos,
+		Image: assets.ImageWispShockwave,
+		Layer: aboveEffectLayer,
+	})
+	playSound(a.world(), assets.AudioWispShocker, a.pos)
+}
+
+func (a *colonyAgentNode) doSpawnWorker() {
+	colony := a.colonyCore
+	if colony.mode != colonyModeNormal {
+		return
+	}
+	switch a.mode {
+	case agentModeStandby, agentModePatrol, agentModeFollowCommander:
+		// OK
+	default:
+		return
+	}
+	if colony.NumAgents() >= colony.calcUnitLimit() {
+		return
+	}
+
+	worker := colony.NewColonyAgentNode(gamedata.WorkerAgentStats, a.pos.Add(detmath.Offset(a.scene.Rand(), -4, 4)))
+	worker.faction = colony.pickAgentFaction()
+	a.world().nodeRunner.AddObject(worker)
+	worker.AssignMode(agentModeStandby, gmath.Vec{}, nil)
+	a.world().result.DronesProduced++
+	createEffect(a.world(), effectConfig{
+		Pos:   worker.pos,
+		Layer: aboveEffectLayer,
+		Image: assets.ImageTeleportEffectSmall,
+	})
+	playSound(a.world(), assets.AudioAgentProduced, a.pos)
+}
+
 func (a *colonyAgentNode) walkTetherTargets(colony *colonyCoreNode, num int, f func(x *colonyAgentNode)) int {
 	targets := a.world().tmpTargetSlice[:0]
 	processed := 0
@@ -1509,7 +1676,7 @@ func (a *colonyAgentNode) walkTetherTargets(colony *colonyCoreNode, num int, f f
 		if x.tether {
 			return false
 		}
-		if x.pos.DistanceSquaredTo(a.pos) > a.stats.SupportRangeSqr {
+		if detmath.DistanceSquaredTo(x.pos, a.pos) > a.stats.SupportRangeSqr {
 			return false
 		}
 		switch x.mode {
@@ -1545,24 +1712,35 @@ func (a *colonyAgentNode) walkTetherTargets(colony *colonyCoreNode, num int, f f
 func (a *colonyAgentNode) findAttackTargets() []targetable {
 	w := a.world()
 
-	maxTargets := a.stats.Weapon.MaxTargets
+	weapon := a.weapon()
+	maxTargets := weapon.MaxTargets
 	targets := w.tmpTargetSlice[:0]
-	w.WalkCreeps(a.pos, a.stats.Weapon.AttackRange, func(creep *creepNode) bool {
-		if a.isValidTarget(creep) {
+	w.WalkCreeps(a.pos, weapon.AttackRange, func(creep *creepNode) bool {
+		if isValidCreepTarget(a.pos, creep, weapon) {
 			targets = append(targets, creep)
 		}
 		return len(targets) >= maxTargets
 	})
+	if len(targets) < maxTargets {
+		w.FindEnemyTargets(a.colonyCore, a.pos, weapon, func(t targetable) bool {
+			targets = append(targets, t)
+			return len(targets) >= maxTargets
+		})
+	}
 
 	return targets
 }
 
-func (a *colonyAgentNode) isValidTarget(creep *creepNode) bool {
-	return isValidCreepTarget(a.pos, creep, a.stats.Weapon)
+// weapon returns the agent weapon with the colony turret upgrades applied.
+func (a *colonyAgentNode) weapon() *gamedata.WeaponStats {
+	if a.IsTurret() && a.colonyCore != nil {
+		return a.colonyCore.turretWeapon(a.stats.Weapon, &a.upgrades)
+	}
+	return a.stats.Weapon
 }
 
 func (a *colonyAgentNode) attackWithProjectile(target targetable, burstSize int) {
-	attackWithProjectile(a.world(), a.stats.Weapon, a, target, burstSize, a.mode == agentModeFollowCommander)
+	attackWithProjectile(a.world(), a.weapon(), a, target, burstSize, a.mode == agentModeFollowCommander)
 }
 
 func (a *colonyAgentNode) attackTargets(targets []targetable, burstSize int) {
@@ -1574,7 +1752,7 @@ func (a *colonyAgentNode) attackTargets(targets []targetable, burstSize int) {
 			if !a.world().simulation {
 				a.createBeam(target, a.stats)
 			}
-			target.OnDamage(multipliedDamage(target, a.stats.Weapon), a)
+			target.OnDamage(multipliedDamage(target, a.weapon()), a)
 		}
 	}
 }
@@ -1598,21 +1776,21 @@ func (a *colonyAgentNode) processAttack(delta float64) {
 
 	targets := a.findAttackTargets()
 	if len(targets) == 0 {
-		a.attackDelay = 0.75 * a.scene.Rand().FloatRange(0.8, 1.4)
+		a.attackDelay = 0.75 * detmath.FloatRange(a.scene.Rand(), 0.8, 1.4)
 		return
 	}
 
-	reloadMultiplier := a.scene.Rand().FloatRange(0.8, 1.2)
+	reloadMultiplier := detmath.FloatRange(a.scene.Rand(), 0.8, 1.2)
 	if a.stats == gamedata.BeamTowerAgentStats {
-		reloadMultiplier += (a.specialDelay * 0.3)
-		a.specialDelay += ((a.stats.Weapon.Reload) + 1.75) * reloadMultiplier
+		reloadMultiplier += float64(a.specialDelay * 0.3)
+		a.specialDelay += float64(((a.stats.Weapon.Reload) + 1.75) * reloadMultiplier)
 	}
 
 	a.attackDelay = a.stats.Weapon.Reload * reloadMultiplier
 	a.energy = gmath.ClampMin(a.energy-a.stats.Weapon.EnergyCost, 0)
 
 	switch a.stats.Kind {
-	case gamedata.AgentDestroyer:
+	case gamedata.AgentDestroyer, gamedata.AgentTitan:
 		target := targets[0]
 		offset := gmath.Vec{X: -7, Y: 2}
 		offsetStep := gmath.Vec{X: 14}
@@ -1645,10 +1823,10 @@ func (a *colonyAgentNode) processAttack(delta float64) {
 			if ally.stats.Kind != gamedata.AgentPrism || ally == a {
 				return false
 			}
-			if ally.pos.DistanceSquaredTo(*pos) > (96 * 96) {
+			if detmath.DistanceSquaredTo(ally.pos, *pos) > (96 * 96) {
 				return false
 			}
-			ally.attackDelay += float64(numReflections) * 0.1
+			ally.attackDelay += float64(float64(numReflections) * 0.1)
 			beam := newBeamNode(a.world(), ge.Pos{Base: pos}, ge.Pos{Base: &ally.pos}, prismBeamColors[numReflections])
 			beam.width = width
 			a.world().nodeRunner.AddObject(beam)
@@ -1669,7 +1847,7 @@ func (a *colonyAgentNode) processAttack(delta float64) {
 		damage.Health *= damageMultiplier(target.GetTargetInfo(), a.stats.Weapon)
 		target.OnDamage(damage, a)
 		createEffect(a.world(), effectConfig{
-			Pos:   target.GetPos().Add(a.world().localRand.Offset(-6, 6)),
+			Pos:   target.GetPos().Add(detmath.Offset(a.world().localRand, -6, 6)),
 			Image: a.stats.BeamExplosion,
 			Layer: effectLayerFromBool(target.IsFlying()),
 		})
@@ -1726,18 +1904,21 @@ func (a *colonyAgentNode) movementSpeed() float64 {
 	if a.tether {
 		multiplier *= 2.0
 	}
+	if a.world().worldEvents != nil && a.world().worldEvents.IsStorm() && a.IsFlying() {
+		multiplier *= stormFlyingSpeedMultiplier
+	}
 	return baseSpeed * multiplier
 }
 
 func (a *colonyAgentNode) moveTowardsWithSpeed(delta, speed float64) bool {
 	// This method is slightly more optimized that from.MoveTowards(dest).
 	travelled := speed * delta
-	distSqr := a.pos.DistanceSquaredTo(a.waypoint)
+	distSqr := detmath.DistanceSquaredTo(a.pos, a.waypoint)
 	if distSqr < travelled*travelled || distSqr < gmath.Epsilon*gmath.Epsilon {
 		a.changePos(a.waypoint)
 		return true
 	}
-	a.changePos(a.pos.Add(a.dir.Mulf(travelled)))
+	a.changePos(detmath.AddScaled(a.pos, a.dir, travelled))
 	return false
 }
 
@@ -1747,7 +1928,7 @@ func (a *colonyAgentNode) moveTowards(delta float64) bool {
 
 func (a *colonyAgentNode) updateFollowCommander(delta float64) {
 	if a.healthRegen != 0 {
-		a.health = gmath.ClampMax(a.health+(delta*a.healthRegen), a.maxHealth)
+		a.health = gmath.ClampMax(a.health+float64(delta*a.healthRegen), a.maxHealth)
 	}
 
 	commander := a.target.(*colonyAgentNode)
@@ -1769,7 +1950,7 @@ func (a *colonyAgentNode) updateFollowCommander(delta float64) {
 
 func (a *colonyAgentNode) updatePatrol(delta float64) {
 	if a.healthRegen != 0 {
-		a.health = gmath.ClampMax(a.health+(delta*a.healthRegen), a.maxHealth)
+		a.health = gmath.ClampMax(a.health+float64(delta*a.healthRegen), a.maxHealth)
 	}
 
 	if a.moveTowards(delta) {
@@ -1792,7 +1973,7 @@ func (a *colonyAgentNode) updateWaitCloning(delta float64) {
 }
 
 func (a *colonyAgentNode) updateTakeoff(delta float64) {
-	height := a.shadowComponent.height + delta*30
+	height := a.shadowComponent.height + float64(delta*30)
 	if a.moveTowards(delta) {
 		height = agentFlightHeight
 	}
@@ -1817,7 +1998,7 @@ func (a *colonyAgentNode) updateRepairBase(delta float64) {
 			// TODO: use local rand and do not create this beam in simulation?
 			buildPos := ge.Pos{
 				Base:   &a.colonyCore.pos,
-				Offset: gmath.Vec{X: a.scene.Rand().FloatRange(-18, 18)},
+				Offset: gmath.Vec{X: detmath.FloatRange(a.scene.Rand(), -18, 18)},
 			}
 			beam := newCloningBeamNode(a.world(), abeamCloning, &a.pos, buildPos)
 			a.cloningBeam = beam
@@ -1829,7 +2010,7 @@ func (a *colonyAgentNode) updateRepairBase(delta float64) {
 	a.dist -= delta
 	if a.dist <= 0 {
 		a.AssignMode(agentModeStandby, gmath.Vec{}, nil)
-		a.colonyCore.OnHeal(a.scene.Rand().FloatRange(3, 5))
+		a.colonyCore.OnHeal(detmath.FloatRange(a.scene.Rand(), 3, 5))
 		return
 	}
 }
@@ -1880,7 +2061,7 @@ func (a *colonyAgentNode) handleForestTransition(nextWaypoint gmath.Vec) {
 }
 
 func (a *colonyAgentNode) updateRelictTakeoff(delta float64) {
-	height := a.shadowComponent.height + delta*30
+	height := a.shadowComponent.height + float64(delta*30)
 	if a.moveTowards(delta) {
 		height = agentFlightHeight
 	}
@@ -1898,14 +2079,14 @@ func (a *colonyAgentNode) updateSiegeGuard(delta float64) {
 
 	turret := a.target.(*siegeTurretNode)
 	if turret.ammo == 0 {
-		a.specialDelay = a.world().rand.FloatRange(60, 70)
+		a.specialDelay = detmath.FloatRange(a.world().rand, 60, 70)
 		turret.ammo = siegeTurretAmmo
 		turret.target = nil
 		return
 	}
 	if turret.target != nil && turret.target.IsDisposed() {
 		turret.target = nil
-		a.specialDelay = a.world().rand.FloatRange(5, 7)
+		a.specialDelay = detmath.FloatRange(a.world().rand, 5, 7)
 		return
 	}
 	if turret.target == nil {
@@ -1916,7 +2097,7 @@ func (a *colonyAgentNode) updateSiegeGuard(delta float64) {
 			if !creep.stats.SiegeTargetable || !creep.CanBeTargeted() {
 				return false
 			}
-			if creep.pos.DistanceSquaredTo(a.pos) > gamedata.SiegeAgentWeapon.AttackRangeSqr {
+			if detmath.DistanceSquaredTo(creep.pos, a.pos) > gamedata.SiegeAgentWeapon.AttackRangeSqr {
 				return false
 			}
 			*targetCandidates = append(*targetCandidates, creep)
@@ -1927,7 +2108,7 @@ func (a *colonyAgentNode) updateSiegeGuard(delta float64) {
 		}
 		if turret.target == nil {
 			// Can't find a target.
-			a.specialDelay = a.world().rand.FloatRange(5, 8)
+			a.specialDelay = detmath.FloatRange(a.world().rand, 5, 8)
 			if turret.ammo < siegeTurretAmmo {
 				turret.ammo++
 				a.specialDelay *= 2
@@ -1938,9 +2119,9 @@ func (a *colonyAgentNode) updateSiegeGuard(delta float64) {
 
 	if !turret.target.stats.Building {
 		// If it can move, check whether it's too far or not.
-		if turret.target.pos.DistanceSquaredTo(a.pos) > 1.1*gamedata.SiegeAgentWeapon.AttackRangeSqr {
+		if detmath.DistanceSquaredTo(turret.target.pos, a.pos) > 1.1*gamedata.SiegeAgentWeapon.AttackRangeSqr {
 			turret.target = nil
-			a.specialDelay = a.world().rand.FloatRange(2, 4)
+			a.specialDelay = detmath.FloatRange(a.world().rand, 2, 4)
 			return
 		}
 	}
@@ -1948,11 +2129,11 @@ func (a *colonyAgentNode) updateSiegeGuard(delta float64) {
 	if turret.ammo%2 == 0 {
 		a.specialDelay = 0.7
 	} else {
-		a.specialDelay = gamedata.SiegeAgentWeapon.Reload * a.world().rand.FloatRange(0.9, 1.1)
+		a.specialDelay = gamedata.SiegeAgentWeapon.Reload * detmath.FloatRange(a.world().rand, 0.9, 1.1)
 	}
 	turret.ammo--
 
-	fireOffset := turret.SetRotation(a.pos.AngleToPoint(turret.target.pos).Normalized())
+	fireOffset := turret.SetRotation(detmath.NormalizedRad(detmath.AngleToPoint(a.pos, turret.target.pos)))
 	toPos := snipePos(gamedata.SiegeAgentWeapon.ProjectileSpeed, a.pos, turret.target.pos, turret.target.GetVelocity())
 	p := a.world().newProjectileNode(projectileConfig{
 		World:      a.world(),
@@ -1971,11 +2152,11 @@ func (a *colonyAgentNode) updateRelictRoomba(delta float64) {
 			if a.path.HasNext() {
 				// TODO: remove code duplication with crawlers.
 				nextPos := nextPathWaypoint(a.world(), a.pos, &a.path, layerLandColony)
-				a.setWaypoint(nextPos.Add(a.world().rand.Offset(-2, 2)))
+				a.setWaypoint(nextPos.Add(detmath.Offset(a.world().rand, -2, 2)))
 				return
 			}
 			a.clearWaypoint()
-			a.specialDelay = a.world().rand.FloatRange(2, 6)
+			a.specialDelay = detmath.FloatRange(a.world().rand, 2, 6)
 		}
 		return
 	}
@@ -1984,14 +2165,14 @@ func (a *colonyAgentNode) updateRelictRoomba(delta float64) {
 		return
 	}
 	owner := a.target.(*colonyCoreNode)
-	if a.pos.DistanceTo(owner.pos) <= 220 {
-		a.specialDelay = a.world().rand.FloatRange(0.8, 4)
+	if detmath.DistanceTo(a.pos, owner.pos) <= 220 {
+		a.specialDelay = detmath.FloatRange(a.world().rand, 0.8, 4)
 		return
 	}
 
-	dist := a.world().rand.FloatRange(40, 128)
-	dst := owner.pos.Add(gmath.RadToVec(a.world().rand.Rad()).Mulf(dist))
-	a.sendTo(a.pos.MoveTowards(dst, 350), layerLandColony)
+	dist := detmath.FloatRange(a.world().rand, 40, 128)
+	dst := detmath.AddScaled(owner.pos, detmath.RadToVec(detmath.RandRad(a.world().rand)), dist)
+	a.sendTo(detmath.MoveTowards(a.pos, dst, 350), layerLandColony)
 }
 
 func (a *colonyAgentNode) updateRelictPatrol(delta float64) {
@@ -2001,8 +2182,8 @@ func (a *colonyAgentNode) updateRelictPatrol(delta float64) {
 		return
 	}
 
-	a.energy = gmath.ClampMax(a.energy+delta*0.5*a.energyRegenRate, a.maxEnergy)
-	a.health = gmath.ClampMax(a.health+(delta*a.healthRegen), a.maxHealth)
+	a.energy = gmath.ClampMax(a.energy+float64(delta*0.5*a.energyRegenRate), a.maxEnergy)
+	a.health = gmath.ClampMax(a.health+float64(delta*a.healthRegen), a.maxHealth)
 
 	if a.attackDelay > 1 {
 		a.waypoint = gmath.Vec{}
@@ -2011,10 +2192,10 @@ func (a *colonyAgentNode) updateRelictPatrol(delta float64) {
 
 	if a.waypoint.IsZero() {
 		// a.dist is squared.
-		if a.pos.DistanceSquaredTo(factory.waypoint) < a.dist {
+		if detmath.DistanceSquaredTo(a.pos, factory.waypoint) < a.dist {
 			return
 		}
-		a.setWaypoint(factory.waypoint.Add(a.scene.Rand().Offset(-40, 40)))
+		a.setWaypoint(factory.waypoint.Add(detmath.Offset(a.scene.Rand(), -40, 40)))
 	}
 	if a.moveTowards(delta) {
 		a.waypoint = gmath.Vec{}
@@ -2042,15 +2223,15 @@ func (a *colonyAgentNode) updateRelictDroneFactory(delta float64) {
 	const maxUnits = 3
 	if a.specialDelay == 0 {
 		if a.extraLevel >= maxUnits {
-			a.specialDelay = a.scene.Rand().FloatRange(4, 8)
+			a.specialDelay = detmath.FloatRange(a.scene.Rand(), 4, 8)
 		} else {
-			a.specialDelay = a.scene.Rand().FloatRange(10, 15)
+			a.specialDelay = detmath.FloatRange(a.scene.Rand(), 10, 15)
 			a.extraLevel++
 			spawnPos := a.pos.Sub(gmath.Vec{Y: 12})
 			unit := newColonyAgentNode(a.colonyCore, gamedata.RelictAgentStats, spawnPos)
 			unit.mode = agentModeRelictTakeoff
 			unit.target = a
-			unit.dist = a.scene.Rand().FloatRange(20, 80)
+			unit.dist = detmath.FloatRange(a.scene.Rand(), 20, 80)
 			unit.dist *= unit.dist
 			world := a.world()
 			world.nodeRunner.AddObject(unit)
@@ -2070,9 +2251,9 @@ func (a *colonyAgentNode) updateRelictDroneFactory(delta float64) {
 	}
 
 	// Choose a new waypoint for troops.
-	a.supportDelay = gmath.ClampMin(a.supportDelay-(delta*a.reloadRate), 0)
+	a.supportDelay = gmath.ClampMin(a.supportDelay-float64(delta*a.reloadRate), 0)
 	if a.supportDelay == 0 {
-		a.supportDelay = a.scene.Rand().FloatRange(6, 12)
+		a.supportDelay = detmath.FloatRange(a.scene.Rand(), 6, 12)
 		rect := gmath.Rect{
 			Min: a.pos.Sub(gmath.Vec{X: 840, Y: 840}),
 			Max: a.pos.Add(gmath.Vec{X: 840, Y: 840}),
@@ -2088,7 +2269,7 @@ func (a *colonyAgentNode) updateHarvester(delta float64) {
 		if target.IsDisposed() {
 			a.target = nil
 			a.clearWaypoint()
-			a.specialDelay = a.world().rand.FloatRange(4, 8)
+			a.specialDelay = detmath.FloatRange(a.world().rand, 4, 8)
 			return
 		}
 	}
@@ -2099,16 +2280,16 @@ func (a *colonyAgentNode) updateHarvester(delta float64) {
 				// TODO: remove code duplication with crawlers and roombas.
 				nextPos := nextPathWaypoint(a.world(), a.pos, &a.path, layerNormal)
 				a.handleForestTransition(nextPos)
-				a.setWaypoint(nextPos.Add(a.world().rand.Offset(-4, 4)))
+				a.setWaypoint(nextPos.Add(detmath.Offset(a.world().rand, -4, 4)))
 				return
 			}
 			a.path = pathing.GridPath{}
 			a.leaveForest()
-			dist := a.waypoint.DistanceTo(target.pos)
+			dist := detmath.DistanceTo(a.waypoint, target.pos)
 			if dist < 10 {
 				a.clearWaypoint()
 			} else {
-				a.setWaypoint(target.pos.Add(a.world().rand.Offset(-4, 4)))
+				a.setWaypoint(target.pos.Add(detmath.Offset(a.world().rand, -4, 4)))
 			}
 		}
 		return
@@ -2124,7 +2305,7 @@ func (a *colonyAgentNode) updateHarvester(delta float64) {
 			return
 		}
 
-		a.specialDelay = a.world().rand.FloatRange(4.5, 6.5)
+		a.specialDelay = detmath.FloatRange(a.world().rand, 4.5, 6.5)
 		harvested := target.Harvest(2)
 		value := float64(harvested) * target.stats.value
 		a.colonyCore.AddGatheredResources(value)
@@ -2177,7 +2358,7 @@ func (a *colonyAgentNode) updateHarvester(delta float64) {
 		if freeOffset.IsZero() {
 			return false
 		}
-		distSqr := e.pos.DistanceSquaredTo(a.pos)
+		distSqr := detmath.DistanceSquaredTo(e.pos, a.pos)
 		if distSqr < closestDistSqr {
 			closestDistSqr = distSqr
 			closestTarget = e
@@ -2186,7 +2367,7 @@ func (a *colonyAgentNode) updateHarvester(delta float64) {
 		return false
 	})
 	if closestTarget == nil {
-		a.specialDelay = a.world().rand.FloatRange(10, 30)
+		a.specialDelay = detmath.FloatRange(a.world().rand, 10, 30)
 		return
 	}
 
@@ -2198,12 +2379,12 @@ func (a *colonyAgentNode) updateHarvester(delta float64) {
 
 func (a *colonyAgentNode) updateRoombaWait(delta float64) {
 	if a.healthRegen != 0 {
-		a.health = gmath.ClampMax(a.health+(delta*a.healthRegen), a.maxHealth)
+		a.health = gmath.ClampMax(a.health+float64(delta*a.healthRegen), a.maxHealth)
 	}
 
 	a.dist -= delta
-	a.health = gmath.ClampMax(a.health+(delta*0.3), a.maxHealth)
-	a.energy = gmath.ClampMax(a.energy+(delta*2.5), a.maxEnergy)
+	a.health = gmath.ClampMax(a.health+float64(delta*0.3), a.maxHealth)
+	a.energy = gmath.ClampMax(a.energy+float64(delta*2.5), a.maxEnergy)
 	if a.dist <= 0 {
 		a.mode = agentModeRoombaPatrol
 	}
@@ -2225,30 +2406,30 @@ func (a *colonyAgentNode) hasWaypoint() bool {
 
 func (a *colonyAgentNode) setWaypoint(pos gmath.Vec) {
 	a.waypoint = pos
-	a.dir = pos.DirectionTo(a.pos)
+	a.dir = detmath.DirectionTo(pos, a.pos)
 }
 
 func (a *colonyAgentNode) updateRoombaPatrol(delta float64) {
 	if a.energy < 0 {
 		// Discharged. It needs some time to recover.
 		a.mode = agentModeRoombaWait
-		a.dist = a.scene.Rand().FloatRange(5, 25)
+		a.dist = detmath.FloatRange(a.scene.Rand(), 5, 25)
 		a.clearWaypoint()
-		a.energy = a.scene.Rand().FloatRange(10, 20)
+		a.energy = detmath.FloatRange(a.scene.Rand(), 10, 20)
 		return
 	}
 
 	// Moving towards destination (or a target).
 	if a.hasWaypoint() {
-		a.energy -= 2.5 * delta
+		a.energy -= float64(2.5 * delta)
 		if a.moveTowards(delta) {
 			if a.target != nil {
 				target := a.target.(*creepNode)
 				if target.IsDisposed() {
 					a.target = nil
-				} else if a.pos.DistanceSquaredTo(target.pos) <= (a.stats.Weapon.AttackRangeSqr * a.supportDelay) {
+				} else if detmath.DistanceSquaredTo(a.pos, target.pos) <= (a.stats.Weapon.AttackRangeSqr * a.supportDelay) {
 					a.mode = agentModeRoombaCombatWait
-					a.dist = a.scene.Rand().FloatRange(7, 16)
+					a.dist = detmath.FloatRange(a.scene.Rand(), 7, 16)
 					a.clearWaypoint()
 					a.target = nil
 					return
@@ -2258,7 +2439,7 @@ func (a *colonyAgentNode) updateRoombaPatrol(delta float64) {
 				if a.mode == agentModeRoombaPatrol && a.health < a.maxHealth*0.8 && a.scene.Rand().Chance(0.1) {
 					a.health = gmath.ClampMax(a.health+2, a.maxHealth)
 					a.mode = agentModeRoombaWait
-					a.dist = a.scene.Rand().FloatRange(4, 10)
+					a.dist = detmath.FloatRange(a.scene.Rand(), 4, 10)
 					a.clearWaypoint()
 					if !a.world().simulation && !a.insideForest {
 						createEffect(a.world(), effectConfig{
@@ -2272,7 +2453,7 @@ func (a *colonyAgentNode) updateRoombaPatrol(delta float64) {
 				// TODO: remove code duplication with crawlers.
 				nextPos := nextPathWaypoint(a.world(), a.pos, &a.path, layerNormal)
 				a.handleForestTransition(nextPos)
-				a.setWaypoint(nextPos.Add(a.world().rand.Offset(-4, 4)))
+				a.setWaypoint(nextPos.Add(detmath.Offset(a.world().rand, -4, 4)))
 				return
 			}
 			a.clearWaypoint()
@@ -2285,25 +2466,25 @@ func (a *colonyAgentNode) updateRoombaPatrol(delta float64) {
 		if target.IsDisposed() {
 			a.target = nil
 		} else {
-			a.sendTo(target.pos.Add(a.scene.Rand().Offset(-80, 80)), layerNormal)
+			a.sendTo(target.pos.Add(detmath.Offset(a.scene.Rand(), -80, 80)), layerNormal)
 			return
 		}
 	}
 
 	if a.mode == agentModeRoombaAttack {
 		a.mode = agentModeRoombaCombatWait
-		a.dist = a.scene.Rand().FloatRange(12, 20)
+		a.dist = detmath.FloatRange(a.scene.Rand(), 12, 20)
 		return
 	}
 	if a.mode == agentModeRoombaGuard && a.scene.Rand().Chance(0.9) {
 		a.mode = agentModeRoombaWait
-		a.dist = a.scene.Rand().FloatRange(10, 25)
+		a.dist = detmath.FloatRange(a.scene.Rand(), 10, 25)
 		return
 	}
 
 	if len(a.world().turrets) != 0 && a.scene.Rand().Chance(0.15) {
 		turret := gmath.RandElem(a.scene.Rand(), a.world().turrets)
-		a.sendTo(turret.pos.Add(a.scene.Rand().Offset(-80, 80)), layerNormal)
+		a.sendTo(turret.pos.Add(detmath.Offset(a.scene.Rand(), -80, 80)), layerNormal)
 		a.mode = agentModeRoombaGuard
 		return
 	}
@@ -2320,11 +2501,11 @@ func (a *colonyAgentNode) updateRoombaPatrol(delta float64) {
 		}
 	})
 	if newTarget != nil {
-		a.supportDelay = a.scene.Rand().FloatRange(0.4, 0.95)
+		a.supportDelay = detmath.FloatRange(a.scene.Rand(), 0.4, 0.95)
 		a.target = newTarget
-		targetPos := newTarget.pos.Add(a.scene.Rand().Offset(-80, 80))
+		targetPos := newTarget.pos.Add(detmath.Offset(a.scene.Rand(), -80, 80))
 		if a.world().HasTreesAt(targetPos, 0) {
-			targetPos = newTarget.pos.Add(a.scene.Rand().Offset(-120, 120))
+			targetPos = newTarget.pos.Add(detmath.Offset(a.scene.Rand(), -120, 120))
 		}
 		a.sendTo(targetPos, layerNormal)
 	} else {
@@ -2333,7 +2514,7 @@ func (a *colonyAgentNode) updateRoombaPatrol(delta float64) {
 			a.sendTo(targetPos, layerNormal)
 		} else {
 			a.mode = agentModeRoombaWait
-			a.dist = a.scene.Rand().FloatRange(2, 5)
+			a.dist = detmath.FloatRange(a.scene.Rand(), 2, 5)
 		}
 	}
 }
@@ -2348,7 +2529,7 @@ func (a *colonyAgentNode) updateConsumeDrone(delta float64) {
 	if a.moveTowards(delta) {
 		// Give a partial drone cost refund.
 		playSound(a.world(), assets.AudioAgentConsumed, a.pos)
-		a.colonyCore.resources += target.stats.Cost * 0.5
+		a.colonyCore.resources += float64(target.stats.Cost * 0.5)
 		a.colonyCore.eliteResources += float64(target.rank)
 		if a.extraLevel < gamedata.DevourerMaxLevel {
 			a.extraLevel++
@@ -2374,7 +2555,7 @@ func (a *colonyAgentNode) updateBomberAttack(delta float64) {
 
 	if a.moveTowards(delta) {
 		bombingPos := a.waypoint.Add(gmath.Vec{Y: agentFlightHeight})
-		if bombingPos.DistanceSquaredTo(creep.pos) > (36 * 36) {
+		if detmath.DistanceSquaredTo(bombingPos, creep.pos) > (36 * 36) {
 			if a.waypointsLeft > 0 {
 				a.waypointsLeft--
 				nextPos := snipePos(a.movementSpeed(), a.pos, creep.pos, creep.GetVelocity())
@@ -2390,6 +2571,45 @@ func (a *colonyAgentNode) updateBomberAttack(delta float64) {
 	}
 }
 
+func (a *colonyAgentNode) updateLayMine(delta float64) {
+	if !a.moveTowards(delta) {
+		return
+	}
+	groundPos := a.pos.Add(gmath.Vec{Y: agentFlightHeight})
+	a.world().nodeRunner.AddObject(newMineNode(a, a.world(), groundPos))
+	a.AssignMode(agentModeStandby, gmath.Vec{}, nil)
+}
+
+func (a *colonyAgentNode) updateRelay(delta float64) {
+	source := a.target.(*essenceSourceNode)
+	if source.IsDisposed() {
+		a.AssignMode(agentModeStandby, gmath.Vec{}, nil)
+		return
+	}
+	if a.hasWaypoint() {
+		if a.moveTowards(delta) {
+			a.clearWaypoint()
+		}
+		return
+	}
+	a.dist -= delta
+	if a.dist <= 0 {
+		a.AssignMode(agentModeStandby, gmath.Vec{}, nil)
+	}
+}
+
+func (a *colonyAgentNode) updateShieldEscort(delta float64) {
+	escorted := a.target.(*colonyAgentNode)
+	if escorted.IsDisposed() || a.waypointsLeft <= 0 {
+		a.AssignMode(agentModeStandby, gmath.Vec{}, nil)
+		return
+	}
+	if a.moveTowards(delta) {
+		a.waypointsLeft--
+		a.setWaypoint(escorted.pos.Add(detmath.Offset(a.scene.Rand(), -40, 40)))
+	}
+}
+
 func (a *colonyAgentNode) updateKamikazeAttack(delta float64) {
 	creep := a.target.(*creepNode)
 
@@ -2414,7 +2634,7 @@ func (a *colonyAgentNode) updateKamikazeAttack(delta float64) {
 	const explosionDamage float64 = 35.0
 	const damageFlags = gamedata.DmgflagNoFlash | gamedata.DmgflagUnblockable
 	if a.moveTowards(delta) {
-		if a.pos.DistanceSquaredTo(creep.pos) > (explosionRangeSqr + 8) {
+		if detmath.DistanceSquaredTo(a.pos, creep.pos) > (explosionRangeSqr + 8) {
 			a.clearWaypoint()
 			return
 		}
@@ -2422,7 +2642,7 @@ func (a *colonyAgentNode) updateKamikazeAttack(delta float64) {
 			Pos:      a.pos,
 			Layer:    aboveEffectLayer,
 			Image:    assets.ImageBigVerticalExplosion1,
-			Rotation: a.pos.AngleToPoint(creep.pos) - math.Pi/2,
+			Rotation: detmath.AngleToPoint(a.pos, creep.pos) - math.Pi/2,
 		})
 		playSound(a.world(), assets.AudioExplosion1, a.pos)
 		creep.OnDamage(gamedata.DamageValue{Health: explosionDamage, Flags: damageFlags}, a)
@@ -2430,7 +2650,7 @@ func (a *colonyAgentNode) updateKamikazeAttack(delta float64) {
 			if !otherCreep.IsFlying() || otherCreep == creep {
 				continue
 			}
-			distSqr := otherCreep.pos.DistanceSquaredTo(a.pos)
+			distSqr := detmath.DistanceSquaredTo(otherCreep.pos, a.pos)
 			if distSqr > explosionRangeSqr {
 				continue
 			}
@@ -2449,7 +2669,7 @@ func (a *colonyAgentNode) updateCaptureBuilding(delta float64) {
 			// TODO: use local rand and do not create this beam in simulation?
 			buildPos := ge.Pos{
 				Base:   &target.pos,
-				Offset: gmath.Vec{X: a.scene.Rand().FloatRange(-10, 10)},
+				Offset: gmath.Vec{X: detmath.FloatRange(a.scene.Rand(), -10, 10)},
 			}
 			beam := newCloningBeamNode(a.world(), abeamCloning, &a.pos, buildPos)
 			a.cloningBeam = beam
@@ -2462,7 +2682,11 @@ func (a *colonyAgentNode) updateCaptureBuilding(delta float64) {
 	if a.dist <= 0 {
 		a.AssignMode(agentModeStandby, gmath.Vec{}, nil)
 		target := a.target.(*neutralBuildingNode)
-		if target.agent == nil {
+		if target.CanBeCapturedBy(a.colonyCore) {
+			if target.agent != nil {
+				// A control point that belongs to another player.
+				target.agent.Destroy()
+			}
 			constructed := newColonyAgentNode(a.colonyCore, target.stats, target.pos)
 			a.colonyCore.AcceptTurret(constructed)
 			a.world().nodeRunner.AddObject(constructed)
@@ -2486,7 +2710,7 @@ func (a *colonyAgentNode) updateRepairTurret(delta float64) {
 			a.clearWaypoint()
 			buildPos := ge.Pos{
 				Base:   &target.pos,
-				Offset: gmath.Vec{X: a.scene.Rand().FloatRange(-10, 10)},
+				Offset: gmath.Vec{X: detmath.FloatRange(a.scene.Rand(), -10, 10)},
 			}
 			beam := newCloningBeamNode(a.world(), abeamCloning, &a.pos, buildPos)
 			a.cloningBeam = beam
@@ -2498,7 +2722,7 @@ func (a *colonyAgentNode) updateRepairTurret(delta float64) {
 	a.dist -= delta
 	if a.dist <= 0 {
 		a.AssignMode(agentModeStandby, gmath.Vec{}, nil)
-		amountRepaired := a.scene.Rand().FloatRange(5, 8)
+		amountRepaired := detmath.FloatRange(a.scene.Rand(), 5, 8)
 		if a.faction == gamedata.GreenFactionTag {
 			amountRepaired *= 1.5
 		}
@@ -2544,14 +2768,14 @@ func (a *colonyAgentNode) updateBuildBase(delta float64) {
 
 func (a *colonyAgentNode) updateRecycleLanding(delta float64) {
 	prevHeight := a.shadowComponent.height
-	a.shadowComponent.UpdateHeight(a.pos, a.shadowComponent.height-delta*30, agentFlightHeight)
+	a.shadowComponent.UpdateHeight(a.pos, a.shadowComponent.height-float64(delta*30), agentFlightHeight)
 	darkenHeight := a.colonyCore.stats.DefaultHeight + 3
 	if prevHeight >= darkenHeight && a.shadowComponent.height < darkenHeight {
 		a.sprite.SetColorScaleRGBA(200, 200, 200, 255)
 	}
 
 	if a.moveTowards(delta) {
-		a.colonyCore.resources += a.stats.Cost * 0.9
+		a.colonyCore.resources += float64(a.stats.Cost * 0.9)
 		if a.rank != 0 {
 			a.colonyCore.eliteResources += float64(a.rank)
 		}
@@ -2568,9 +2792,9 @@ func (a *colonyAgentNode) updateMerging(delta float64) {
 		return
 	}
 	if !a.hasWaypoint() {
-		dist := target.pos.DistanceTo(a.pos)
+		dist := detmath.DistanceTo(target.pos, a.pos)
 		if dist > 64 {
-			a.setWaypoint(a.pos.MoveTowards(target.pos, dist-20).Add(a.scene.Rand().Offset(-8, 8)))
+			a.setWaypoint(detmath.MoveTowards(a.pos, target.pos, dist-20).Add(detmath.Offset(a.scene.Rand(), -8, 8)))
 			return
 		}
 	}
@@ -2586,8 +2810,8 @@ func (a *colonyAgentNode) updateMerging(delta float64) {
 		a.world().nodeRunner.AddObject(beam)
 	}
 	a.dist -= delta
-	if a.pos.DistanceSquaredTo(target.pos) > (10 * 10) {
-		a.changePos(a.pos.MoveTowards(target.pos, delta*12))
+	if detmath.DistanceSquaredTo(a.pos, target.pos) > (10 * 10) {
+		a.changePos(detmath.MoveTowards(a.pos, target.pos, delta*12))
 	} else {
 		// Merging is x3 faster when units are next to each other.
 		a.dist -= delta * 2
@@ -2601,7 +2825,7 @@ func (a *colonyAgentNode) updateMerging(delta float64) {
 	}
 	if a.dist <= 0 {
 		mergingFailed := (a.mode == agentModeMergingRoomba && !posIsFreeWithFlags(a.world(), nil, a.pos, 2, collisionSkipSmallCrawlers|collisionSkipTeleporters)) ||
-			(a.pos.DistanceSquaredTo(target.pos) > (30 * 30))
+			(detmath.DistanceSquaredTo(a.pos, target.pos) > (30 * 30))
 		if mergingFailed {
 			a.AssignMode(agentModeStandby, gmath.Vec{}, nil)
 			target.AssignMode(agentModeStandby, gmath.Vec{}, nil)
@@ -2709,13 +2933,13 @@ func (a *colonyAgentNode) updateMakeClone(delta float64) {
 }
 
 func (a *colonyAgentNode) getCloserWaypoint(targetPos gmath.Vec, spread, preferredDist float64) gmath.Vec {
-	currentDist := a.pos.DistanceTo(targetPos)
+	currentDist := detmath.DistanceTo(a.pos, targetPos)
 	if currentDist <= preferredDist {
-		return targetPos.Add(a.scene.Rand().Offset(-spread, spread))
+		return targetPos.Add(detmath.Offset(a.scene.Rand(), -spread, spread))
 	}
 	const maxMoveDist float64 = 96.0
-	dist := gmath.ClampMax(maxMoveDist*a.scene.Rand().FloatRange(0.8, 1.2), gmath.ClampMin(currentDist-preferredDist, maxMoveDist*0.25))
-	result := targetPos.DirectionTo(a.pos).Mulf(dist).Add(a.pos).Add(a.scene.Rand().Offset(-28, 28))
+	dist := gmath.ClampMax(maxMoveDist*detmath.FloatRange(a.scene.Rand(), 0.8, 1.2), gmath.ClampMin(currentDist-preferredDist, maxMoveDist*0.25))
+	result := detmath.AddScaled(a.pos, detmath.DirectionTo(targetPos, a.pos), dist).Add(detmath.Offset(a.scene.Rand(), -28, 28))
 	return result
 }
 
@@ -2742,7 +2966,7 @@ func (a *colonyAgentNode) updatePanic(delta float64) {
 			a.AssignMode(agentModeStandby, gmath.Vec{}, nil)
 			return
 		}
-		waypoint := a.pos.Add(a.scene.Rand().Offset(-32, 32))
+		waypoint := a.pos.Add(detmath.Offset(a.scene.Rand(), -32, 32))
 		a.setWaypoint(correctedPos(a.world().rect, waypoint, 64))
 	}
 }
@@ -2759,7 +2983,7 @@ func (a *colonyAgentNode) updateCourierFlight(delta float64) {
 			}
 			return
 		}
-		if target.pos.DistanceSquaredTo(a.pos) < (70 * 70) {
+		if detmath.DistanceSquaredTo(target.pos, a.pos) < (70 * 70) {
 			if a.payload != 0 {
 				target.resources += a.cargoValue
 				a.clearCargo()
@@ -2767,7 +2991,7 @@ func (a *colonyAgentNode) updateCourierFlight(delta float64) {
 			beam := newTextureBeamNode(a.world(), ge.Pos{Base: &a.pos}, ge.Pos{Base: &target.pos}, a.stats.BeamTexture, a.stats.BeamSlideSpeed, a.stats.BeamOpaqueTime)
 			a.world().nodeRunner.AddObject(beam)
 			playSound(a.world(), assets.AudioCourierResourceBeam, a.pos)
-			dist := target.pos.DistanceTo(a.colonyCore.pos)
+			dist := detmath.DistanceTo(target.pos, a.colonyCore.pos)
 			// Now go back and bring some resources.
 			if dist > 115 {
 				a.payload = a.maxPayload()
@@ -2783,25 +3007,25 @@ func (a *colonyAgentNode) updateCourierFlight(delta float64) {
 			return
 		}
 		// TODO: use a followPos here?
-		a.setWaypoint(a.pos.DirectionTo(target.pos).Mulf(60).Add(target.pos).Add(a.scene.Rand().Offset(-20, 20)))
+		a.setWaypoint(detmath.AddScaled(target.pos, detmath.DirectionTo(a.pos, target.pos), 60).Add(detmath.Offset(a.scene.Rand(), -20, 20)))
 	}
 }
 
 func (a *colonyAgentNode) updateFollow(delta float64) {
 	if a.moveTowards(delta) {
-		target := a.target.(*creepNode)
-		if a.waypointsLeft == 0 || target.IsDisposed() || !target.CanBeTargeted() {
+		target := a.target.(targetable)
+		if a.waypointsLeft == 0 || target.IsDisposed() || !canFollowTarget(target) {
 			a.AssignMode(agentModeStandby, gmath.Vec{}, nil)
 			return
 		}
 		a.waypointsLeft--
-		a.setWaypoint(a.followWaypoint(target.pos))
+		a.setWaypoint(a.followWaypoint(*target.GetPos()))
 	}
 }
 
 func (a *colonyAgentNode) updateAlignStandby(delta float64) {
 	speed := a.movementSpeed()
-	height := a.shadowComponent.height + delta*speed
+	height := a.shadowComponent.height + float64(delta*speed)
 	if a.moveTowardsWithSpeed(delta, speed) {
 		height = agentFlightHeight
 	}
@@ -2813,10 +3037,10 @@ func (a *colonyAgentNode) updateAlignStandby(delta float64) {
 
 func (a *colonyAgentNode) updateStandby(delta float64) {
 	if a.healthRegen != 0 {
-		a.health = gmath.ClampMax(a.health+(delta*a.healthRegen), a.maxHealth)
+		a.health = gmath.ClampMax(a.health+float64(delta*a.healthRegen), a.maxHealth)
 	}
 
-	a.energy = gmath.ClampMax(a.energy+delta*0.5*a.energyRegenRate, a.maxEnergy)
+	a.energy = gmath.ClampMax(a.energy+float64(delta*0.5*a.energyRegenRate), a.maxEnergy)
 	if a.moveTowards(delta) {
 		if a.stats.Tier == 1 && a.lifetime < 0 && a.colonyCore.mode == colonyModeNormal {
 			a.AssignMode(agentModeRecycleReturn, gmath.Vec{}, nil)
@@ -2829,7 +3053,7 @@ func (a *colonyAgentNode) updateStandby(delta float64) {
 				a.waypointsLeft = 0
 				traitRoll := a.scene.Rand().Float()
 				if a.hasTrait(traitAdventurer) && traitRoll <= 0.4 {
-					pos := a.pos.Add(a.scene.Rand().Offset(-100, 100))
+					pos := a.pos.Add(detmath.Offset(a.scene.Rand(), -100, 100))
 					a.AssignMode(agentModeMove, pos, nil)
 					// Add some energy to compensate for this unproductive behavior.
 					a.energy = gmath.ClampMax(a.energy+5, a.maxEnergy)
@@ -2847,7 +3071,7 @@ func (a *colonyAgentNode) updateStandby(delta float64) {
 }
 
 func (a *colonyAgentNode) updateCloakHide(delta float64) {
-	a.energy = gmath.ClampMax(a.energy+delta*a.energyRegenRate, a.maxEnergy)
+	a.energy = gmath.ClampMax(a.energy+float64(delta*a.energyRegenRate), a.maxEnergy)
 	if a.cloaking <= 0 {
 		a.health = gmath.ClampMax(a.health+2, a.maxHealth)
 		a.AssignMode(agentModeStandby, gmath.Vec{}, nil)
@@ -2855,7 +3079,7 @@ func (a *colonyAgentNode) updateCloakHide(delta float64) {
 }
 
 func (a *colonyAgentNode) updateCharging(delta float64) {
-	a.energy = gmath.ClampMax(a.energy+delta*4*a.energyRegenRate, a.maxEnergy)
+	a.energy = gmath.ClampMax(a.energy+float64(delta*4*a.energyRegenRate), a.maxEnergy)
 	if a.energy >= a.maxEnergy*0.55 {
 		a.energyBill = 0
 		a.AssignMode(agentModeStandby, gmath.Vec{}, nil)
@@ -2871,7 +3095,7 @@ func (a *colonyAgentNode) updatePosing(delta float64) {
 }
 
 func (a *colonyAgentNode) updateForcedCharging(delta float64) {
-	a.energy = gmath.ClampMax(a.energy+delta*2.0*a.energyRegenRate, a.maxEnergy)
+	a.energy = gmath.ClampMax(a.energy+float64(delta*2.0*a.energyRegenRate), a.maxEnergy)
 	if a.energy >= a.energyTarget {
 		a.energyTarget = 0
 		a.AssignMode(agentModeStandby, gmath.Vec{}, nil)
@@ -2884,7 +3108,7 @@ func (a *colonyAgentNode) updateMineSulfurEssence(delta float64) {
 			a.waypoint = gmath.Vec{}
 			if !a.world().simulation {
 				source := a.target.(*essenceSourceNode)
-				offset := a.world().localRand.Offset(-6, 6)
+				offset := detmath.Offset(a.world().localRand, -6, 6)
 				beam := newCloningBeamNode(a.world(), abeamSulfurMining, &a.pos, ge.Pos{Base: &source.pos, Offset: offset})
 				a.cloningBeam = beam
 				a.world().nodeRunner.AddObject(beam)
@@ -2927,7 +3151,7 @@ func (a *colonyAgentNode) updateMineEssence(delta float64) {
 
 func (a *colonyAgentNode) updatePickup(delta float64) {
 	speed := a.movementSpeed()
-	height := a.shadowComponent.height - delta*speed
+	height := a.shadowComponent.height - float64(delta*speed)
 	if a.moveTowardsWithSpeed(delta, speed) {
 		height = 0
 		a.mode = agentModeResourceTakeoff
@@ -2943,7 +3167,7 @@ func (a *colonyAgentNode) updatePickup(delta float64) {
 
 func (a *colonyAgentNode) updateResourceTakeoff(delta float64) {
 	speed := a.movementSpeed()
-	height := a.shadowComponent.height + delta*speed
+	height := a.shadowComponent.height + float64(delta*speed)
 	if a.moveTowardsWithSpeed(delta, speed) {
 		height = agentFlightHeight
 	}
@@ -2959,16 +3183,20 @@ func (a *colonyAgentNode) clearCargo() {
 	a.cargoEliteValue = 0
 }
 
+func (a *colonyAgentNode) unloadCargo() {
+	a.colonyCore.AddGatheredResources(a.cargoValue)
+	a.colonyCore.eliteResources += a.cargoEliteValue
+	a.world().result.EliteResourcesGathered = a.cargoEliteValue
+	a.clearCargo()
+}
+
 func (a *colonyAgentNode) updateReturn(delta float64) {
 	if a.moveTowards(delta) {
 		if a.IsCloaked() {
 			a.doUncloak()
 		}
 		if a.payload != 0 {
-			a.colonyCore.AddGatheredResources(a.cargoValue)
-			a.colonyCore.eliteResources += a.cargoEliteValue
-			a.world().result.EliteResourcesGathered = a.cargoEliteValue
-			a.clearCargo()
+			a.unloadCargo()
 			playSound(a.world(), assets.AudioEssenceCollected, a.pos)
 		}
 		a.AssignMode(agentModeStandby, gmath.Vec{}, nil)
@@ -11,6 +11,7 @@ import (
 	"github.com/quasilyte/gsignal"
 
 	"github.com/quasilyte/roboden-game/assets"
+	"github.com/quasilyte/roboden-game/detmath"
 	"github.com/quasilyte/roboden-game/gamedata"
 	"github.com/quasilyte/roboden-game/pathing"
 )
@@ -20,6 +21,8 @@ const (
 	maxEvoPoints     float64 = 20
 	maxEvoGain       float64 = 1.0
 	blueEvoThreshold float64 = 18.0
+
+	colonyBurrowDuration float64 = 20.0
 )
 
 type colonyCoreMode int
@@ -87,6 +90,8 @@ type colonyCoreNode struct {
 	evoPoints        float64
 	world            *worldState
 
+	techs colonyTechState
+
 	agents          *colonyAgentContainer
 	roombas         []*colonyAgentNode
 	turrets         []*colonyAgentNode
@@ -109,6 +114,8 @@ type colonyCoreNode struct {
 
 	attackDelay float64
 
+	burrowTime float64
+
 	actionDelay float64
 	priorities  *weightContainer[colonyPriority]
 
@@ -190,7 +197,7 @@ func (c *colonyCoreNode) addSpriteToStage(s *ge.Sprite) {
 	switch c.stats {
 	case gamedata.ArkCoreStats:
 		c.world.stage.AddSortableGraphicsSlightlyAbove(s, &c.drawOrder)
-	case gamedata.DenCoreStats:
+	case gamedata.DenCoreStats, gamedata.HiveCoreStats, gamedata.BeaconCoreStats:
 		c.world.stage.AddSprite(s)
 	case gamedata.TankCoreStats:
 		c.world.stage.AddSortableGraphics(s, &c.drawOrder)
@@ -237,7 +244,7 @@ func (c *colonyCoreNode) Init(scene *ge.Scene) {
 		c.flyingSprite.Shader = c.sprite.Shader
 	}
 	switch c.stats {
-	case gamedata.ArkCoreStats, gamedata.DenCoreStats:
+	case gamedata.ArkCoreStats, gamedata.DenCoreStats, gamedata.HiveCoreStats, gamedata.BeaconCoreStats:
 		c.world.stage.AddSortableGraphicsSlightlyAbove(c.flyingSprite, &c.drawOrder)
 	case gamedata.TankCoreStats:
 		c.world.stage.AddSortableGraphics(c.flyingSprite, &c.drawOrder)
@@ -323,7 +330,7 @@ func (c *colonyCoreNode) IsFlying() bool {
 
 func (c *colonyCoreNode) MaxFlyDistanceSqr() float64 {
 	dist := c.MaxFlyDistance()
-	return dist * dist
+	return float64(dist * dist)
 }
 
 func (c *colonyCoreNode) MaxFlyDistance() float64 {
@@ -331,11 +338,11 @@ func (c *colonyCoreNode) MaxFlyDistance() float64 {
 }
 
 func (c *colonyCoreNode) PatrolRadius() float64 {
-	return c.realRadius * (1.0 + c.GetSecurityPriority()*0.25)
+	return float64(c.realRadius * (1.0 + float64(c.GetSecurityPriority()*0.25)))
 }
 
 func (c *colonyCoreNode) AttackRadius() float64 {
-	return 1.4*c.PatrolRadius() + 320
+	return float64(1.4*c.PatrolRadius()) + 320
 }
 
 func (c *colonyCoreNode) GetPos() *gmath.Vec { return &c.pos }
@@ -343,7 +350,7 @@ func (c *colonyCoreNode) GetPos() *gmath.Vec { return &c.pos }
 func (c *colonyCoreNode) GetVelocity() gmath.Vec {
 	switch c.mode {
 	case colonyModeTakeoff, colonyModeRelocating, colonyModeLanding:
-		return c.pos.VecTowards(c.waypoint, c.movementSpeed())
+		return detmath.VecTowards(c.pos, c.waypoint, c.movementSpeed())
 	default:
 		return gmath.Vec{}
 	}
@@ -358,6 +365,10 @@ func (c *colonyCoreNode) OnHeal(amount float64) {
 }
 
 func (c *colonyCoreNode) OnDamage(damage gamedata.DamageValue, source targetable) {
+	if c.burrowTime > 0 {
+		return
+	}
+
 	c.health -= damage.Health
 	if c.health < 0 {
 		if c.shadowComponent.height == 0 {
@@ -434,7 +445,7 @@ func (c *colonyCoreNode) GetSecurityPriority() float64 {
 }
 
 func (c *colonyCoreNode) CloneAgentNode(a *colonyAgentNode) *colonyAgentNode {
-	pos := a.pos.Add(c.scene.Rand().Offset(-4, 4))
+	pos := a.pos.Add(detmath.Offset(c.scene.Rand(), -4, 4))
 	cloned := a.Clone()
 	cloned.pos = pos
 	c.AcceptAgent(cloned)
@@ -442,8 +453,11 @@ func (c *colonyCoreNode) CloneAgentNode(a *colonyAgentNode) *colonyAgentNode {
 }
 
 func (c *colonyCoreNode) NewColonyAgentNode(stats *gamedata.AgentStats, pos gmath.Vec) *colonyAgentNode {
-	if stats.Tier == 3 {
+	switch stats.Tier {
+	case 3:
 		c.world.result.T3created++
+	case 4:
+		c.world.result.T4created++
 	}
 	a := newColonyAgentNode(c, stats, pos)
 	c.AcceptAgent(a)
@@ -453,6 +467,7 @@ func (c *colonyCoreNode) NewColonyAgentNode(stats *gamedata.AgentStats, pos gmat
 func (c *colonyCoreNode) DetachAgent(a *colonyAgentNode) {
 	a.EventDestroyed.Disconnect(c)
 	c.agents.Remove(a)
+	c.world.unindexAgent(a)
 }
 
 func (c *colonyCoreNode) AcceptRoomba(roomba *colonyAgentNode) {
@@ -464,6 +479,7 @@ func (c *colonyCoreNode) AcceptRoomba(roomba *colonyAgentNode) {
 }
 
 func (c *colonyCoreNode) AddGatheredResources(value float64) {
+	value = float64(value) // The callers often pass a product here
 	c.resources += value
 	c.world.result.ResourcesGathered += value
 }
@@ -477,6 +493,7 @@ func (c *colonyCoreNode) AcceptTurret(turret *colonyAgentNode) {
 			c.world.UnmarkPos(x.pos)
 		}
 		c.world.turrets = xslices.Remove(c.world.turrets, x)
+		c.world.unindexAgent(x)
 		c.turrets = xslices.Remove(c.turrets, x)
 	})
 	if !turret.stats.IsNeutral {
@@ -486,6 +503,7 @@ func (c *colonyCoreNode) AcceptTurret(turret *colonyAgentNode) {
 		c.world.MarkPos(turret.pos, ptagBlocked)
 	}
 	c.world.turrets = append(c.world.turrets, turret)
+	c.world.indexAgent(c.world.turretIndex, turret)
 	c.turrets = append(c.turrets, turret)
 	turret.colonyCore = c
 	c.EventTurretAccepted.Emit(turret)
@@ -508,8 +526,10 @@ func (c *colonyCoreNode) AcceptTurret(turret *colonyAgentNode) {
 func (c *colonyCoreNode) AcceptAgent(a *colonyAgentNode) {
 	a.EventDestroyed.Connect(c, func(x *colonyAgentNode) {
 		c.agents.Remove(x)
+		c.world.unindexAgent(x)
 	})
 	c.agents.Add(a)
+	c.world.indexAgent(c.world.agentIndex, a)
 	a.colonyCore = c
 }
 
@@ -551,6 +571,12 @@ func (c *colonyCoreNode) Update(delta float64) {
 	c.freeWorkerDelay = gmath.ClampMin(c.freeWorkerDelay-delta, 0)
 	c.resourceDelay = gmath.ClampMin(c.resourceDelay-delta, 0)
 	c.heavyDamageWarningCooldown = gmath.ClampMin(c.heavyDamageWarningCooldown-delta, 0)
+	if c.burrowTime > 0 {
+		c.burrowTime -= delta
+		if c.burrowTime <= 0 {
+			c.stopBurrow()
+		}
+	}
 
 	c.processUpkeep(delta)
 
@@ -577,8 +603,8 @@ func (c *colonyCoreNode) stopTeleportationEffect() {
 }
 
 func (c *colonyCoreNode) updateTeleporting(delta float64) {
-	c.teleportDelay -= delta
-	c.sprite.Shader.SetFloatValue("Time", 20-(c.teleportDelay*10))
+	c.teleportDelay -= float64(delta * c.teleportSpeed())
+	c.sprite.Shader.SetFloatValue("Time", 20-float64(c.teleportDelay*10))
 
 	if c.teleportDelay <= 0 {
 		relocationPoint := c.relocationPoint
@@ -599,27 +625,7 @@ func (c *colonyCoreNode) updateTeleporting(delta float64) {
 		playSound(c.world, assets.AudioTeleportDone, c.pos)
 		playSound(c.world, assets.AudioTeleportDone, relocationPoint)
 
-		c.agents.Each(func(a *colonyAgentNode) {
-			switch a.mode {
-			case agentModeKamikazeAttack, agentModeBomberAttack:
-				return
-			}
-			// Create effect at the source pos.
-			createEffect(c.world, effectConfig{
-				Pos:   a.pos,
-				Layer: aboveEffectLayer,
-				Image: assets.ImageTeleportEffectSmall,
-			})
-			a.pos = relocationPoint.Add(c.world.rand.Offset(-38, 38))
-			a.shadowComponent.UpdatePos(a.pos)
-			// Create effect at the destination pos.
-			createEffect(c.world, effectConfig{
-				Pos:   a.pos,
-				Layer: aboveEffectLayer,
-				Image: assets.ImageTeleportEffectSmall,
-			})
-			a.AssignMode(agentModePosing, gmath.Vec{X: c.world.rand.FloatRange(0.5, 2.5)}, nil)
-		})
+		c.teleportAgents(relocationPoint)
 
 		createEffect(c.world, effectConfig{
 			Pos:   c.pos,
@@ -651,6 +657,45 @@ func (c *colonyCoreNode) updateTeleporting(delta float64) {
 	}
 }
 
+func (c *colonyCoreNode) teleportAgents(pos gmath.Vec) {
+	c.agents.Each(func(a *colonyAgentNode) {
+		switch a.mode {
+		case agentModeKamikazeAttack, agentModeBomberAttack:
+			return
+		}
+		// Create effect at the source pos.
+		createEffect(c.world, effectConfig{
+			Pos:   a.pos,
+			Layer: aboveEffectLayer,
+			Image: assets.ImageTeleportEffectSmall,
+		})
+		a.pos = pos.Add(detmath.Offset(c.world.rand, -38, 38))
+		a.shadowComponent.UpdatePos(a.pos)
+		// Create effect at the destination pos.
+		createEffect(c.world, effectConfig{
+			Pos:   a.pos,
+			Layer: aboveEffectLayer,
+			Image: assets.ImageTeleportEffectSmall,
+		})
+		a.AssignMode(agentModePosing, gmath.Vec{X: detmath.FloatRange(c.world.rand, 0.5, 2.5)}, nil)
+	})
+}
+
+func (c *colonyCoreNode) startBurrow() {
+	c.burrowTime = colonyBurrowDuration
+	c.flashComponent.resetColors()
+	c.hatchFlashComponent.resetColors()
+	c.sprite.SetColorScaleRGBA(150, 150, 170, 255)
+	c.hatch.SetColorScaleRGBA(150, 150, 170, 255)
+	playSound(c.world, assets.AudioColonyLanded, c.pos)
+}
+
+func (c *colonyCoreNode) stopBurrow() {
+	c.burrowTime = 0
+	c.flashComponent.resetColors()
+	c.hatchFlashComponent.resetColors()
+}
+
 func (c *colonyCoreNode) movementSpeed() float64 {
 	switch c.mode {
 	case colonyModeTakeoff, colonyModeLanding:
@@ -732,12 +777,12 @@ func (c *colonyCoreNode) calcUnitLimit() int {
 	// 128 => 10
 	// 256 => 61
 	// 400 => 118
-	calculated := (gmath.ClampMin(c.realRadius-128, 0) * 0.4) + float64(c.stats.StartingDrones)
+	calculated := float64(gmath.ClampMin(c.realRadius-128, 0)*0.4) + float64(c.stats.StartingDrones)
 	growth := c.GetGrowthPriority()
 	if growth > 0.1 {
 		// 50% growth priority gives 24 extra units to the limit.
 		// 80% => 42 extra units
-		calculated += (growth - 0.1) * 60
+		calculated += float64((growth - 0.1) * 60)
 	}
 	calculated *= c.stats.DroneLimitScaling
 	return gmath.Clamp(int(calculated), c.stats.StartingDrones, c.stats.DroneLimit)
@@ -819,7 +864,7 @@ func (c *colonyCoreNode) processUpkeep(delta float64) {
 		return
 	}
 	c.eliteResources = gmath.ClampMax(c.eliteResources, 10)
-	c.upkeepDelay = c.scene.Rand().FloatRange(7.5, 12.5)
+	c.upkeepDelay = detmath.FloatRange(c.scene.Rand(), 7.5, 12.5)
 	upkeepPrice, _ := c.calcUpkeed()
 	if c.resources < upkeepPrice {
 		if c.GetResourcePriority() < 0.5 {
@@ -863,6 +908,10 @@ func (c *colonyCoreNode) enterTakeoffMode() {
 }
 
 func (c *colonyCoreNode) doRelocation(pos gmath.Vec) bool {
+	if c.burrowTime > 0 {
+		return false
+	}
+
 	c.relocationPoint = pos
 
 	c.agents.Each(func(a *colonyAgentNode) {
@@ -878,7 +927,7 @@ func (c *colonyCoreNode) doRelocation(pos gmath.Vec) bool {
 	})
 
 	switch c.stats {
-	case gamedata.DenCoreStats:
+	case gamedata.DenCoreStats, gamedata.HiveCoreStats, gamedata.BeaconCoreStats:
 		c.acceleration = 0.5
 		c.unmarkCells(c.pos)
 		c.shadowComponent.SetVisibility(true)
@@ -909,11 +958,11 @@ func (c *colonyCoreNode) doRelocation(pos gmath.Vec) bool {
 func (c *colonyCoreNode) updateTakeoff(delta float64) {
 	c.drawOrder = c.pos.Y - 64
 	speed := c.movementSpeed()
-	height := c.shadowComponent.height + delta*speed
+	height := c.shadowComponent.height + float64(delta*speed)
 	if c.moveTowards(delta, speed, c.waypoint) {
 		height = c.stats.FlightHeight
 		switch c.stats {
-		case gamedata.DenCoreStats:
+		case gamedata.DenCoreStats, gamedata.HiveCoreStats, gamedata.BeaconCoreStats:
 			c.waypoint = c.relocationPoint.Sub(gmath.Vec{Y: c.stats.FlightHeight})
 			c.mode = colonyModeRelocating
 		case gamedata.ArkCoreStats:
@@ -936,7 +985,7 @@ func (c *colonyCoreNode) canLandAt(coord pathing.GridCoord) bool {
 
 func (c *colonyCoreNode) sendTo(pos gmath.Vec) {
 	switch c.stats {
-	case gamedata.DenCoreStats:
+	case gamedata.DenCoreStats, gamedata.HiveCoreStats, gamedata.BeaconCoreStats:
 		c.relocationPoint = pos
 		c.waypoint = pos.Sub(gmath.Vec{Y: c.stats.FlightHeight})
 
@@ -955,10 +1004,10 @@ func (c *colonyCoreNode) sendTo(pos gmath.Vec) {
 func (c *colonyCoreNode) updateRelocating(delta float64) {
 	c.processAttack(delta * 0.25)
 
-	c.acceleration = gmath.ClampMax(c.acceleration+(delta*0.3), 1)
+	c.acceleration = gmath.ClampMax(c.acceleration+float64(delta*0.3), 1)
 	if c.moveTowards(delta, c.movementSpeed(), c.waypoint) {
 		switch c.stats {
-		case gamedata.DenCoreStats:
+		case gamedata.DenCoreStats, gamedata.HiveCoreStats, gamedata.BeaconCoreStats:
 			// The landing spot could be unavailable by the moment we reach it.
 			coord := c.world.pathgrid.PosToCoord(c.relocationPoint)
 			if c.canLandAt(coord) {
@@ -983,7 +1032,7 @@ func (c *colonyCoreNode) updateRelocating(delta float64) {
 		case gamedata.TankCoreStats:
 			if c.path.HasNext() {
 				nextPos := nextPathWaypoint(c.world, c.pos, &c.path, layerLandColony)
-				c.waypoint = nextPos.Add(c.world.rand.Offset(-3, 3))
+				c.waypoint = nextPos.Add(detmath.Offset(c.world.rand, -3, 3))
 				break
 			}
 			if !c.world.PosIsFree(c.pos, layerLandColony) {
@@ -1012,11 +1061,11 @@ func (c *colonyCoreNode) findArkHoverSpot() gmath.Vec {
 		if c == other {
 			continue
 		}
-		if other.pos.DistanceSquaredTo(c.pos) < (pathing.CellSize*pathing.CellSize)+5 {
+		if detmath.DistanceSquaredTo(other.pos, c.pos) < (pathing.CellSize*pathing.CellSize)+5 {
 			offset := gmath.RandElem(c.world.rand, colonyNear2x2CellOffsets)
 			return c.pos.Add(gmath.Vec{
-				X: float64(offset.X) * pathing.CellSize,
-				Y: float64(offset.Y) * pathing.CellSize,
+				X: float64(float64(offset.X) * pathing.CellSize),
+				Y: float64(float64(offset.Y) * pathing.CellSize),
 			})
 		}
 	}
@@ -1024,11 +1073,11 @@ func (c *colonyCoreNode) findArkHoverSpot() gmath.Vec {
 		if construction.stats != colonyCoreConstructionStats {
 			continue
 		}
-		if construction.pos.DistanceSquaredTo(c.pos) < (8 * 8) {
+		if detmath.DistanceSquaredTo(construction.pos, c.pos) < (8 * 8) {
 			offset := gmath.RandElem(c.world.rand, colonyNear2x2CellOffsets)
 			return c.pos.Add(gmath.Vec{
-				X: float64(offset.X) * pathing.CellSize,
-				Y: float64(offset.Y) * pathing.CellSize,
+				X: float64(float64(offset.X) * pathing.CellSize),
+				Y: float64(float64(offset.Y) * pathing.CellSize),
 			})
 		}
 	}
@@ -1067,7 +1116,7 @@ func (c *colonyCoreNode) enterNormalMode() {
 func (c *colonyCoreNode) updateLanding(delta float64) {
 	c.drawOrder = c.pos.Y - 64
 	speed := c.movementSpeed()
-	height := c.shadowComponent.height - delta*speed
+	height := c.shadowComponent.height - float64(delta*speed)
 	if c.moveTowards(delta, speed, c.waypoint) {
 		height = 0
 		c.enterNormalMode()
@@ -1084,7 +1133,7 @@ func (c *colonyCoreNode) updateLanding(delta float64) {
 func (c *colonyCoreNode) maybeTeleport() {
 	var teleporter *teleporterNode
 	for _, tp := range c.world.teleporters {
-		if tp.pos.DistanceTo(c.pos) < 34 {
+		if detmath.DistanceTo(tp.pos, c.pos) < 34 {
 			teleporter = tp
 			break
 		}
@@ -1119,7 +1168,7 @@ func (c *colonyCoreNode) crushCrawlers() {
 		if creep.stats.Kind != gamedata.CreepCrawler {
 			continue
 		}
-		distSqr := creep.pos.DistanceSquaredTo(crushPos)
+		distSqr := detmath.DistanceSquaredTo(creep.pos, crushPos)
 		if distSqr <= crushRangeSqr {
 			creep.Destroy() // Defeat without an explosion
 			c.world.result.CreepsStomped++
@@ -1180,11 +1229,11 @@ func (c *colonyCoreNode) attackWithWeapon(weapon *gamedata.WeaponStats) {
 		return false
 	})
 	if target != nil {
-		c.attackDelay = weapon.Reload * c.world.rand.FloatRange(0.9, 1.1)
+		c.attackDelay = weapon.Reload * detmath.FloatRange(c.world.rand, 0.9, 1.1)
 		attackWithProjectile(c.world, weapon, c, target, weapon.BurstSize, false)
 		return
 	}
-	c.attackDelay = c.world.rand.FloatRange(0.4, 0.9)
+	c.attackDelay = detmath.FloatRange(c.world.rand, 0.4, 0.9)
 }
 
 func (c *colonyCoreNode) updateNormal(delta float64) {
@@ -1199,19 +1248,19 @@ func (c *colonyCoreNode) updateNormal(delta float64) {
 
 func (c *colonyCoreNode) doAction() {
 	if c.resourceShortage >= 5 && c.GetResourcePriority() < 0.4 {
-		c.AddPriority(priorityResources, c.scene.Rand().FloatRange(0.01, 0.03))
+		c.AddPriority(priorityResources, detmath.FloatRange(c.scene.Rand(), 0.01, 0.03))
 		c.resourceShortage -= 5
 	}
 
 	action := c.planner.PickAction()
 	if action.Kind == actionNone {
-		c.actionDelay = c.scene.Rand().FloatRange(0.15, 0.3)
+		c.actionDelay = detmath.FloatRange(c.scene.Rand(), 0.15, 0.3)
 		return
 	}
 	if c.tryExecutingAction(action) {
-		c.actionDelay = c.scene.Rand().FloatRange(action.TimeCost*0.75, action.TimeCost*1.25)
+		c.actionDelay = detmath.FloatRange(c.scene.Rand(), action.TimeCost*0.75, action.TimeCost*1.25)
 	} else {
-		c.actionDelay = c.scene.Rand().FloatRange(0.15, 0.25)
+		c.actionDelay = detmath.FloatRange(c.scene.Rand(), 0.15, 0.25)
 	}
 }
 
@@ -1247,7 +1296,7 @@ func (c *colonyCoreNode) calcResourceDelay() float64 {
 	// 0.3 resource priority: 2.8 delay
 	// 0.5 resource priority: 2.0 delay
 	// 0.7 resource priority: 1.2 delay
-	return 4.0 - (c.GetResourcePriority() * 4)
+	return 4.0 - float64(c.GetResourcePriority()*4)
 }
 
 func (c *colonyCoreNode) tryExecutingAction(action colonyAction) bool {
@@ -1300,7 +1349,7 @@ func (c *colonyCoreNode) tryExecutingAction(action colonyAction) bool {
 		// * 300 radius => 0.5
 		// * 400 radius => 0.1 (min)
 		evoGainMultiplier := gmath.Clamp(2.0-(c.realRadius/200), 0.1, 1.5)
-		c.evoPoints = gmath.ClampMax(c.evoPoints+(evoGain*evoGainMultiplier), maxEvoPoints)
+		c.evoPoints = gmath.ClampMax(c.evoPoints+float64(evoGain*evoGainMultiplier), maxEvoPoints)
 		c.updateEvoDiode()
 		return true
 
@@ -1310,12 +1359,50 @@ func (c *colonyCoreNode) tryExecutingAction(action colonyAction) bool {
 		if target.resources*1.3 < c.resources && c.resources > 60 {
 			const resPerUnit float64 = 12
 			courier.payload = gmath.ClampMax(courier.maxPayload(), int(c.resources/resPerUnit))
-			cargoValue := float64(courier.payload) * resPerUnit
+			cargoValue := float64(float64(courier.payload) * resPerUnit)
 			courier.cargoValue = cargoValue + 0.5
 			c.resources -= cargoValue
 		}
 		return courier.AssignMode(agentModeCourierFlight, gmath.Vec{}, action.Value)
 
+	case actionDeployRelay:
+		relay := action.Value2.(*colonyAgentNode)
+		return relay.AssignMode(agentModeRelay, gmath.Vec{}, action.Value)
+
+	case actionLayMine:
+		const mineCost = 6.0
+		if c.resources < mineCost {
+			return false
+		}
+		minelayer := action.Value2.(*colonyAgentNode)
+		if !minelayer.AssignMode(agentModeLayMine, action.Value.(gmath.Vec), nil) {
+			return false
+		}
+		c.resources -= mineCost
+		return true
+
+	case actionSendShielder:
+		shielder := action.Value2.(*colonyAgentNode)
+		return shielder.AssignMode(agentModeShieldEscort, gmath.Vec{}, action.Value)
+
+	case actionCoreAbility:
+		switch c.stats.Ability {
+		case gamedata.CoreAbilityBurrow:
+			if c.burrowTime > 0 {
+				return false
+			}
+			c.startBurrow()
+			return true
+		case gamedata.CoreAbilityRecall:
+			if c.agents.TotalNum() == 0 {
+				return false
+			}
+			playSound(c.world, assets.AudioTeleportDone, c.pos)
+			c.teleportAgents(c.pos)
+			return true
+		}
+		return false
+
 	case actionMineSulfurEssence:
 		if c.agents.NumAvailableWorkers() == 0 {
 			return false
@@ -1357,14 +1444,14 @@ func (c *colonyCoreNode) tryExecutingAction(action colonyAction) bool {
 		// 0.7 resource priority: 13
 		// 0.8 resource priority: 15 (cap)
 		resourcesPriority := c.GetResourcePriority()
-		priorityCapacity := gmath.Clamp((resourcesPriority*20)-1, 0, 15)
+		priorityCapacity := gmath.Clamp(float64(resourcesPriority*20)-1, 0, 15)
 		// 15 drones => +0
 		// 25 drones => +1
 		// 35 drones => +2
 		// 45 drones => +3
 		// 100 drones => +8
 		colonySizeBonus := gmath.Clamp((len(c.agents.workers)-15)/10, 0, 10)
-		toAssign := int(math.Floor(priorityCapacity)*c.scene.Rand().FloatRange(0.8, 1.3)) + colonySizeBonus
+		toAssign := int(math.Floor(priorityCapacity)*detmath.FloatRange(c.scene.Rand(), 0.8, 1.3)) + colonySizeBonus
 		if toAssign == 0 {
 			return false
 		}
@@ -1511,8 +1598,8 @@ func (c *colonyCoreNode) tryExecutingAction(action colonyAction) bool {
 		agent2 := action.Value2.(*colonyAgentNode)
 		mode := agentModeMerging
 		mergePoint := midpoint(agent1.pos, agent2.pos)
-		mergePos1 := mergePoint.Add(c.scene.Rand().Offset(-14, 14))
-		mergePos2 := mergePoint.Add(c.scene.Rand().Offset(-14, 14))
+		mergePos1 := mergePoint.Add(detmath.Offset(c.scene.Rand(), -14, 14))
+		mergePos2 := mergePoint.Add(detmath.Offset(c.scene.Rand(), -14, 14))
 		if gamedata.ColonyAgentKind(action.Value4) == gamedata.AgentRoomba {
 			mode = agentModeMergingRoomba
 			if !posIsFreeWithFlags(c.world, nil, mergePoint.Add(gmath.Vec{Y: agentFlightHeight}), 10, collisionSkipSmallCrawlers|collisionSkipTeleporters) {
@@ -1542,20 +1629,20 @@ func (c *colonyCoreNode) tryExecutingAction(action colonyAction) bool {
 		return true
 
 	case actionDefenceGarrison:
-		attacker := action.Value.(*creepNode)
+		attacker := action.Value.(targetable)
 		numAgents := c.scene.Rand().IntRange(2, 4)
 		c.pickCombatUnits(numAgents, func(a *colonyAgentNode) {
-			if a.mode == agentModeStandby && a.CanAttack(attacker.TargetKind()) {
+			if a.mode == agentModeStandby && a.CanAttack(targetKindOf(attacker)) {
 				a.AssignMode(agentModeFollow, gmath.Vec{}, attacker)
 			}
 		})
 		return true
 
 	case actionDefencePatrol:
-		attacker := action.Value.(*creepNode)
+		attacker := action.Value.(targetable)
 		numAgents := c.scene.Rand().IntRange(2, 4)
 		c.pickCombatUnits(numAgents, func(a *colonyAgentNode) {
-			if a.CanAttack(attacker.TargetKind()) {
+			if a.CanAttack(targetKindOf(attacker)) {
 				a.AssignMode(agentModeFollow, gmath.Vec{}, attacker)
 			}
 		})
@@ -1592,10 +1679,10 @@ func (c *colonyCoreNode) pickCombatUnits(n int, f func(a *colonyAgentNode)) {
 
 func (c *colonyCoreNode) moveTowards(delta, speed float64, pos gmath.Vec) bool {
 	travelled := speed * delta
-	if c.pos.DistanceTo(pos) <= travelled {
+	if detmath.DistanceTo(c.pos, pos) <= travelled {
 		c.pos = pos
 		return true
 	}
-	c.pos = c.pos.MoveTowards(pos, travelled)
+	c.pos = detmath.MoveTowards(c.pos, pos, travelled)
 	return false
 }
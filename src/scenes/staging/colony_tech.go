@@ -0,0 +1,203 @@
+package staging
+
+import (
+	resource "github.com/quasilyte/ebitengine-resource"
+	"github.com/quasilyte/roboden-game/assets"
+	"github.com/quasilyte/roboden-game/gamedata"
+)
+
+// colonyTech is a per-colony research node.
+// Techs are unlocked with the evolution points through the research card.
+type colonyTech int
+
+const (
+	techNone colonyTech = iota
+	techYellowArmor
+	techRedArmor
+	techGreenArmor
+	techBlueArmor
+	techTurretRange
+	techTurretRange2
+	techFastTeleport
+	techNum
+)
+
+type colonyTechInfo struct {
+	name     string
+	requires colonyTech
+	evoCost  float64
+	faction  gamedata.FactionTag
+	icon     resource.ImageID
+}
+
+var colonyTechTable = [...]colonyTechInfo{
+	techYellowArmor: {
+		name:    "yellow_armor",
+		evoCost: 6,
+		faction: gamedata.YellowFactionTag,
+		icon:    assets.ImageActionResearch,
+	},
+	techRedArmor: {
+		name:    "red_armor",
+		evoCost: 6,
+		faction: gamedata.RedFactionTag,
+		icon:    assets.ImageActionResearch,
+	},
+	techGreenArmor: {
+		name:    "green_armor",
+		evoCost: 6,
+		faction: gamedata.GreenFactionTag,
+		icon:    assets.ImageActionResearch,
+	},
+	techBlueArmor: {
+		name:    "blue_armor",
+		evoCost: 6,
+		faction: gamedata.BlueFactionTag,
+		icon:    assets.ImageActionResearch,
+	},
+	techTurretRange: {
+		name:    "turret_range",
+		evoCost: 5,
+		icon:    assets.ImageActionUpgradeTurret,
+	},
+	techTurretRange2: {
+		name:     "turret_range2",
+		requires: techTurretRange,
+		evoCost:  8,
+		icon:     assets.ImageActionUpgradeTurret,
+	},
+	techFastTeleport: {
+		name:    "fast_teleport",
+		evoCost: 4,
+		icon:    assets.ImageActionRecall,
+	},
+}
+
+// The research card replaces the regular choice cards
+// with the tech options, so there can be only 4 of them.
+const maxResearchOptions = 4
+
+// researchOrder lists the techs in the order they're offered.
+// The generic techs go before the armors; the dominating faction
+// armor is moved to the front by ResearchOptions.
+var researchOrder = [...]colonyTech{
+	techTurretRange,
+	techTurretRange2,
+	techFastTeleport,
+	techYellowArmor,
+	techRedArmor,
+	techGreenArmor,
+	techBlueArmor,
+}
+
+const (
+	techArmorHealthMultiplier = 1.2
+	techTurretRangeMultiplier = 1.15
+	techFastTeleportSpeed     = 1.6
+)
+
+func armorTechForFaction(faction gamedata.FactionTag) colonyTech {
+	switch faction {
+	case gamedata.YellowFactionTag:
+		return techYellowArmor
+	case gamedata.RedFactionTag:
+		return techRedArmor
+	case gamedata.GreenFactionTag:
+		return techGreenArmor
+	case gamedata.BlueFactionTag:
+		return techBlueArmor
+	default:
+		return techNone
+	}
+}
+
+type colonyTechState struct {
+	researched [techNum]bool
+
+	// Turret weapons with the range techs and turret upgrades applied.
+	// Rebuilt after every turret range tech is researched.
+	turretWeapons map[turretWeaponKey]*gamedata.WeaponStats
+}
+
+func (c *colonyCoreNode) HasTech(tech colonyTech) bool {
+	return c.techs.researched[tech]
+}
+
+func (c *colonyCoreNode) canResearch(tech colonyTech) bool {
+	if tech == techNone || c.HasTech(tech) {
+		return false
+	}
+	info := colonyTechTable[tech]
+	if info.requires != techNone && !c.HasTech(info.requires) {
+		return false
+	}
+	if tech == techFastTeleport && len(c.world.teleporters) == 0 {
+		return false
+	}
+	return true
+}
+
+// ResearchOptions returns the techs that are offered by the research card.
+// The armor for the dominating faction goes first, then the rest of
+// the techs are listed in the researchOrder.
+// At most maxResearchOptions techs are returned.
+func (c *colonyCoreNode) ResearchOptions() []colonyTech {
+	options := make([]colonyTech, 0, maxResearchOptions)
+
+	dominant := gamedata.NeutralFactionTag
+	dominantWeight := 0.0
+	for _, kv := range c.factionWeights.Elems {
+		if kv.Key != gamedata.NeutralFactionTag && kv.Weight > dominantWeight {
+			dominant = kv.Key
+			dominantWeight = kv.Weight
+		}
+	}
+	dominantArmor := armorTechForFaction(dominant)
+	if c.canResearch(dominantArmor) {
+		options = append(options, dominantArmor)
+	}
+	for _, tech := range researchOrder {
+		if len(options) == maxResearchOptions {
+			break
+		}
+		if tech != dominantArmor && c.canResearch(tech) {
+			options = append(options, tech)
+		}
+	}
+	return options
+}
+
+func (c *colonyCoreNode) CanResearch(tech colonyTech) bool {
+	return c.canResearch(tech) && c.evoPoints >= colonyTechTable[tech].evoCost
+}
+
+func (c *colonyCoreNode) Research(tech colonyTech) bool {
+	if !c.CanResearch(tech) {
+		return false
+	}
+	c.evoPoints -= colonyTechTable[tech].evoCost
+	c.updateEvoDiode()
+	c.techs.researched[tech] = true
+
+	switch tech {
+	case techTurretRange, techTurretRange2:
+		c.techs.turretWeapons = nil
+	case techYellowArmor, techRedArmor, techGreenArmor, techBlueArmor:
+		faction := colonyTechTable[tech].faction
+		c.agents.Each(func(a *colonyAgentNode) {
+			if a.faction == faction {
+				a.maxHealth *= techArmorHealthMultiplier
+				a.health *= techArmorHealthMultiplier
+			}
+		})
+	}
+
+	return true
+}
+
+func (c *colonyCoreNode) teleportSpeed() float64 {
+	if c.HasTech(techFastTeleport) {
+		return techFastTeleportSpeed
+	}
+	return 1
+}
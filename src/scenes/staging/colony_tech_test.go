@@ -0,0 +1,97 @@
+package staging
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/roboden-game/gamedata"
+	"github.com/quasilyte/roboden-game/serverapi"
+)
+
+func TestResearchChoices(t *testing.T) {
+	var rand gmath.Rand
+	rand.SetSeed(1)
+	world := &worldState{
+		rand:       &rand,
+		coreDesign: gamedata.DenCoreStats,
+		config: &gamedata.LevelConfig{
+			ReplayLevelConfig: serverapi.ReplayLevelConfig{TechTree: true},
+		},
+	}
+	colony := &colonyCoreNode{world: world, sprite: &ge.Sprite{}}
+	colony.factionWeights = newWeightContainer(
+		gamedata.NeutralFactionTag,
+		gamedata.YellowFactionTag,
+		gamedata.RedFactionTag,
+		gamedata.GreenFactionTag,
+		gamedata.BlueFactionTag)
+	colony.factionWeights.SetWeight(gamedata.NeutralFactionTag, 0.2)
+	colony.factionWeights.SetWeight(gamedata.RedFactionTag, 0.5)
+	colony.factionWeights.SetWeight(gamedata.BlueFactionTag, 0.3)
+	colony.techs.researched[techTurretRange] = true
+
+	// The dominating faction armor goes first.
+	// Fast teleport is not offered: there are no teleporters.
+	wantTechs := []colonyTech{techRedArmor, techTurretRange2, techYellowArmor, techGreenArmor}
+	techsString := func(techs []colonyTech) string {
+		var s []string
+		for _, tech := range techs {
+			s = append(s, colonyTechTable[tech].name)
+		}
+		return fmt.Sprint(s)
+	}
+	if have := colony.ResearchOptions(); techsString(have) != techsString(wantTechs) {
+		t.Fatalf("research options:\nhave: %v\nwant: %v", techsString(have), techsString(wantTechs))
+	}
+
+	g := newChoiceGenerator(world, nil)
+	g.state = choiceReady
+	g.specialOptionIndex = int(specialResearch)
+	var selected []selectedChoice
+	g.EventChoiceSelected.Connect(nil, func(choice selectedChoice) {
+		selected = append(selected, choice)
+	})
+	cardTechs := func() []colonyTech {
+		var techs []colonyTech
+		for _, o := range g.GetChoices().cards {
+			techs = append(techs, o.tech)
+		}
+		return techs
+	}
+
+	// The research card shows the options.
+	if !g.TryExecute(colony, 4, gmath.Vec{}) {
+		t.Fatal("can't show the research options")
+	}
+	if have := cardTechs(); techsString(have) != techsString(wantTechs) {
+		t.Fatalf("research cards:\nhave: %v\nwant: %v", techsString(have), techsString(wantTechs))
+	}
+
+	// Activating it again brings the regular cards back.
+	if !g.TryExecute(colony, 4, gmath.Vec{}) {
+		t.Fatal("can't hide the research options")
+	}
+	for i, o := range g.GetChoices().cards {
+		if o.special != specialChoiceNone {
+			t.Fatalf("card[%d] is not a regular card after the research options are hidden", i)
+		}
+	}
+	if !g.IsReady() || len(selected) != 2 {
+		t.Fatalf("showing the research options should not start the cooldown")
+	}
+
+	// Picking the option researches it.
+	g.TryExecute(colony, 4, gmath.Vec{})
+	if !g.TryExecute(colony, 1, gmath.Vec{}) {
+		t.Fatal("can't pick a research option")
+	}
+	choice := selected[len(selected)-1]
+	if choice.Option.special != specialResearch || choice.Option.tech != techTurretRange2 || choice.Colony != colony {
+		t.Fatalf("unexpected research choice: %v %v", choice.Option.special, choice.Option.tech)
+	}
+	if g.IsReady() || g.researchColony != nil {
+		t.Fatalf("the research pick should start the cooldown")
+	}
+}
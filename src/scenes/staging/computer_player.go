@@ -6,6 +6,7 @@ import (
 	"github.com/quasilyte/ge"
 	"github.com/quasilyte/ge/xslices"
 	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/roboden-game/detmath"
 	"github.com/quasilyte/roboden-game/gamedata"
 )
 
@@ -14,6 +15,8 @@ type computerPlayer struct {
 	state *playerState
 	scene *ge.Scene
 
+	profile *gamedata.BotProfile
+
 	choiceGen       *choiceGenerator
 	choiceSelection choiceSelection
 
@@ -68,10 +71,12 @@ type computerColony struct {
 }
 
 func newComputerPlayer(world *worldState, state *playerState, choiceGen *choiceGenerator) *computerPlayer {
+	profile := world.config.PlayerBotProfile(state.id)
 	p := &computerPlayer{
 		world:     world,
 		state:     state,
 		scene:     world.rootScene,
+		profile:   profile,
 		choiceGen: choiceGen,
 
 		resourceCards:  make([]int, 0, 4),
@@ -79,7 +84,7 @@ func newComputerPlayer(world *worldState, state *playerState, choiceGen *choiceG
 		evolutionCards: make([]int, 0, 4),
 		securityCards:  make([]int, 0, 4),
 
-		buildColonyDelay: world.rand.FloatRange(60, 3*60),
+		buildColonyDelay: detmath.FloatRange(world.rand, 60, 3*60) / profile.Expansion,
 	}
 
 	switch p.world.turretDesign {
@@ -97,7 +102,7 @@ func newComputerPlayer(world *worldState, state *playerState, choiceGen *choiceG
 
 	numColoniesPicker := gmath.NewRandPicker[int](world.rand)
 	switch world.coreDesign {
-	case gamedata.DenCoreStats:
+	case gamedata.DenCoreStats, gamedata.HiveCoreStats, gamedata.BeaconCoreStats:
 		p.colonyTargetRadius = 370
 		p.minRadiusBeforeColony = 180
 		numColoniesPicker.AddOption(1, 0.05)
@@ -124,6 +129,7 @@ func newComputerPlayer(world *worldState, state *playerState, choiceGen *choiceG
 		panic("bot can't play on this core design")
 	}
 	p.maxColonies = numColoniesPicker.Pick()
+	p.maxColonies = gmath.ClampMin(int(math.Round(float64(p.maxColonies)*p.profile.Expansion)), 1)
 
 	p.attackGroup = make([]*computerColony, 0, p.maxColonies)
 
@@ -152,7 +158,7 @@ func newComputerPlayer(world *worldState, state *playerState, choiceGen *choiceG
 		}
 		wrapped := &computerColony{
 			node:       colony,
-			moveDelay:  p.world.rand.FloatRange(10, 15),
+			moveDelay:  detmath.FloatRange(p.world.rand, 10, 15),
 			maxTurrets: p.maxTurretsForColony(),
 		}
 		colony.EventDestroyed.Connect(p, func(_ *colonyCoreNode) {
@@ -174,20 +180,20 @@ func newComputerPlayer(world *worldState, state *playerState, choiceGen *choiceG
 }
 
 func (p *computerPlayer) maxTurretsForColony() int {
+	n := 0
 	switch p.world.turretDesign {
 	case gamedata.GunpointAgentStats:
-		return p.world.rand.IntRange(2, 5)
+		n = p.world.rand.IntRange(2, 5)
 	case gamedata.BeamTowerAgentStats:
-		return p.world.rand.IntRange(1, 4)
+		n = p.world.rand.IntRange(1, 4)
 	case gamedata.TetherBeaconAgentStats:
-		return p.world.rand.IntRange(0, 2)
+		n = p.world.rand.IntRange(0, 2)
 	case gamedata.HarvesterAgentStats:
-		return p.world.rand.IntRange(1, 2)
+		n = p.world.rand.IntRange(1, 2)
 	case gamedata.SiegeAgentStats:
-		return p.world.rand.IntRange(1, 2)
-	default:
-		return 0
+		n = p.world.rand.IntRange(1, 2)
 	}
+	return int(math.Round(float64(n) * p.profile.TurretPreference))
 }
 
 func (p *computerPlayer) Init() {
@@ -236,27 +242,35 @@ func (p *computerPlayer) handleInput() {
 
 	if p.comebackDelay == 0 {
 		if p.maybeDoComeback() {
-			p.comebackDelay = p.world.rand.FloatRange(140, 200)
+			p.comebackDelay = detmath.FloatRange(p.world.rand, 140, 200)
 			return
 		}
-		p.comebackDelay = p.world.rand.FloatRange(10, 20)
+		p.comebackDelay = detmath.FloatRange(p.world.rand, 10, 20)
 	}
 
 	p.calculatedColonyPower = false
+	if p.profile.MistakeRate != 0 && p.world.rand.Chance(p.profile.MistakeRate) {
+		// The bot hesitates and misses its turn.
+		p.actionDelay = detmath.FloatRange(p.world.rand, 0.75, 2.0) * p.profile.ActionDelay
+		return
+	}
 	if p.maybeDoAction() {
-		p.actionDelay = p.world.rand.FloatRange(1.5, 4)
+		p.actionDelay = detmath.FloatRange(p.world.rand, 1.5, 4) * p.profile.ActionDelay
 	} else {
-		p.actionDelay = p.world.rand.FloatRange(0.75, 2.0)
+		p.actionDelay = detmath.FloatRange(p.world.rand, 0.75, 2.0) * p.profile.ActionDelay
 	}
 }
 
 func (p *computerPlayer) findGoodComebackSpot(leaderColony *computerColony, colonyPower int, dist float64) (gmath.Vec, int) {
 	var bestPos gmath.Vec
 	var bestScore int
-	probePos := leaderColony.node.pos.MoveTowards(p.world.rect.Center(), dist)
+	probePos := detmath.MoveTowards(leaderColony.node.pos, p.world.rect.Center(), dist)
 	randIterate(p.world.rand, comebackProbeOffsets, func(offset gmath.Vec) bool {
 		pos := probePos.Add(offset)
-		resourceScore, _ := p.calcPosResources(leaderColony.node, pos, 200)
+		resourceScore, _ := p.probePosResources(leaderColony.node, pos, 200)
+		if resourceScore == 0 {
+			return false
+		}
 		dangerScore, _ := p.calcPosDangerWithHazards(pos, 250)
 		multiplier := float64(colonyPower) / float64(dangerScore)
 		score := int(float64(resourceScore) * multiplier)
@@ -320,7 +334,7 @@ func (p *computerPlayer) maybeDoComeback() bool {
 		if c == leaderColony || c.dangerLevel == 0 {
 			continue
 		}
-		if c.node.pos.DistanceTo(leaderColony.node.pos) < 240 {
+		if detmath.DistanceTo(c.node.pos, leaderColony.node.pos) < 240 {
 			group = append(group, c)
 		}
 	}
@@ -331,7 +345,7 @@ func (p *computerPlayer) maybeDoComeback() bool {
 		c.moveDelay *= 2
 		pos := bestPos
 		if i != 0 {
-			pos = bestPos.Add(p.world.rand.Offset(-80, 80))
+			pos = bestPos.Add(detmath.Offset(p.world.rand, -80, 80))
 		}
 		c.nextPos = nextPos
 		p.executeMoveAction(c.node, pos)
@@ -345,7 +359,7 @@ func (p *computerPlayer) maybeDoColonyAction(colony *computerColony) bool {
 
 	if !colony.nextPos.IsZero() {
 		colony.nextPos = gmath.Vec{}
-		if colony.node.pos.DistanceTo(colony.nextPos) > 64 {
+		if detmath.DistanceTo(colony.node.pos, colony.nextPos) > 64 {
 			p.executeMoveAction(colony.node, colony.nextPos)
 			return true
 		}
@@ -355,17 +369,17 @@ func (p *computerPlayer) maybeDoColonyAction(colony *computerColony) bool {
 	// going to send their colonies into combat.
 	if colony.attackDelay == 0 {
 		if p.maybeStartAttackingDreadnought(colony) {
-			colony.attackDelay = p.world.rand.FloatRange(50, 110)
+			colony.attackDelay = detmath.FloatRange(p.world.rand, 50, 110)
 		} else {
-			colony.attackDelay = p.world.rand.FloatRange(15, 30)
+			colony.attackDelay = detmath.FloatRange(p.world.rand, 15, 30)
 		}
 	}
 
 	if colony.attackBaseDelay == 0 {
-		if p.maybeAttackCreepBase(colony) {
-			colony.attackBaseDelay = p.world.rand.FloatRange(80, 120)
+		if p.maybeAttackCreepBase(colony) || p.maybeAttackEnemyColony(colony) {
+			colony.attackBaseDelay = detmath.FloatRange(p.world.rand, 80, 120)
 		} else {
-			colony.attackBaseDelay = p.world.rand.FloatRange(20, 35)
+			colony.attackBaseDelay = detmath.FloatRange(p.world.rand, 20, 35)
 		}
 	}
 
@@ -376,10 +390,10 @@ func (p *computerPlayer) maybeDoColonyAction(colony *computerColony) bool {
 		if p.maybeDoAttacking(colony) {
 			return true
 		}
-		if colony.attacking == 0 || colony.node.pos.DistanceTo(p.world.boss.pos) <= 250 {
+		if colony.attacking == 0 || detmath.DistanceTo(colony.node.pos, p.world.boss.pos) <= 250 {
 			colony.attacking = 0
 			if p.maybeStayForAttack() {
-				delay := p.world.rand.FloatRange(9, 16)
+				delay := detmath.FloatRange(p.world.rand, 9, 16)
 				colony.moveDelay = delay
 				colony.defendDelay = delay
 				return true
@@ -389,10 +403,10 @@ func (p *computerPlayer) maybeDoColonyAction(colony *computerColony) bool {
 
 	if colony.defendDelay == 0 {
 		if delay := p.maybeDoDefensiveAction(colony); delay != 0 {
-			colony.defendDelay = delay * p.world.rand.FloatRange(0.5, 2)
+			colony.defendDelay = delay * detmath.FloatRange(p.world.rand, 0.5, 2)
 			return true
 		}
-		colony.defendDelay = p.world.rand.FloatRange(3, 6)
+		colony.defendDelay = detmath.FloatRange(p.world.rand, 3, 6)
 	}
 
 	// Only defensive actions (like relocation) are allowed
@@ -405,13 +419,13 @@ func (p *computerPlayer) maybeDoColonyAction(colony *computerColony) bool {
 	if colony.moveDelay == 0 {
 		if delay := p.maybeMoveColony(colony); delay != 0 {
 			if colony.node.stats == gamedata.TankCoreStats {
-				colony.moveDelay = delay * p.world.rand.FloatRange(0.6, 1.1)
+				colony.moveDelay = delay * detmath.FloatRange(p.world.rand, 0.6, 1.1)
 			} else {
-				colony.moveDelay = delay * p.world.rand.FloatRange(0.8, 1.4)
+				colony.moveDelay = delay * detmath.FloatRange(p.world.rand, 0.8, 1.4)
 			}
 			return true
 		}
-		colony.moveDelay = p.world.rand.FloatRange(5, 10)
+		colony.moveDelay = detmath.FloatRange(p.world.rand, 5, 10)
 	}
 
 	// All actions below require the choices (cards) to be ready.
@@ -421,34 +435,34 @@ func (p *computerPlayer) maybeDoColonyAction(colony *computerColony) bool {
 
 	if p.buildColonyDelay == 0 && p.choiceSelection.special.special == specialBuildColony {
 		if p.maybeBuildColony(colony) {
-			p.buildColonyDelay = p.world.rand.FloatRange(80, 6*60)
+			p.buildColonyDelay = detmath.FloatRange(p.world.rand, 80, 6*60) / p.profile.Expansion
 			return true
 		}
-		p.buildColonyDelay = p.world.rand.FloatRange(30, 60)
+		p.buildColonyDelay = detmath.FloatRange(p.world.rand, 30, 60) / p.profile.Expansion
 	}
 
 	if p.buildTurretDelay == 0 && p.choiceSelection.special.special == specialBuildGunpoint && colony.node.numTurretsBuilt < colony.maxTurrets {
 		if p.maybeBuildTurret(colony) {
-			p.buildTurretDelay = p.world.rand.FloatRange(40, 2*90)
+			p.buildTurretDelay = detmath.FloatRange(p.world.rand, 40, 2*90) / p.profile.TurretPreference
 			return true
 		}
-		p.buildTurretDelay = p.world.rand.FloatRange(5, 20)
+		p.buildTurretDelay = detmath.FloatRange(p.world.rand, 5, 20) / p.profile.TurretPreference
 	}
 
 	if colony.specialDelay == 0 {
 		if p.maybeUseSpecial(colony) {
-			colony.specialDelay = p.world.rand.FloatRange(15, 50)
+			colony.specialDelay = detmath.FloatRange(p.world.rand, 15, 50)
 			return true
 		}
-		colony.specialDelay = p.world.rand.FloatRange(2, 5)
+		colony.specialDelay = detmath.FloatRange(p.world.rand, 2, 5)
 	}
 
 	if colony.factionDelay == 0 {
 		if p.maybeChangePriorities(colony) {
-			colony.factionDelay = p.world.rand.FloatRange(10, 15)
+			colony.factionDelay = detmath.FloatRange(p.world.rand, 10, 15)
 			return true
 		}
-		colony.factionDelay = p.world.rand.FloatRange(3, 20)
+		colony.factionDelay = detmath.FloatRange(p.world.rand, 3, 20)
 	}
 
 	return false
@@ -480,7 +494,7 @@ func (p *computerPlayer) shouldWait(colony *colonyCoreNode) float64 {
 				eliteResources = true
 			}
 		case agentModeMerging:
-			if a.stats.Tier == 2 {
+			if a.stats.Tier >= 2 {
 				t3merging = true
 			}
 		case agentModeMakeClone:
@@ -545,7 +559,7 @@ func (p *computerPlayer) bossTargetKind() gamedata.TargetKind {
 }
 
 func (p *computerPlayer) maybeStayForAttack() bool {
-	dist := p.world.boss.pos.DistanceTo(p.state.selectedColony.pos)
+	dist := detmath.DistanceTo(p.world.boss.pos, p.state.selectedColony.pos)
 	if dist > 275 {
 		return false
 	}
@@ -567,7 +581,7 @@ func (p *computerPlayer) maybeDoAttacking(colony *computerColony) bool {
 		return false
 	}
 
-	dist := p.world.boss.pos.DistanceTo(colony.node.pos)
+	dist := detmath.DistanceTo(p.world.boss.pos, colony.node.pos)
 
 	if p.choiceGen.IsReady() {
 		if p.choiceSelection.special.special == specialAttack && dist < 0.9*colony.node.AttackRadius() {
@@ -576,7 +590,7 @@ func (p *computerPlayer) maybeDoAttacking(colony *computerColony) bool {
 	}
 
 	if dist > 0.8*colony.node.PatrolRadius() {
-		p.executeMoveAction(colony.node, p.world.boss.pos.Add(p.world.rand.Offset(-128, 128)))
+		p.executeMoveAction(colony.node, p.world.boss.pos.Add(detmath.Offset(p.world.rand, -128, 128)))
 		return true
 	}
 
@@ -591,10 +605,12 @@ func (p *computerPlayer) maybeAddTankPower(colony *colonyCoreNode, power int) in
 }
 
 func (p *computerPlayer) maybeAttackCreepBase(colony *computerColony) bool {
-	if colony.node.agents.TotalNum() < 30 {
+	aggression := p.profile.Aggression
+	numAgents := float64(colony.node.agents.TotalNum())
+	if numAgents < 30/aggression {
 		return false
 	}
-	if colony.node.agents.TotalNum() < 45 {
+	if numAgents < 45/aggression {
 		if colony.node.resources < 0.4*colony.node.maxVisualResources() {
 			return false
 		}
@@ -606,7 +622,7 @@ func (p *computerPlayer) maybeAttackCreepBase(colony *computerColony) bool {
 	}
 
 	power := p.selectedColonyPower(gamedata.TargetAny)
-	if power < 60 {
+	if float64(power) < 60/aggression {
 		return false
 	}
 
@@ -625,7 +641,7 @@ func (p *computerPlayer) maybeAttackCreepBase(colony *computerColony) bool {
 				return false
 			}
 		}
-		distSqr := creep.pos.DistanceSquaredTo(colony.node.pos)
+		distSqr := detmath.DistanceSquaredTo(creep.pos, colony.node.pos)
 		if distSqr < colony.node.realRadiusSqr {
 			return false
 		}
@@ -633,7 +649,7 @@ func (p *computerPlayer) maybeAttackCreepBase(colony *computerColony) bool {
 			return false
 		}
 		danger, _ := p.calcPosDanger(creep.pos, 250)
-		if int(float64(danger)*1.6) > power {
+		if int(float64(danger)*1.6/aggression) > power {
 			return false
 		}
 		return true
@@ -642,16 +658,92 @@ func (p *computerPlayer) maybeAttackCreepBase(colony *computerColony) bool {
 		return false
 	}
 
-	p.executeMoveAction(colony.node, enemyBase.pos.Add(p.world.rand.Offset(-160, 160)))
+	p.executeMoveAction(colony.node, enemyBase.pos.Add(detmath.Offset(p.world.rand, -160, 160)))
 
 	return true
 }
 
+// maybeAttackEnemyColony sends the colony towards the closest enemy colony.
+// It's only possible in the colony-vs-colony modes.
+func (p *computerPlayer) maybeAttackEnemyColony(colony *computerColony) bool {
+	if !p.world.hostileColonies {
+		return false
+	}
+
+	aggression := p.profile.Aggression
+	if float64(colony.node.agents.TotalNum()) < 40/aggression {
+		return false
+	}
+	power := p.maybeAddTankPower(colony.node, p.selectedColonyPower(gamedata.TargetAny))
+	if float64(power) < 80/aggression {
+		return false
+	}
+
+	var target *colonyCoreNode
+	targetDistSqr := math.MaxFloat64
+	for _, other := range p.world.allColonies {
+		if !p.world.AreEnemies(colony.node, other) {
+			continue
+		}
+		distSqr := detmath.DistanceSquaredTo(other.pos, colony.node.pos)
+		if distSqr < targetDistSqr {
+			target = other
+			targetDistSqr = distSqr
+		}
+	}
+	if target == nil {
+		return false
+	}
+
+	defence := float64(p.calcEnemyColonyDefence(target)) * detmath.FloatRange(p.world.rand, 1.1, 1.4)
+	if float64(power)*aggression < defence {
+		return false
+	}
+
+	// Land close enough for the drones to reach the enemy colony.
+	// A distant target is approached in several jumps.
+	landingDist := gmath.ClampMin(math.Sqrt(targetDistSqr)-float64(colony.node.AttackRadius()*0.6), 0)
+	landingDist = gmath.ClampMax(landingDist, colony.node.MaxFlyDistance()*0.9)
+	if landingDist < 64 {
+		return false
+	}
+	pos := detmath.MoveTowards(colony.node.pos, target.pos, landingDist)
+	p.executeMoveAction(colony.node, pos.Add(detmath.Offset(p.world.rand, -64, 64)))
+
+	return true
+}
+
+// calcEnemyColonyDefence estimates the power of the enemy colony drones and its defensive structures.
+func (p *computerPlayer) calcEnemyColonyDefence(target *colonyCoreNode) int {
+	enemyID := target.player.GetState().id
+	return p.calcColonyPower(target, gamedata.TargetAny) +
+		p.world.influenceMap.Control(enemyID, target.pos, target.realRadius*1.5)
+}
+
+// calcPosEnemyPower is calcPosDanger for the colony-vs-colony modes:
+// it estimates the enemy colonies power around pos.
+func (p *computerPlayer) calcPosEnemyPower(colony *colonyCoreNode, pos gmath.Vec, r float64) int {
+	if !p.world.hostileColonies {
+		return 0
+	}
+	total := 0
+	for _, other := range p.world.allColonies {
+		if !p.world.AreEnemies(colony, other) {
+			continue
+		}
+		if detmath.DistanceSquaredTo(other.pos, pos) > (r+other.realRadius)*(r+other.realRadius) {
+			continue
+		}
+		total += p.calcEnemyColonyDefence(other)
+	}
+	return total
+}
+
 func (p *computerPlayer) maybeStartAttackingDreadnought(colony *computerColony) bool {
 	if p.world.boss == nil {
 		return false
 	}
-	distSqr := p.world.boss.pos.DistanceSquaredTo(colony.node.pos)
+	distSqr := detmath.DistanceSquaredTo(p.world.boss.pos, colony.node.pos)
 	jumpDistSqr := colony.node.MaxFlyDistanceSqr() + 100
 	if distSqr > 2.75*jumpDistSqr {
 		return false
@@ -665,8 +757,8 @@ func (p *computerPlayer) maybeStartAttackingDreadnought(colony *computerColony)
 		if otherColony.node == colony.node || otherColony.attacking != 0 {
 			continue
 		}
-		addToGroup := (otherColony.node.stats == gamedata.TankCoreStats && otherColony.node.pos.DistanceTo(colony.node.pos) <= 200) ||
-			otherColony.node.pos.DistanceSquaredTo(p.world.boss.pos) <= 1.55*otherColony.node.MaxFlyDistanceSqr()
+		addToGroup := (otherColony.node.stats == gamedata.TankCoreStats && detmath.DistanceTo(otherColony.node.pos, colony.node.pos) <= 200) ||
+			detmath.DistanceSquaredTo(otherColony.node.pos, p.world.boss.pos) <= 1.55*otherColony.node.MaxFlyDistanceSqr()
 		if !addToGroup {
 			continue
 		}
@@ -679,8 +771,8 @@ func (p *computerPlayer) maybeStartAttackingDreadnought(colony *computerColony)
 	}
 
 	bossDanger, _ := p.calcPosDanger(p.world.boss.pos, 200)
-	bossDanger = int(float64(bossDanger) * p.world.rand.FloatRange(1.15, 1.55))
-	if totalPower < bossDanger {
+	bossDanger = int(float64(bossDanger) * detmath.FloatRange(p.world.rand, 1.15, 1.55))
+	if float64(totalPower)*p.profile.Aggression < float64(bossDanger) {
 		return false
 	}
 
@@ -724,7 +816,7 @@ func (p *computerPlayer) maybeRegroup(colony *computerColony) bool {
 		if colony.node == other {
 			continue
 		}
-		if colony.node.pos.DistanceSquaredTo(other.pos) < (200 * 200) {
+		if detmath.DistanceSquaredTo(colony.node.pos, other.pos) < (200 * 200) {
 			return false
 		}
 	}
@@ -741,10 +833,10 @@ func (p *computerPlayer) maybeRegroup(colony *computerColony) bool {
 			return false
 		}
 		return c.mode == colonyModeNormal &&
-			(c.pos.DistanceSquaredTo(colony.node.pos) < 2*colony.node.MaxFlyDistanceSqr())
+			(detmath.DistanceSquaredTo(c.pos, colony.node.pos) < 2*colony.node.MaxFlyDistanceSqr())
 	})
 	if otherColony != nil {
-		p.executeMoveAction(otherColony, otherColony.pos.Add(p.world.rand.Offset(-96, 96)))
+		p.executeMoveAction(otherColony, otherColony.pos.Add(detmath.Offset(p.world.rand, -96, 96)))
 		return true
 	}
 
@@ -757,7 +849,7 @@ func (p *computerPlayer) maybeRetreatFromBoss(colony *computerColony) bool {
 		return false
 	}
 
-	bossDist := boss.pos.DistanceTo(colony.node.pos)
+	bossDist := detmath.DistanceTo(boss.pos, colony.node.pos)
 	if bossDist > colony.node.PatrolRadius()+360 {
 		return false
 	}
@@ -770,10 +862,10 @@ func (p *computerPlayer) maybeRetreatFromBoss(colony *computerColony) bool {
 
 	if p.world.rand.Chance(0.65) {
 		// Try to get out of the boss movement trajectory.
-		bossDir := boss.waypoint.DirectionTo(boss.pos)
-		probe1 := bossDir.Rotated(gmath.Rad(p.world.rand.FloatRange(0.45, 1.1))).Mulf(colony.node.MaxFlyDistance()).Add(colony.node.pos)
+		bossDir := detmath.DirectionTo(boss.waypoint, boss.pos)
+		probe1 := detmath.AddScaled(colony.node.pos, detmath.Rotated(bossDir, gmath.Rad(detmath.FloatRange(p.world.rand, 0.45, 1.1))), colony.node.MaxFlyDistance())
 		danger1, _ := p.calcPosDangerWithHazards(probe1, colony.node.realRadius)
-		probe2 := bossDir.Rotated(gmath.Rad(-p.world.rand.FloatRange(0.45, 1.1))).Mulf(colony.node.MaxFlyDistance()).Add(colony.node.pos)
+		probe2 := detmath.AddScaled(colony.node.pos, detmath.Rotated(bossDir, gmath.Rad(-detmath.FloatRange(p.world.rand, 0.45, 1.1))), colony.node.MaxFlyDistance())
 		danger2, _ := p.calcPosDangerWithHazards(probe2, colony.node.realRadius)
 		if danger1 == danger2 && p.world.rand.Bool() {
 			danger1, probe1 = danger2, probe2
@@ -801,7 +893,7 @@ func (p *computerPlayer) maybeHandleHowitzerThreat(colony *computerColony) bool
 	colony.howitzerAttacker = nil
 
 	danger, _ := p.calcPosDanger(howitzer.pos, 250)
-	requiredPower := int(float64(danger) * p.world.rand.FloatRange(0.9, 1.3))
+	requiredPower := int(float64(danger) * detmath.FloatRange(p.world.rand, 0.9, 1.3))
 
 	colonyPower := p.maybeAddTankPower(colony.node, p.selectedColonyPower(gamedata.TargetGround))
 	var colonyForAttack *colonyCoreNode
@@ -816,20 +908,20 @@ func (p *computerPlayer) maybeHandleHowitzerThreat(colony *computerColony) bool
 			return otherColonyPower >= requiredPower &&
 				c.resources > 40 &&
 				c.mode == colonyModeNormal &&
-				(c.pos.DistanceSquaredTo(howitzer.pos) < c.MaxFlyDistanceSqr()*1.1)
+				(detmath.DistanceSquaredTo(c.pos, howitzer.pos) < c.MaxFlyDistanceSqr()*1.1)
 		})
 		if otherColony != nil && p.world.rand.Chance(0.95) {
 			colonyForAttack = otherColony.node
 		}
 	} else {
 		// Have enough power to destroy the howitzer.
-		if (colony.node.pos.DistanceSquaredTo(howitzer.pos) < colony.node.MaxFlyDistanceSqr()*1.5) && p.world.rand.Chance(0.75) {
+		if (detmath.DistanceSquaredTo(colony.node.pos, howitzer.pos) < colony.node.MaxFlyDistanceSqr()*1.5) && p.world.rand.Chance(0.75) {
 			colonyForAttack = colony.node
 		}
 	}
 
 	if colonyForAttack != nil {
-		p.executeMoveAction(colonyForAttack, howitzer.pos.Add(p.world.rand.Offset(-140, 140)))
+		p.executeMoveAction(colonyForAttack, howitzer.pos.Add(detmath.Offset(p.world.rand, -140, 140)))
 		return true
 	}
 
@@ -846,11 +938,11 @@ func (p *computerPlayer) maybeRetreatFrom(colony *computerColony, pos gmath.Vec)
 			if c.mode != colonyModeNormal || c.NumAgents() < 25 || c.agents.NumAvailableFighters() < 5 {
 				return false
 			}
-			dist := c.pos.DistanceSquaredTo(colony.node.pos)
+			dist := detmath.DistanceSquaredTo(c.pos, colony.node.pos)
 			return dist < 1.5*colony.node.MaxFlyDistanceSqr() && dist > (200*200)
 		})
 		if otherColony != nil {
-			p.executeMoveAction(colony.node, otherColony.pos.Add(p.world.rand.Offset(-96, 96)))
+			p.executeMoveAction(colony.node, otherColony.pos.Add(detmath.Offset(p.world.rand, -96, 96)))
 			return 75
 		}
 	}
@@ -880,9 +972,9 @@ func (p *computerPlayer) maybeRetreatFrom(colony *computerColony, pos gmath.Vec)
 
 	numProbes := p.world.rand.IntRange(5, 7)
 	for i := 0; i < numProbes; i++ {
-		dir := gmath.RadToVec(p.world.rand.Rad())
-		dist := colony.node.MaxFlyDistance()*p.world.rand.FloatRange(0.7, 1.2) + 200
-		candidatePos := dir.Mulf(dist).Add(colony.node.pos)
+		dir := detmath.RadToVec(detmath.RandRad(p.world.rand))
+		dist := float64(colony.node.MaxFlyDistance()*detmath.FloatRange(p.world.rand, 0.7, 1.2)) + 200
+		candidatePos := detmath.AddScaled(colony.node.pos, dir, dist)
 		danger, _ := p.calcPosDanger(candidatePos, colony.node.PatrolRadius()+300)
 		resourceScore, _ := p.calcPosResources(colony.node, colony.node.pos, colony.node.realRadius)
 		if danger < safestSpotDanger {
@@ -914,15 +1006,15 @@ func (p *computerPlayer) maybeRetreatFrom(colony *computerColony, pos gmath.Vec)
 			if other == colony.node || other.agents.TotalNum() < 20 || other.mode != colonyModeNormal {
 				return false
 			}
-			if other.pos.DistanceSquaredTo(colony.node.pos) < (180 * 180) {
+			if detmath.DistanceSquaredTo(other.pos, colony.node.pos) < (180 * 180) {
 				return false
 			}
-			stepPos := colony.node.pos.MoveTowards(other.pos, 1.1*colony.node.MaxFlyDistance())
+			stepPos := detmath.MoveTowards(colony.node.pos, other.pos, 1.1*colony.node.MaxFlyDistance())
 			stepDanger, _ := p.calcPosDanger(stepPos, 300)
 			return int(0.9*float64(stepDanger)) <= safestSpotDanger
 		})
 		if otherColony != nil {
-			p.executeMoveAction(colony.node, otherColony.pos.Add(p.world.rand.Offset(-96, 96)))
+			p.executeMoveAction(colony.node, otherColony.pos.Add(detmath.Offset(p.world.rand, -96, 96)))
 			return 5
 		}
 	}
@@ -933,9 +1025,9 @@ func (p *computerPlayer) maybeRetreatFrom(colony *computerColony, pos gmath.Vec)
 	}
 
 	// Just run away from the immediate threat.
-	retreatAngle := pos.AngleToPoint(colony.node.pos) + gmath.Rad(p.world.rand.FloatRange(-0.35, 0.35))
-	retreatDir := gmath.RadToVec(retreatAngle)
-	p.executeMoveAction(colony.node, retreatDir.Mulf(colony.node.MaxFlyDistance()).Add(colony.node.pos))
+	retreatAngle := detmath.AngleToPoint(pos, colony.node.pos) + gmath.Rad(detmath.FloatRange(p.world.rand, -0.35, 0.35))
+	retreatDir := detmath.RadToVec(retreatAngle)
+	p.executeMoveAction(colony.node, detmath.AddScaled(colony.node.pos, retreatDir, colony.node.MaxFlyDistance()))
 	return 5
 }
 
@@ -944,12 +1036,13 @@ func (p *computerPlayer) maybeBuildColony(colony *computerColony) bool {
 		return false
 	}
 
-	if colony.node.NumAgents() < 20 || colony.node.realRadius < p.minRadiusBeforeColony {
+	expansion := p.profile.Expansion
+	if float64(colony.node.NumAgents()) < 20/expansion || colony.node.realRadius < p.minRadiusBeforeColony {
 		return false
 	}
 
 	if p.world.boss != nil {
-		if p.world.boss.pos.DistanceTo(colony.node.pos) < 320 {
+		if detmath.DistanceTo(p.world.boss.pos, colony.node.pos) < 320 {
 			return false
 		}
 		pathDist := pointToLineDistance(colony.node.pos, p.world.boss.pos, p.world.boss.waypoint)
@@ -959,8 +1052,8 @@ func (p *computerPlayer) maybeBuildColony(colony *computerColony) bool {
 	}
 
 	currentResourcesScore, _ := p.calcPosResources(colony.node, colony.node.pos, colony.node.realRadius*0.7)
-	canBuild := (float64(currentResourcesScore) >= 200 && (colony.node.resources*p.world.rand.FloatRange(0.8, 1.2)) > 170) ||
-		((float64(currentResourcesScore) * p.world.rand.FloatRange(0.8, 1.2)) >= 400) ||
+	canBuild := (float64(currentResourcesScore) >= 200 && (colony.node.resources*detmath.FloatRange(p.world.rand, 0.8, 1.2)) > 170/expansion) ||
+		((float64(currentResourcesScore) * detmath.FloatRange(p.world.rand, 0.8, 1.2)) >= 400) ||
 		(colony.node.stats == gamedata.TankCoreStats && len(p.state.colonies) < 3 && (colony.node.resources >= colony.node.maxVisualResources()*0.85) && colony.node.agents.NumAvailableWorkers() >= 15) ||
 		(colony.node.resources > 100 && colony.node.agents.NumAvailableWorkers() >= 30 && p.world.rand.Chance(0.1))
 	if !canBuild {
@@ -969,15 +1062,15 @@ func (p *computerPlayer) maybeBuildColony(colony *computerColony) bool {
 
 	success := p.tryExecuteAction(colony.node, 4, gmath.Vec{})
 	if success {
-		colony.moveDelay += p.world.rand.FloatRange(30, 100)
+		colony.moveDelay += detmath.FloatRange(p.world.rand, 30, 100)
 	}
 	return success
 }
 
 func (p *computerPlayer) maybeBuildTurret(colony *computerColony) bool {
 	currentResourcesScore, _ := p.calcPosResources(colony.node, colony.node.pos, colony.node.realRadius*0.7)
-	canBuild := (float64(currentResourcesScore) >= 100 && (colony.node.resources*p.world.rand.FloatRange(0.8, 1.2)) > (140*p.turretCostMultiplier)) ||
-		((float64(currentResourcesScore) * p.world.rand.FloatRange(0.8, 1.2)) >= 250) ||
+	canBuild := (float64(currentResourcesScore) >= 100 && (colony.node.resources*detmath.FloatRange(p.world.rand, 0.8, 1.2)) > (140*p.turretCostMultiplier/p.profile.TurretPreference)) ||
+		((float64(currentResourcesScore) * detmath.FloatRange(p.world.rand, 0.8, 1.2)) >= 250) ||
 		(colony.node.resources > 80 && colony.node.agents.NumAvailableWorkers() > 30 && p.world.rand.Chance(0.15))
 	if !canBuild {
 		return false
@@ -985,12 +1078,70 @@ func (p *computerPlayer) maybeBuildTurret(colony *computerColony) bool {
 
 	success := p.tryExecuteAction(colony.node, 4, gmath.Vec{})
 	if success {
-		colony.moveDelay += p.world.rand.FloatRange(20, 35)
+		colony.moveDelay += detmath.FloatRange(p.world.rand, 20, 35)
 	}
 	return success
 }
 
 func (p *computerPlayer) maybeUseSpecial(colony *computerColony) bool {
+	if p.choiceSelection.special.special == specialBurrow {
+		if colony.node.burrowTime == 0 && colony.node.health < colony.node.maxHealth*0.6 {
+			danger, _ := p.calcPosDanger(colony.node.pos, colony.node.realRadius)
+			if danger > 0 {
+				return p.tryExecuteAction(colony.node, 4, gmath.Vec{})
+			}
+		}
+	}
+
+	if p.choiceSelection.special.special == specialRecall {
+		danger, _ := p.calcPosDanger(colony.node.pos, colony.node.realRadius)
+		if danger > 0 {
+			numAway := 0
+			farDistSqr := (colony.node.realRadius * 1.5) * (colony.node.realRadius * 1.5)
+			for _, a := range colony.node.agents.fighters {
+				if detmath.DistanceSquaredTo(a.pos, colony.node.pos) > farDistSqr {
+					numAway++
+				}
+			}
+			if numAway >= 4 {
+				return p.tryExecuteAction(colony.node, 4, gmath.Vec{})
+			}
+		}
+	}
+
+	if p.choiceSelection.special.special == specialResearch {
+		// The research card shows the tech options;
+		// the first affordable one is picked right away.
+		for i, tech := range colony.node.ResearchOptions() {
+			if !colony.node.CanResearch(tech) {
+				continue
+			}
+			if p.world.rand.Chance(0.7) {
+				return p.tryExecuteAction(colony.node, 4, gmath.Vec{}) &&
+					p.tryExecuteAction(colony.node, i, gmath.Vec{})
+			}
+			break
+		}
+	}
+
+	if p.choiceSelection.special.special == specialUpgradeTurret {
+		turret, _ := colony.node.NextTurretUpgrade(gmath.Vec{}, turretUpgradeAuto)
+		if turret != nil && colony.node.CanUpgradeTurret(turret.pos, turretUpgradeAuto) && colony.node.resources > 80 && p.world.rand.Chance(0.6) {
+			return p.tryExecuteAction(colony.node, 4, turret.pos)
+		}
+	}
+
+	if p.choiceSelection.special.special == specialRecycleTurret {
+		// Only recycle the turrets that were left behind after the relocation.
+		turret := colony.node.NextTurretToRecycle(gmath.Vec{})
+		if turret != nil {
+			maxDist := colony.node.realRadius * 2
+			if detmath.DistanceSquaredTo(turret.pos, colony.node.pos) > maxDist*maxDist {
+				return p.tryExecuteAction(colony.node, 4, turret.pos)
+			}
+		}
+	}
+
 	if p.choiceSelection.special.special == specialIncreaseRadius {
 		increaseRadius := (colony.node.resources > 100 && colony.node.realRadius < p.colonyTargetRadius) ||
 			(colony.node.realRadius < 200 && p.world.rand.Chance(0.5))
@@ -1040,6 +1191,10 @@ func (p *computerPlayer) maybeUseSpecial(colony *computerColony) bool {
 				if danger != 0 && power > 2*danger {
 					return p.tryExecuteAction(colony.node, 4, gmath.Vec{})
 				}
+				enemyPower := p.calcPosEnemyPower(colony.node, colony.node.pos, colony.node.AttackRadius())
+				if enemyPower != 0 && float64(power)*p.profile.Aggression > float64(enemyPower) {
+					return p.tryExecuteAction(colony.node, 4, gmath.Vec{})
+				}
 			}
 		}
 
@@ -1071,6 +1226,7 @@ func (p *computerPlayer) maybeChangePriorities(colony *computerColony) bool {
 	if colony.node.factionWeights.GetWeight(gamedata.NeutralFactionTag) > 0.5 {
 		randomCardChance = 0.7
 	}
+	randomCardChance += p.profile.MistakeRate
 	if p.world.rand.Chance(randomCardChance) {
 		// Use a random card.
 		return p.tryExecuteAction(colony.node, p.world.rand.IntRange(0, 3), gmath.Vec{})
@@ -1098,7 +1254,7 @@ func (p *computerPlayer) maybeChangePriorities(colony *computerColony) bool {
 	c := colony.node
 
 	if c.resources < 50 && len(p.resourceCards) != 0 {
-		increaseResourcesChance := gmath.Clamp(1.0-(p.world.rand.FloatRange(0.8, 1.2)*c.GetResourcePriority()), 0, 1)
+		increaseResourcesChance := gmath.Clamp(1.0-float64(detmath.FloatRange(p.world.rand, 0.8, 1.2)*c.GetResourcePriority()), 0, 1)
 		if p.world.rand.Chance(increaseResourcesChance) {
 			return p.tryExecuteAction(colony.node, gmath.RandElem(p.world.rand, p.resourceCards), gmath.Vec{})
 		}
@@ -1111,7 +1267,7 @@ func (p *computerPlayer) maybeChangePriorities(colony *computerColony) bool {
 			(2*c.NumAgents() < c.calcUnitLimit()) ||
 			(c.resources >= (c.maxVisualResources() * 0.85))
 		if needMoreGrowth {
-			increaseGrowthChance := gmath.Clamp(0.1+(1.0-(p.world.rand.FloatRange(0.9, 1.2)*c.GetGrowthPriority())), 0, 1)
+			increaseGrowthChance := gmath.Clamp(0.1+(1.0-float64(detmath.FloatRange(p.world.rand, 0.9, 1.2)*c.GetGrowthPriority())), 0, 1)
 			if p.world.rand.Chance(increaseGrowthChance) {
 				return p.tryExecuteAction(colony.node, gmath.RandElem(p.world.rand, p.growthCards), gmath.Vec{})
 			}
@@ -1122,7 +1278,7 @@ func (p *computerPlayer) maybeChangePriorities(colony *computerColony) bool {
 		needMoreEvolution := (c.agents.tier2Num >= 4 && c.agents.tier3Num < 15) ||
 			(c.agents.tier2Num < 5 && c.GetEvolutionPriority() < 0.05)
 		if needMoreEvolution {
-			increaseElolutionChance := gmath.Clamp(0.1+(1.0-(p.world.rand.FloatRange(0.7, 1.0)*c.GetGrowthPriority())), 0, 1)
+			increaseElolutionChance := gmath.Clamp(0.1+(1.0-float64(detmath.FloatRange(p.world.rand, 0.7, 1.0)*c.GetGrowthPriority())), 0, 1)
 			if p.world.rand.Chance(increaseElolutionChance) {
 				return p.tryExecuteAction(colony.node, gmath.RandElem(p.world.rand, p.evolutionCards), gmath.Vec{})
 			}
@@ -1139,11 +1295,11 @@ func (p *computerPlayer) maybeChangePriorities(colony *computerColony) bool {
 }
 
 func (p *computerPlayer) maybeMoveColony(colony *computerColony) float64 {
-	resourcesReach := colony.node.realRadius*0.4 + 100
+	resourcesReach := float64(colony.node.realRadius*0.4) + 100
 	resourcesScore, _ := p.calcPosResources(colony.node, colony.node.pos, resourcesReach)
 
 	// Reason to move 1: swarmed by enemies.
-	colonyPower := int(float64(p.selectedColonyPower(gamedata.TargetAny)) * p.world.rand.FloatRange(0.9, 1.1))
+	colonyPower := int(float64(p.selectedColonyPower(gamedata.TargetAny)) * detmath.FloatRange(p.world.rand, 0.9, 1.1))
 	danger, dangerousPos := p.calcPosDanger(colony.node.pos, colony.node.realRadius+100)
 	doRetreat := (danger >= p.world.rand.IntRange(700, 1000)) ||
 		(colony.retreatPos.IsZero() && danger > (int(2.2*float64(colonyPower))+15) && colony.node.resources < 120) ||
@@ -1179,17 +1335,17 @@ func (p *computerPlayer) maybeMoveColony(colony *computerColony) float64 {
 	// Reason to move 3: can capture something.
 	if len(p.world.neutralBuildings) != 0 && p.captureDelay == 0 && colony.node.resources >= 130 && p.world.rand.Chance(0.4) {
 		b := randIterate(p.world.rand, p.world.neutralBuildings, func(b *neutralBuildingNode) bool {
-			return b.agent == nil && b.pos.DistanceSquaredTo(colony.node.pos) < colony.node.MaxFlyDistanceSqr()+96
+			return b.CanBeCapturedBy(colony.node) && detmath.DistanceSquaredTo(b.pos, colony.node.pos) < colony.node.MaxFlyDistanceSqr()+96
 		})
 		if b != nil {
 			danger, _ := p.calcPosDangerWithHazards(colony.node.pos, colony.node.realRadius+100)
 			if danger < 2*p.selectedColonyPower(gamedata.TargetAny) {
-				p.captureDelay = p.world.rand.FloatRange(50, 100)
-				p.executeMoveAction(colony.node, b.pos.Add(p.world.rand.Offset(-128, 128)))
+				p.captureDelay = detmath.FloatRange(p.world.rand, 50, 100)
+				p.executeMoveAction(colony.node, b.pos.Add(detmath.Offset(p.world.rand, -128, 128)))
 				colony.retreatPos = gmath.Vec{}
 				return 70
 			} else {
-				p.captureDelay = p.world.rand.FloatRange(15, 30)
+				p.captureDelay = detmath.FloatRange(p.world.rand, 15, 30)
 			}
 		}
 	}
@@ -1198,8 +1354,8 @@ func (p *computerPlayer) maybeMoveColony(colony *computerColony) float64 {
 	// This is just inconvenient for the player.
 	if !p.world.innerRect.Contains(colony.node.pos) && p.world.rand.Chance(0.9) {
 		pos := randomSectorPos(p.world.rand, p.world.innerRect)
-		dir := gmath.RadToVec(colony.node.pos.AngleToPoint(pos))
-		candidatePos := dir.Mulf(colony.node.MaxFlyDistance()).Add(colony.node.pos)
+		dir := detmath.RadToVec(detmath.AngleToPoint(colony.node.pos, pos))
+		candidatePos := detmath.AddScaled(colony.node.pos, dir, colony.node.MaxFlyDistance())
 		danger, _ := p.calcPosDanger(candidatePos, colony.node.PatrolRadius()+100)
 		power := p.selectedColonyPower(gamedata.TargetAny)
 		if danger < power/3 {
@@ -1236,12 +1392,12 @@ func (p *computerPlayer) calcColonyPower(c *colonyCoreNode, targetFlags gamedata
 		droneScore := power
 		switch a.rank {
 		case 1:
-			droneScore += power * 0.25
+			droneScore += float64(power * 0.25)
 		case 2:
-			droneScore += power * 0.5
+			droneScore += float64(power * 0.5)
 		}
 		if a.faction == gamedata.RedFactionTag {
-			droneScore += power * 0.1
+			droneScore += float64(power * 0.1)
 		}
 		droneScore *= ((a.health / a.maxHealth) + 0.2) * p.world.dronePowerMultiplier
 		score += int(droneScore)
@@ -1261,13 +1417,9 @@ func (p *computerPlayer) calcPosResources(colony *colonyCoreNode, pos gmath.Vec,
 	resourcesScore := 0
 	bestResource := 0
 	var bestResourcePos gmath.Vec
-	rSqr := r * r
-	for _, res := range p.world.essenceSources {
+	p.world.essenceIndex.WalkRadius(pos, r, func(res *essenceSourceNode) bool {
 		if res.beingHarvested {
-			continue
-		}
-		if res.pos.DistanceSquaredTo(pos) > rSqr {
-			continue
+			return false
 		}
 		score := int(res.stats.value) * res.resource
 		if res.stats == redCrystalSource {
@@ -1295,19 +1447,30 @@ func (p *computerPlayer) calcPosResources(colony *colonyCoreNode, pos gmath.Vec,
 			bestResourcePos = res.pos
 		}
 		resourcesScore += score
-	}
+		return false
+	})
 	return resourcesScore, bestResourcePos
 }
 
+// probePosResources is calcPosResources for the strategic location probes.
+// Most of the probes are far away from the colony and have no resources around,
+// the influence map allows us to skip the essence index walk for them.
+func (p *computerPlayer) probePosResources(colony *colonyCoreNode, pos gmath.Vec, r float64) (int, gmath.Vec) {
+	if p.world.influenceMap.Resources(pos, r) == 0 {
+		return 0, gmath.Vec{}
+	}
+	return p.calcPosResources(colony, pos, r)
+}
+
 func (p *computerPlayer) findRandomResourcesSpot(colony *computerColony) gmath.Vec {
 	pos := randomSectorPos(p.world.rand, p.world.innerRect)
-	waypointPos := colony.node.pos.MoveTowards(pos, colony.node.MaxFlyDistance())
+	waypointPos := detmath.MoveTowards(colony.node.pos, pos, colony.node.MaxFlyDistance())
 	danger, _ := p.calcPosDangerWithHazards(waypointPos, colony.node.PatrolRadius()+100)
 	power := p.selectedColonyPower(gamedata.TargetAny)
 	if 2*danger > power {
 		return gmath.Vec{}
 	}
-	score, _ := p.calcPosResources(colony.node, pos, colony.node.realRadius*0.5+120)
+	score, _ := p.probePosResources(colony.node, pos, float64(colony.node.realRadius*0.5)+120)
 	if score < 50 {
 		return gmath.Vec{}
 	}
@@ -1318,24 +1481,24 @@ func (p *computerPlayer) findBestResourcesSpot(colony *computerColony, maxDanger
 	// Project several random lines and see whether any of these
 	// lead us somewhere good.
 
-	resourcesReach := colony.node.realRadius*0.5 + 120
+	resourcesReach := float64(colony.node.realRadius*0.5) + 120
 
 	bestScore := currentScore
 	var bestScorePos gmath.Vec
-	currentAngle := p.world.rand.Rad()
+	currentAngle := detmath.RandRad(p.world.rand)
 	const numProbes = 8
 	currentDist := r / 4
 	for j := 0; j < 4; j++ {
 		for i := 0; i < numProbes; i++ {
-			dir := gmath.RadToVec(currentAngle)
+			dir := detmath.RadToVec(currentAngle)
 			currentAngle += (2 * math.Pi) / numProbes
-			dist := currentDist * p.world.rand.FloatRange(0.8, 1.1)
-			candidatePos := dir.Mulf(dist).Add(colony.node.pos)
-			score, bestResPos := p.calcPosResources(colony.node, candidatePos, resourcesReach)
+			dist := currentDist * detmath.FloatRange(p.world.rand, 0.8, 1.1)
+			candidatePos := detmath.AddScaled(colony.node.pos, dir, dist)
+			score, bestResPos := p.probePosResources(colony.node, candidatePos, resourcesReach)
 			if score > bestScore {
-				checkedSpot := bestResPos.Add(p.world.rand.Offset(-32, 32))
+				checkedSpot := bestResPos.Add(detmath.Offset(p.world.rand, -32, 32))
 				danger, _ := p.calcPosDangerWithHazards(checkedSpot, colony.node.PatrolRadius()+260)
-				if !colony.retreatPos.IsZero() && checkedSpot.DistanceSquaredTo(colony.retreatPos) < (260*260) {
+				if !colony.retreatPos.IsZero() && detmath.DistanceSquaredTo(checkedSpot, colony.retreatPos) < (260*260) {
 					danger += 60
 				}
 				if danger <= maxDanger {
@@ -1353,7 +1516,23 @@ func (p *computerPlayer) findBestResourcesSpot(colony *computerColony, maxDanger
 				}
 			}
 		}
-		currentDist += r / 4
+		currentDist += float64(r / 4)
+	}
+
+	// The random probes can miss a rich spot that is located between them.
+	// The influence map knows where such spots are.
+	if cellPos, _ := p.world.influenceMap.FindResourcesCell(colony.node.pos, r, maxDanger); !cellPos.IsZero() {
+		score, _ := p.calcPosResources(colony.node, cellPos, resourcesReach)
+		if score > bestScore {
+			danger, _ := p.calcPosDangerWithHazards(cellPos, colony.node.PatrolRadius()+260)
+			if !colony.retreatPos.IsZero() && detmath.DistanceSquaredTo(cellPos, colony.retreatPos) < (260*260) {
+				danger += 60
+			}
+			if danger <= maxDanger {
+				bestScore = score
+				bestScorePos = cellPos
+			}
+		}
 	}
 
 	// Now check if there are any teleporters around.
@@ -1363,7 +1542,7 @@ func (p *computerPlayer) findBestResourcesSpot(colony *computerColony, maxDanger
 		if danger > maxDanger {
 			return false
 		}
-		score, _ := p.calcPosResources(colony.node, tp.other.pos, resourcesReach)
+		score, _ := p.probePosResources(colony.node, tp.other.pos, resourcesReach)
 		if score > bestScore {
 			bestScore = score
 			bestScorePos = tp.pos
@@ -1376,7 +1555,7 @@ func (p *computerPlayer) findBestResourcesSpot(colony *computerColony, maxDanger
 
 func (p *computerPlayer) moveColonyToResources(currentResourcesScore int, colony *computerColony) float64 {
 	resourcesReach := colony.node.MaxFlyDistance() + 60
-	currentSpotScore := int(float64(currentResourcesScore) * p.world.rand.FloatRange(0.9, 1.25))
+	currentSpotScore := int(float64(currentResourcesScore) * detmath.FloatRange(p.world.rand, 0.9, 1.25))
 
 	colonyPower := p.selectedColonyPower(gamedata.TargetAny)
 
@@ -1402,19 +1581,19 @@ func (p *computerPlayer) moveColonyToResources(currentResourcesScore int, colony
 	bestScorePos = correctedPos(p.world.innerRect2, bestScorePos, 0)
 	var minDist float64
 	switch colony.node.stats {
-	case gamedata.DenCoreStats:
+	case gamedata.DenCoreStats, gamedata.HiveCoreStats, gamedata.BeaconCoreStats:
 		minDist = 180
 	case gamedata.TankCoreStats:
 		minDist = 100
 	case gamedata.ArkCoreStats:
 		minDist = 70
 	}
-	if minDist != 0 && bestScorePos.DistanceTo(colony.node.pos) < minDist {
+	if minDist != 0 && detmath.DistanceTo(bestScorePos, colony.node.pos) < minDist {
 		return 0
 	}
 
 	nextMoveDelay := 55.0
-	if bestScorePos.DistanceSquaredTo(colony.node.pos) > 1.25*colony.node.MaxFlyDistanceSqr() {
+	if detmath.DistanceSquaredTo(bestScorePos, colony.node.pos) > 1.25*colony.node.MaxFlyDistanceSqr() {
 		nextMoveDelay = 5
 	}
 
@@ -1443,7 +1622,7 @@ func (p *computerPlayer) calcPosDangerWithHazards(pos gmath.Vec, r float64) (int
 	if p.world.envKind == gamedata.EnvInferno {
 		geyserSafeDist := r * 0.9
 		for _, geyser := range p.world.lavaGeysers {
-			dist := geyser.pos.DistanceTo(pos)
+			dist := detmath.DistanceTo(geyser.pos, pos)
 			if dist < geyserSafeDist {
 				// dist=0   => 350
 				// dist=100 => 175
@@ -1455,7 +1634,7 @@ func (p *computerPlayer) calcPosDangerWithHazards(pos gmath.Vec, r float64) (int
 			}
 		}
 		for _, puddle := range p.world.lavaPuddles {
-			if puddle.centerPos.DistanceTo(pos) < r {
+			if detmath.DistanceTo(puddle.centerPos, pos) < r {
 				if p.hasRepairbots {
 					danger += 4
 				} else {
@@ -1482,7 +1661,7 @@ func (p *computerPlayer) findUsableTeleporter(colony *colonyCoreNode, f func(*te
 	}
 
 	return randIterate(p.world.rand, p.world.teleporters, func(tp *teleporterNode) bool {
-		if tp.pos.DistanceSquaredTo(colony.pos) > 0.9*colony.MaxFlyDistanceSqr() {
+		if detmath.DistanceSquaredTo(tp.pos, colony.pos) > 0.9*colony.MaxFlyDistanceSqr() {
 			return false
 		}
 		if !tp.CanBeUsedBy(colony) {
@@ -6,6 +6,7 @@ import (
 	"github.com/quasilyte/gmath"
 	"github.com/quasilyte/gsignal"
 	"github.com/quasilyte/roboden-game/assets"
+	"github.com/quasilyte/roboden-game/detmath"
 	"github.com/quasilyte/roboden-game/gamedata"
 )
 
@@ -136,7 +137,7 @@ func (c *constructionNode) IsDisposed() bool {
 
 func (c *constructionNode) Update(delta float64) {
 	c.constructPosBase = c.pos.Add(gmath.Vec{
-		Y: (c.maxBuildHeight * (1.0 - c.progress)) - c.initialBuildHeight,
+		Y: float64(c.maxBuildHeight*(1.0-c.progress)) - c.initialBuildHeight,
 	})
 	c.attention = gmath.ClampMin(c.attention-delta, 0)
 }
@@ -146,7 +147,7 @@ func (c *constructionNode) GetConstructPos() ge.Pos {
 	return ge.Pos{
 		Base: &c.constructPosBase,
 		// TODO: use local rand?
-		Offset: gmath.Vec{X: c.world.rand.FloatRange(-xdelta, xdelta)},
+		Offset: gmath.Vec{X: detmath.FloatRange(c.world.rand, -xdelta, xdelta)},
 	}
 }
 
@@ -162,8 +163,8 @@ func (c *constructionNode) GetTargetInfo() targetInfo {
 func (c *constructionNode) IsFlying() bool { return false }
 
 func (c *constructionNode) OnDamage(damage gamedata.DamageValue, source targetable) {
-	c.progress -= damage.Health * c.stats.DamageModifier
-	xdelta := c.sprite.ImageWidth() * 0.3
+	c.progress -= float64(damage.Health * c.stats.DamageModifier)
+	xdelta := float64(c.sprite.ImageWidth() * 0.3)
 	if c.progress < 0 {
 		rect := gmath.Rect{
 			Min: c.constructPosBase.Sub(gmath.Vec{X: xdelta, Y: 8}),
@@ -174,14 +175,14 @@ func (c *constructionNode) OnDamage(damage gamedata.DamageValue, source targetab
 		return
 	}
 	// TODO: use local rand?
-	explosionOffset := c.world.rand.FloatRange(-xdelta, xdelta)
-	explosionPos := c.constructPosBase.Add(gmath.Vec{X: explosionOffset, Y: c.world.rand.FloatRange(0, 4)})
+	explosionOffset := detmath.FloatRange(c.world.rand, -xdelta, xdelta)
+	explosionPos := c.constructPosBase.Add(gmath.Vec{X: explosionOffset, Y: detmath.FloatRange(c.world.rand, 0, 4)})
 	createExplosion(c.world, normalEffectLayer, explosionPos)
 	c.sprite.Shader.SetFloatValue("Time", c.progress)
 }
 
 func (c *constructionNode) Construct(v float64, builder *colonyCoreNode) bool {
-	c.progress += v * c.stats.ConstructionSpeed
+	c.progress += float64(v * c.stats.ConstructionSpeed)
 	if c.progress >= 1 {
 		c.done(builder)
 		return true
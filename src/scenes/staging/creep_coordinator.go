@@ -2,6 +2,7 @@ package staging
 
 import (
 	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/roboden-game/detmath"
 	"github.com/quasilyte/roboden-game/gamedata"
 )
 
@@ -32,9 +33,9 @@ func newCreepCoordinator(world *worldState) *creepCoordinator {
 		world:         world,
 		crawlers:      make([]*creepNode, 0, 16),
 		groupSlice:    make([]*creepNode, 0, 48),
-		attackDelay:   world.rand.FloatRange(10, 30),
-		scatterDelay:  world.rand.FloatRange(2*60, 3*60),
-		relocateDelay: world.rand.FloatRange(1*60, 3*60),
+		attackDelay:   detmath.FloatRange(world.rand, 10, 30),
+		scatterDelay:  detmath.FloatRange(world.rand, 2*60, 3*60),
+		relocateDelay: detmath.FloatRange(world.rand, 1*60, 3*60),
 	}
 }
 
@@ -74,54 +75,56 @@ func (c *creepCoordinator) Update(delta float64) {
 func (c *creepCoordinator) sendScout() {
 	scout := gmath.RandElem(c.world.rand, c.crawlers)
 	if scout.specialModifier != crawlerIdle {
-		c.scoutingDelay = c.world.rand.FloatRange(1.6, 3)
+		c.scoutingDelay = detmath.FloatRange(c.world.rand, 1.6, 3)
 		return
 	}
 
 	if c.world.rand.Chance(0.35) {
-		c.scoutingDelay = c.world.rand.FloatRange(4, 8.5)
+		c.scoutingDelay = detmath.FloatRange(c.world.rand, 4, 8.5)
 		return
 	}
 
 	if c.world.config.GameMode == gamedata.ModeArena {
-		c.scoutingDelay = c.world.rand.FloatRange(20.0, 30.0)
+		c.scoutingDelay = detmath.FloatRange(c.world.rand, 20.0, 30.0)
 	} else {
-		c.scoutingDelay = c.world.rand.FloatRange(30.0, 50.0)
+		c.scoutingDelay = detmath.FloatRange(c.world.rand, 30.0, 50.0)
 	}
 
-	scoutingDist := 320 * c.world.rand.FloatRange(1, 2)
-	scoutingDest := gmath.RadToVec(c.world.rand.Rad()).Mulf(scoutingDist).Add(scout.pos)
+	scoutingDist := 320 * detmath.FloatRange(c.world.rand, 1, 2)
+	scoutingDest := detmath.AddScaled(scout.pos, detmath.RadToVec(detmath.RandRad(c.world.rand)), scoutingDist)
 	scout.specialModifier = crawlerMove
 	scout.waypoint = c.world.pathgrid.AlignPos(scout.pos)
 	p := c.world.BuildPath(scout.waypoint, scoutingDest, layerNormal)
+	scout.leaveGroup()
 	scout.path = p.Steps
 }
 
 func (c *creepCoordinator) tryLaunchingRelocation() {
 	leader := gmath.RandElem(c.world.rand, c.crawlers)
 	if leader.specialModifier != crawlerIdle {
-		c.relocateDelay = c.world.rand.FloatRange(3, 8)
+		c.relocateDelay = detmath.FloatRange(c.world.rand, 3, 8)
 		return
 	}
 
 	group := c.collectGroup(leader.pos, false, 300, 10, 20)
 	if len(group) < 2 {
-		c.relocateDelay = c.world.rand.FloatRange(4, 10)
+		c.relocateDelay = detmath.FloatRange(c.world.rand, 4, 10)
 		return
 	}
 
 	if c.world.config.GameMode == gamedata.ModeArena {
-		c.relocateDelay = c.world.rand.FloatRange(25, 55)
+		c.relocateDelay = detmath.FloatRange(c.world.rand, 25, 55)
 	} else {
-		c.relocateDelay = c.world.rand.FloatRange(60, 90)
+		c.relocateDelay = detmath.FloatRange(c.world.rand, 60, 90)
 	}
 
 	targetPos := correctedPos(c.world.rect, randomSectorPos(c.world.rand, c.world.rect), 480)
 	for _, creep := range group {
-		creepTargetPos := correctedPos(c.world.rect, targetPos.Add(c.world.rand.Offset(-96, 96)), 32)
+		creepTargetPos := correctedPos(c.world.rect, targetPos.Add(detmath.Offset(c.world.rand, -96, 96)), 32)
 
 		creep.specialModifier = crawlerMove
 		p := c.world.BuildPath(creep.pos, creepTargetPos, layerNormal)
+		creep.leaveGroup()
 		creep.path = p.Steps
 		creep.waypoint = c.world.pathgrid.AlignPos(creep.pos)
 	}
@@ -130,23 +133,23 @@ func (c *creepCoordinator) tryLaunchingRelocation() {
 func (c *creepCoordinator) tryLaunchingScatter() {
 	leader := gmath.RandElem(c.world.rand, c.crawlers)
 	if leader.specialModifier != crawlerIdle {
-		c.scatterDelay = c.world.rand.FloatRange(4, 10)
+		c.scatterDelay = detmath.FloatRange(c.world.rand, 4, 10)
 		return
 	}
 
 	group := c.collectGroup(leader.pos, false, 300, 2, 10)
 	if len(group) < 2 {
-		c.scatterDelay = c.world.rand.FloatRange(8, 14)
+		c.scatterDelay = detmath.FloatRange(c.world.rand, 8, 14)
 		return
 	}
 
 	switch c.world.config.GameMode {
 	case gamedata.ModeArena:
-		c.scatterDelay = c.world.rand.FloatRange(55, 85)
+		c.scatterDelay = detmath.FloatRange(c.world.rand, 55, 85)
 	case gamedata.ModeReverse:
-		c.scatterDelay = c.world.rand.FloatRange(90, 150)
+		c.scatterDelay = detmath.FloatRange(c.world.rand, 90, 150)
 	default:
-		c.scatterDelay = c.world.rand.FloatRange(70, 90)
+		c.scatterDelay = detmath.FloatRange(c.world.rand, 70, 90)
 	}
 
 	c.scatterCreeps(group)
@@ -159,7 +162,7 @@ func (c *creepCoordinator) Rally(pos gmath.Vec, maxRange float64) int {
 	var closestTarget *colonyCoreNode
 	var closestDistSqr float64
 	for _, colony := range c.world.allColonies {
-		distSqr := colony.pos.DistanceSquaredTo(pos)
+		distSqr := detmath.DistanceSquaredTo(colony.pos, pos)
 		if distSqr > maxAttackDistSqr {
 			continue
 		}
@@ -177,19 +180,41 @@ func (c *creepCoordinator) Rally(pos gmath.Vec, maxRange float64) int {
 }
 
 func (c *creepCoordinator) findColonyToAttack(pos gmath.Vec, r float64) *colonyCoreNode {
-	if len(c.world.allColonies) == 0 {
+	colonies := c.world.allColonies
+	if len(colonies) == 0 {
 		return nil
 	}
+
+	// Prefer the colonies with the weakest defences.
+	// The iteration starts from a random colony, so the equally
+	// defended colonies have equal chances to be attacked.
+	offset := 0
+	if len(colonies) > 1 {
+		offset = c.world.rand.IntRange(0, len(colonies)-1)
+	}
 	rSqr := r * r
-	return randIterate(c.world.rand, c.world.allColonies, func(c *colonyCoreNode) bool {
-		return c.pos.DistanceSquaredTo(pos) <= rSqr
-	})
+	var target *colonyCoreNode
+	lowestDefence := 0
+	for i := range colonies {
+		colony := colonies[(i+offset)%len(colonies)]
+		if detmath.DistanceSquaredTo(colony.pos, pos) > rSqr {
+			continue
+		}
+		// The creeps that are already around the colony make it an easier target.
+		defence := c.world.influenceMap.Control(colony.player.GetState().id, colony.pos, colony.PatrolRadius()) -
+			c.world.influenceMap.Threat(colony.pos, colony.PatrolRadius())
+		if target == nil || defence < lowestDefence {
+			target = colony
+			lowestDefence = defence
+		}
+	}
+	return target
 }
 
 func (c *creepCoordinator) tryAttackingRuins() {
 	leader := gmath.RandElem(c.world.rand, c.crawlers)
 	if leader.specialModifier != crawlerIdle {
-		c.attackRuinsDelay = c.world.rand.FloatRange(8, 20)
+		c.attackRuinsDelay = detmath.FloatRange(c.world.rand, 8, 20)
 		return
 	}
 
@@ -197,17 +222,17 @@ func (c *creepCoordinator) tryAttackingRuins() {
 		return b.agent != nil
 	})
 	if b == nil {
-		c.attackRuinsDelay = c.world.rand.FloatRange(20, 50)
+		c.attackRuinsDelay = detmath.FloatRange(c.world.rand, 20, 50)
 		return
 	}
 
 	group := c.collectGroup(leader.pos, false, 250, 2, 5)
 	if len(group) < 2 {
-		c.attackRuinsDelay = c.world.rand.FloatRange(10, 20)
+		c.attackRuinsDelay = detmath.FloatRange(c.world.rand, 10, 20)
 		return
 	}
 
-	c.attackRuinsDelay = c.world.rand.FloatRange(100, 200)
+	c.attackRuinsDelay = detmath.FloatRange(c.world.rand, 100, 200)
 	c.sendCreepsToAttack(group, b.pos)
 }
 
@@ -216,13 +241,13 @@ func (c *creepCoordinator) tryLaunchingAttack() {
 	leader := gmath.RandElem(c.world.rand, c.crawlers)
 	if leader.specialModifier != crawlerIdle {
 		// Bad leader pick attempt, try later.
-		c.attackDelay = c.world.rand.FloatRange(1.2, 2.6)
+		c.attackDelay = detmath.FloatRange(c.world.rand, 1.2, 2.6)
 		return
 	}
 
 	group := c.collectGroup(leader.pos, false, 300, 2, cap(c.groupSlice))
 
-	maxAttackRange := 1024.0 * c.world.rand.FloatRange(0.8, 1.2)
+	maxAttackRange := 1024.0 * detmath.FloatRange(c.world.rand, 0.8, 1.2)
 	if c.world.config.GameMode == gamedata.ModeArena {
 		maxAttackRange *= 1.5
 	}
@@ -231,7 +256,7 @@ func (c *creepCoordinator) tryLaunchingAttack() {
 	target := c.findColonyToAttack(leader.pos, maxAttackRange)
 	if target == nil {
 		// No reachable targets for this group, try later.
-		c.attackDelay = c.world.rand.FloatRange(4.5, 6.5)
+		c.attackDelay = detmath.FloatRange(c.world.rand, 4.5, 6.5)
 		return
 	}
 
@@ -240,11 +265,11 @@ func (c *creepCoordinator) tryLaunchingAttack() {
 	// The next action will be much later.
 	switch c.world.config.GameMode {
 	case gamedata.ModeArena:
-		c.attackDelay = c.world.rand.FloatRange(25.0, 55.0)
+		c.attackDelay = detmath.FloatRange(c.world.rand, 25.0, 55.0)
 	case gamedata.ModeReverse:
-		c.attackDelay = c.world.rand.FloatRange(65.0, 130.0)
+		c.attackDelay = detmath.FloatRange(c.world.rand, 65.0, 130.0)
 	default:
-		c.attackDelay = c.world.rand.FloatRange(30.0, 70.0)
+		c.attackDelay = detmath.FloatRange(c.world.rand, 30.0, 70.0)
 	}
 
 	c.sendCreepsToAttack(group, target.pos)
@@ -252,8 +277,8 @@ func (c *creepCoordinator) tryLaunchingAttack() {
 
 func (c *creepCoordinator) scatterCreeps(group []*creepNode) {
 	for _, creep := range group {
-		dist := c.world.rand.FloatRange(128, 400)
-		targetPos := gmath.RadToVec(c.world.rand.Rad()).Mulf(dist).Add(creep.pos)
+		dist := detmath.FloatRange(c.world.rand, 128, 400)
+		targetPos := detmath.AddScaled(creep.pos, detmath.RadToVec(detmath.RandRad(c.world.rand)), dist)
 		creep.SendTo(targetPos)
 		creep.wasRetreating = false
 	}
@@ -267,12 +292,12 @@ func (c *creepCoordinator) sendCreepsToAttack(group []*creepNode, targetPos gmat
 			minDist = 0.8
 			maxDist = 1.05
 		}
-		dist := c.world.rand.FloatRange(creep.stats.Weapon.AttackRange*minDist, creep.stats.Weapon.AttackRange*maxDist)
-		dir := gmath.RadToVec(c.world.rand.Rad()).Mulf(dist)
-		waypoint := dir.Add(targetPos)
+		dist := detmath.FloatRange(c.world.rand, creep.stats.Weapon.AttackRange*minDist, creep.stats.Weapon.AttackRange*maxDist)
+		dir := detmath.RadToVec(detmath.RandRad(c.world.rand))
+		waypoint := detmath.AddScaled(targetPos, dir, dist)
 		if c.world.HasTreesAt(waypoint, 0) {
 			// Try to find a better spot.
-			waypoint = dir.Mulf(-1).Add(targetPos)
+			waypoint = detmath.AddScaled(targetPos, dir, -dist)
 		}
 		creep.SendTo(waypoint)
 		creep.wasRetreating = false
@@ -301,7 +326,7 @@ func (c *creepCoordinator) collectGroup(pos gmath.Vec, guards bool, r float64, m
 		default:
 			continue
 		}
-		if creep.pos.DistanceSquaredTo(pos) > rSqr {
+		if detmath.DistanceSquaredTo(creep.pos, pos) > rSqr {
 			continue
 		}
 		group = append(group, creep)
@@ -8,8 +8,10 @@ import (
 	"github.com/quasilyte/gsignal"
 
 	"github.com/quasilyte/roboden-game/assets"
+	"github.com/quasilyte/roboden-game/detmath"
 	"github.com/quasilyte/roboden-game/gamedata"
 	"github.com/quasilyte/roboden-game/pathing"
+	"github.com/quasilyte/roboden-game/spatial"
 )
 
 const (
@@ -66,6 +68,14 @@ type creepNode struct {
 	bossStage int
 	fragScore int
 
+	// The ground creeps that move as a part of the big group share
+	// the flow field towards the group destination (groupDest).
+	// After reaching it, they walk to their own spot (groupSpot).
+	groupDest gmath.Vec
+	groupSpot gmath.Vec
+
+	spatialHandle spatial.Handle
+
 	EventDestroyed    gsignal.Event[*creepNode]
 	EventBuildingStop gsignal.Event[gsignal.Void]
 }
@@ -144,7 +154,7 @@ func (c *creepNode) Init(scene *ge.Scene) {
 		if c.super {
 			c.anim.SetOffsetY(c.sprite.FrameHeight)
 		}
-		c.anim.Tick(c.world.localRand.FloatRange(0, 0.7))
+		c.anim.Tick(detmath.FloatRange(c.world.localRand, 0, 0.7))
 	} else if c.super {
 		c.sprite.FrameOffset.Y = c.sprite.FrameHeight
 	}
@@ -177,17 +187,17 @@ func (c *creepNode) Init(scene *ge.Scene) {
 		}
 		c.world.centurionRallyPointPtr = &c.pos
 	case gamedata.CreepFortress:
-		c.specialDelay = c.scene.Rand().FloatRange(3.5*60, 4.5*60)
+		c.specialDelay = detmath.FloatRange(c.scene.Rand(), 3.5*60, 4.5*60)
 	case gamedata.CreepWispLair:
-		c.attackDelay = c.scene.Rand().FloatRange(30, 50)
+		c.attackDelay = detmath.FloatRange(c.scene.Rand(), 30, 50)
 	case gamedata.CreepWisp:
-		c.specialDelay = c.scene.Rand().FloatRange(2, 20)
+		c.specialDelay = detmath.FloatRange(c.scene.Rand(), 2, 20)
 	case gamedata.CreepServant:
-		c.specialDelay = c.scene.Rand().FloatRange(0.5, 3)
+		c.specialDelay = detmath.FloatRange(c.scene.Rand(), 0.5, 3)
 	case gamedata.CreepBuilder:
-		c.specialDelay = c.scene.Rand().FloatRange(15, 30)
+		c.specialDelay = detmath.FloatRange(c.scene.Rand(), 15, 30)
 	case gamedata.CreepCrawlerBase:
-		c.attackDelay = c.scene.Rand().FloatRange(5, 10)
+		c.attackDelay = detmath.FloatRange(c.scene.Rand(), 5, 10)
 	case gamedata.CreepTurretConstruction, gamedata.CreepCrawlerBaseConstruction:
 		if !c.world.simulation {
 			c.sprite.Shader = scene.NewShader(assets.ShaderCreepTurretBuild)
@@ -199,11 +209,11 @@ func (c *creepNode) Init(scene *ge.Scene) {
 		c.specialTarget = trunk
 		c.world.nodeRunner.AddObject(trunk)
 		trunk.SetVisibility(false)
-		c.specialDelay = c.scene.Rand().FloatRange(20, 30)
+		c.specialDelay = detmath.FloatRange(c.scene.Rand(), 20, 30)
 	case gamedata.CreepAssault:
 		c.specialModifier = 1 // Damage shield is available
 	case gamedata.CreepCenturion:
-		c.specialModifier = c.scene.Rand().FloatRange(10, 20)
+		c.specialModifier = detmath.FloatRange(c.scene.Rand(), 10, 20)
 		c.centurionReady = true
 	}
 
@@ -249,6 +259,13 @@ func (c *creepNode) Destroy() {
 func (c *creepNode) IsDisposed() bool { return c.disposed }
 
 func (c *creepNode) Update(delta float64) {
+	c.update(delta)
+	if !c.disposed {
+		c.world.updateCreepIndex(c)
+	}
+}
+
+func (c *creepNode) update(delta float64) {
 	c.flashComponent.Update(delta)
 
 	c.shield = gmath.ClampMin(c.shield-delta, 0)
@@ -258,7 +275,7 @@ func (c *creepNode) Update(delta float64) {
 	c.disarm = gmath.ClampMin(c.disarm-delta, 0)
 	c.attackDelay = gmath.ClampMin(c.attackDelay-delta, 0)
 	if c.stats.Weapon != nil && c.attackDelay == 0 && c.disarm == 0 && !c.cloaking && !c.insideForest {
-		c.attackDelay = c.stats.Weapon.Reload * c.scene.Rand().FloatRange(0.8, 1.2)
+		c.attackDelay = c.stats.Weapon.Reload * detmath.FloatRange(c.scene.Rand(), 0.8, 1.2)
 		targets := c.findTargets()
 		weapon := c.stats.Weapon
 		if c.super && c.stats.SuperWeapon != nil {
@@ -318,7 +335,7 @@ func (c *creepNode) GetVelocity() gmath.Vec {
 	if c.waypoint.IsZero() {
 		return gmath.Vec{}
 	}
-	return c.pos.VecTowards(c.waypoint, c.movementSpeed())
+	return detmath.VecTowards(c.pos, c.waypoint, c.movementSpeed())
 }
 
 func (c *creepNode) GetTargetInfo() targetInfo {
@@ -375,7 +392,7 @@ func (c *creepNode) explode() {
 		createAreaExplosion(c.world, spriteRect(c.pos, c.sprite), normalEffectLayer)
 		c.world.CreateScrapsAt(bigScrapCreepSource, c.pos.Add(gmath.Vec{Y: 4}))
 		for i := 0; i < 3; i++ {
-			c.world.CreateScrapsAt(scrapCreepSource, c.pos.Add(c.world.rand.Offset(-12, 12)))
+			c.world.CreateScrapsAt(scrapCreepSource, c.pos.Add(detmath.Offset(c.world.rand, -12, 12)))
 		}
 	case gamedata.CreepTurretConstruction, gamedata.CreepCrawlerBaseConstruction:
 		createExplosion(c.world, normalEffectLayer, c.pos)
@@ -426,7 +443,7 @@ func (c *creepNode) onHealthDamage(damage gamedata.DamageValue) bool {
 	}
 
 	if !damage.HasFlag(gamedata.DmgflagNoFlash) {
-		c.flashComponent.SetFlash(c.world.localRand.FloatRange(0.07, 0.14))
+		c.flashComponent.SetFlash(detmath.FloatRange(c.world.localRand, 0.07, 0.14))
 	}
 
 	c.health -= healthDamage
@@ -439,7 +456,7 @@ func (c *creepNode) onHealthDamage(damage gamedata.DamageValue) bool {
 	if c.stats == gamedata.AssaultCreepStats {
 		if c.health <= c.maxHealth*0.2 && c.specialModifier > 0 && c.world.rand.Chance(0.9) {
 			c.specialModifier = 0
-			c.shield = c.world.rand.FloatRange(3.0, 4.0)
+			c.shield = detmath.FloatRange(c.world.rand, 3.0, 4.0)
 			if c.super {
 				c.shield *= 2
 			}
@@ -512,10 +529,11 @@ func (c *creepNode) OnDamage(damage gamedata.DamageValue, source targetable) {
 		if c.specialModifier == crawlerGuard {
 			c.specialModifier = crawlerIdle
 		}
-		if c.specialModifier == crawlerIdle && (c.pos.DistanceTo(*source.GetPos()) > c.stats.Weapon.AttackRange*0.8) && c.world.rand.Chance(0.45) {
-			followPos := c.pos.MoveTowards(*source.GetPos(), 64*c.world.rand.FloatRange(0.8, 1.4))
+		if c.specialModifier == crawlerIdle && (detmath.DistanceTo(c.pos, *source.GetPos()) > c.stats.Weapon.AttackRange*0.8) && c.world.rand.Chance(0.45) {
+			followPos := detmath.MoveTowards(c.pos, *source.GetPos(), 64*detmath.FloatRange(c.world.rand, 0.8, 1.4))
 			p := c.world.BuildPath(c.pos, followPos, layerNormal)
 			c.specialModifier = crawlerMove
+			c.leaveGroup()
 			c.path = p.Steps
 			c.waypoint = c.world.pathgrid.AlignPos(c.pos)
 		}
@@ -586,7 +604,7 @@ func (c *creepNode) spawnServants(n int, colony *colonyCoreNode) {
 	angle := startAngle
 	for i := 0; i < n; i++ {
 		super := c.super && (i == 0 || i == n-1)
-		dir := gmath.RadToVec(angle)
+		dir := detmath.RadToVec(angle)
 		spawn := newServantSpawnerNode(c.world, c.pos, dir, colony)
 		spawn.super = super
 		c.world.nodeRunner.AddObject(spawn)
@@ -603,6 +621,9 @@ func (c *creepNode) doAttack(target targetable, weapon *gamedata.WeaponStats) {
 			burstSize += 2
 			burstDelay = 0.25
 		}
+		if c.stats == gamedata.StealthCrawlerCreepStats && c.world.worldEvents != nil && c.world.worldEvents.IsNight() {
+			burstSize++
+		}
 		targetVelocity := target.GetVelocity()
 		j := 0
 		seq := uint8(0)
@@ -616,7 +637,7 @@ func (c *creepNode) doAttack(target targetable, weapon *gamedata.WeaponStats) {
 			for i := 0; i < attacksPerBurst; i++ {
 				burstCorrectedPos := *target.GetPos()
 				if i != 0 {
-					burstCorrectedPos = burstCorrectedPos.Add(targetVelocity.Mulf(burstDelay))
+					burstCorrectedPos = detmath.AddScaled(burstCorrectedPos, targetVelocity, burstDelay)
 				}
 				toPos := snipePos(weapon.ProjectileSpeed, c.pos, burstCorrectedPos, targetVelocity)
 				fireDelay := float64(j) * burstDelay
@@ -625,7 +646,7 @@ func (c *creepNode) doAttack(target targetable, weapon *gamedata.WeaponStats) {
 					World:     c.world,
 					Weapon:    weapon,
 					Attacker:  c,
-					ToPos:     toPos.Add(c.scene.Rand().Offset(-4, 4)),
+					ToPos:     toPos.Add(detmath.Offset(c.scene.Rand(), -4, 4)),
 					Target:    target,
 					FireDelay: fireDelay,
 				})
@@ -646,7 +667,7 @@ func (c *creepNode) doAttack(target targetable, weapon *gamedata.WeaponStats) {
 		c.world.nodeRunner.AddObject(beam)
 		if c.stats.BeamExplosion != assets.ImageNone {
 			createEffect(c.world, effectConfig{
-				Pos:   target.GetPos().Add(c.world.localRand.Offset(-6, 6)),
+				Pos:   target.GetPos().Add(detmath.Offset(c.world.localRand, -6, 6)),
 				Layer: effectLayerFromBool(target.IsFlying()),
 				Image: c.stats.BeamExplosion,
 			})
@@ -654,10 +675,10 @@ func (c *creepNode) doAttack(target targetable, weapon *gamedata.WeaponStats) {
 	}
 
 	if c.stats.Kind == gamedata.CreepDominator {
-		targetDir := c.pos.DirectionTo(*target.GetPos())
+		targetDir := detmath.DirectionTo(c.pos, *target.GetPos())
 		const deg90rad = 1.5708
-		vec1 := targetDir.Rotated(deg90rad).Mulf(4)
-		vec2 := targetDir.Rotated(-deg90rad).Mulf(4)
+		vec1 := detmath.Rotated(targetDir, deg90rad).Mulf(4)
+		vec2 := detmath.Rotated(targetDir, -deg90rad).Mulf(4)
 
 		rearBeam1pos := ge.Pos{Base: &c.pos, Offset: vec1}
 		rearBeam1targetPos := ge.Pos{Base: target.GetPos(), Offset: vec2}
@@ -674,7 +695,7 @@ func (c *creepNode) doAttack(target targetable, weapon *gamedata.WeaponStats) {
 }
 
 func (c *creepNode) retreatFrom(pos gmath.Vec, minRange, maxRange float64) {
-	c.SendTo(retreatPos(c.scene.Rand(), c.scene.Rand().FloatRange(minRange, maxRange), c.pos, pos))
+	c.SendTo(retreatPos(c.scene.Rand(), detmath.FloatRange(c.scene.Rand(), minRange, maxRange), c.pos, pos))
 	c.wasAttacking = false
 }
 
@@ -684,7 +705,57 @@ func (c *creepNode) SendTo(pos gmath.Vec) {
 		return
 	}
 
-	p := c.world.BuildPath(c.pos, pos, layerNormal)
+	c.leaveGroup()
+	c.followPath(c.world.BuildPath(c.pos, pos, layerNormal))
+}
+
+// SendToWithGroup is like SendTo, but the ground creeps follow
+// the flow field that is shared by the entire group.
+func (c *creepNode) SendToWithGroup(groupDest, pos gmath.Vec) {
+	if c.IsFlying() {
+		c.setWaypoint(pos)
+		return
+	}
+
+	p := c.world.BuildFlowPath(c.pos, groupDest)
+	if p.Steps.Len() == 0 {
+		// Can't use the flow field from here.
+		c.SendTo(pos)
+		return
+	}
+	c.groupDest = groupDest
+	c.groupSpot = pos
+	c.followPath(p)
+}
+
+// continueGroupMove builds the next path leg of the group move.
+// It returns false if there is nowhere to go.
+func (c *creepNode) continueGroupMove() bool {
+	if c.groupDest.IsZero() {
+		return false
+	}
+
+	if c.world.pathgrid.PosToCoord(c.pos) != c.world.pathgrid.PosToCoord(c.groupDest) {
+		p := c.world.BuildFlowPath(c.pos, c.groupDest)
+		if p.Steps.Len() != 0 {
+			c.path = p.Steps
+			return true
+		}
+	}
+
+	// The group destination is reached (or it became unreachable).
+	p := c.world.BuildPath(c.pos, c.groupSpot, layerNormal)
+	c.leaveGroup()
+	c.path = p.Steps
+	return c.path.HasNext()
+}
+
+func (c *creepNode) leaveGroup() {
+	c.groupDest = gmath.Vec{}
+	c.groupSpot = gmath.Vec{}
+}
+
+func (c *creepNode) followPath(p pathing.BuildPathResult) {
 	c.path = p.Steps
 	c.waypoint = c.world.pathgrid.AlignPos(c.pos)
 	switch c.stats.Kind {
@@ -719,7 +790,7 @@ func (c *creepNode) findTargets() []targetable {
 
 	targets := c.world.tmpTargetSlice[:0]
 	if c.aggro > 0 && c.aggroTarget != nil {
-		if c.aggroTarget.IsDisposed() || c.pos.DistanceSquaredTo(*c.aggroTarget.GetPos()) > c.stats.Weapon.AttackRangeSqr {
+		if c.aggroTarget.IsDisposed() || detmath.DistanceSquaredTo(c.pos, *c.aggroTarget.GetPos()) > c.stats.Weapon.AttackRangeSqr {
 			c.aggroTarget = nil
 		} else {
 			targets = append(targets, c.aggroTarget)
@@ -743,11 +814,20 @@ func (c *creepNode) findTargets() []targetable {
 	}
 
 	if !skipGroundTargets {
+		for _, s := range c.world.scavengers {
+			if len(targets) >= maxTargets {
+				return targets
+			}
+			if detmath.DistanceSquaredTo(s.pos, c.pos) > c.stats.Weapon.AttackRangeSqr {
+				continue
+			}
+			targets = append(targets, s)
+		}
 		for _, colony := range c.world.constructions {
 			if len(targets) >= maxTargets {
 				return targets
 			}
-			if colony.pos.DistanceSquaredTo(c.pos) > c.stats.Weapon.AttackRangeSqr {
+			if detmath.DistanceSquaredTo(colony.pos, c.pos) > c.stats.Weapon.AttackRangeSqr {
 				continue
 			}
 			targets = append(targets, colony)
@@ -761,7 +841,7 @@ func (c *creepNode) findTargets() []targetable {
 		if !colony.IsFlying() && skipGroundTargets {
 			return false
 		}
-		if colony.pos.DistanceSquaredTo(c.pos) > c.stats.Weapon.AttackRangeSqr {
+		if detmath.DistanceSquaredTo(colony.pos, c.pos) > c.stats.Weapon.AttackRangeSqr {
 			return false
 		}
 		targets = append(targets, colony)
@@ -803,18 +883,18 @@ func (c *creepNode) wandererMovement(delta float64) {
 				return // Waiting for a game over?
 			}
 			colony := gmath.RandElem(c.scene.Rand(), c.world.allColonies)
-			c.setWaypoint(colony.pos.Add(c.scene.Rand().Offset(-200, 200)))
+			c.setWaypoint(colony.pos.Add(detmath.Offset(c.scene.Rand(), -200, 200)))
 			c.wasAttacking = true
 		} else if c.scene.Rand().Chance(0.3) {
 			// Go to a random screen location.
 			pos := gmath.Vec{
-				X: c.scene.Rand().FloatRange(0, c.world.width),
-				Y: c.scene.Rand().FloatRange(0, c.world.height),
+				X: detmath.FloatRange(c.scene.Rand(), 0, c.world.width),
+				Y: detmath.FloatRange(c.scene.Rand(), 0, c.world.height),
 			}
 			c.setWaypoint(pos)
 			c.wasAttacking = false
 		} else {
-			c.setWaypoint(c.pos.Add(c.scene.Rand().Offset(-100, 100)))
+			c.setWaypoint(c.pos.Add(detmath.Offset(c.scene.Rand(), -100, 100)))
 			c.wasAttacking = false
 		}
 	}
@@ -839,7 +919,7 @@ func (c *creepNode) updatePrimitiveWanderer(delta float64) {
 func (c *creepNode) updateDominator(delta float64) {
 	if c.world.boss != nil && c.waypoint.IsZero() {
 		if c.world.rand.Chance(0.6) {
-			wp := c.world.boss.pos.Add(c.world.rand.Offset(-180, 180))
+			wp := c.world.boss.pos.Add(detmath.Offset(c.world.rand, -180, 180))
 			c.setWaypoint(wp)
 		}
 	}
@@ -861,21 +941,21 @@ func (c *creepNode) updateBuilder(delta float64) {
 
 	// It regenerates 1 health over 5 seconds (*0.2).
 	// 12 hp over minute.
-	c.health = gmath.ClampMax(c.health+(delta*0.2), c.maxHealth)
+	c.health = gmath.ClampMax(c.health+float64(delta*0.2), c.maxHealth)
 
 	if c.specialTarget != nil {
 		// Building in progress.
 		turret := c.specialTarget.(*creepNode)
 		if turret.IsDisposed() {
 			c.specialTarget = nil
-			c.specialDelay = c.scene.Rand().FloatRange(30, 50)
+			c.specialDelay = detmath.FloatRange(c.scene.Rand(), 30, 50)
 			c.EventBuildingStop.Emit(gsignal.Void{})
 			return
 		}
 		if turret.stats.Kind == gamedata.CreepTurret || turret.stats.Kind == gamedata.CreepCrawlerBase {
 			// Constructed successfully.
 			c.specialTarget = nil
-			c.specialDelay = c.scene.Rand().FloatRange(70, 120)
+			c.specialDelay = detmath.FloatRange(c.scene.Rand(), 70, 120)
 			c.EventBuildingStop.Emit(gsignal.Void{})
 			return
 		}
@@ -908,7 +988,7 @@ func (c *creepNode) updateBuilder(delta float64) {
 			}
 			// Try finding a better spot nearby.
 			for i := 0; i < 5; i++ {
-				pos := turretPos.Add(c.world.rand.Offset(-160, 160))
+				pos := turretPos.Add(detmath.Offset(c.world.rand, -160, 160))
 				pos = c.world.AdjustCellPos(correctedPos(c.world.rect, pos, 320), 4)
 				if c.canBuildHere(pos) {
 					c.waypoint = pos.Sub(gmath.Vec{Y: agentFlightHeight})
@@ -980,16 +1060,15 @@ func (c *creepNode) maybeSpawnCrawlers() bool {
 func (c *creepNode) isNearEnemyBase(dist float64) bool {
 	distSqr := dist * dist
 	for _, colony := range c.world.allColonies {
-		if colony.pos.DistanceSquaredTo(c.pos) < distSqr {
+		if detmath.DistanceSquaredTo(colony.pos, c.pos) < distSqr {
 			return true
 		}
 	}
 	if !c.cloaking {
-		for _, turret := range c.world.turrets {
-			if turret.pos.DistanceSquaredTo(c.pos) < distSqr {
-				return true
-			}
-		}
+		_, found := c.world.turretIndex.WalkRadius(c.pos, dist, func(turret *colonyAgentNode) bool {
+			return detmath.DistanceSquaredTo(turret.pos, c.pos) < distSqr
+		})
+		return found
 	}
 	return false
 }
@@ -1003,7 +1082,7 @@ func (c *creepNode) findHowitzerTarget(rangeMultiplier float64) targetable {
 	}
 	randIterate(c.world.rand, c.world.allColonies, func(colony *colonyCoreNode) bool {
 		if !colony.IsFlying() {
-			distSqr := colony.pos.DistanceSquaredTo(c.pos)
+			distSqr := detmath.DistanceSquaredTo(colony.pos, c.pos)
 			canAttack := distSqr > minAttackRangeSqr && distSqr < maxAttackRangeSqr
 			if canAttack {
 				target = colony
@@ -1011,7 +1090,7 @@ func (c *creepNode) findHowitzerTarget(rangeMultiplier float64) targetable {
 			}
 		}
 		turretTarget := randIterate(c.world.rand, colony.turrets, func(turret *colonyAgentNode) bool {
-			distSqr := turret.pos.DistanceSquaredTo(c.pos)
+			distSqr := detmath.DistanceSquaredTo(turret.pos, c.pos)
 			return distSqr > minAttackRangeSqr && distSqr < maxAttackRangeSqr
 		})
 		if turretTarget != nil {
@@ -1039,12 +1118,13 @@ func (c *creepNode) updateHowitzer(delta float64) {
 			if c.specialDelay == 0 && c.path.HasNext() && !c.insideForest && c.world.innerRect.Contains(c.pos) {
 				if c.isNearEnemyBase(c.stats.SpecialWeapon.AttackRange * 0.8) {
 					c.path = pathing.GridPath{}
+					c.leaveGroup()
 				}
 			}
-			if c.path.HasNext() {
+			if c.path.HasNext() || c.continueGroupMove() {
 				nextPos := nextPathWaypoint(c.world, c.pos, &c.path, layerNormal)
 				c.handleForestTransition(nextPos)
-				c.waypoint = nextPos.Add(c.world.rand.Offset(-4, 4))
+				c.waypoint = nextPos.Add(detmath.Offset(c.world.rand, -4, 4))
 				return
 			}
 			c.specialModifier = howitzerIdle
@@ -1056,10 +1136,10 @@ func (c *creepNode) updateHowitzer(delta float64) {
 	if c.specialModifier == howitzerReady {
 		if c.specialDelay == 0 {
 			target := c.findHowitzerTarget(1.0)
-			c.specialDelay = c.stats.SpecialWeapon.Reload * c.world.rand.FloatRange(0.8, 1.2)
+			c.specialDelay = c.stats.SpecialWeapon.Reload * detmath.FloatRange(c.world.rand, 0.8, 1.2)
 			if target != nil && c.world.rand.Chance(0.9) {
 				targetPos := *target.GetPos()
-				dir := c.pos.AngleToPoint(targetPos).Normalized()
+				dir := detmath.NormalizedRad(detmath.AngleToPoint(c.pos, targetPos))
 				trunk := c.specialTarget.(*howitzerTrunkNode)
 				fireOffset := trunk.SetRotation(dir)
 				p := c.world.newProjectileNode(projectileConfig{
@@ -1087,7 +1167,7 @@ func (c *creepNode) updateHowitzer(delta float64) {
 
 	if c.specialModifier == howitzerFoldTurret {
 		if c.anim.Tick(delta) {
-			c.specialDelay = c.world.rand.FloatRange(5, 15)
+			c.specialDelay = detmath.FloatRange(c.world.rand, 5, 15)
 			c.specialModifier = howitzerIdle
 			c.altSprite.Visible = false
 			c.sprite.Visible = true
@@ -1101,7 +1181,7 @@ func (c *creepNode) updateHowitzer(delta float64) {
 	if c.specialModifier == howitzerPreparing {
 		if c.anim.Tick(delta) {
 			c.specialModifier = howitzerReady
-			c.specialDelay = c.world.rand.FloatRange(2, 5)
+			c.specialDelay = detmath.FloatRange(c.world.rand, 2, 5)
 			c.sprite.Visible = true
 			c.sprite.FrameOffset.X = float64(c.sprite.FrameWidth) * 4
 			c.altSprite.Visible = false
@@ -1121,12 +1201,12 @@ func (c *creepNode) updateHowitzer(delta float64) {
 			c.altSprite.Visible = true
 		} else {
 			var dst gmath.Vec
-			dist := c.world.rand.FloatRange(96, 256)
+			dist := detmath.FloatRange(c.world.rand, 96, 256)
 			colony := gmath.RandElem(c.world.rand, c.world.allColonies)
-			if colony != nil && colony.pos.DistanceTo(c.pos) > 420 && c.world.rand.Chance(0.2) {
-				dst = colony.pos.DirectionTo(c.pos).Mulf(dist).Add(c.pos)
+			if colony != nil && detmath.DistanceTo(colony.pos, c.pos) > 420 && c.world.rand.Chance(0.2) {
+				dst = detmath.AddScaled(c.pos, detmath.DirectionTo(colony.pos, c.pos), dist)
 			} else {
-				dst = gmath.RadToVec(c.world.rand.Rad()).Mulf(dist).Add(c.pos)
+				dst = detmath.AddScaled(c.pos, detmath.RadToVec(detmath.RandRad(c.world.rand)), dist)
 			}
 			c.SendTo(dst)
 		}
@@ -1176,12 +1256,13 @@ func (c *creepNode) updateCrawler(delta float64) {
 			if c.path.HasNext() && !c.insideForest {
 				if c.isNearEnemyBase(96) {
 					c.path = pathing.GridPath{}
+					c.leaveGroup()
 				}
 			}
-			if c.path.HasNext() {
+			if c.path.HasNext() || c.continueGroupMove() {
 				nextPos := nextPathWaypoint(c.world, c.pos, &c.path, layerNormal)
 				c.handleForestTransition(nextPos)
-				c.waypoint = nextPos.Add(c.world.rand.Offset(-4, 4))
+				c.waypoint = nextPos.Add(detmath.Offset(c.world.rand, -4, 4))
 				return
 			}
 			c.specialModifier = crawlerIdle
@@ -1200,7 +1281,7 @@ func (c *creepNode) updateWisp(delta float64) {
 
 	// It regenerates 1 health over 2 seconds (*0.5).
 	// 30 hp over minute.
-	c.health = gmath.ClampMax(c.health+(delta*0.5), c.maxHealth)
+	c.health = gmath.ClampMax(c.health+float64(delta*0.5), c.maxHealth)
 
 	if c.moveTowards(delta, c.waypoint) {
 		c.waypoint = gmath.Vec{}
@@ -1213,7 +1294,7 @@ func (c *creepNode) updateWisp(delta float64) {
 				Image: assets.ImageOrganicRestored,
 			})
 			c.specialTarget = nil
-			c.specialDelay = c.world.rand.FloatRange(20, 45)
+			c.specialDelay = detmath.FloatRange(c.world.rand, 20, 45)
 		}
 	}
 
@@ -1223,7 +1304,7 @@ func (c *creepNode) updateWisp(delta float64) {
 		farFromTargets := true
 		for _, colony := range c.world.allColonies {
 			// Don't process the unlikely candidates.
-			if colony.pos.DistanceSquaredTo(c.pos) > (520 * 520) {
+			if detmath.DistanceSquaredTo(colony.pos, c.pos) > (520 * 520) {
 				continue
 			}
 			farFromTargets = false
@@ -1231,7 +1312,7 @@ func (c *creepNode) updateWisp(delta float64) {
 				if a.IsCloaked() {
 					return
 				}
-				if a.pos.DistanceSquaredTo(c.pos) > (attackRange * attackRange) {
+				if detmath.DistanceSquaredTo(a.pos, c.pos) > (attackRange * attackRange) {
 					return
 				}
 				attacking = true
@@ -1239,7 +1320,7 @@ func (c *creepNode) updateWisp(delta float64) {
 			})
 		}
 		if attacking {
-			c.attackDelay = c.world.rand.FloatRange(10, 20)
+			c.attackDelay = detmath.FloatRange(c.world.rand, 10, 20)
 			createEffect(c.world, effectConfig{
 				Pos:   c.pos,
 				Image: assets.ImageWispShockwave,
@@ -1248,9 +1329,9 @@ func (c *creepNode) updateWisp(delta float64) {
 			playSound(c.world, assets.AudioWispShocker, c.pos)
 		} else {
 			if farFromTargets {
-				c.attackDelay = c.world.rand.FloatRange(2.5, 5.75)
+				c.attackDelay = detmath.FloatRange(c.world.rand, 2.5, 5.75)
 			} else {
-				c.attackDelay = c.world.rand.FloatRange(0.5, 2.0)
+				c.attackDelay = detmath.FloatRange(c.world.rand, 0.5, 2.0)
 			}
 		}
 	}
@@ -1270,11 +1351,11 @@ func (c *creepNode) updateWisp(delta float64) {
 				return true
 			})
 			if c.specialTarget == nil {
-				c.specialDelay = c.world.rand.FloatRange(15, 60)
+				c.specialDelay = detmath.FloatRange(c.world.rand, 15, 60)
 			}
 		} else {
 			if c.world.wispLair != nil && c.world.rand.Chance(0.25) {
-				c.setWaypoint(c.world.wispLair.pos.Add(c.world.rand.Offset(-64, 64)))
+				c.setWaypoint(c.world.wispLair.pos.Add(detmath.Offset(c.world.rand, -64, 64)))
 			} else {
 				c.waypoint = correctedPos(c.world.rect, randomSectorPos(c.world.rand, c.world.rect), 196)
 			}
@@ -1308,7 +1389,7 @@ func (c *creepNode) updateTurretConstruction(delta float64) {
 		return
 	}
 
-	c.specialModifier += delta * 0.02
+	c.specialModifier += float64(delta * 0.02)
 	if !c.sprite.Shader.IsNil() {
 		c.sprite.Shader.SetFloatValue("Time", c.specialModifier)
 	}
@@ -1325,7 +1406,7 @@ func (c *creepNode) updateFortress(delta float64) {
 	if c.specialDelay != 0 {
 		return
 	}
-	spawnDelay := c.world.rand.FloatRange(25, 40)
+	spawnDelay := detmath.FloatRange(c.world.rand, 25, 40)
 	c.specialDelay = spawnDelay
 	c.specialModifier++
 
@@ -1372,7 +1453,7 @@ func (c *creepNode) updateTemplar(delta float64) {
 			c.shadowComponent.SetVisibility(true)
 			c.shadowComponent.UpdateHeight(c.pos, agentFlightHeight, agentFlightHeight)
 		} else {
-			c.specialDelay = c.world.rand.FloatRange(3, 10)
+			c.specialDelay = detmath.FloatRange(c.world.rand, 3, 10)
 		}
 	}
 }
@@ -1404,13 +1485,13 @@ func (c *creepNode) updateCenturion(delta float64) {
 		if c.moveTowards(delta, c.waypoint) {
 			c.specialTarget = nil
 			pos := c.pos.Add(gmath.Vec{Y: agentFlightHeight})
-			if pos.DistanceTo(target.pos) < 32 && !target.super {
+			if detmath.DistanceTo(pos, target.pos) < 32 && !target.super {
 				target.MakeSuper()
 				playSound(c.world, assets.AudioCreepPromoted, target.pos)
 				createEffect(c.world, effectConfig{Pos: target.pos, Image: assets.ImageDroneConsumed})
-				c.specialModifier = c.scene.Rand().FloatRange(20, 35)
+				c.specialModifier = detmath.FloatRange(c.scene.Rand(), 20, 35)
 			} else {
-				c.specialModifier = c.scene.Rand().FloatRange(3, 6)
+				c.specialModifier = detmath.FloatRange(c.scene.Rand(), 3, 6)
 			}
 		}
 		return
@@ -1426,7 +1507,7 @@ func (c *creepNode) updateCenturion(delta float64) {
 				if other.insideForest {
 					return false
 				}
-				if other.pos.DistanceSquaredTo(c.pos) > (160 * 160) {
+				if detmath.DistanceSquaredTo(other.pos, c.pos) > (160 * 160) {
 					return false
 				}
 				return true
@@ -1436,7 +1517,7 @@ func (c *creepNode) updateCenturion(delta float64) {
 				c.setWaypoint(candidate.pos.Sub(gmath.Vec{Y: agentFlightHeight}))
 				return
 			}
-			c.specialModifier = c.scene.Rand().FloatRange(5, 10)
+			c.specialModifier = detmath.FloatRange(c.scene.Rand(), 5, 10)
 		}
 	}
 
@@ -1452,14 +1533,14 @@ func (c *creepNode) updateCenturion(delta float64) {
 	}
 
 	if c.waypoint.IsZero() {
-		c.SendCenturion(*c.world.centurionRallyPointPtr, c.world.rand.FloatRange(160, 196))
+		c.SendCenturion(*c.world.centurionRallyPointPtr, detmath.FloatRange(c.world.rand, 160, 196))
 	}
 
 	if c.moveTowards(delta, c.waypoint) {
 		c.centurionReady = true
 		c.waypoint = gmath.Vec{}
 		if c.world.rand.Chance(0.1) {
-			c.specialDelay = c.world.rand.FloatRange(1, 3.5)
+			c.specialDelay = detmath.FloatRange(c.world.rand, 1, 3.5)
 		}
 		if c.spawnedFromBase {
 			c.spawnedFromBase = false
@@ -1472,7 +1553,7 @@ func (c *creepNode) updateCenturion(delta float64) {
 func (c *creepNode) updateWispLair(delta float64) {
 	// It regenerates 1 health over 5 seconds (*0.2).
 	// 12 hp over minute.
-	c.health = gmath.ClampMax(c.health+(delta*0.2), c.maxHealth)
+	c.health = gmath.ClampMax(c.health+float64(delta*0.2), c.maxHealth)
 
 	if c.attackDelay != 0 {
 		return
@@ -1482,7 +1563,7 @@ func (c *creepNode) updateWispLair(delta float64) {
 	}
 
 	spawnPos := c.pos.Sub(gmath.Vec{Y: 20})
-	c.attackDelay = c.scene.Rand().FloatRange(25, 45)
+	c.attackDelay = detmath.FloatRange(c.scene.Rand(), 25, 45)
 	c.specialModifier++
 
 	wisp := c.world.NewCreepNode(spawnPos, gamedata.WispCreepStats)
@@ -1515,11 +1596,11 @@ func (c *creepNode) updateCreepCrawlerBase(delta float64) {
 
 	spawnPos := c.pos.Add(gmath.Vec{Y: 16})
 	dstOffset := gmath.Vec{
-		X: c.scene.Rand().FloatRange(-160, 160),
-		Y: c.scene.Rand().FloatRange(-160, 160),
+		X: detmath.FloatRange(c.scene.Rand(), -160, 160),
+		Y: detmath.FloatRange(c.scene.Rand(), -160, 160),
 	}
 	dstPos := spawnPos.Add(dstOffset)
-	c.attackDelay = c.scene.Rand().FloatRange(15, 30) * productionDelay
+	c.attackDelay = detmath.FloatRange(c.scene.Rand(), 15, 30) * productionDelay
 	c.specialModifier++
 
 	crawler := c.world.NewCreepNode(spawnPos, gamedata.CrawlerCreepStats)
@@ -1541,7 +1622,7 @@ func (c *creepNode) updateCreepCrawlerBase(delta float64) {
 func (c *creepNode) updateCreepBase(delta float64) {
 	c.specialDelay = gmath.ClampMin(c.specialDelay-delta, 0)
 	if c.specialDelay == 0 && c.specialModifier < 15 {
-		c.specialDelay = c.scene.Rand().FloatRange(80, 120)
+		c.specialDelay = detmath.FloatRange(c.scene.Rand(), 80, 120)
 		c.specialModifier += 1 // base level up
 	}
 
@@ -1567,7 +1648,7 @@ func (c *creepNode) updateCreepBase(delta float64) {
 		return
 	}
 
-	spawnDelay := c.scene.Rand().FloatRange(65, 80)
+	spawnDelay := detmath.FloatRange(c.scene.Rand(), 65, 80)
 	if level > 11 {
 		spawnDelay *= 0.9
 	} else if level >= 14 {
@@ -1661,8 +1742,8 @@ func (c *creepNode) updateServant(delta float64) {
 	} else {
 		// Fly around the target base.
 		const maxDistSqr float64 = 196 * 196
-		if c.waypoint.IsZero() || c.waypoint.DistanceSquaredTo(target.pos) > maxDistSqr {
-			c.waypoint = target.pos.Add(c.scene.Rand().Offset(-164, 164))
+		if c.waypoint.IsZero() || detmath.DistanceSquaredTo(c.waypoint, target.pos) > maxDistSqr {
+			c.waypoint = target.pos.Add(detmath.Offset(c.scene.Rand(), -164, 164))
 		}
 		if c.moveTowards(delta, c.waypoint) {
 			c.waypoint = gmath.Vec{}
@@ -1671,7 +1752,7 @@ func (c *creepNode) updateServant(delta float64) {
 
 	c.specialDelay = gmath.ClampMin(c.specialDelay-delta, 0)
 	if c.specialDelay == 0 && c.disarm == 0 {
-		c.specialDelay = c.scene.Rand().FloatRange(4, 6)
+		c.specialDelay = detmath.FloatRange(c.scene.Rand(), 4, 6)
 		wave := newServantWaveNode(c)
 		c.world.nodeRunner.AddObject(wave)
 		playSound(c.world, assets.AudioServantWave, c.pos)
@@ -1683,22 +1764,22 @@ func (c *creepNode) updateUberBoss(delta float64) {
 	if c.specialDelay == 0 && c.specialModifier == 0 {
 		if c.maybeSpawnCrawlers() {
 			// Time until the first crawler is spawned.
-			c.specialDelay = c.scene.Rand().FloatRange(7, 10)
+			c.specialDelay = detmath.FloatRange(c.scene.Rand(), 7, 10)
 		} else {
-			c.specialDelay = c.scene.Rand().FloatRange(3, 7)
+			c.specialDelay = detmath.FloatRange(c.scene.Rand(), 3, 7)
 		}
 	}
 
 	// It regenerates 1 health over 4 seconds (*0.25).
 	// Meaning it's 15 health per minute.
 	// In other words, 10 minutes recover 150 health for this guy.
-	c.health = gmath.ClampMax(c.health+(delta*0.25), c.maxHealth)
+	c.health = gmath.ClampMax(c.health+float64(delta*0.25), c.maxHealth)
 	c.updateHealthShader()
 
 	const crawlersSpawnHeight float64 = 10
 	if c.specialModifier != 0 && c.shadowComponent.height != crawlersSpawnHeight {
-		height := c.shadowComponent.height - delta*5
-		c.pos.Y += delta * 5
+		height := c.shadowComponent.height - float64(delta*5)
+		c.pos.Y += float64(delta * 5)
 		if height <= crawlersSpawnHeight {
 			height = crawlersSpawnHeight
 			c.sprite.FrameOffset.X = c.sprite.FrameWidth
@@ -1710,7 +1791,7 @@ func (c *creepNode) updateUberBoss(delta float64) {
 	if c.specialModifier != 0 && c.shadowComponent.height == crawlersSpawnHeight {
 		if c.specialDelay == 0 {
 			// Time until next crawler is spawned.
-			c.specialDelay = c.scene.Rand().FloatRange(4, 6)
+			c.specialDelay = detmath.FloatRange(c.scene.Rand(), 4, 6)
 			c.specialModifier--
 			if c.specialModifier > 0 {
 				spawnPos := c.crawlerSpawnPos()
@@ -1740,7 +1821,7 @@ func (c *creepNode) updateUberBoss(delta float64) {
 			if c.world.config.GameMode == gamedata.ModeReverse {
 				c.specialDelay = 60 * 60 * 60 // ~never
 			} else {
-				c.specialDelay = c.scene.Rand().FloatRange(50, 90)
+				c.specialDelay = detmath.FloatRange(c.scene.Rand(), 50, 90)
 			}
 			c.sprite.FrameOffset.X = 0
 			c.altSprite.Visible = false
@@ -1750,8 +1831,8 @@ func (c *creepNode) updateUberBoss(delta float64) {
 	}
 
 	if c.shadowComponent.height != agentFlightHeight {
-		height := c.shadowComponent.height + delta*5
-		c.pos.Y -= delta * 5
+		height := c.shadowComponent.height + float64(delta*5)
+		c.pos.Y -= float64(delta * 5)
 		if height >= agentFlightHeight {
 			height = agentFlightHeight
 		}
@@ -1761,8 +1842,8 @@ func (c *creepNode) updateUberBoss(delta float64) {
 
 	if c.waypoint.IsZero() {
 		pos := gmath.Vec{
-			X: c.scene.Rand().FloatRange(0, c.world.width),
-			Y: c.scene.Rand().FloatRange(0, c.world.height),
+			X: detmath.FloatRange(c.scene.Rand(), 0, c.world.width),
+			Y: detmath.FloatRange(c.scene.Rand(), 0, c.world.height),
 		}
 		c.waypoint = correctedPos(c.world.rect, pos, 96)
 	}
@@ -1807,6 +1888,20 @@ func (c *creepNode) movementSpeed() float64 {
 	if c.slow > 0 {
 		multiplier = 0.55
 	}
+	if c.world.envKind == gamedata.EnvIce && !c.IsFlying() {
+		// Ground units are sliding over the frozen lakes.
+		if c.world.pathgrid.GetCellValue(c.world.pathgrid.PosToCoord(c.pos), layerFindFrozenLake) == 1 {
+			multiplier *= iceSlideSpeedMultiplier
+		}
+	}
+	if events := c.world.worldEvents; events != nil {
+		if events.IsStorm() && c.IsFlying() {
+			multiplier *= stormFlyingSpeedMultiplier
+		}
+		if events.IsNight() && c.stats == gamedata.StealthCrawlerCreepStats {
+			multiplier *= nightStealthCrawlerSpeedMultiplier
+		}
+	}
 	return c.stats.Speed * multiplier
 }
 
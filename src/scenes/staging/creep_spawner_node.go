@@ -12,6 +12,7 @@ type creepSpawnerNode struct {
 	delay      float64
 	pos        gmath.Vec
 	creepDest  gmath.Vec
+	groupDest  gmath.Vec
 	creepStats *gamedata.CreepStats
 	fragScore  int
 	super      bool
@@ -47,7 +48,7 @@ func (spawner *creepSpawnerNode) Update(delta float64) {
 		creep := spawner.world.NewCreepNode(spawner.pos, spawner.creepStats)
 		creep.super = spawner.super
 		spawner.world.nodeRunner.AddObject(creep)
-		creep.SendTo(spawner.creepDest)
+		creep.SendToWithGroup(spawner.groupDest, spawner.creepDest)
 		creep.fragScore = spawner.fragScore
 		return
 	}
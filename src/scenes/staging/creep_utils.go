@@ -3,6 +3,7 @@ package staging
 import (
 	"github.com/quasilyte/gmath"
 	"github.com/quasilyte/roboden-game/assets"
+	"github.com/quasilyte/roboden-game/detmath"
 	"github.com/quasilyte/roboden-game/gamedata"
 )
 
@@ -29,13 +30,14 @@ func sendCreeps(world *worldState, g arenaWaveGroup) gmath.Vec {
 				continue
 			}
 		} else {
-			creepPos = spawnPos.Add(world.rand.Offset(-60, 60))
+			creepPos = spawnPos.Add(detmath.Offset(world.rand, -60, 60))
 		}
 
-		creepTargetPos := targetPos.Add(world.rand.Offset(-64, 64))
+		creepTargetPos := targetPos.Add(detmath.Offset(world.rand, -64, 64))
 		if spawnDelay > 0 {
 			spawner := newCreepSpawnerNode(world, spawnDelay, creepPos, creepTargetPos, u.stats)
 			spawner.super = u.super
+			spawner.groupDest = targetPos
 			spawner.fragScore = u.fragScore
 			world.nodeRunner.AddObject(spawner)
 		} else {
@@ -43,7 +45,7 @@ func sendCreeps(world *worldState, g arenaWaveGroup) gmath.Vec {
 			creep.super = u.super
 			creep.fragScore = u.fragScore
 			world.nodeRunner.AddObject(creep)
-			creep.SendTo(creepTargetPos)
+			creep.SendToWithGroup(targetPos, creepTargetPos)
 		}
 	}
 
@@ -0,0 +1,222 @@
+package staging
+
+import (
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/roboden-game/detmath"
+	"github.com/quasilyte/roboden-game/gamedata"
+)
+
+// creepsComputerPlayer is a bot that commands the creeps in the reverse mode.
+//
+// It plays the same cards as the human creeps player: buys the units
+// for the attack sides that face the weakest colony, sends the waves
+// once enough units are queued and raises the tech level.
+type creepsComputerPlayer struct {
+	world *worldState
+	state *playerState
+
+	profile *gamedata.BotProfile
+
+	creepsState *creepsPlayerState
+
+	choiceGen       *choiceGenerator
+	choiceSelection choiceSelection
+
+	actionDelay     float64
+	centurionsDelay float64
+
+	// targetSide is an attack side that is the closest to the target colony.
+	targetSide int
+}
+
+func newCreepsComputerPlayer(world *worldState, state *playerState, choiceGen *choiceGenerator) *creepsComputerPlayer {
+	return &creepsComputerPlayer{
+		world:       world,
+		state:       state,
+		profile:     world.config.PlayerBotProfile(state.id),
+		creepsState: choiceGen.creepsState,
+		choiceGen:   choiceGen,
+		actionDelay: detmath.FloatRange(world.rand, 2, 5),
+	}
+}
+
+func (p *creepsComputerPlayer) Init() {
+	p.state.Init(p.world)
+
+	p.choiceGen.EventChoiceReady.Connect(p, func(selection choiceSelection) {
+		p.choiceSelection = selection
+	})
+}
+
+func (p *creepsComputerPlayer) GetState() *playerState { return p.state }
+
+func (p *creepsComputerPlayer) IsDisposed() bool { return false }
+
+func (p *creepsComputerPlayer) Update(computedDelta, delta float64) {
+	if p.world.boss == nil {
+		return
+	}
+
+	p.actionDelay = gmath.ClampMin(p.actionDelay-computedDelta, 0)
+	p.centurionsDelay = gmath.ClampMin(p.centurionsDelay-computedDelta, 0)
+	if p.actionDelay != 0 {
+		return
+	}
+
+	if p.maybeDoAction() {
+		p.actionDelay = detmath.FloatRange(p.world.rand, 1.5, 4) * p.profile.ActionDelay
+	} else {
+		p.actionDelay = detmath.FloatRange(p.world.rand, 0.75, 2.0) * p.profile.ActionDelay
+	}
+}
+
+func (p *creepsComputerPlayer) maybeDoAction() bool {
+	if p.profile.MistakeRate != 0 && p.world.rand.Chance(p.profile.MistakeRate) {
+		// The bot hesitates and misses its turn.
+		return false
+	}
+
+	target := p.findTargetColony()
+	if target == nil {
+		return false
+	}
+	p.targetSide = p.closestAttackSide(target.pos)
+
+	if p.centurionsDelay == 0 {
+		if p.maybeSendCenturions(target) {
+			p.centurionsDelay = detmath.FloatRange(p.world.rand, 25, 45)
+			return true
+		}
+		p.centurionsDelay = detmath.FloatRange(p.world.rand, 5, 10)
+	}
+
+	// All actions below require the choices (cards) to be ready.
+	if !p.choiceGen.IsReady() {
+		return false
+	}
+
+	if p.maybeUseSpecial() {
+		return true
+	}
+	return p.maybeBuyUnits()
+}
+
+func (p *creepsComputerPlayer) findTargetColony() *colonyCoreNode {
+	// The weakest colony is the best target.
+	var target *colonyCoreNode
+	lowestStrength := 0.0
+	for _, colony := range p.world.allColonies {
+		strength := float64(colony.NumAgents()) * ((colony.health / colony.maxHealth) + 0.5)
+		if target == nil || strength < lowestStrength {
+			target = colony
+			lowestStrength = strength
+		}
+	}
+	return target
+}
+
+func (p *creepsComputerPlayer) closestAttackSide(pos gmath.Vec) int {
+	side := 0
+	minDistSqr := 0.0
+	for i, area := range p.world.spawnAreas {
+		distSqr := detmath.DistanceSquaredTo(area.Center(), pos)
+		if i == 0 || distSqr < minDistSqr {
+			side = i
+			minDistSqr = distSqr
+		}
+	}
+	return side
+}
+
+func (p *creepsComputerPlayer) colonyNearBoss(dist float64) bool {
+	distSqr := dist * dist
+	for _, colony := range p.world.allColonies {
+		if detmath.DistanceSquaredTo(colony.pos, p.world.boss.pos) < distSqr {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *creepsComputerPlayer) queuedCost() int {
+	total := 0
+	for _, cg := range p.creepsState.attackSides {
+		total += cg.totalCost
+	}
+	return total
+}
+
+func (p *creepsComputerPlayer) maybeSendCenturions(target *colonyCoreNode) bool {
+	if len(p.world.centurions) < 3 || !p.world.AllCenturionsReady() {
+		return false
+	}
+	if !p.world.rand.Chance(0.5 * p.profile.Aggression) {
+		return false
+	}
+	pos := target.pos.Add(detmath.Offset(p.world.rand, -96, 96))
+	return p.choiceGen.TryExecute(nil, -1, pos)
+}
+
+func (p *creepsComputerPlayer) maybeUseSpecial() bool {
+	boss := p.world.boss
+	techLevel := p.creepsState.techLevel
+
+	use := false
+	switch p.choiceSelection.special.special {
+	case specialAtomicBomb:
+		use = true
+
+	case specialIncreaseTech, specialIncreaseTechX2:
+		// The tech is the most valuable in the early game.
+		// The aggressive commanders prefer to spend the time on the units.
+		if techLevel < 2 {
+			chance := gmath.Clamp(1.2-float64(techLevel*0.5), 0.2, 1) / p.profile.Aggression
+			use = p.world.rand.Chance(chance)
+		}
+
+	case specialSendCreeps:
+		minCost := float64(p.creepsState.maxSideCost) / p.profile.Aggression
+		use = float64(p.queuedCost()) >= minCost ||
+			p.creepsState.attackSides[p.targetSide].totalCost >= p.creepsState.maxSideCost
+
+	case specialRally:
+		use = p.colonyNearBoss(400)
+
+	case specialSpawnCrawlers:
+		use = p.colonyNearBoss(350)
+
+	case specialBossAttack:
+		use = boss.health >= (boss.maxHealth*0.5)/p.profile.Aggression &&
+			p.world.rand.Chance(0.4*p.profile.Aggression)
+	}
+
+	if !use {
+		return false
+	}
+	return p.choiceGen.TryExecute(nil, 4, gmath.Vec{})
+}
+
+func (p *creepsComputerPlayer) maybeBuyUnits() bool {
+	bestIndex := -1
+	bestScore := 0.0
+	for i, card := range p.choiceSelection.cards {
+		cg := p.creepsState.attackSides[card.direction]
+		if cg.totalCost >= p.creepsState.maxSideCost {
+			continue
+		}
+		// The higher tech units are stronger.
+		info := creepOptionInfoList[creepCardID(card.special)]
+		score := 1 + info.minTechLevel
+		if card.direction == p.targetSide {
+			score *= 2
+		}
+		if score > bestScore {
+			bestIndex = i
+			bestScore = score
+		}
+	}
+	if bestIndex == -1 {
+		return false
+	}
+	return p.choiceGen.TryExecute(nil, bestIndex, gmath.Vec{})
+}
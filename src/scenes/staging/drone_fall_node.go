@@ -5,6 +5,7 @@ import (
 	"github.com/quasilyte/ge"
 	"github.com/quasilyte/gmath"
 	"github.com/quasilyte/roboden-game/assets"
+	"github.com/quasilyte/roboden-game/detmath"
 )
 
 type droneFallNode struct {
@@ -83,20 +84,20 @@ func (d *droneFallNode) IsDisposed() bool { return d.sprite.IsDisposed() }
 func (d *droneFallNode) Update(delta float64) {
 	const fallSpeed float64 = 60
 
-	d.height -= delta * fallSpeed
+	d.height -= float64(delta * fallSpeed)
 	if d.height <= 0 {
 		d.Destroy()
 		return
 	}
 
-	d.pos.Y += delta * fallSpeed
-	d.pos.X += d.world.rand.FloatRange(-6, 6) * delta
+	d.pos.Y += float64(delta * fallSpeed)
+	d.pos.X += float64(detmath.FloatRange(d.world.rand, -6, 6) * delta)
 
 	d.rotation += gmath.Rad(delta * 2)
 
 	if d.shadow != nil {
 		d.shadow.Pos.Offset.Y = d.height + 4
-		newShadowAlpha := float32(1.0 - ((d.height / agentFlightHeight) * 0.5))
+		newShadowAlpha := float32(1.0 - float64((d.height/agentFlightHeight)*0.5))
 		d.shadow.SetAlpha(newShadowAlpha)
 	}
 }
@@ -7,4 +7,5 @@ var (
 	errInvalidColonyIndex = errors.New("invalid colony index")
 	errExcessiveAcions    = errors.New("excessive actions")
 	errBadCheckpoint      = errors.New("mismatching checkpoint value")
+	errBadActionLog       = errors.New("resumed game state mismatch")
 )
@@ -6,7 +6,9 @@ import (
 	"github.com/quasilyte/gmath"
 	"github.com/quasilyte/gsignal"
 	"github.com/quasilyte/roboden-game/assets"
+	"github.com/quasilyte/roboden-game/detmath"
 	"github.com/quasilyte/roboden-game/gamedata"
+	"github.com/quasilyte/roboden-game/spatial"
 )
 
 type essenceSourceStats struct {
@@ -24,6 +26,10 @@ type essenceSourceStats struct {
 	size            float64
 }
 
+// maxEssenceSourceSize is the biggest essenceSourceStats.size value.
+// It's used to find the sources that collide with something.
+const maxEssenceSourceSize = 32.0
+
 var redCrystalSource = &essenceSourceStats{
 	name:            "red_crystal",
 	image:           assets.ImageEssenceRedCrystalSource,
@@ -85,6 +91,18 @@ var crystalSource = &essenceSourceStats{
 	size:            16,
 }
 
+var frostCrystalSource = &essenceSourceStats{
+	name:            "frost_crystal",
+	image:           assets.ImageEssenceFrostCrystalSource,
+	capacity:        gmath.MakeRange(30, 40),
+	regenDelay:      0,  // none
+	value:           10, // 300-400 total
+	spritesheet:     true,
+	canDeplete:      true,
+	harvesterTarget: true,
+	size:            16,
+}
+
 var sulfurSource = &essenceSourceStats{
 	name:            "sulfur",
 	image:           assets.ImageEssenceSulfurSource,
@@ -219,6 +237,8 @@ type essenceSourceNode struct {
 	rotation gmath.Rad
 	pos      gmath.Vec
 
+	spatialHandle spatial.Handle
+
 	EventDestroyed gsignal.Event[*essenceSourceNode]
 }
 
@@ -266,7 +286,7 @@ func (e *essenceSourceNode) Init(scene *ge.Scene) {
 	e.resource = e.capacity
 
 	if e.stats == organicSource {
-		e.resource = int(float64(e.resource) * scene.Rand().FloatRange(0.2, 0.5))
+		e.resource = int(float64(e.resource) * detmath.FloatRange(scene.Rand(), 0.2, 0.5))
 	}
 	if e.stats == redCrystalSource {
 		if e.world.envKind == gamedata.EnvInferno {
@@ -296,7 +316,7 @@ func (e *essenceSourceNode) Update(delta float64) {
 	}
 	e.recoverDelay -= delta
 	if e.recoverDelay <= 0 {
-		e.recoverDelay = e.recoverDelayTimer * e.scene.Rand().FloatRange(0.75, 1.25)
+		e.recoverDelay = e.recoverDelayTimer * detmath.FloatRange(e.scene.Rand(), 0.75, 1.25)
 		e.resource = gmath.ClampMax(e.resource+1, e.capacity)
 		e.percengage = float64(e.resource) / float64(e.capacity)
 		e.updateShader()
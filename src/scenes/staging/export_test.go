@@ -0,0 +1,9 @@
+package staging
+
+import "github.com/quasilyte/gmath"
+
+// TryExecuteHumanChoice activates the card of the human player selected colony.
+func (c *Controller) TryExecuteHumanChoice(playerIndex, cardIndex int) bool {
+	human := c.world.players[playerIndex].(*humanPlayer)
+	return human.choiceGen.TryExecute(human.state.selectedColony, cardIndex, gmath.Vec{})
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/quasilyte/ge"
 	"github.com/quasilyte/gmath"
 	"github.com/quasilyte/roboden-game/assets"
+	"github.com/quasilyte/roboden-game/detmath"
 	"github.com/quasilyte/roboden-game/pathing"
 )
 
@@ -35,8 +36,8 @@ func newForestClusterNode(world *worldState, config forestClusterConfig) *forest
 	f.outerRect = gmath.Rect{
 		Min: originPos,
 		Max: originPos.Add(gmath.Vec{
-			X: float64(f.config.width) * pathing.CellSize,
-			Y: float64(f.config.height) * pathing.CellSize,
+			X: float64(float64(f.config.width) * pathing.CellSize),
+			Y: float64(float64(f.config.height) * pathing.CellSize),
 		}),
 	}
 	f.innerRect = gmath.Rect{
@@ -57,8 +58,8 @@ func (f *forestClusterNode) init(scene *ge.Scene) []pendingImage {
 	for y := 0; y < f.config.height; y++ {
 		for x := 0; x < f.config.width; x++ {
 			pos := f.config.pos.Add(gmath.Vec{
-				X: float64(x) * pathing.CellSize,
-				Y: float64(y) * pathing.CellSize,
+				X: float64(float64(x) * pathing.CellSize),
+				Y: float64(float64(y) * pathing.CellSize),
 			})
 
 			if f.world.HasTreesAt(pos, 0) {
@@ -91,7 +92,7 @@ func (f *forestClusterNode) init(scene *ge.Scene) []pendingImage {
 				} else {
 					numSprites = f.world.localRand.IntRange(2, 3)
 				}
-				startAngle := f.world.localRand.Rad()
+				startAngle := detmath.RandRad(f.world.localRand)
 				angle := startAngle
 				arcFinished := false
 				for i := 0; i < numSprites; i++ {
@@ -104,16 +105,16 @@ func (f *forestClusterNode) init(scene *ge.Scene) []pendingImage {
 					}
 					var offset gmath.Vec
 					if i == 0 {
-						offset = f.world.localRand.Offset(-4, 4)
+						offset = detmath.Offset(f.world.localRand, -4, 4)
 					} else {
-						dist := f.world.localRand.FloatRange(8, 15)
+						dist := detmath.FloatRange(f.world.localRand, 8, 15)
 						var dir gmath.Vec
 						if !arcFinished {
-							dir = gmath.RadToVec(angle)
-							angleDelta := gmath.Rad(f.world.localRand.FloatRange(0.1, 0.7))
+							dir = detmath.RadToVec(angle)
+							angleDelta := gmath.Rad(detmath.FloatRange(f.world.localRand, 0.1, 0.7))
 							angle += angleDelta
 						} else {
-							dir = gmath.RadToVec(f.world.localRand.Rad())
+							dir = detmath.RadToVec(detmath.RandRad(f.world.localRand))
 						}
 						offset = dir.Mulf(dist)
 						if !arcFinished && f.world.localRand.Chance(0.4) {
@@ -147,7 +148,7 @@ func (f *forestClusterNode) CollidesWith(pos gmath.Vec, r float64) bool {
 		return f.ContainsPos(pos)
 	}
 
-	offset := gmath.Vec{X: r * 0.5, Y: r * 0.5}
+	offset := gmath.Vec{X: float64(r * 0.5), Y: float64(r * 0.5)}
 	objectRect := gmath.Rect{
 		Min: pos.Sub(offset),
 		Max: pos.Add(offset),
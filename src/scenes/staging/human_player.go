@@ -9,6 +9,7 @@ import (
 	"github.com/quasilyte/gsignal"
 	"github.com/quasilyte/roboden-game/assets"
 	"github.com/quasilyte/roboden-game/controls"
+	"github.com/quasilyte/roboden-game/detmath"
 	"github.com/quasilyte/roboden-game/gamedata"
 	"github.com/quasilyte/roboden-game/gameinput"
 	"github.com/quasilyte/roboden-game/gameui"
@@ -29,6 +30,7 @@ type humanPlayer struct {
 	recipeTab            *recipeTabNode
 	choiceWindow         *choiceWindowNode
 	rpanel               *rpanelNode
+	techPanel            *techPanelNode
 	cursor               *gameui.CursorNode
 	radar                *radarNode
 	screenButtons        *screenButtonsNode
@@ -40,6 +42,12 @@ type humanPlayer struct {
 	droneSelectorsUsed int
 	droneSelectors     []*ge.Sprite
 
+	// The turret that is used as the turret cards target.
+	// Clicking the selected turret again cycles through its upgrade tracks.
+	turretTarget         *colonyAgentNode
+	turretTargetTrack    turretUpgrade
+	turretTargetSelector *ge.Sprite
+
 	choiceCardColony     *colonyCoreNode
 	choiceCardIndex      int
 	choiceCardHighligh   *ge.Sprite
@@ -68,6 +76,7 @@ type humanPlayerConfig struct {
 
 func newHumanPlayer(config humanPlayerConfig) *humanPlayer {
 	canPing := config.world.config.GameMode != gamedata.ModeReverse &&
+		!config.world.config.GameMode.IsCompetitive() &&
 		config.world.config.PlayersMode == serverapi.PmodeTwoPlayers &&
 		config.world.config.ExecMode == gamedata.ExecuteNormal
 	p := &humanPlayer{
@@ -128,6 +137,9 @@ func (p *humanPlayer) CreateChoiceWindow(disableSpecial bool) {
 		if p.rpanel != nil {
 			p.rpanel.UpdateMetrics()
 		}
+		if p.techPanel != nil {
+			p.techPanel.UpdateMetrics()
+		}
 	})
 
 	if disableSpecial {
@@ -159,6 +171,12 @@ func (p *humanPlayer) Init() {
 		}
 	}
 
+	if p.creepsState == nil && p.world.config.TurretUpgrades {
+		p.turretTargetSelector = p.scene.NewSprite(assets.ImageDroneSelector)
+		p.turretTargetSelector.Visible = false
+		p.state.camera.Private.AddGraphicsSlightlyAbove(p.turretTargetSelector)
+	}
+
 	{
 		choiceCardHighligh := p.scene.NewSprite(assets.ImageFloppyHighlight)
 		choiceCardHighligh.Visible = false
@@ -193,6 +211,10 @@ func (p *humanPlayer) Init() {
 			p.rpanel = newCreepsRpanelNode(p.state.camera.Camera, p.creepsState)
 		} else {
 			p.rpanel = newRpanelNode(p.state.camera.Camera)
+			if p.world.config.TechTree {
+				p.techPanel = newTechPanelNode(p.state.camera.Camera)
+				p.scene.AddObject(p.techPanel)
+			}
 		}
 		p.scene.AddObject(p.rpanel)
 	}
@@ -290,6 +312,9 @@ func (p *humanPlayer) AfterUpdateStep() {
 		p.flyingColonySelector.Visible = flying
 		p.updateWaypointLine()
 	}
+	if p.turretTarget != nil && p.turretTarget.IsDisposed() {
+		p.selectTurretTarget(nil)
+	}
 }
 
 func (p *humanPlayer) BeforeUpdateStep(delta float64) {
@@ -333,7 +358,12 @@ func (p *humanPlayer) Update(computedDelta, delta float64) {
 	}
 
 	if p.choiceCardIndex != -1 {
-		if !p.choiceGen.TryExecute(p.choiceCardColony, p.choiceCardIndex, gmath.Vec{}) {
+		pos := p.turretTargetPos(p.choiceCardColony)
+		param := 0
+		if !pos.IsZero() {
+			param = turretUpgradeParam(p.turretTargetTrack)
+		}
+		if !p.choiceGen.TryExecuteWithParam(p.choiceCardColony, p.choiceCardIndex, pos, param) {
 			p.scene.Audio().PlaySound(assets.AudioError)
 		}
 		p.choiceCardIndex = -1
@@ -445,7 +475,7 @@ func (p *humanPlayer) handleInput() {
 				if colony == p.state.selectedColony {
 					continue
 				}
-				dist := colony.pos.DistanceTo(globalClickPos)
+				dist := detmath.DistanceTo(colony.pos, globalClickPos)
 				if dist > selectDist {
 					continue
 				}
@@ -464,6 +494,23 @@ func (p *humanPlayer) handleInput() {
 		return
 	}
 
+	// Selecting the turret cards target is OK during the pause.
+	if hasClick && p.turretTargetSelector != nil && selectedColony != nil {
+		globalClickPos := p.state.camera.AbsClickPos(clickPos)
+		selectDist := 24.0
+		if p.world.deviceInfo.IsMobile() {
+			selectDist = 48.0
+		}
+		if turret := selectedColony.TurretAt(globalClickPos, selectDist); turret != nil {
+			if turret == p.turretTarget {
+				p.nextTurretTargetTrack()
+				return
+			}
+			p.selectTurretTarget(turret)
+			return
+		}
+	}
+
 	// Centering the camera on some spot is OK during the pause.
 	if p.radar != nil {
 		requestedCameraPos, ok := p.radar.ResolveClick(clickPos)
@@ -483,7 +530,7 @@ func (p *humanPlayer) handleInput() {
 	if selectedColony != nil && selectedColony.relocationPoint.IsZero() && selectedColony.mode == colonyModeNormal {
 		if pos, ok := p.cursor.ClickPos(controls.ActionMoveChoice); ok {
 			globalClickPos := p.state.camera.AbsClickPos(pos)
-			if globalClickPos.DistanceTo(selectedColony.pos) > 28 {
+			if detmath.DistanceTo(globalClickPos, selectedColony.pos) > 28 {
 				selectedColony.plannedRelocationPoint = globalClickPos
 			} else {
 				selectedColony.plannedRelocationPoint = gmath.Vec{}
@@ -538,6 +585,9 @@ func (p *humanPlayer) selectColony(colony *colonyCoreNode) {
 		}
 	}
 	p.state.selectedColony = colony
+	if p.turretTarget != nil {
+		p.selectTurretTarget(nil)
+	}
 
 	if p.radar != nil {
 		p.radar.SetBase(p.state.selectedColony)
@@ -546,6 +596,10 @@ func (p *humanPlayer) selectColony(colony *colonyCoreNode) {
 		p.rpanel.SetBase(p.state.selectedColony)
 		p.rpanel.UpdateMetrics()
 	}
+	if p.techPanel != nil {
+		p.techPanel.SetBase(p.state.selectedColony)
+		p.techPanel.UpdateMetrics()
+	}
 	if p.state.selectedColony == nil {
 		p.colonySelector.Visible = false
 		p.flyingColonySelector.Visible = false
@@ -561,6 +615,9 @@ func (p *humanPlayer) selectColony(colony *colonyCoreNode) {
 	if p.rpanel != nil {
 		p.state.selectedColony.EventPrioritiesChanged.Connect(p, func(_ *colonyCoreNode) {
 			p.rpanel.UpdateMetrics()
+			if p.techPanel != nil {
+				p.techPanel.UpdateMetrics()
+			}
 		})
 	}
 	p.colonySelector.Pos.Base = &p.state.selectedColony.pos
@@ -569,6 +626,40 @@ func (p *humanPlayer) selectColony(colony *colonyCoreNode) {
 	p.updateWaypointLine()
 }
 
+func (p *humanPlayer) selectTurretTarget(turret *colonyAgentNode) {
+	p.turretTarget = turret
+	p.turretTargetTrack = turretUpgradeAuto
+	if turret == nil {
+		p.turretTargetSelector.Visible = false
+		return
+	}
+	p.scene.Audio().PlaySound(assets.AudioBaseSelect)
+	p.turretTargetSelector.Pos.Base = &turret.pos
+	p.turretTargetSelector.Visible = true
+}
+
+// nextTurretTargetTrack selects the next upgrade track of the turret target.
+// After the last track, the turret target is deselected.
+func (p *humanPlayer) nextTurretTargetTrack() {
+	if p.turretTargetTrack == turretUpgradeNum-1 || !isUpgradableTurret(p.turretTarget) {
+		p.selectTurretTarget(nil)
+		return
+	}
+	p.turretTargetTrack++
+	p.scene.Audio().PlaySound(assets.AudioBaseSelect)
+}
+
+// turretTargetPos returns the position of the turret cards target
+// that belongs to the colony (or a zero vector if there is no such target).
+// Without a target, the turret cards select the turret on their own.
+func (p *humanPlayer) turretTargetPos(colony *colonyCoreNode) gmath.Vec {
+	turret := p.turretTarget
+	if turret == nil || turret.IsDisposed() || turret.colonyCore != colony {
+		return gmath.Vec{}
+	}
+	return turret.pos
+}
+
 func (p *humanPlayer) highlightDrones(droneStats *gamedata.AgentStats) {
 	if p.state.selectedColony == nil {
 		return
@@ -0,0 +1,163 @@
+package staging
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	resource "github.com/quasilyte/ebitengine-resource"
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/roboden-game/assets"
+	"github.com/quasilyte/roboden-game/detmath"
+)
+
+// Ground units move faster while sliding over the frozen lakes.
+const iceSlideSpeedMultiplier = 1.4
+
+type iceLakeAtlasLayer struct {
+	texture       resource.ImageID
+	frozenTexture resource.ImageID
+	weight        float64
+}
+
+var iceLakeAtlas = []iceLakeAtlasLayer{
+	{texture: assets.ImageIceLake, frozenTexture: assets.ImageIceLakeFrozen, weight: 0.6},
+	{texture: assets.ImageIceLake2, frozenTexture: assets.ImageIceLakeFrozen2, weight: 0.4},
+}
+
+// iceLakeNode is a lake that freezes and thaws over time.
+//
+// A frozen lake can be crossed by the ground units.
+// An unfrozen lake blocks the ground movement.
+// Nothing can be built on a lake, regardless of its state.
+type iceLakeNode struct {
+	rect gmath.Rect
+
+	frozen     bool
+	stateDelay float64
+
+	sprite       *ge.Sprite
+	frozenSprite *ge.Sprite
+
+	world *worldState
+}
+
+func newIceLakeNode(world *worldState, rect gmath.Rect) *iceLakeNode {
+	return &iceLakeNode{
+		rect:   rect,
+		world:  world,
+		frozen: true,
+	}
+}
+
+func (lake *iceLakeNode) Init(scene *ge.Scene) {
+	lake.stateDelay = detmath.FloatRange(lake.world.rand, 60, 150)
+
+	lake.sprite = ge.NewSprite(scene.Context())
+	lake.sprite.Centered = false
+	lake.sprite.Pos.Base = &lake.rect.Min
+	lake.frozenSprite = ge.NewSprite(scene.Context())
+	lake.frozenSprite.Centered = false
+	lake.frozenSprite.Pos.Base = &lake.rect.Min
+
+	texture := ebiten.NewImage(int(lake.rect.Width()), int(lake.rect.Height()))
+	lake.sprite.SetImage(resource.Image{Data: texture})
+	frozenTexture := ebiten.NewImage(int(lake.rect.Width()), int(lake.rect.Height()))
+	lake.frozenSprite.SetImage(resource.Image{Data: frozenTexture})
+
+	// Both textures should have the same tiles layout,
+	// so we use two identically seeded rands for them.
+	var waterRand gmath.Rand
+	var iceRand gmath.Rand
+	seed := lake.world.localRand.PositiveInt64()
+	waterRand.SetSeed(seed)
+	iceRand.SetSeed(seed)
+
+	layerPicker := gmath.NewRandPicker[int](lake.world.localRand)
+	for i, l := range iceLakeAtlas {
+		layerPicker.AddOption(i, l.weight)
+	}
+
+	for y := 0.0; y < lake.rect.Height(); y += 32.0 {
+		for x := 0.0; x < lake.rect.Width(); x += 32.0 {
+			layer := iceLakeAtlas[layerPicker.Pick()]
+			drawLiquidTile(&waterRand, texture, scene.LoadImage(layer.texture), lake.rect, x, y)
+			drawLiquidTile(&iceRand, frozenTexture, scene.LoadImage(layer.frozenTexture), lake.rect, x, y)
+		}
+	}
+
+	lake.world.stage.AddSpriteBelow(lake.sprite)
+	lake.world.stage.AddSpriteBelow(lake.frozenSprite)
+	lake.sprite.Visible = false
+}
+
+func (lake *iceLakeNode) IsDisposed() bool { return false }
+
+func (lake *iceLakeNode) Update(delta float64) {
+	lake.stateDelay = gmath.ClampMin(lake.stateDelay-delta, 0)
+	if lake.stateDelay != 0 {
+		return
+	}
+
+	if !lake.frozen {
+		lake.setFrozen(true)
+		lake.stateDelay = detmath.FloatRange(lake.world.rand, 60, 150)
+		return
+	}
+
+	// Don't let the ice melt under the ground units feet.
+	if lake.hasGroundUnits() {
+		lake.stateDelay = detmath.FloatRange(lake.world.rand, 3, 5)
+		return
+	}
+	lake.setFrozen(false)
+	lake.stateDelay = detmath.FloatRange(lake.world.rand, 30, 60)
+}
+
+// hasGroundUnits reports whether any ground unit stands on the lake:
+// a creep, a scavenger or a ground colony (like a tank core).
+// The colony drones are either flying or immobile buildings.
+func (lake *iceLakeNode) hasGroundUnits() bool {
+	for _, colony := range lake.world.allColonies {
+		// The ground colonies body is about the size of a big creep.
+		if !colony.IsFlying() && lake.CollidesWith(colony.pos, 24) {
+			return true
+		}
+	}
+	for _, s := range lake.world.scavengers {
+		if lake.CollidesWith(s.pos, s.stats.Size) {
+			return true
+		}
+	}
+	center := lake.rect.Center()
+	r := gmath.ClampMin(lake.rect.Width(), lake.rect.Height())
+	found := lake.world.WalkCreeps(center, r, func(creep *creepNode) bool {
+		return !creep.IsFlying() && lake.CollidesWith(creep.pos, creep.stats.Size)
+	})
+	return found != nil
+}
+
+func (lake *iceLakeNode) setFrozen(frozen bool) {
+	lake.frozen = frozen
+	lake.sprite.Visible = !frozen
+	lake.frozenSprite.Visible = frozen
+
+	tag := ptagBlocked
+	if frozen {
+		tag = ptagFrozenLake
+	}
+	for y := lake.rect.Min.Y; y < lake.rect.Max.Y; y += wallTileSize {
+		for x := lake.rect.Min.X; x < lake.rect.Max.X; x += wallTileSize {
+			pos := gmath.Vec{X: x, Y: y}
+			lake.world.UnmarkPos(pos)
+			lake.world.MarkPos(pos, tag)
+		}
+	}
+}
+
+func (lake *iceLakeNode) CollidesWith(pos gmath.Vec, r float64) bool {
+	offset := gmath.Vec{X: float64(r*0.5) + 12, Y: float64(r*0.5) + 12}
+	objectRect := gmath.Rect{
+		Min: pos.Sub(offset),
+		Max: pos.Add(offset),
+	}
+	return lake.rect.Overlaps(objectRect)
+}
@@ -0,0 +1,213 @@
+package staging
+
+import (
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/roboden-game/detmath"
+	"github.com/quasilyte/roboden-game/gamedata"
+)
+
+const (
+	influenceCellSize = 64.0
+
+	// The map is rebuilt once per this amount of (game) seconds.
+	// The AI decisions don't need a more precise world view.
+	influenceUpdateInterval = 1.0
+)
+
+// influenceMap is a coarse grid that summarizes the world state for the AI.
+//
+// Every cell accumulates the values of the objects located inside it:
+//   - threat layer: the creeps power (see calcCreepPower)
+//   - resources layer: the value of the essence sources that are not being harvested
+//   - control layer: the turrets and roombas power, per player
+//
+// The queries are much cheaper than the world scans, but the map
+// can be a bit outdated (see influenceUpdateInterval) and it's coarse.
+// Therefore it's only used for the strategic decisions:
+//   - the bot skips the location probes that have no resources around
+//   - the bot looks for the rich spots that its probes missed
+//   - the colony keeps more patrol drones when the creeps are approaching
+//   - the creep coordinator attacks the least protected colonies
+//
+// The reactive decisions (like the danger estimation or the defence)
+// use the precise world scans.
+// The map is updated during the sequential tick phase, so it's deterministic.
+type influenceMap struct {
+	world *worldState
+
+	numCols int
+	numRows int
+
+	threat    []int
+	resources []int
+	control   [][]int
+
+	updateDelay float64
+}
+
+func newInfluenceMap(world *worldState) *influenceMap {
+	numCols := int(world.width/influenceCellSize) + 1
+	numRows := int(world.height/influenceCellSize) + 1
+	numCells := numCols * numRows
+	return &influenceMap{
+		world:     world,
+		numCols:   numCols,
+		numRows:   numRows,
+		threat:    make([]int, numCells),
+		resources: make([]int, numCells),
+	}
+}
+
+func (m *influenceMap) Update(delta float64) {
+	m.updateDelay -= delta
+	if m.updateDelay > 0 {
+		return
+	}
+	m.updateDelay = influenceUpdateInterval
+	m.rebuild()
+}
+
+func (m *influenceMap) rebuild() {
+	clearInts(m.threat)
+	clearInts(m.resources)
+	if len(m.control) != len(m.world.players) {
+		m.control = make([][]int, len(m.world.players))
+		for i := range m.control {
+			m.control[i] = make([]int, len(m.threat))
+		}
+	}
+	for _, layer := range m.control {
+		clearInts(layer)
+	}
+
+	for _, creep := range m.world.creeps {
+		// Even the weakest creep is a threat, so the non-zero
+		// threat level always means that there are some creeps around.
+		m.threat[m.cellIndex(creep.pos)] += gmath.ClampMin(calcCreepPower(m.world, creep), 1)
+	}
+
+	for _, res := range m.world.essenceSources {
+		if res.beingHarvested {
+			continue
+		}
+		// Like with the creeps, the non-zero value means that
+		// there are some sources to harvest around.
+		m.resources[m.cellIndex(res.pos)] += gmath.ClampMin(int(res.stats.value)*res.resource, 1)
+	}
+
+	for _, turret := range m.world.turrets {
+		power := getTurretPower(turret.stats)
+		if power == 0 || turret.colonyCore == nil {
+			continue
+		}
+		id := turret.colonyCore.player.GetState().id
+		m.control[id][m.cellIndex(turret.pos)] += power
+	}
+	for _, p := range m.world.players {
+		pstate := p.GetState()
+		if !pstate.hasRoombas {
+			continue
+		}
+		for _, c := range pstate.colonies {
+			for _, roomba := range c.roombas {
+				m.control[pstate.id][m.cellIndex(roomba.pos)] += int(gamedata.RoombaAgentStats.Cost)
+			}
+		}
+	}
+}
+
+// Threat returns the total creeps power around pos.
+func (m *influenceMap) Threat(pos gmath.Vec, r float64) int {
+	total := 0
+	m.walkCells(pos, r, func(i int) {
+		total += m.threat[i]
+	})
+	return total
+}
+
+// Resources returns the base value of the available resources around pos.
+// It doesn't take any colony-specific modifiers into account.
+func (m *influenceMap) Resources(pos gmath.Vec, r float64) int {
+	total := 0
+	m.walkCells(pos, r, func(i int) {
+		total += m.resources[i]
+	})
+	return total
+}
+
+// Control returns the player defensive structures power around pos.
+func (m *influenceMap) Control(playerID int, pos gmath.Vec, r float64) int {
+	if playerID >= len(m.control) {
+		return 0
+	}
+	layer := m.control[playerID]
+	total := 0
+	m.walkCells(pos, r, func(i int) {
+		total += layer[i]
+	})
+	return total
+}
+
+// FindResourcesCell returns a center of the richest cell around pos
+// that has a threat level not higher than maxThreat.
+// The second result is that cell resources value (0 if nothing was found).
+func (m *influenceMap) FindResourcesCell(pos gmath.Vec, r float64, maxThreat int) (gmath.Vec, int) {
+	bestScore := 0
+	var bestPos gmath.Vec
+	m.walkCells(pos, r, func(i int) {
+		score := m.resources[i]
+		if score <= bestScore || m.threat[i] > maxThreat {
+			return
+		}
+		bestScore = score
+		bestPos = m.cellCenter(i)
+	})
+	return bestPos, bestScore
+}
+
+// walkCells calls f for every cell that intersects with the circle.
+// The cells are visited in a fixed order.
+func (m *influenceMap) walkCells(pos gmath.Vec, r float64, f func(i int)) {
+	minCol, minRow := m.cellCoord(gmath.Vec{X: pos.X - r, Y: pos.Y - r})
+	maxCol, maxRow := m.cellCoord(gmath.Vec{X: pos.X + r, Y: pos.Y + r})
+	rSqr := r * r
+	for row := minRow; row <= maxRow; row++ {
+		for col := minCol; col <= maxCol; col++ {
+			// The closest cell point to the circle center.
+			closest := gmath.Vec{
+				X: gmath.Clamp(pos.X, float64(col)*influenceCellSize, float64(col+1)*influenceCellSize),
+				Y: gmath.Clamp(pos.Y, float64(row)*influenceCellSize, float64(row+1)*influenceCellSize),
+			}
+			if detmath.DistanceSquaredTo(closest, pos) > rSqr {
+				continue
+			}
+			f(row*m.numCols + col)
+		}
+	}
+}
+
+func (m *influenceMap) cellCoord(pos gmath.Vec) (col, row int) {
+	col = gmath.Clamp(int(pos.X/influenceCellSize), 0, m.numCols-1)
+	row = gmath.Clamp(int(pos.Y/influenceCellSize), 0, m.numRows-1)
+	return col, row
+}
+
+func (m *influenceMap) cellIndex(pos gmath.Vec) int {
+	col, row := m.cellCoord(pos)
+	return row*m.numCols + col
+}
+
+func (m *influenceMap) cellCenter(i int) gmath.Vec {
+	col := i % m.numCols
+	row := i / m.numCols
+	return gmath.Vec{
+		X: (float64(col) + 0.5) * influenceCellSize,
+		Y: (float64(row) + 0.5) * influenceCellSize,
+	}
+}
+
+func clearInts(s []int) {
+	for i := range s {
+		s[i] = 0
+	}
+}
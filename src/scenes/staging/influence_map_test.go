@@ -0,0 +1,50 @@
+package staging
+
+import (
+	"testing"
+
+	"github.com/quasilyte/gmath"
+)
+
+func TestInfluenceMapQueries(t *testing.T) {
+	m := newInfluenceMap(&worldState{width: 640, height: 480})
+
+	pt := func(x, y float64) gmath.Vec {
+		return gmath.Vec{X: x, Y: y}
+	}
+	m.threat[m.cellIndex(pt(100, 100))] = 10
+	m.threat[m.cellIndex(pt(300, 100))] = 25
+	m.threat[m.cellIndex(pt(-50, 470))] = 1 // Outside of the world
+	m.resources[m.cellIndex(pt(100, 300))] = 40
+	m.resources[m.cellIndex(pt(500, 300))] = 90
+
+	tests := []struct {
+		pos           gmath.Vec
+		r             float64
+		wantThreat    int
+		wantResources int
+	}{
+		{pt(100, 100), 1, 10, 0},
+		{pt(200, 100), 70, 25, 0},
+		{pt(200, 100), 150, 35, 0},
+		{pt(100, 300), 10, 0, 40},
+		{pt(10, 470), 20, 1, 0},
+		{pt(320, 240), 1000, 36, 130},
+	}
+
+	for i, test := range tests {
+		if threat := m.Threat(test.pos, test.r); threat != test.wantThreat {
+			t.Fatalf("test[%d]: threat at %v r=%.0f:\nhave: %d\nwant: %d",
+				i, test.pos, test.r, threat, test.wantThreat)
+		}
+		if resources := m.Resources(test.pos, test.r); resources != test.wantResources {
+			t.Fatalf("test[%d]: resources at %v r=%.0f:\nhave: %d\nwant: %d",
+				i, test.pos, test.r, resources, test.wantResources)
+		}
+	}
+
+	cellPos, score := m.FindResourcesCell(pt(320, 240), 1000, 0)
+	if cellPos != pt(480, 288) || score != 90 {
+		t.Fatalf("find resources cell: have %v %d", cellPos, score)
+	}
+}
@@ -0,0 +1,140 @@
+package staging
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/gsignal"
+	"github.com/quasilyte/roboden-game/gamedata"
+)
+
+// kothTargetScore is a number of points that is required to win.
+// Every captured control point gives its owner 1 point per second.
+const kothTargetScore = 600
+
+// kothManager implements the king of the hill game rules.
+//
+// There are always exactly two players (see LevelConfig.Finalize).
+// The match is won by either getting enough points or by
+// destroying all colonies of the other player.
+type kothManager struct {
+	world *worldState
+
+	scene *ge.Scene
+
+	scores [2]int
+	winner int
+
+	scoreDelay float64
+	finished   bool
+
+	infos []*messageNode
+
+	EventFinished gsignal.Event[gsignal.Void]
+}
+
+func newKothManager(world *worldState) *kothManager {
+	return &kothManager{
+		world:      world,
+		winner:     -1,
+		scoreDelay: 1,
+	}
+}
+
+func (m *kothManager) Init(scene *ge.Scene) {
+	m.scene = scene
+
+	s := m.createInfoText()
+	for _, cam := range m.world.cameras {
+		info := newScreenTutorialHintNode(cam, gmath.Vec{X: 16, Y: 70}, gmath.Vec{}, s)
+		info.xpadding = 20
+		m.world.nodeRunner.AddObject(info)
+		m.infos = append(m.infos, info)
+	}
+}
+
+func (m *kothManager) IsDisposed() bool {
+	return false
+}
+
+func (m *kothManager) Update(delta float64) {
+	if m.finished {
+		return
+	}
+
+	m.scoreDelay -= delta
+	if m.scoreDelay > 0 {
+		return
+	}
+	m.scoreDelay = 1 + m.scoreDelay
+
+	for _, b := range m.world.neutralBuildings {
+		if b.stats != gamedata.ControlPointAgentStats || b.agent == nil {
+			continue
+		}
+		m.scores[b.agent.colonyCore.player.GetState().id]++
+	}
+
+	m.winner = m.findWinner()
+	if m.winner != -1 {
+		m.finished = true
+		for _, info := range m.infos {
+			info.Dispose()
+		}
+		m.EventFinished.Emit(gsignal.Void{})
+		return
+	}
+
+	s := m.createInfoText()
+	for _, info := range m.infos {
+		info.UpdateText(s)
+	}
+}
+
+func (m *kothManager) findWinner() int {
+	// A player without colonies can't capture anything,
+	// there is no reason to wait for the points.
+	alive0 := len(m.world.players[0].GetState().colonies) != 0
+	alive1 := len(m.world.players[1].GetState().colonies) != 0
+	switch {
+	case alive0 && !alive1:
+		return 0
+	case !alive0 && alive1:
+		return 1
+	}
+
+	// Equal scores lead to another round of capturing.
+	if m.scores[0] == m.scores[1] {
+		return -1
+	}
+	if m.scores[0] >= kothTargetScore || m.scores[1] >= kothTargetScore {
+		if m.scores[0] > m.scores[1] {
+			return 0
+		}
+		return 1
+	}
+	return -1
+}
+
+func (m *kothManager) createInfoText() string {
+	d := m.scene.Dict()
+
+	var buf strings.Builder
+	buf.Grow(64)
+
+	for i, score := range m.scores {
+		buf.WriteString(d.Get("game.koth.player"))
+		buf.WriteString(" ")
+		buf.WriteString(strconv.Itoa(i + 1))
+		buf.WriteString(": ")
+		buf.WriteString(strconv.Itoa(score))
+		buf.WriteString("\n")
+	}
+	buf.WriteString(d.Get("game.koth.goal"))
+	buf.WriteString(": ")
+	buf.WriteString(strconv.Itoa(kothTargetScore))
+
+	return buf.String()
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/quasilyte/ge"
 	"github.com/quasilyte/gmath"
 	"github.com/quasilyte/roboden-game/assets"
+	"github.com/quasilyte/roboden-game/detmath"
 	"github.com/quasilyte/roboden-game/gamedata"
 )
 
@@ -28,7 +29,7 @@ func newLavaGeyserNode(world *worldState, pos gmath.Vec) *lavaGeyserNode {
 }
 
 func (n *lavaGeyserNode) Init(scene *ge.Scene) {
-	n.fireDelay = scene.Rand().FloatRange(15, 30)
+	n.fireDelay = detmath.FloatRange(scene.Rand(), 15, 30)
 
 	n.sprite = scene.NewSprite(assets.ImageLavaGeyser)
 	n.sprite.Pos.Base = &n.pos
@@ -103,10 +104,10 @@ func (n *lavaGeyserNode) Update(delta float64) {
 				})
 			}
 			if n.lineDecayDelay <= 0 && n.lineHeight >= 10 {
-				n.lineHeight = gmath.ClampMin(n.lineHeight-delta*280, 10)
+				n.lineHeight = gmath.ClampMin(n.lineHeight-float64(delta*280), 10)
 			} else {
 				if n.lineHeight < 80 {
-					n.lineHeight = gmath.ClampMax(n.lineHeight+310*delta, 80)
+					n.lineHeight = gmath.ClampMax(n.lineHeight+float64(310*delta), 80)
 					if n.lineHeight == 80 {
 						n.createBurstEffect(n.pos.Sub(gmath.Vec{Y: 75}))
 						n.dealDamage()
@@ -132,6 +133,6 @@ func (n *lavaGeyserNode) Update(delta float64) {
 
 	n.createBurstEffect(n.pos.Sub(gmath.Vec{Y: 11}))
 
-	n.fireDelay = n.world.rand.FloatRange(20, 40)
+	n.fireDelay = detmath.FloatRange(n.world.rand, 20, 40)
 	playSound(n.world, assets.AudioLavaBurst1, n.pos)
 }
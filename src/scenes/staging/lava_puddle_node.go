@@ -6,6 +6,7 @@ import (
 	"github.com/quasilyte/ge"
 	"github.com/quasilyte/gmath"
 	"github.com/quasilyte/roboden-game/assets"
+	"github.com/quasilyte/roboden-game/detmath"
 	"github.com/quasilyte/roboden-game/gamedata"
 )
 
@@ -48,7 +49,7 @@ func newLavaPuddleNode(world *worldState, rect gmath.Rect) *lavaPuddleNode {
 	return &lavaPuddleNode{
 		rect:      rect,
 		world:     world,
-		centerPos: rect.Min.Add(gmath.Vec{X: rect.Width() * 0.5, Y: rect.Height() * 0.5}),
+		centerPos: rect.Min.Add(gmath.Vec{X: float64(rect.Width() * 0.5), Y: float64(rect.Height() * 0.5)}),
 	}
 }
 
@@ -57,14 +58,14 @@ func (lava *lavaPuddleNode) Init(scene *ge.Scene) {
 	lava.sprite.Centered = false
 	lava.sprite.Pos.Base = &lava.rect.Min
 
-	lava.fireDelay = lava.world.rand.FloatRange(30, 200)
+	lava.fireDelay = detmath.FloatRange(lava.world.rand, 30, 200)
 
 	lava.maxResourceSpawns = lava.world.rand.IntRange(0, 3)
 
 	if lava.world.graphicsSettings.AllShadersEnabled {
 		lava.sprite.Shader = scene.NewShader(assets.ShaderLavaPuddle)
-		lava.shaderTimeSpeed = 2.5 * lava.world.localRand.FloatRange(0.95, 1.1)
-		lava.shaderTime = lava.world.localRand.FloatRange(545.7, 964.70)
+		lava.shaderTimeSpeed = 2.5 * detmath.FloatRange(lava.world.localRand, 0.95, 1.1)
+		lava.shaderTime = detmath.FloatRange(lava.world.localRand, 545.7, 964.70)
 		lava.sprite.Shader.SetFloatValue("Time", lava.shaderTime)
 		lava.sprite.Shader.SetIntValue("Seed", lava.world.localRand.IntRange(0, 999))
 	}
@@ -80,59 +81,61 @@ func (lava *lavaPuddleNode) Init(scene *ge.Scene) {
 	for y := 0.0; y < lava.rect.Height(); y += 32.0 {
 		for x := 0.0; x < lava.rect.Width(); x += 32.0 {
 			tileImages := scene.LoadImage(layerPicker.Pick())
-			lava.drawTile(texture, tileImages, x, y)
+			drawLiquidTile(lava.world.localRand, texture, tileImages, lava.rect, x, y)
 		}
 	}
 
 	lava.world.stage.AddSpriteBelow(lava.sprite)
 }
 
-func (lava *lavaPuddleNode) drawTile(dst *ebiten.Image, texture resource.Image, x, y float64) {
+// drawLiquidTile draws a single 32x32 tile of the rect-shaped liquid texture.
+// The border tiles are selected depending on the x and y position inside the rect.
+func drawLiquidTile(rand *gmath.Rand, dst *ebiten.Image, texture resource.Image, rect gmath.Rect, x, y float64) {
 	var tileIndex int
 	var flipHorizontal bool
 	var flipVertical bool
 	if x == 0 {
 		switch {
 		case y == 0:
-			if lava.world.localRand.Bool() {
+			if rand.Bool() {
 				tileIndex = 0
 			} else {
 				tileIndex = 2
 				flipHorizontal = true
 			}
-		case y == lava.rect.Height()-32:
-			if lava.world.localRand.Bool() {
+		case y == rect.Height()-32:
+			if rand.Bool() {
 				tileIndex = 6
 			} else {
 				tileIndex = 8
 				flipHorizontal = true
 			}
 		default:
-			if lava.world.localRand.Bool() {
+			if rand.Bool() {
 				tileIndex = 3
 			} else {
 				tileIndex = 5
 				flipHorizontal = true
 			}
 		}
-	} else if x == lava.rect.Width()-32 {
+	} else if x == rect.Width()-32 {
 		switch {
 		case y == 0:
-			if lava.world.localRand.Bool() {
+			if rand.Bool() {
 				tileIndex = 2
 			} else {
 				tileIndex = 0
 				flipHorizontal = true
 			}
-		case y == lava.rect.Height()-32:
-			if lava.world.localRand.Bool() {
+		case y == rect.Height()-32:
+			if rand.Bool() {
 				tileIndex = 8
 			} else {
 				tileIndex = 6
 				flipHorizontal = true
 			}
 		default:
-			if lava.world.localRand.Bool() {
+			if rand.Bool() {
 				tileIndex = 5
 			} else {
 				tileIndex = 3
@@ -143,14 +146,14 @@ func (lava *lavaPuddleNode) drawTile(dst *ebiten.Image, texture resource.Image,
 		switch {
 		case y == 0:
 			tileIndex = 1
-			flipHorizontal = lava.world.localRand.Bool()
-		case y == lava.rect.Height()-32:
+			flipHorizontal = rand.Bool()
+		case y == rect.Height()-32:
 			tileIndex = 7
-			flipHorizontal = lava.world.localRand.Bool()
+			flipHorizontal = rand.Bool()
 		default:
 			tileIndex = 4
-			flipHorizontal = lava.world.localRand.Bool()
-			flipVertical = lava.world.localRand.Bool()
+			flipHorizontal = rand.Bool()
+			flipVertical = rand.Bool()
 		}
 	}
 
@@ -172,9 +175,9 @@ func (lava *lavaPuddleNode) IsDisposed() bool { return false }
 
 func (lava *lavaPuddleNode) Update(delta float64) {
 	if !lava.sprite.Shader.IsNil() {
-		lava.shaderTime += delta * lava.shaderTimeSpeed
+		lava.shaderTime += float64(delta * lava.shaderTimeSpeed)
 		if lava.shaderTime > 999999999 {
-			lava.shaderTime = lava.world.localRand.FloatRange(0, 9)
+			lava.shaderTime = detmath.FloatRange(lava.world.localRand, 0, 9)
 		}
 		lava.sprite.Shader.SetFloatValue("Time", lava.shaderTime)
 	}
@@ -184,7 +187,7 @@ func (lava *lavaPuddleNode) Update(delta float64) {
 		return
 	}
 
-	lava.fireDelay = lava.world.rand.FloatRange(10, 30)
+	lava.fireDelay = detmath.FloatRange(lava.world.rand, 10, 30)
 	spawnPad := gmath.Vec{X: 20, Y: 20}
 	spawnRect := gmath.Rect{
 		Min: lava.rect.Min.Add(spawnPad),
@@ -206,7 +209,7 @@ func (lava *lavaPuddleNode) Update(delta float64) {
 				if creep.stats.Kind != gamedata.CreepCrawler {
 					return false
 				}
-				if creep.pos.DistanceSquaredTo(spawnPos) > weapon.AttackRangeSqr {
+				if detmath.DistanceSquaredTo(creep.pos, spawnPos) > weapon.AttackRangeSqr {
 					return false
 				}
 				target = creep
@@ -216,8 +219,8 @@ func (lava *lavaPuddleNode) Update(delta float64) {
 		}
 	}
 	if target == nil {
-		dist := lava.world.rand.FloatRange(80, weapon.AttackRange)
-		attackPos = gmath.RadToVec(lava.world.rand.Rad()).Mulf(dist).Add(spawnPos)
+		dist := detmath.FloatRange(lava.world.rand, 80, weapon.AttackRange)
+		attackPos = detmath.AddScaled(spawnPos, detmath.RadToVec(detmath.RandRad(lava.world.rand)), dist)
 	}
 
 	lava.attacker.pos = spawnPos
@@ -248,7 +251,7 @@ func (lava *lavaPuddleNode) Update(delta float64) {
 }
 
 func (lava *lavaPuddleNode) CollidesWith(pos gmath.Vec, r float64) bool {
-	offset := gmath.Vec{X: r*0.5 + 12, Y: r*0.5 + 12}
+	offset := gmath.Vec{X: float64(r*0.5) + 12, Y: float64(r*0.5) + 12}
 	objectRect := gmath.Rect{
 		Min: pos.Sub(offset),
 		Max: pos.Add(offset),
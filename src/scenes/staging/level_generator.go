@@ -9,6 +9,7 @@ import (
 	"github.com/quasilyte/ge"
 	"github.com/quasilyte/ge/xslices"
 	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/roboden-game/detmath"
 	"github.com/quasilyte/roboden-game/gamedata"
 	"github.com/quasilyte/roboden-game/pathing"
 )
@@ -23,12 +24,21 @@ type levelGenerator struct {
 	rng                gmath.Rand
 	world              *worldState
 	playerSpawn        gmath.Vec
+	spawns             []gmath.Vec
 	sectors            []gmath.Rect
 	sectorSlider       gmath.Slider
 	activeSectors      []gmath.Rect
 	activeSectorSlider gmath.Slider
 	bg                 *ge.TiledBackground
 
+	// symmetry is a map reflection mode.
+	// For symmetrical maps, only the primaryRect part of the world
+	// is generated; everything else is a reflection.
+	symmetry    gamedata.WorldSymmetry
+	primaryRect gmath.Rect
+	// pairing defines how the second player spawn is derived from the first one.
+	pairing gamedata.WorldSymmetry
+
 	resourcesByStats map[*essenceSourceStats][]*essenceSourceNode
 
 	pendingResources []*essenceSourceNode
@@ -38,6 +48,9 @@ type creepPlacingConfig struct {
 	CreepInit func(creep *creepNode)
 	Pad       float64
 	NoScraps  bool
+	// NoMirror disables the symmetrical copy creation.
+	// This is used for the unique creeps.
+	NoMirror bool
 }
 
 func newLevelGenerator(scene *ge.Scene, bg *ge.TiledBackground, world *worldState) *levelGenerator {
@@ -96,6 +109,32 @@ func newLevelGenerator(scene *ge.Scene, bg *ge.TiledBackground, world *worldStat
 		panic(fmt.Sprintf("unexpected world shape: %d", g.world.mapShape))
 	}
 
+	g.symmetry = gamedata.WorldSymmetry(world.config.Symmetry)
+	g.pairing = gamedata.SymmetryRotational
+	if g.mirrored() {
+		g.pairing = g.symmetry
+		g.primaryRect = g.world.rect
+		switch {
+		case g.symmetry == gamedata.SymmetryMirrorY:
+			g.primaryRect.Max.Y = g.world.height / 2
+		case g.symmetry == gamedata.SymmetryRotational && g.world.mapShape == gamedata.WorldVertical:
+			g.primaryRect.Max.Y = g.world.height / 2
+		default:
+			g.primaryRect.Max.X = g.world.width / 2
+		}
+		// All random positions are selected inside the primary half.
+		// The sectors that are located on the other side are discarded.
+		clipped := g.sectors[:0]
+		for _, sector := range g.sectors {
+			rect := g.clipRect(sector)
+			if rect.IsEmpty() {
+				continue
+			}
+			clipped = append(clipped, rect)
+		}
+		g.sectors = clipped
+	}
+
 	g.sectorSlider.SetBounds(0, len(g.sectors)-1)
 	return g
 }
@@ -103,7 +142,29 @@ func newLevelGenerator(scene *ge.Scene, bg *ge.TiledBackground, world *worldStat
 func (g *levelGenerator) Generate() {
 	g.playerSpawn = g.world.rect.Center()
 
-	if g.world.mapShape == gamedata.WorldSquare {
+	if g.world.config.GameMode.IsCompetitive() {
+		// Both rivals should have equal conditions,
+		// so the colonies are spawned on the opposite sides of the map.
+		switch g.world.mapShape {
+		case gamedata.WorldSquare:
+			g.playerSpawn = gmath.Vec{X: 400, Y: 400}
+		case gamedata.WorldHorizontal:
+			g.playerSpawn.X = 320
+		case gamedata.WorldVertical:
+			g.playerSpawn.Y = 320
+		}
+		second := g.pairedPos(g.playerSpawn)
+		if detmath.DistanceTo(second, g.playerSpawn) < 640 {
+			// The symmetry axis goes through the spawn point.
+			// Use the opposite side of the map instead.
+			g.pairing = gamedata.SymmetryRotational
+			second = g.pairedPos(g.playerSpawn)
+		}
+		g.spawns = []gmath.Vec{g.playerSpawn, second}
+		g.activeSectors = g.sectors
+	} else if g.world.mapShape == gamedata.WorldSquare || g.mirrored() {
+		// A symmetrical map always has its spawn point in the center,
+		// so both halves are equally distant from the colony.
 		g.activeSectors = g.sectors
 	} else {
 		if g.rng.Bool() {
@@ -124,13 +185,19 @@ func (g *levelGenerator) Generate() {
 	}
 	g.activeSectorSlider.SetBounds(0, len(g.activeSectors)-1)
 
-	g.world.spawnPos = g.playerSpawn
+	if len(g.spawns) == 0 {
+		g.spawns = []gmath.Vec{g.playerSpawn}
+		g.world.spawnPos = g.playerSpawn
+	} else {
+		g.world.spawnPos = g.world.rect.Center()
+	}
 
 	type genStep struct {
 		name string
 		fn   func()
 	}
 	var steps = []genStep{
+		{"place_control_points", g.placeControlPoints},
 		{"place_landmarks", g.placeLandmarks},
 		{"place_teleporters", g.placeTeleporters},
 		{"place_relicts", g.placeRelicts},
@@ -170,7 +237,7 @@ func (g *levelGenerator) randomFreePosWithFallback(sector, fallback gmath.Rect,
 	for i := 0; i < 3; i++ {
 		pos = g.randomFreePos(sector, radius, pad)
 		selectedSector = sector
-		if avoidSpawnPos && pos.DistanceTo(g.playerSpawn) < 196 {
+		if avoidSpawnPos && g.spawnDistance(pos) < 196 {
 			continue
 		}
 		break
@@ -225,6 +292,76 @@ func (g *levelGenerator) randomFreePos(sector gmath.Rect, radius, pad float64) g
 	return gmath.Vec{}
 }
 
+// spawnDistance returns the distance to the closest colony spawn point.
+func (g *levelGenerator) spawnDistance(pos gmath.Vec) float64 {
+	dist := math.MaxFloat64
+	for _, spawn := range g.spawns {
+		dist = math.Min(dist, detmath.DistanceTo(pos, spawn))
+	}
+	return dist
+}
+
+// reflectPos returns a symmetrical copy position for the generated object.
+func (g *levelGenerator) reflectPos(pos gmath.Vec) gmath.Vec {
+	return g.transformPos(g.symmetry, pos)
+}
+
+// pairedPos returns a position that matches pos for the second player.
+func (g *levelGenerator) pairedPos(pos gmath.Vec) gmath.Vec {
+	return g.transformPos(g.pairing, pos)
+}
+
+func (g *levelGenerator) transformPos(symmetry gamedata.WorldSymmetry, pos gmath.Vec) gmath.Vec {
+	switch symmetry {
+	case gamedata.SymmetryMirrorX:
+		return gmath.Vec{X: g.world.width - pos.X, Y: pos.Y}
+	case gamedata.SymmetryMirrorY:
+		return gmath.Vec{X: pos.X, Y: g.world.height - pos.Y}
+	case gamedata.SymmetryRotational:
+		return gmath.Vec{X: g.world.width - pos.X, Y: g.world.height - pos.Y}
+	default:
+		return pos
+	}
+}
+
+// reflectRect is like reflectPos, but for rects.
+// The world size is a multiple of the cell size, so the
+// grid-aligned rects remain aligned after the reflection.
+func (g *levelGenerator) reflectRect(rect gmath.Rect) gmath.Rect {
+	a := g.reflectPos(rect.Min)
+	b := g.reflectPos(rect.Max)
+	return gmath.Rect{
+		Min: gmath.Vec{X: math.Min(a.X, b.X), Y: math.Min(a.Y, b.Y)},
+		Max: gmath.Vec{X: math.Max(a.X, b.X), Y: math.Max(a.Y, b.Y)},
+	}
+}
+
+func (g *levelGenerator) clipRect(rect gmath.Rect) gmath.Rect {
+	return gmath.Rect{
+		Min: gmath.Vec{X: math.Max(rect.Min.X, g.primaryRect.Min.X), Y: math.Max(rect.Min.Y, g.primaryRect.Min.Y)},
+		Max: gmath.Vec{X: math.Min(rect.Max.X, g.primaryRect.Max.X), Y: math.Min(rect.Max.Y, g.primaryRect.Max.Y)},
+	}
+}
+
+func (g *levelGenerator) mirrored() bool {
+	return g.symmetry != gamedata.SymmetryNone
+}
+
+// inPrimaryHalf reports whether pos belongs to the generated part of the map.
+// Non-symmetrical maps are generated as a whole.
+func (g *levelGenerator) inPrimaryHalf(pos gmath.Vec) bool {
+	if !g.mirrored() {
+		return true
+	}
+	r := g.primaryRect
+	return pos.X >= r.Min.X && pos.X <= r.Max.X &&
+		pos.Y >= r.Min.Y && pos.Y <= r.Max.Y
+}
+
+func (g *levelGenerator) rectInPrimaryHalf(rect gmath.Rect) bool {
+	return g.inPrimaryHalf(rect.Min) && g.inPrimaryHalf(rect.Max)
+}
+
 func (g *levelGenerator) randomPos(sector gmath.Rect) gmath.Vec {
 	return randomSectorPos(&g.rng, sector)
 }
@@ -272,10 +409,15 @@ func (g *levelGenerator) placeTeleporters() {
 	for i := 0; i < g.world.config.Teleporters; i++ {
 		tp1sectorIndex := gmath.RandIndex(g.world.rand, g.sectors)
 		tp1pos, tp1sector := g.randomFreePosWithFallback(g.sectors[tp1sectorIndex], g.nextSector(tp1sectorIndex, g.sectors), 96, 196, true)
-		tp1 := &teleporterNode{id: i, pos: g.world.Adjust2x2CellPos(tp1pos, 0).Sub(teleportOffset), world: g.world}
+		tp1aligned := g.world.Adjust2x2CellPos(tp1pos, 0)
+		tp1 := &teleporterNode{id: i, pos: tp1aligned.Sub(teleportOffset), world: g.world}
 
 		var tp2 *teleporterNode
-		for {
+		if g.mirrored() {
+			// The teleporters are connecting the two map halves.
+			tp2 = &teleporterNode{id: i, pos: g.reflectPos(tp1aligned).Sub(teleportOffset), world: g.world}
+		}
+		for tp2 == nil {
 			tp2sectorIndex := gmath.RandIndex(g.world.rand, g.sectors)
 			tp2sector := g.sectors[tp2sectorIndex]
 			if tp2sector == tp1sector {
@@ -325,11 +467,40 @@ func (g *levelGenerator) placeRelicts() {
 			b := newNeutralBuildingNode(g.world, a, pos)
 			b.Init(g.scene)
 			g.world.neutralBuildings = append(g.world.neutralBuildings, b)
+			if g.mirrored() {
+				b := newNeutralBuildingNode(g.world, a, g.reflectPos(pos))
+				b.Init(g.scene)
+				g.world.neutralBuildings = append(g.world.neutralBuildings, b)
+			}
 			break
 		}
 	}
 }
 
+func (g *levelGenerator) placeControlPoints() {
+	if g.world.config.GameMode != gamedata.ModeKingOfTheHill {
+		return
+	}
+
+	// One point is located right in the middle of the map.
+	// The other two are symmetrical: one is closer to the first player,
+	// the other is closer to the second player.
+	center := g.world.rect.Center()
+	dir := detmath.DirectionTo(g.spawns[0], center)
+	sideDir := gmath.Vec{X: -dir.Y, Y: dir.X}
+	side := detmath.AddScaled(detmath.LinearInterpolate(g.spawns[0], center, 0.5), sideDir, 260)
+	positions := []gmath.Vec{
+		center,
+		side,
+		g.pairedPos(side),
+	}
+	for _, pos := range positions {
+		b := newNeutralBuildingNode(g.world, gamedata.ControlPointAgentStats, g.world.pathgrid.AlignPos(pos))
+		b.Init(g.scene)
+		g.world.neutralBuildings = append(g.world.neutralBuildings, b)
+	}
+}
+
 func (g *levelGenerator) placePlayers() {
 	extraOffset := gmath.Vec{}
 	if g.world.coreDesign == gamedata.TankCoreStats {
@@ -342,18 +513,37 @@ func (g *levelGenerator) placePlayers() {
 		return
 	}
 
+	if g.world.config.GameMode.IsCompetitive() {
+		for i, p := range g.world.players {
+			g.createBase(p, g.spawns[i].Add(extraOffset), true)
+		}
+		return
+	}
+
 	switch len(g.world.config.Players) {
 	case 1:
 		g.createBase(g.world.players[0], g.playerSpawn.Add(extraOffset), true)
 	case 2:
-		playerOffset := gmath.Vec{X: 64, Y: 64}
-		g.createBase(g.world.players[0], g.playerSpawn.Sub(playerOffset).Add(extraOffset), true)
-		g.createBase(g.world.players[1], g.playerSpawn.Add(playerOffset).Add(extraOffset), true)
+		firstPos, secondPos := g.coopSpawns()
+		g.createBase(g.world.players[0], firstPos.Add(extraOffset), true)
+		g.createBase(g.world.players[1], secondPos.Add(extraOffset), true)
 	default:
 		panic(fmt.Sprintf("invalid number of players: %d", len(g.world.config.Players)))
 	}
 }
 
+// coopSpawns returns the bases positions for the two allied players.
+// Only the symmetrical maps use the paired positions;
+// otherwise the players start next to each other.
+func (g *levelGenerator) coopSpawns() (gmath.Vec, gmath.Vec) {
+	playerOffset := gmath.Vec{X: 64, Y: 64}
+	firstPos := g.playerSpawn.Sub(playerOffset)
+	if g.mirrored() {
+		return firstPos, g.pairedPos(firstPos)
+	}
+	return firstPos, g.playerSpawn.Add(playerOffset)
+}
+
 func (g *levelGenerator) createBase(p player, pos gmath.Vec, mainBase bool) {
 	core := g.world.NewColonyCoreNode(colonyConfig{
 		World:  g.world,
@@ -371,20 +561,20 @@ func (g *levelGenerator) createBase(p player, pos gmath.Vec, mainBase bool) {
 	}
 
 	for i := 0; i < 5; i++ {
-		a := core.NewColonyAgentNode(gamedata.WorkerAgentStats, core.pos.Add(g.rng.Offset(-20, 20)))
+		a := core.NewColonyAgentNode(gamedata.WorkerAgentStats, core.pos.Add(detmath.Offset(&g.rng, -20, 20)))
 		g.world.nodeRunner.AddObject(a)
 		a.AssignMode(agentModeStandby, gmath.Vec{}, nil)
 	}
 	if g.world.config.GameMode == gamedata.ModeReverse {
 		for i := 0; i < 5; i++ {
-			a := core.NewColonyAgentNode(gamedata.ScoutAgentStats, core.pos.Add(g.rng.Offset(-20, 20)))
+			a := core.NewColonyAgentNode(gamedata.ScoutAgentStats, core.pos.Add(detmath.Offset(&g.rng, -20, 20)))
 			g.world.nodeRunner.AddObject(a)
 			a.AssignMode(agentModeStandby, gmath.Vec{}, nil)
 		}
 	}
 	if mainBase {
 		for _, stats := range g.world.config.ExtraDrones {
-			a := core.NewColonyAgentNode(stats, core.pos.Add(g.scene.Rand().Offset(-20, 20)))
+			a := core.NewColonyAgentNode(stats, core.pos.Add(detmath.Offset(g.scene.Rand(), -20, 20)))
 			g.world.nodeRunner.AddObject(a)
 			a.AssignMode(agentModeStandby, gmath.Vec{}, nil)
 		}
@@ -408,13 +598,8 @@ func (g *levelGenerator) placeCreepsClusterImpl(sector gmath.Rect, maxSize int,
 	pos := correctedPos(sector, g.randomPos(sector), config.Pad)
 	initialPos := pos
 	unitPos := pos
-	for i := 0; i < maxSize; i++ {
-		if stats.Building {
-			pos = g.world.AdjustCellPos(pos, 6)
-		}
-		if !posIsFree(g.world, nil, pos, 24) || pos.DistanceTo(g.playerSpawn) < 520 {
-			break
-		}
+	mirrored := g.mirrored() && !config.NoMirror
+	createCreep := func(pos gmath.Vec) *creepNode {
 		creep := g.world.NewCreepNode(pos, stats)
 		if config.CreepInit != nil {
 			config.CreepInit(creep)
@@ -423,12 +608,31 @@ func (g *levelGenerator) placeCreepsClusterImpl(sector gmath.Rect, maxSize int,
 			creep.specialModifier = crawlerGuard
 		}
 		g.world.nodeRunner.AddObject(creep)
+		return creep
+	}
+	for i := 0; i < maxSize; i++ {
+		if stats.Building {
+			pos = g.world.AdjustCellPos(pos, 6)
+		}
+		if !posIsFree(g.world, nil, pos, 24) || g.spawnDistance(pos) < 520 {
+			break
+		}
+		if mirrored {
+			// Both the creep and its copy should be placeable.
+			reflected := g.reflectPos(pos)
+			if !g.inPrimaryHalf(pos) || !posIsFree(g.world, nil, reflected, 24) || g.spawnDistance(reflected) < 520 {
+				break
+			}
+			createCreep(reflected)
+			placed++
+		}
+		creep := createCreep(pos)
 		unitPos = pos
-		direction := gmath.RadToVec(rand.Rad()).Mulf(32)
+		direction := detmath.RadToVec(detmath.RandRad(rand))
 		if rand.Bool() {
-			pos = initialPos.Add(direction)
+			pos = detmath.AddScaled(initialPos, direction, 32)
 		} else {
-			pos = pos.Add(direction)
+			pos = detmath.AddScaled(pos, direction, 32)
 		}
 		placedCreep = creep
 		placed++
@@ -437,9 +641,12 @@ func (g *levelGenerator) placeCreepsClusterImpl(sector gmath.Rect, maxSize int,
 	if !config.NoScraps && placed != 0 && rand.Chance(0.7) {
 		numScraps := rand.IntRange(1, 2)
 		for i := 0; i < numScraps; i++ {
-			scrapPos := g.adjustResourcePos(gmath.RadToVec(rand.Rad()).Mulf(rand.FloatRange(64, 128)).Add(unitPos))
+			scrapPos := g.adjustResourcePos(detmath.AddScaled(unitPos, detmath.RadToVec(detmath.RandRad(rand)), detmath.FloatRange(rand, 64, 128)))
 			if posIsFree(g.world, nil, scrapPos, 8) {
 				g.world.CreateScrapsAt(scrapSource, scrapPos)
+				if mirrored && posIsFree(g.world, nil, g.reflectPos(scrapPos), 8) {
+					g.world.CreateScrapsAt(scrapSource, g.reflectPos(scrapPos))
+				}
 			}
 		}
 	}
@@ -456,7 +663,7 @@ func (g *levelGenerator) checkResourceMinDist(pos gmath.Vec, minDistSqr float64,
 	}
 	otherSpots := g.resourcesByStats[kind]
 	for _, res := range otherSpots {
-		if res.pos.DistanceSquaredTo(pos) < minDistSqr {
+		if detmath.DistanceSquaredTo(res.pos, pos) < minDistSqr {
 			return false
 		}
 	}
@@ -480,20 +687,28 @@ func (g *levelGenerator) placeResourceCluster(sector gmath.Rect, maxSize int, mi
 	for i := 0; i < maxSize; i++ {
 		pos = g.adjustResourcePos(pos)
 		if g.world.config.GameMode == gamedata.ModeTutorial {
-			if pos.DistanceTo(g.playerSpawn) < 220 {
+			if detmath.DistanceTo(pos, g.playerSpawn) < 220 {
 				break
 			}
 		}
 		if !posIsFree(g.world, nil, pos, 8) || !g.checkResourceMinDist(pos, minDistSqr, kind) {
 			break
 		}
+		if g.mirrored() {
+			reflected := g.reflectPos(pos)
+			if !g.inPrimaryHalf(pos) || !posIsFree(g.world, nil, reflected, 8) {
+				break
+			}
+			addedSpots = append(addedSpots, g.world.NewEssenceSourceNode(kind, reflected))
+			placed++
+		}
 		source := g.world.NewEssenceSourceNode(kind, pos)
 		addedSpots = append(addedSpots, source)
-		direction := gmath.RadToVec(rand.Rad()).Mulf(32)
+		direction := detmath.RadToVec(detmath.RandRad(rand))
 		if rand.Bool() {
-			pos = initialPos.Add(direction)
+			pos = detmath.AddScaled(initialPos, direction, 32)
 		} else {
-			pos = pos.Add(direction)
+			pos = detmath.AddScaled(pos, direction, 32)
 		}
 		placed++
 	}
@@ -533,6 +748,7 @@ func (g *levelGenerator) placeResources() {
 
 	numRedOil := 0
 	numRedCrystals := 0
+	numFrostCrystals := 0
 	if g.world.config.EliteResources {
 		numRedOil = gmath.ClampMin(int(float64(rand.IntRange(2, 3))*multiplier), 2)
 		numRedCrystals = int(float64(rand.IntRange(10, 15)) * multiplier)
@@ -565,6 +781,12 @@ func (g *levelGenerator) placeResources() {
 		numIron = 0
 		numOrganic = 0
 		numRedCrystals = int(float64(numRedCrystals) * 1.1)
+	case gamedata.EnvIce:
+		numSulfur = 0
+		numOrganic = 0
+		numOil /= 2
+		numCrystals /= 2
+		numFrostCrystals = int(float64(rand.IntRange(10, 14)) * multiplier)
 	}
 
 	if !g.world.config.GoldEnabled {
@@ -621,6 +843,12 @@ func (g *levelGenerator) placeResources() {
 		g.sectorSlider.Inc()
 		numSulfur -= g.placeResourceCluster(sector, gmath.ClampMax(clusterSize, numSulfur), 160, sulfurSource)
 	}
+	for numFrostCrystals > 0 {
+		clusterSize := rand.IntRange(1, 3)
+		sector := g.sectors[g.sectorSlider.Value()]
+		g.sectorSlider.Inc()
+		numFrostCrystals -= g.placeResourceCluster(sector, gmath.ClampMax(clusterSize, numFrostCrystals), 140, frostCrystalSource)
+	}
 	for numOil > 0 {
 		sector := g.sectors[g.sectorSlider.Value()]
 		g.sectorSlider.Inc()
@@ -681,9 +909,13 @@ func (g *levelGenerator) deployStartingResources() {
 	// If there are no resources near the colony spawn pos,
 	// place something in there.
 	for _, core := range g.world.allColonies {
+		if !g.inPrimaryHalf(core.pos) {
+			// The other half colonies will get the reflected resources.
+			continue
+		}
 		hasResources := xslices.ContainsWhere(g.world.essenceSources, func(source *essenceSourceNode) bool {
 			// We don't count scraps as some viable starting resource.
-			return source.pos.DistanceTo(core.pos) <= minResourceDist(source.stats) &&
+			return detmath.DistanceTo(source.pos, core.pos) <= minResourceDist(source.stats) &&
 				source.stats != scrapSource &&
 				source.stats != smallScrapSource &&
 				source.stats != redCrystalSource &&
@@ -702,15 +934,22 @@ func (g *levelGenerator) deployStartingResources() {
 			case gamedata.EnvInferno:
 				res = goldSource
 				resNum = 1
+			case gamedata.EnvIce:
+				res = frostCrystalSource
+				resNum = 1
 			}
 			for i := 0; i < resNum; i++ {
 				for j := 0; j < 5; j++ {
-					pos := g.adjustResourcePos(gmath.RadToVec(rand.Rad()).Mulf(80).Add(core.pos))
+					pos := g.adjustResourcePos(detmath.AddScaled(core.pos, detmath.RadToVec(detmath.RandRad(rand)), 80))
 					if !posIsFree(g.world, nil, pos, 14) {
 						continue
 					}
 					essence := g.world.NewEssenceSourceNode(res, pos)
 					g.pendingResources = append(g.pendingResources, essence)
+					if g.mirrored() && posIsFree(g.world, nil, g.reflectPos(pos), 14) {
+						reflected := g.world.NewEssenceSourceNode(res, g.reflectPos(pos))
+						g.pendingResources = append(g.pendingResources, reflected)
+					}
 					break
 				}
 			}
@@ -753,13 +992,13 @@ func (g *levelGenerator) placeBoss() {
 	if g.world.config.GameMode == gamedata.ModeReverse {
 		boss.specialDelay = 60 * 60 * 60 // ~never
 	} else {
-		boss.specialDelay = g.rng.FloatRange(3*60, 4*60)
+		boss.specialDelay = detmath.FloatRange(&g.rng, 3*60, 4*60)
 	}
 	boss.super = g.world.config.SuperCreeps
 	g.world.nodeRunner.AddObject(boss)
 
 	if g.world.config.GameMode == gamedata.ModeReverse || g.world.config.CoordinatorCreeps {
-		coordinator := g.world.NewCreepNode(pos.Add(g.rng.Offset(-32, 32)), gamedata.CenturionCreepStats)
+		coordinator := g.world.NewCreepNode(pos.Add(detmath.Offset(&g.rng, -32, 32)), gamedata.CenturionCreepStats)
 		g.world.nodeRunner.AddObject(coordinator)
 	}
 
@@ -796,11 +1035,11 @@ func (g *levelGenerator) placeCreeps() {
 			CreepInit: func(creep *creepNode) {
 				if !placedSuperMortar && g.world.config.SuperCreeps {
 					creep.super = true
-					placedSuperMortar = true
 				}
 			},
 		})
 		if creep != nil {
+			placedSuperMortar = true
 			region := gmath.Rect{
 				Min: creep.pos.Sub(gmath.Vec{X: 96, Y: 96}),
 				Max: creep.pos.Add(gmath.Vec{X: 96, Y: 96}),
@@ -873,7 +1112,7 @@ func (g *levelGenerator) placeCreepBases() {
 	for numWispLairs > 0 {
 		sector := g.sectors[g.sectorSlider.Value()]
 		g.sectorSlider.Inc()
-		numWispLairs -= g.placeCreepsCluster(sector, 1, gamedata.WispLairCreepStats, creepPlacingConfig{Pad: 196})
+		numWispLairs -= g.placeCreepsCluster(sector, 1, gamedata.WispLairCreepStats, creepPlacingConfig{Pad: 196, NoMirror: true})
 	}
 
 	numFortresses := 0
@@ -884,7 +1123,7 @@ func (g *levelGenerator) placeCreepBases() {
 	for numFortresses > 0 {
 		sector := g.sectors[g.sectorSlider.Value()]
 		g.sectorSlider.Inc()
-		numFortresses -= g.placeCreepsCluster(sector, 1, gamedata.FortressCreepStats, creepPlacingConfig{Pad: 256})
+		numFortresses -= g.placeCreepsCluster(sector, 1, gamedata.FortressCreepStats, creepPlacingConfig{Pad: 256, NoMirror: true})
 	}
 
 	if hasWispLair || hasFortresses {
@@ -916,9 +1155,16 @@ func (g *levelGenerator) placeCreepBases() {
 		return // Zero bases
 	}
 
+	numBases := g.world.config.NumCreepBases
+	if g.mirrored() {
+		// Every base has a symmetrical copy, so the
+		// number of bases is rounded up to an even number.
+		numBases = (numBases + 1) / 2
+	}
+
 	if g.world.mapShape != gamedata.WorldSquare {
 		g.activeSectorSlider.TrySetValue(g.rng.IntRange(0, len(g.activeSectors)-1))
-		for i := 0; i < g.world.config.NumCreepBases; i++ {
+		for i := 0; i < numBases; i++ {
 			sector := g.activeSectors[g.activeSectorSlider.Value()]
 			g.activeSectorSlider.Inc()
 			basePos := g.randomFreePos(sector, 48, 140)
@@ -940,10 +1186,21 @@ func (g *levelGenerator) placeCreepBases() {
 			// bottom border
 			{Min: gmath.Vec{X: pad, Y: g.world.height - borderWidth - pad}, Max: gmath.Vec{X: g.world.width - pad, Y: g.world.height - pad}},
 		}
+		if g.mirrored() {
+			clipped := borders[:0]
+			for _, border := range borders {
+				border = g.clipRect(border)
+				if border.IsEmpty() {
+					continue
+				}
+				clipped = append(clipped, border)
+			}
+			borders = clipped
+		}
 		gmath.Shuffle(&g.rng, borders)
 		var borderSlider gmath.Slider
 		borderSlider.SetBounds(0, len(borders)-1)
-		for i := 0; i < g.world.config.NumCreepBases; i++ {
+		for i := 0; i < numBases; i++ {
 			border := borders[borderSlider.Value()]
 			borderSlider.Inc()
 			basePos := g.randomFreePos(border, 48, 32)
@@ -955,7 +1212,7 @@ func (g *levelGenerator) placeCreepBases() {
 
 func (g *levelGenerator) createCreepBase(i int, basePos gmath.Vec) {
 	if g.world.debugLogs {
-		g.world.sessionState.Logf("deployed a creep base %d at %v distance is %f", i+1, basePos, basePos.DistanceTo(g.playerSpawn))
+		g.world.sessionState.Logf("deployed a creep base %d at %v distance is %f", i+1, basePos, detmath.DistanceTo(basePos, g.playerSpawn))
 	}
 	baseRegion := gmath.Rect{
 		Min: basePos.Sub(gmath.Vec{X: 148, Y: 148}),
@@ -985,13 +1242,23 @@ func (g *levelGenerator) createCreepBase(i int, basePos gmath.Vec) {
 	base := g.world.NewCreepNode(basePos, gamedata.BaseCreepStats)
 	base.super = super
 	if i == 1 || i == 3 {
-		base.specialDelay = (9 * 60.0) * g.rng.FloatRange(0.9, 1.1)
+		base.specialDelay = (9 * 60.0) * detmath.FloatRange(&g.rng, 0.9, 1.1)
 	} else {
 		base.specialModifier = 1.0 // Initial base level
-		base.specialDelay = g.rng.FloatRange(60, 120)
-		base.attackDelay = g.rng.FloatRange(40, 50)
+		base.specialDelay = detmath.FloatRange(&g.rng, 60, 120)
+		base.attackDelay = detmath.FloatRange(&g.rng, 40, 50)
 	}
 	g.world.nodeRunner.AddObject(base)
+
+	if g.mirrored() {
+		// The copy has the same timings, so both sides are attacked at the same time.
+		reflected := g.world.NewCreepNode(g.reflectPos(basePos), gamedata.BaseCreepStats)
+		reflected.super = base.super
+		reflected.specialModifier = base.specialModifier
+		reflected.specialDelay = base.specialDelay
+		reflected.attackDelay = base.attackDelay
+		g.world.nodeRunner.AddObject(reflected)
+	}
 }
 
 func (g *levelGenerator) placeLandmarks() {
@@ -1001,6 +1268,8 @@ func (g *levelGenerator) placeLandmarks() {
 	case gamedata.EnvInferno:
 		g.placeLavaPuddles()
 		g.placeLavaGeysers()
+	case gamedata.EnvIce:
+		g.placeIceLakes()
 	}
 }
 
@@ -1041,6 +1310,9 @@ func (g *levelGenerator) placeLavaPuddles() {
 		maxPuddles = 35
 	}
 	numPuddles := rand.IntRange(minPuddles, maxPuddles)
+	if g.mirrored() {
+		numPuddles = (numPuddles + 1) / 2
+	}
 
 	canPlacePuddle := func(pos gmath.Vec, width, height int) bool {
 		for offsetY := 0.0; offsetY < float64(height)*32; offsetY += 32 {
@@ -1097,17 +1369,103 @@ func (g *levelGenerator) placeLavaPuddles() {
 		rectOrigin := pos.Sub(gmath.Vec{X: 16, Y: 16})
 		rect := gmath.Rect{
 			Min: rectOrigin,
-			Max: rectOrigin.Add(gmath.Vec{X: float64(width) * 32, Y: float64(height) * 32}),
+			Max: rectOrigin.Add(gmath.Vec{X: float64(float64(width) * 32), Y: float64(float64(height) * 32)}),
 		}
 		rect.Max.X = math.Ceil(rect.Max.X)
 		rect.Max.Y = math.Ceil(rect.Max.Y)
-		puddle := newLavaPuddleNode(g.world, rect)
-		g.world.nodeRunner.AddObject(puddle)
-		g.world.lavaPuddles = append(g.world.lavaPuddles, puddle)
-		g.fillPathgridRect(rect, ptagLava)
+		if g.mirrored() {
+			reflected := g.reflectRect(rect)
+			if !g.rectInPrimaryHalf(rect) || !canPlacePuddle(reflected.Min.Add(gmath.Vec{X: 16, Y: 16}), width, height) {
+				continue
+			}
+			g.createLavaPuddle(reflected)
+		}
+		g.createLavaPuddle(rect)
 	}
 }
 
+func (g *levelGenerator) createLavaPuddle(rect gmath.Rect) {
+	puddle := newLavaPuddleNode(g.world, rect)
+	g.world.nodeRunner.AddObject(puddle)
+	g.world.lavaPuddles = append(g.world.lavaPuddles, puddle)
+	g.fillPathgridRect(rect, ptagLava)
+}
+
+func (g *levelGenerator) placeIceLakes() {
+	rand := g.world.rand
+
+	minLakes := 5
+	maxLakes := 7
+	switch g.world.config.WorldSize {
+	case 1:
+		minLakes = 8
+		maxLakes = 11
+	case 2:
+		minLakes = 14
+		maxLakes = 19
+	case 3:
+		minLakes = 22
+		maxLakes = 27
+	}
+	numLakes := rand.IntRange(minLakes, maxLakes)
+	if g.mirrored() {
+		numLakes = (numLakes + 1) / 2
+	}
+
+	canPlaceLake := func(pos gmath.Vec, width, height int) bool {
+		for offsetY := 0.0; offsetY < float64(height)*32; offsetY += 32 {
+			for offsetX := 0.0; offsetX < float64(width)*32; offsetX += 32 {
+				checkPos := pos.Add(gmath.Vec{X: offsetX, Y: offsetY})
+				if !posIsFree(g.world, nil, checkPos, 40) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	g.sectorSlider.TrySetValue(rand.IntRange(0, len(g.sectors)-1))
+	for i := 0; i < numLakes; i++ {
+		sector := g.sectors[g.sectorSlider.Value()]
+		g.sectorSlider.Inc()
+		pos := g.randomFreePos(sector, 64, 196)
+		if pos.IsZero() {
+			continue
+		}
+		pos = g.world.pathgrid.AlignPos(pos)
+		width := rand.IntRange(2, 6)
+		height := rand.IntRange(2, 4)
+		if rand.Bool() {
+			width, height = height, width
+		}
+		if !canPlaceLake(pos, width, height) {
+			continue
+		}
+		rectOrigin := pos.Sub(gmath.Vec{X: 16, Y: 16})
+		rect := gmath.Rect{
+			Min: rectOrigin,
+			Max: rectOrigin.Add(gmath.Vec{X: float64(float64(width) * 32), Y: float64(float64(height) * 32)}),
+		}
+		rect.Max.X = math.Ceil(rect.Max.X)
+		rect.Max.Y = math.Ceil(rect.Max.Y)
+		if g.mirrored() {
+			reflected := g.reflectRect(rect)
+			if !g.rectInPrimaryHalf(rect) || !canPlaceLake(reflected.Min.Add(gmath.Vec{X: 16, Y: 16}), width, height) {
+				continue
+			}
+			g.createIceLake(reflected)
+		}
+		g.createIceLake(rect)
+	}
+}
+
+func (g *levelGenerator) createIceLake(rect gmath.Rect) {
+	lake := newIceLakeNode(g.world, rect)
+	g.world.nodeRunner.AddObject(lake)
+	g.world.iceLakes = append(g.world.iceLakes, lake)
+	g.fillPathgridRect(rect, ptagFrozenLake)
+}
+
 func (g *levelGenerator) placeLavaGeysers() {
 	rand := g.world.rand
 
@@ -1125,6 +1483,9 @@ func (g *levelGenerator) placeLavaGeysers() {
 		maxGeysers = 22
 	}
 	numGeysers := rand.IntRange(minGeysers, maxGeysers)
+	if g.mirrored() {
+		numGeysers = (numGeysers + 1) / 2
+	}
 
 	g.sectorSlider.TrySetValue(rand.IntRange(0, len(g.sectors)-1))
 	for i := 0; i < numGeysers; i++ {
@@ -1135,12 +1496,19 @@ func (g *levelGenerator) placeLavaGeysers() {
 			continue
 		}
 		adjustedPos := g.world.AdjustCellPos(pos, 6)
-		geyser := newLavaGeyserNode(g.world, adjustedPos)
-		g.world.nodeRunner.AddObject(geyser)
-		g.world.lavaGeysers = append(g.world.lavaGeysers, geyser)
+		g.createLavaGeyser(adjustedPos)
+		if g.mirrored() {
+			g.createLavaGeyser(g.reflectPos(adjustedPos))
+		}
 	}
 }
 
+func (g *levelGenerator) createLavaGeyser(pos gmath.Vec) {
+	geyser := newLavaGeyserNode(g.world, pos)
+	g.world.nodeRunner.AddObject(geyser)
+	g.world.lavaGeysers = append(g.world.lavaGeysers, geyser)
+}
+
 func (g *levelGenerator) placeForests() {
 	if g.world.envKind != gamedata.EnvForest {
 		return
@@ -1148,9 +1516,12 @@ func (g *levelGenerator) placeForests() {
 
 	rand := &g.rng
 
-	playerTerritory := gmath.Rect{
-		Min: g.playerSpawn.Sub(gmath.Vec{X: 96, Y: 96}),
-		Max: g.playerSpawn.Add(gmath.Vec{X: 96, Y: 96}),
+	playerTerritories := make([]gmath.Rect, len(g.spawns))
+	for i, spawn := range g.spawns {
+		playerTerritories[i] = gmath.Rect{
+			Min: spawn.Sub(gmath.Vec{X: 96, Y: 96}),
+			Max: spawn.Add(gmath.Vec{X: 96, Y: 96}),
+		}
 	}
 
 	var maxForests int
@@ -1193,8 +1564,8 @@ func (g *levelGenerator) placeForests() {
 			width := g.world.rand.IntRange(minForestSize, maxForestSize)
 			height := g.world.rand.IntRange(minForestSize, maxForestSize)
 
-			xOverflow := (pos.X + float64(width)*32) - (g.world.width - 32.0)
-			yOverflow := (pos.Y + float64(height)*32) - (g.world.height - 32.0)
+			xOverflow := (pos.X + float64(float64(width)*32)) - (g.world.width - 32.0)
+			yOverflow := (pos.Y + float64(float64(height)*32)) - (g.world.height - 32.0)
 			if xOverflow > 0 {
 				pos.X -= xOverflow
 			}
@@ -1207,18 +1578,26 @@ func (g *levelGenerator) placeForests() {
 				width:  width,
 				height: height,
 			})
-			if forest.outerRect.Overlaps(playerTerritory) {
+			if g.forestOverlaps(forest, playerTerritories) {
 				continue
 			}
 
-			trees = append(trees, forest.init(g.scene)...)
-
-			// TODO: move it to fillPathgrid step or maybe get rid of that stage instead?
-			forest.walkRects(func(rect gmath.Rect) {
-				g.fillPathgridRect(rect, ptagForest)
-			})
+			if g.mirrored() {
+				if !g.rectInPrimaryHalf(forest.outerRect) {
+					continue
+				}
+				reflected := newForestClusterNode(g.world, forestClusterConfig{
+					pos:    g.reflectRect(forest.outerRect).Min,
+					width:  width,
+					height: height,
+				})
+				if g.forestOverlaps(reflected, playerTerritories) {
+					continue
+				}
+				trees = append(trees, g.createForest(reflected)...)
+			}
 
-			g.world.forests = append(g.world.forests, forest)
+			trees = append(trees, g.createForest(forest)...)
 		}
 	}
 
@@ -1232,6 +1611,24 @@ func (g *levelGenerator) placeForests() {
 	}
 }
 
+func (g *levelGenerator) forestOverlaps(forest *forestClusterNode, territories []gmath.Rect) bool {
+	return xslices.ContainsWhere(territories, func(r gmath.Rect) bool {
+		return forest.outerRect.Overlaps(r)
+	})
+}
+
+func (g *levelGenerator) createForest(forest *forestClusterNode) []pendingImage {
+	trees := forest.init(g.scene)
+
+	// TODO: move it to fillPathgrid step or maybe get rid of that stage instead?
+	forest.walkRects(func(rect gmath.Rect) {
+		g.fillPathgridRect(rect, ptagForest)
+	})
+
+	g.world.forests = append(g.world.forests, forest)
+	return trees
+}
+
 func (g *levelGenerator) placeWalls() {
 	rand := &g.rng
 
@@ -1260,6 +1657,11 @@ func (g *levelGenerator) placeWalls() {
 		numMountains = int(float64(numMountains) * 1.2)
 	}
 
+	if g.mirrored() {
+		numWallClusters = (numWallClusters + 1) / 2
+		numMountains = (numMountains + 1) / 2
+	}
+
 	const (
 		// A simple 1x1 wall tile (rect shape: true).
 		wallPit int = iota
@@ -1356,8 +1758,8 @@ func (g *levelGenerator) placeWalls() {
 
 		// Wall positions should be rounded to a tile size.
 		{
-			x := math.Floor(pos.X/wallTileSize) * wallTileSize
-			y := math.Floor(pos.Y/wallTileSize) * wallTileSize
+			x := float64(math.Floor(pos.X/wallTileSize) * wallTileSize)
+			y := float64(math.Floor(pos.Y/wallTileSize) * wallTileSize)
 			pos = gmath.Vec{X: x + wallTileSize/2, Y: y + wallTileSize/2}
 		}
 
@@ -1478,9 +1880,7 @@ func (g *levelGenerator) placeWalls() {
 		if len(config.points) != 0 {
 			config.atlas = wallAtras{layers: landcrackAtlas}
 			config.world = g.world
-			wall := g.world.NewWallClusterNode(config)
-			g.scene.AddObject(wall)
-			wall.initOriented(g.bg, g.scene)
+			g.createWallCluster(config)
 		}
 	}
 
@@ -1560,8 +1960,48 @@ func (g *levelGenerator) placeWalls() {
 		var config wallClusterConfig
 		config.chunks = chunks
 		config.world = g.world
-		wall := g.world.NewWallClusterNode(config)
-		g.scene.AddObject(wall)
+		g.createWallCluster(config)
+	}
+}
+
+func (g *levelGenerator) createWallCluster(config wallClusterConfig) {
+	if !g.mirrored() {
+		g.initWallCluster(config)
+		return
+	}
+
+	// Only the primary half parts are kept, so the
+	// wall and its copy never overlap.
+	config.points = xslices.RemoveIf(config.points, func(p gmath.Vec) bool {
+		return !g.inPrimaryHalf(p)
+	})
+	config.chunks = xslices.RemoveIf(config.chunks, func(chunk wallChunk) bool {
+		return !g.inPrimaryHalf(chunk.pos)
+	})
+	if len(config.points) == 0 && len(config.chunks) == 0 {
+		return
+	}
+
+	reflected := config
+	reflected.points = make([]gmath.Vec, len(config.points))
+	for i, p := range config.points {
+		reflected.points[i] = g.reflectPos(p)
+	}
+	reflected.chunks = make([]wallChunk, len(config.chunks))
+	for i, chunk := range config.chunks {
+		reflected.chunks[i] = wallChunk{pos: g.reflectPos(chunk.pos), kind: chunk.kind}
+	}
+
+	g.initWallCluster(config)
+	g.initWallCluster(reflected)
+}
+
+func (g *levelGenerator) initWallCluster(config wallClusterConfig) {
+	wall := g.world.NewWallClusterNode(config)
+	g.scene.AddObject(wall)
+	if len(config.chunks) != 0 {
 		wall.initChunks(g.bg, g.scene)
+	} else {
+		wall.initOriented(g.bg, g.scene)
 	}
 }
@@ -0,0 +1,45 @@
+package staging
+
+import (
+	"testing"
+
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/roboden-game/gamedata"
+)
+
+func TestLevelGeneratorCoopSpawns(t *testing.T) {
+	pt := func(x, y float64) gmath.Vec {
+		return gmath.Vec{X: x, Y: y}
+	}
+
+	tests := []struct {
+		symmetry gamedata.WorldSymmetry
+		pairing  gamedata.WorldSymmetry
+		first    gmath.Vec
+		second   gmath.Vec
+	}{
+		// Non-symmetrical maps keep the players next to each other
+		// regardless of the pairing.
+		{gamedata.SymmetryNone, gamedata.SymmetryNone, pt(436, 336), pt(564, 464)},
+		{gamedata.SymmetryNone, gamedata.SymmetryRotational, pt(436, 336), pt(564, 464)},
+		{gamedata.SymmetryNone, gamedata.SymmetryMirrorX, pt(436, 336), pt(564, 464)},
+
+		{gamedata.SymmetryMirrorX, gamedata.SymmetryMirrorX, pt(436, 336), pt(1564, 336)},
+		{gamedata.SymmetryMirrorY, gamedata.SymmetryMirrorY, pt(436, 336), pt(436, 1264)},
+		{gamedata.SymmetryRotational, gamedata.SymmetryRotational, pt(436, 336), pt(1564, 1264)},
+	}
+
+	for i, test := range tests {
+		g := &levelGenerator{
+			world:       &worldState{width: 2000, height: 1600},
+			playerSpawn: pt(500, 400),
+			symmetry:    test.symmetry,
+			pairing:     test.pairing,
+		}
+		first, second := g.coopSpawns()
+		if first != test.first || second != test.second {
+			t.Fatalf("test[%d]: symmetry=%v pairing=%v:\nhave: %v %v\nwant: %v %v",
+				i, test.symmetry, test.pairing, first, second, test.first, test.second)
+		}
+	}
+}
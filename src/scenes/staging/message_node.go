@@ -117,7 +117,7 @@ func (m *messageNode) UpdateText(s string) {
 
 func (m *messageNode) Update(delta float64) {
 	if m.highlight {
-		m.highlightValue = gmath.Clamp(m.highlightValue+(delta*m.highlightStep), 0, 1)
+		m.highlightValue = gmath.Clamp(m.highlightValue+float64(delta*m.highlightStep), 0, 1)
 		if m.highlightValue == 0 {
 			m.highlightStep = +1
 		} else if m.highlightValue == 1 {
@@ -0,0 +1,103 @@
+package staging
+
+import (
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/roboden-game/assets"
+	"github.com/quasilyte/roboden-game/detmath"
+	"github.com/quasilyte/roboden-game/gamedata"
+)
+
+// mineNode is a ground mine planted by a minelayer drone.
+// It detonates when a ground creep gets close enough.
+type mineNode struct {
+	owner  *colonyAgentNode
+	world  *worldState
+	sprite *ge.Sprite
+	pos    gmath.Vec
+
+	armDelay   float64
+	checkDelay float64
+	lifetime   float64
+}
+
+func newMineNode(owner *colonyAgentNode, world *worldState, pos gmath.Vec) *mineNode {
+	return &mineNode{
+		owner: owner,
+		pos:   pos,
+		world: world,
+	}
+}
+
+func (m *mineNode) Init(scene *ge.Scene) {
+	m.sprite = scene.NewSprite(assets.ImageBomb)
+	m.sprite.Pos.Base = &m.pos
+	m.world.stage.AddSprite(m.sprite)
+
+	m.armDelay = 1.5
+	m.lifetime = 90
+}
+
+func (m *mineNode) IsDisposed() bool {
+	return m.sprite.IsDisposed()
+}
+
+func (m *mineNode) dispose() {
+	m.sprite.Dispose()
+}
+
+func (m *mineNode) explode() {
+	createEffect(m.world, effectConfig{
+		Pos:   m.pos,
+		Image: assets.ImageBombExplosion,
+		Layer: normalEffectLayer,
+	})
+	playSound(m.world, assets.AudioExplosion1, m.pos)
+
+	// Mines are weaker than the bomber bombs,
+	// but they're cheap and they can be planted in advance.
+	const mineMaxDamage = 20.0
+	const maxRadius = 48
+	const maxRadiusSqr = maxRadius * maxRadius
+	m.world.WalkCreeps(m.pos, maxRadius, func(creep *creepNode) bool {
+		if creep.IsFlying() {
+			return false
+		}
+		distSqr := detmath.DistanceSquaredTo(m.pos, creep.pos)
+		if distSqr <= maxRadiusSqr {
+			damageMultiplier := 1.0 - ((distSqr * 0.5) / maxRadiusSqr)
+			creep.OnDamage(gamedata.DamageValue{Health: mineMaxDamage * damageMultiplier, Flags: gamedata.DmgflagNoFlash}, m.owner)
+		}
+		return false
+	})
+}
+
+func (m *mineNode) Update(delta float64) {
+	m.lifetime -= delta
+	if m.lifetime <= 0 {
+		m.dispose()
+		return
+	}
+
+	if m.armDelay > 0 {
+		m.armDelay -= delta
+		return
+	}
+
+	m.checkDelay -= delta
+	if m.checkDelay > 0 {
+		return
+	}
+	m.checkDelay = 0.25
+
+	const triggerDistSqr = 20 * 20
+	triggered := m.world.WalkCreeps(m.pos, 20, func(creep *creepNode) bool {
+		return !creep.IsFlying() &&
+			creep.CanBeTargeted() &&
+			detmath.DistanceSquaredTo(creep.pos, m.pos) <= triggerDistSqr
+	})
+	if triggered != nil {
+		m.dispose()
+		m.explode()
+	}
+}
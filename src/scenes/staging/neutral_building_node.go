@@ -44,6 +44,16 @@ func (b *neutralBuildingNode) Init(scene *ge.Scene) {
 	b.world.MarkPos(b.pos, ptagBlocked)
 }
 
+// CanBeCapturedBy reports whether the colony can send a worker to capture this building.
+// Most of the buildings can be captured only while they're not functioning,
+// but the control points can also be taken over from another player.
+func (b *neutralBuildingNode) CanBeCapturedBy(colony *colonyCoreNode) bool {
+	if b.agent == nil {
+		return true
+	}
+	return b.stats == gamedata.ControlPointAgentStats && b.agent.colonyCore.player != colony.player
+}
+
 func (b *neutralBuildingNode) AssignAgent(a *colonyAgentNode) {
 	b.sprite.Visible = a == nil
 	b.agent = a
@@ -18,7 +18,9 @@ type nodeRunner struct {
 
 	numSteps int
 
-	creepCoordinator *creepCoordinator
+	creepCoordinator     *creepCoordinator
+	scavengerCoordinator *scavengerCoordinator
+	influenceMap         *influenceMap
 
 	projectiles      []*projectileNode
 	addedProjectiles []*projectileNode
@@ -91,8 +93,11 @@ func (r *nodeRunner) runTick(computedDelta float64) {
 	r.timePlayed += computedDelta
 	r.ticks++
 
+	r.influenceMap.Update(computedDelta)
 	r.creepCoordinator.Update(computedDelta)
-	r.world.Update()
+	if r.scavengerCoordinator != nil {
+		r.scavengerCoordinator.Update(computedDelta)
+	}
 
 	liveProjectiles := r.projectiles[:0]
 	for _, p := range r.projectiles {
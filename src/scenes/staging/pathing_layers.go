@@ -7,10 +7,21 @@ const (
 	ptagBlocked uint8 = 1
 	ptagForest  uint8 = 2
 	ptagLava    uint8 = 3
+
+	// Frozen lakes can be crossed by the ground units at a normal cost,
+	// but nothing can land there.
+	// Unfrozen lakes are marked as blocked.
+	ptagFrozenLake uint8 = 4
 )
 
+// The path graph cluster size, in cells.
+const pathgraphClusterSize = 8
+
+// The layer values are used as the cell costs by the path graphs:
+// the ground units prefer to walk around the forests.
 var (
-	layerNormal     = pathing.MakeGridLayer(1, 0, 1, 0)
-	layerLandColony = pathing.MakeGridLayer(1, 0, 0, 0)
-	layerFindLava   = pathing.MakeGridLayer(0, 0, 0, 1)
+	layerNormal         = pathing.MakeGridLayer(1, 0, 2, 0, 1)
+	layerLandColony     = pathing.MakeGridLayer(1, 0, 0, 0, 0)
+	layerFindLava       = pathing.MakeGridLayer(0, 0, 0, 1, 0)
+	layerFindFrozenLake = pathing.MakeGridLayer(0, 0, 0, 0, 1)
 )
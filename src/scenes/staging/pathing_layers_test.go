@@ -0,0 +1,20 @@
+package staging
+
+import "testing"
+
+func TestPathingLayers(t *testing.T) {
+	if have, want := layerNormal.Get(ptagFrozenLake), layerNormal.Get(ptagFree); have != want {
+		t.Fatalf("frozen lake cost: have %d, want %d (same as a free cell)", have, want)
+	}
+	if layerNormal.Get(ptagForest) <= layerNormal.Get(ptagFree) {
+		t.Fatalf("forests should be more expensive than the free cells")
+	}
+	if layerLandColony.Get(ptagFrozenLake) != 0 {
+		t.Fatalf("colonies should not land on the frozen lakes")
+	}
+	for _, tag := range []uint8{ptagFree, ptagBlocked, ptagForest, ptagLava} {
+		if layerFindFrozenLake.Get(tag) != 0 {
+			t.Fatalf("tag %d is reported as a frozen lake", tag)
+		}
+	}
+}
@@ -91,7 +91,7 @@ func (state *creepsPlayerState) RecalcMaxCost() {
 	const maxCost = maxArenaGroupBudget * maxCreepGroupsPerSide
 	const maxCostTechRequired = 2.0
 	const multiplier = 1.1 / maxCostTechRequired
-	cost := ((state.techLevel * multiplier) * maxCost) + 10
+	cost := float64((state.techLevel*multiplier)*maxCost) + 10
 	state.maxSideCost = int(gmath.ClampMax(cost, maxCost))
 }
 
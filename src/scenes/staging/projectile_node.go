@@ -7,6 +7,7 @@ import (
 	"github.com/quasilyte/gmath"
 	"github.com/quasilyte/gsignal"
 	"github.com/quasilyte/roboden-game/assets"
+	"github.com/quasilyte/roboden-game/detmath"
 	"github.com/quasilyte/roboden-game/gamedata"
 )
 
@@ -94,15 +95,15 @@ func (p *projectileNode) Init(scene *ge.Scene) {
 
 		arcPower := p.weapon.ArcPower
 		if p.weapon.RandArc {
-			arcPower *= scene.Rand().FloatRange(0.9, 1.5)
+			arcPower *= detmath.FloatRange(scene.Rand(), 0.9, 1.5)
 		}
 
 		speed := p.weapon.ProjectileSpeed
 		if p.weapon.RandArc {
-			p.rotation = p.pos.AngleToPoint(p.toPos)
-			p.rotation += gmath.Rad(scene.Rand().FloatRange(-0.5, 0.5))
-			p.pos = p.pos.MoveInDirection(14, p.rotation)
-			speed *= scene.Rand().FloatRange(0.85, 1.25)
+			p.rotation = detmath.AngleToPoint(p.pos, p.toPos)
+			p.rotation += gmath.Rad(detmath.FloatRange(scene.Rand(), -0.5, 0.5))
+			p.pos = detmath.MoveInDirection(p.pos, 14, p.rotation)
+			speed *= detmath.FloatRange(scene.Rand(), 0.85, 1.25)
 		} else {
 			p.rotation = -math.Pi / 2
 		}
@@ -118,10 +119,10 @@ func (p *projectileNode) Init(scene *ge.Scene) {
 				speed *= 1.4
 			}
 		}
-		dist := p.pos.DistanceTo(p.toPos)
+		dist := detmath.DistanceTo(p.pos, p.toPos)
 		t := dist / speed
 		p.arcProgressionScaling = 1.0 / t
-		power := gmath.Vec{Y: dist * arcPower}
+		power := gmath.Vec{Y: float64(dist * arcPower)}
 		if inversed {
 			p.arcFrom = p.pos.Sub(power)
 			p.arcTo = p.toPos.Sub(power)
@@ -131,9 +132,9 @@ func (p *projectileNode) Init(scene *ge.Scene) {
 		}
 		p.arcStart = p.pos
 	} else if p.weapon.ProjectileRotateSpeed == 0 && p.fireDelay == 0 {
-		p.rotation = p.pos.AngleToPoint(p.toPos)
+		p.rotation = detmath.AngleToPoint(p.pos, p.toPos)
 	} else {
-		p.rotation = scene.Rand().Rad()
+		p.rotation = detmath.RandRad(scene.Rand())
 	}
 
 	if !p.world.simulation {
@@ -147,14 +148,14 @@ func (p *projectileNode) Init(scene *ge.Scene) {
 	if p.weapon.Accuracy != 1.0 && !p.guided {
 		missChance := 1.0 - p.weapon.Accuracy
 		if missChance != 0 && scene.Rand().Chance(missChance) {
-			dist := p.pos.DistanceTo(p.toPos)
+			dist := detmath.DistanceTo(p.pos, p.toPos)
 			// 100 => 25
 			// 200 => 50
 			// 400 => 100
 			offsetValue := gmath.Clamp(dist*0.25, 24, 140)
-			p.toPos = p.toPos.Add(scene.Rand().Offset(-offsetValue, offsetValue))
+			p.toPos = p.toPos.Add(detmath.Offset(scene.Rand(), -offsetValue, offsetValue))
 		} else if p.arcProgressionScaling != 0 {
-			p.toPos = p.toPos.Add(scene.Rand().Offset(-8, 8))
+			p.toPos = p.toPos.Add(detmath.Offset(scene.Rand(), -8, 8))
 		}
 	}
 
@@ -187,7 +188,7 @@ func (p *projectileNode) Update(delta float64) {
 			p.setSpriteVisibility(true)
 			p.pos = p.attacker.GetPos().Add(p.calcOffset())
 			if p.weapon.ProjectileRotateSpeed == 0 {
-				p.rotation = p.pos.AngleToPoint(p.toPos)
+				p.rotation = detmath.AngleToPoint(p.pos, p.toPos)
 			}
 			p.arcStart = p.pos
 			if p.weapon.ProjectileFireSound {
@@ -205,11 +206,11 @@ func (p *projectileNode) Update(delta float64) {
 	}
 
 	if p.arcProgressionScaling == 0 {
-		if p.pos.DistanceTo(p.toPos) <= travelled {
+		if detmath.DistanceTo(p.pos, p.toPos) <= travelled {
 			p.detonate()
 			return
 		}
-		p.pos = p.pos.MoveTowards(p.toPos, travelled)
+		p.pos = detmath.MoveTowards(p.pos, p.toPos, travelled)
 		if p.weapon.ProjectileRotateSpeed != 0 {
 			p.rotation += gmath.Rad(delta * p.weapon.ProjectileRotateSpeed)
 		}
@@ -217,14 +218,14 @@ func (p *projectileNode) Update(delta float64) {
 		return
 	}
 
-	p.arcProgression += delta * p.arcProgressionScaling
+	p.arcProgression += float64(delta * p.arcProgressionScaling)
 	if p.arcProgression >= 1 {
 		p.detonate()
 		return
 	}
-	newPos := p.arcStart.CubicInterpolate(p.arcFrom, p.toPos, p.arcTo, p.arcProgression)
+	newPos := detmath.CubicInterpolate(p.arcStart, p.arcFrom, p.toPos, p.arcTo, p.arcProgression)
 	if !p.weapon.RoundProjectile {
-		p.rotation = p.pos.AngleToPoint(newPos)
+		p.rotation = detmath.AngleToPoint(p.pos, newPos)
 	}
 	p.pos = newPos
 	p.setSpriteVisibility(true)
@@ -238,37 +239,37 @@ func (p *projectileNode) updateTrail(delta float64) {
 
 	switch p.weapon.TrailEffect {
 	case gamedata.ProjectileTrailIonMortar, gamedata.ProjectileTrailSuperIonMortar:
-		p.trailCounter = p.world.localRand.FloatRange(0.06, 0.12)
+		p.trailCounter = detmath.FloatRange(p.world.localRand, 0.06, 0.12)
 		img := assets.ImageIonMortarTrail
 		if p.weapon.TrailEffect == gamedata.ProjectileTrailSuperIonMortar {
 			img = assets.ImageSuperIonMortarTrail
 		}
 		p.world.nodeRunner.AddObject(newEffectNode(p.world, p.pos, aboveEffectLayer, img))
 	case gamedata.ProjectileTrailTankColonyWeapon1:
-		p.trailCounter = p.world.localRand.FloatRange(0.09, 0.15)
+		p.trailCounter = detmath.FloatRange(p.world.localRand, 0.09, 0.15)
 		effect := newEffectNode(p.world, p.pos, aboveEffectLayer, assets.ImageTankColonyWeapon1Trail)
-		effect.rotation = p.world.localRand.Rad()
+		effect.rotation = detmath.RandRad(p.world.localRand)
 		p.world.nodeRunner.AddObject(effect)
 	case gamedata.ProjectileTrailSmoke:
-		p.trailCounter = p.world.localRand.FloatRange(0.1, 0.3)
+		p.trailCounter = detmath.FloatRange(p.world.localRand, 0.1, 0.3)
 		p.world.nodeRunner.AddObject(newEffectNode(p.world, p.pos, aboveEffectLayer, assets.ImageProjectileSmoke))
 	case gamedata.ProjectileTrailRoomba:
-		p.trailCounter = p.world.localRand.FloatRange(0.1, 0.2)
+		p.trailCounter = detmath.FloatRange(p.world.localRand, 0.1, 0.2)
 		effect := newEffectNode(p.world, p.pos, slightlyAboveEffectLayer, assets.ImageRoombaLaserTrail)
 		effect.rotation = p.rotation
 		p.world.nodeRunner.AddObject(effect)
 	case gamedata.ProjectileTrailEnergySpear:
-		p.trailCounter = p.world.localRand.FloatRange(0.03, 0.08)
+		p.trailCounter = detmath.FloatRange(p.world.localRand, 0.03, 0.08)
 		effect := newEffectNode(p.world, p.pos, slightlyAboveEffectLayer, assets.ImageEnergySpearTrail)
 		effect.rotation = p.rotation
 		p.world.nodeRunner.AddObject(effect)
 	case gamedata.ProjectileTrailMagma:
-		p.trailCounter = p.world.localRand.FloatRange(0.05, 0.09)
+		p.trailCounter = detmath.FloatRange(p.world.localRand, 0.05, 0.09)
 		effect := newEffectNode(p.world, p.pos, aboveEffectLayer, assets.ImageMagmaTrail)
 		effect.rotation = p.rotation
 		p.world.nodeRunner.AddObject(effect)
 	case gamedata.ProjectileTrailFire:
-		p.trailCounter = p.world.localRand.FloatRange(0.06, 0.1)
+		p.trailCounter = detmath.FloatRange(p.world.localRand, 0.06, 0.1)
 		effect := newEffectNode(p.world, p.pos, aboveEffectLayer, assets.ImageFireTrail)
 		effect.rotation = p.rotation
 		p.world.nodeRunner.AddObject(effect)
@@ -310,7 +311,7 @@ func (p *projectileNode) createExplosion() {
 		layer = aboveEffectLayer
 	}
 
-	explosionPos := p.pos.Add(p.world.localRand.Offset(-4, 4))
+	explosionPos := p.pos.Add(detmath.Offset(p.world.localRand, -4, 4))
 	switch explosionKind {
 	case gamedata.ProjectileExplosionIonBlast:
 		createEffect(p.world, effectConfig{Pos: explosionPos, Image: assets.ImageIonBlast, Layer: layer})
@@ -396,7 +397,7 @@ func (p *projectileNode) detonate() {
 	if p.guided {
 		impactAreaSqr *= 1.1
 	}
-	if p.toPos.DistanceSquaredTo(*p.target.GetPos()) > impactAreaSqr {
+	if detmath.DistanceSquaredTo(p.toPos, *p.target.GetPos()) > impactAreaSqr {
 		if p.weapon.AlwaysExplodes {
 			p.createExplosion()
 		}
@@ -7,6 +7,7 @@ import (
 	"github.com/quasilyte/ge/xslices"
 	"github.com/quasilyte/gmath"
 	"github.com/quasilyte/roboden-game/assets"
+	"github.com/quasilyte/roboden-game/detmath"
 	"github.com/quasilyte/roboden-game/gamedata"
 )
 
@@ -162,8 +163,8 @@ func (r *radarNode) Init(scene *ge.Scene) {
 	}
 	r.sprite = scene.NewSprite(img)
 	r.sprite.Pos.Offset = gmath.Vec{
-		X: 8 + r.sprite.ImageWidth()/2,
-		Y: 1080/2 - (8 + r.sprite.ImageHeight()/2),
+		X: 8 + float64(r.sprite.ImageWidth()/2),
+		Y: 1080/2 - (8 + float64(r.sprite.ImageHeight()/2)),
 	}
 	r.player.state.camera.UI.AddGraphics(r.sprite)
 
@@ -262,10 +263,19 @@ func (r *radarNode) Update(delta float64) {
 	}
 }
 
+// hiddenByBlizzard reports whether the blizzard
+// hides the object at pos from the radar.
+func (r *radarNode) hiddenByBlizzard(pos gmath.Vec) bool {
+	if r.world.blizzard == nil || !r.world.blizzard.IsActive() {
+		return false
+	}
+	return detmath.DistanceSquaredTo(r.colony.pos, pos) > blizzardRadarRange*blizzardRadarRange
+}
+
 func (r *radarNode) translatePosToOffset(pos gmath.Vec) gmath.Vec {
 	local := gmath.Vec{
-		X: pos.X * r.scaleRatioX,
-		Y: pos.Y * r.scaleRatioY,
+		X: float64(pos.X * r.scaleRatioX),
+		Y: float64(pos.Y * r.scaleRatioY),
 	}
 	return local.Sub(gmath.Vec{X: r.radius, Y: r.radius})
 }
@@ -336,9 +346,9 @@ func (r *radarNode) update(delta float64) {
 		r.setBossVisibility(false)
 		return
 	}
-	radarScanDirection := (r.direction.Normalized() + 2*math.Pi)
-	bossDirection := r.colony.pos.AngleToPoint(r.world.boss.pos).Normalized() + 2*math.Pi
-	if radarScanDirection.AngleDelta2(bossDirection) < 0.1 && !r.bossSpot.Visible {
+	radarScanDirection := (detmath.NormalizedRad(r.direction) + 2*math.Pi)
+	bossDirection := detmath.NormalizedRad(detmath.AngleToPoint(r.colony.pos, r.world.boss.pos)) + 2*math.Pi
+	if radarScanDirection.AngleDelta2(bossDirection) < 0.1 && !r.bossSpot.Visible && !r.hiddenByBlizzard(r.world.boss.pos) {
 		r.setBossVisibility(true)
 		r.bossSpot.SetAlpha(1)
 	}
@@ -351,11 +361,12 @@ func (r *radarNode) update(delta float64) {
 		r.setBossVisibility(false)
 		return
 	}
-	bossDist := r.world.boss.pos.DistanceTo(r.colony.pos)
+	bossDist := detmath.DistanceTo(r.world.boss.pos, r.colony.pos)
 	extraOffset := gmath.Vec{X: 2, Y: 2}
 	if bossDist > r.nearDist {
 		// Boss is far away.
-		r.bossSpot.Pos.Offset = gmath.RadToVec(bossDirection).Mulf(r.nearDistPixels).Sub(extraOffset)
+		dir := detmath.RadToVec(bossDirection)
+		r.bossSpot.Pos.Offset = gmath.Vec{X: float64(dir.X * r.nearDistPixels), Y: float64(dir.Y * r.nearDistPixels)}.Sub(extraOffset)
 		if r.bossSpot.ImageID() != assets.ImageRadarBossFar {
 			r.bossSpot.SetImage(r.scene.LoadImage(assets.ImageRadarBossFar))
 		}
@@ -366,16 +377,16 @@ func (r *radarNode) update(delta float64) {
 			X: r.scaleRatioX * bossDist,
 			Y: r.scaleRatioY * bossDist,
 		}
-		r.bossSpot.Pos.Offset = gmath.RadToVec(bossDirection).Mul(scale).Sub(extraOffset)
+		scaledDir := detmath.RadToVec(bossDirection).Mul(scale)
+		r.bossSpot.Pos.Offset = gmath.Vec{X: float64(scaledDir.X), Y: float64(scaledDir.Y)}.Sub(extraOffset)
 		if r.bossSpot.ImageID() != assets.ImageRadarBossNear {
 			r.bossSpot.SetImage(r.scene.LoadImage(assets.ImageRadarBossNear))
 		}
 		r.bossSpot.Visible = true
 		startPos := r.bossSpot.Pos.Resolve().Add(extraOffset)
-		endPos := r.bossPath.BeginPos.Offset.Add(gmath.RadToVec(r.world.boss.GetVelocity().Angle()).Mulf(r.width))
+		endPos := detmath.AddScaled(r.bossPath.BeginPos.Offset, detmath.RadToVec(r.world.boss.GetVelocity().Angle()), r.width)
 		fromCircleToObject := endPos.Sub(r.pos)
-		fromCircleToObject = fromCircleToObject.Mulf(r.radius / fromCircleToObject.Len())
-		endPos = r.pos.Add(fromCircleToObject)
+		endPos = detmath.AddScaled(r.pos, fromCircleToObject, r.radius/detmath.Len(fromCircleToObject))
 		r.bossPath.BeginPos.Offset = startPos
 		r.bossPath.EndPos.Offset = endPos
 	}
@@ -67,7 +67,7 @@ func (tab *recipeTabNode) Init(scene *ge.Scene) {
 	}
 
 	drawDrone := func(dst *ebiten.Image, stats *gamedata.AgentStats, faction gamedata.FactionTag, cellWidth, offsetX, offsetY float64) {
-		halfWidth := cellWidth * 0.5
+		halfWidth := float64(cellWidth * 0.5)
 		droneImage := scene.LoadImage(stats.Image)
 		droneFrame := getDroneFrame(droneImage)
 		frameSize := droneFrame.Bounds().Size()
@@ -76,12 +76,12 @@ func (tab *recipeTabNode) Init(scene *ge.Scene) {
 		if int(stats.Kind) < len(extraOffsets) {
 			drawOptions.GeoM.Translate(0, extraOffsets[stats.Kind])
 		}
-		drawOptions.GeoM.Translate(halfWidth-(float64(frameSize.X)*0.5), 15-(float64(frameSize.Y)*0.5))
+		drawOptions.GeoM.Translate(halfWidth-float64(float64(frameSize.X)*0.5), 15-float64(float64(frameSize.Y)*0.5))
 		dst.DrawImage(droneFrame, &drawOptions)
 		if faction != gamedata.NeutralFactionTag {
 			drawOptions.GeoM.Reset()
 			drawOptions.GeoM.Translate(offsetX, offsetY)
-			drawOptions.GeoM.Translate(halfWidth-(float64(diodeSize.X)*0.5), 15-(float64(diodeSize.Y)*0.5)+stats.DiodeOffset)
+			drawOptions.GeoM.Translate(halfWidth-float64(float64(diodeSize.X)*0.5), 15-float64(float64(diodeSize.Y)*0.5)+stats.DiodeOffset)
 			drawOptions.ColorM.ScaleWithColor(gamedata.FactionByTag(faction).Color)
 			dst.DrawImage(diode, &drawOptions)
 		}
@@ -102,7 +102,7 @@ func (tab *recipeTabNode) Init(scene *ge.Scene) {
 		panic("should never happen")
 	}
 
-	tab.rects = make([]recipeTabRect, 0, len(tab.world.tier2recipes))
+	tab.rects = make([]recipeTabRect, 0, len(tab.world.tier2recipes)+len(gamedata.Tier4agentMergeRecipes))
 	tab.pos = gmath.Vec{X: 8, Y: 8}
 
 	numRecipes := len(tab.world.config.Tier2Recipes)
@@ -111,12 +111,31 @@ func (tab *recipeTabNode) Init(scene *ge.Scene) {
 	imageWidth := int(droneWidth)*numRecipes + ((numRecipes - 1) * droneSeparator)
 	imageHeight := 15 + 5 + 30
 
+	// The tier 4 recipes are rendered in a second row.
+	// Their subjects are big tier 3 drones, so the cells are wider.
+	numApexRecipes := len(gamedata.Tier4agentMergeRecipes)
+	apexDroneWidth := 70.0
+	apexImageWidth := 0
+	apexImageHeight := 0
+	if numApexRecipes != 0 {
+		apexImageWidth = int(apexDroneWidth)*numApexRecipes + ((numApexRecipes - 1) * droneSeparator)
+		apexImageHeight = 30 + 5 + 30
+	}
+
 	tile := ge.NewRect(scene.Context(), float64(droneWidth+2), float64(imageHeight+2))
 	tile.Centered = false
 	tile.OutlineWidth = 0
 	tile.FillColorScale.SetRGBA(0x13, 0x1a, 0x22, 160)
 
-	combined := ebiten.NewImage(imageWidth+2, imageHeight+2)
+	combinedWidth := imageWidth
+	if apexImageWidth > combinedWidth {
+		combinedWidth = apexImageWidth
+	}
+	combinedHeight := imageHeight + 2
+	if apexImageHeight != 0 {
+		combinedHeight += droneSeparator + apexImageHeight + 2
+	}
+	combined := ebiten.NewImage(combinedWidth+2, combinedHeight)
 	offsetX := 0.0
 	for _, recipe := range tab.world.tier2recipes {
 		rect := gmath.Rect{
@@ -135,6 +154,35 @@ func (tab *recipeTabNode) Init(scene *ge.Scene) {
 		})
 		offsetX += 30.0 + float64(droneSeparator)
 	}
+
+	if numApexRecipes != 0 {
+		apexTile := ge.NewRect(scene.Context(), float64(apexDroneWidth+2), float64(apexImageHeight+2))
+		apexTile.Centered = false
+		apexTile.OutlineWidth = 0
+		apexTile.FillColorScale = tile.FillColorScale
+		offsetX = 0
+		offsetY := float64(imageHeight + 2 + droneSeparator)
+		for _, recipe := range gamedata.Tier4agentMergeRecipes {
+			rect := gmath.Rect{
+				Min: tab.pos.Add(gmath.Vec{X: offsetX, Y: offsetY}),
+				Max: tab.pos.Add(gmath.Vec{X: offsetX + apexDroneWidth + 2, Y: offsetY + float64(apexImageHeight+2)}),
+			}
+			apexTile.DrawWithOffset(combined, gmath.Vec{X: offsetX, Y: offsetY})
+			marginX := 1.0
+			marginY := 1.0
+			drone1 := gamedata.FindRecipeByName(recipe.Drone1.Kind.String()).Result
+			drone2 := gamedata.FindRecipeByName(recipe.Drone2.Kind.String()).Result
+			drawDrone(combined, drone1, gamedata.NeutralFactionTag, 35, marginX+offsetX, offsetY+marginY)
+			drawDrone(combined, drone2, gamedata.NeutralFactionTag, 35, marginX+offsetX+35, offsetY+marginY)
+			drawDrone(combined, recipe.Result, gamedata.NeutralFactionTag, 70, marginX+offsetX, offsetY+30+5+marginY)
+			tab.rects = append(tab.rects, recipeTabRect{
+				drone: recipe.Result,
+				rect:  rect,
+			})
+			offsetX += apexDroneWidth + float64(droneSeparator)
+		}
+	}
+
 	tab.combinedImage = combined
 }
 
@@ -54,7 +54,7 @@ func (p *replayPlayer) Update(computedDelta, delta float64) {
 		if a.Kind == serverapi.ActionMove {
 			ok = p.choiceGen.TryExecute(p.state.selectedColony, -1, gmath.Vec{X: a.Pos[0], Y: a.Pos[1]})
 		} else {
-			ok = p.choiceGen.TryExecute(p.state.selectedColony, int(a.Kind)-1, gmath.Vec{})
+			ok = p.choiceGen.TryExecuteWithParam(p.state.selectedColony, int(a.Kind)-1, gmath.Vec{X: a.Pos[0], Y: a.Pos[1]}, a.Param)
 		}
 		if !ok {
 			fmt.Println("fail at", a.Tick, time.Second*time.Duration(p.world.nodeRunner.timePlayed), "player=", p.state.id, "action=", a.Kind)
@@ -57,6 +57,7 @@ type battleResults struct {
 	DominatorsSurvived int
 
 	T3created       int
+	T4created       int
 	ColoniesBuilt   int
 	RadiusIncreases int
 
@@ -74,6 +75,8 @@ type battleResults struct {
 	RedCrystalsCollected int
 
 	ArenaLevel           int
+	KothScores           [2]int
+	Winner               int
 	Score                int
 	DifficultyScore      int
 	DronePointsAllocated int
@@ -86,6 +89,7 @@ type battleResults struct {
 	Replay [][]serverapi.PlayerAction
 
 	Tier3Drones []gamedata.ColonyAgentKind
+	Tier4Drones []gamedata.ColonyAgentKind
 
 	NumPauses        int
 	NumFastForwards  int
@@ -126,6 +130,8 @@ func (c *resultsController) makeGameReplay() serverapi.GameReplay {
 	replay.GameVersion = gamedata.BuildNumber
 	replay.GameCommit = c.state.GameCommitHash
 	replay.LevelGenChecksum = c.results.LevelGenChecksum
+	replay.RulesetChecksum = gamedata.RulesetChecksum
+	replay.Mod = c.state.ModName
 	replay.Config = c.config.ReplayLevelConfig
 	replay.Actions = c.results.Replay
 	replay.Results.Score = c.results.Score
@@ -150,6 +156,11 @@ func (c *resultsController) updateProgress() {
 
 	stats.TotalPlayTime += c.results.TimePlayed
 
+	if c.config.Mission != "" {
+		c.updateCampaignProgress()
+		return
+	}
+
 	if c.config.GameMode == gamedata.ModeTutorial {
 		if !stats.TutorialCompleted {
 			stats.TotalScore += c.results.Score
@@ -175,6 +186,12 @@ func (c *resultsController) updateProgress() {
 		}
 		stats.Tier3DronesSeen = append(stats.Tier3DronesSeen, k.String())
 	}
+	for _, k := range c.results.Tier4Drones {
+		if xslices.Contains(stats.Tier4DronesSeen, k.String()) {
+			continue
+		}
+		stats.Tier4DronesSeen = append(stats.Tier4DronesSeen, k.String())
+	}
 
 	stats.TotalScore += c.results.Score
 	switch c.config.GameMode {
@@ -213,6 +230,23 @@ func (c *resultsController) updateProgress() {
 	c.rewards.newModes = contentUpdates.ModesUnlocked
 }
 
+func (c *resultsController) updateCampaignProgress() {
+	stats := &c.state.Persistent.PlayerStats
+
+	mission := gamedata.FindCampaignMission(c.config.Mission)
+
+	// The mission score reward is granted by the contentlock package.
+	contentUpdates := contentlock.CompleteCampaignMission(c.state, mission)
+	if c.config.GameMode == gamedata.ModeTutorial {
+		stats.TutorialCompleted = true
+	}
+	c.rewards.newCores = contentUpdates.CoresUnlocked
+	c.rewards.newDrones = contentUpdates.DronesUnlocked
+	c.rewards.newTurrets = contentUpdates.TurretsUnlocked
+	c.rewards.newOptions = contentUpdates.OptionsUnlocked
+	c.rewards.newModes = contentUpdates.ModesUnlocked
+}
+
 func (c *resultsController) Update(delta float64) {
 	if c.state.CombinedInput.ActionIsJustPressed(controls.ActionMenuBack) {
 		if c.rewards.IsEmpty() {
@@ -280,6 +314,8 @@ func (c *resultsController) checkAchievements() ([]string, []string) {
 			unlocked = c.results.TimePlayed.Hours() >= 2
 		case "t3less":
 			unlocked = c.results.T3created == 0
+		case "apex":
+			unlocked = c.results.T4created != 0 && c.config.GameMode != gamedata.ModeReverse
 		case "cheapbuild10":
 			unlocked = c.results.DronePointsAllocated <= 10
 		case "hightension":
@@ -351,7 +387,14 @@ func (c *resultsController) checkAchievements() ([]string, []string) {
 }
 
 func (c *resultsController) calcResultTag() (string, bool) {
-	if c.config.GameMode == gamedata.ModeReverse && c.config.PlayersMode == serverapi.PmodeTwoPlayers {
+	if c.config.GameMode.IsCompetitive() {
+		if c.results.Winner == 1 {
+			return "menu.results.player2_win", false
+		}
+		return "menu.results.player1_win", false
+	}
+	twoSides := c.config.PlayersMode == serverapi.PmodeTwoPlayers || c.config.PlayersMode == serverapi.PmodeTwoBots
+	if c.config.GameMode == gamedata.ModeReverse && twoSides {
 		if c.results.BossDefeated {
 			return "menu.results.player2_win", false
 		}
@@ -406,7 +449,12 @@ func (c *resultsController) initUI() {
 		[2]string{d.Get("menu.results.drones_total"), itoa(c.results.DronesProduced)},
 		[2]string{d.Get("menu.results.creeps_defeated"), itoa(c.results.CreepsDefeated)},
 	)
-	if c.config.GameMode != gamedata.ModeTutorial && c.hasPlayers {
+	if c.config.GameMode == gamedata.ModeKingOfTheHill {
+		lines = append(lines,
+			[2]string{d.Get("menu.results.player1_points"), itoa(c.results.KothScores[0])},
+			[2]string{d.Get("menu.results.player2_points"), itoa(c.results.KothScores[1])},
+		)
+	} else if c.config.GameMode != gamedata.ModeTutorial && c.hasPlayers {
 		if (c.config.GameMode == gamedata.ModeInfArena) || c.results.Victory {
 			if c.highScore {
 				lines = append(lines, [2]string{d.Get("menu.results.score"), fmt.Sprintf("%v (%s)", c.results.Score, d.Get("menu.results.new_record"))})
@@ -30,8 +30,8 @@ var (
 )
 
 func setPriorityIconFrame(s *ge.Sprite, priority colonyPriority, faction gamedata.FactionTag) {
-	offsetX := float64(priority) * 16.0
-	offsetX += float64(faction) * (16.0 * 4)
+	offsetX := float64(float64(priority) * 16.0)
+	offsetX += float64(float64(faction) * (16.0 * 4))
 	s.FrameOffset.X = offsetX
 }
 
@@ -152,13 +152,13 @@ func (panel *rpanelNode) initPrioritiesForColonies() {
 	}
 	for i, priority := range priorities {
 		bar := panel.scene.NewSprite(assets.ImagePriorityBar)
-		bar.Pos.Offset = gmath.Vec{X: (cameraWidth - (panel.layerSprite1.FrameWidth - 16)) + ((18 + bar.FrameWidth) * float64(i))}
+		bar.Pos.Offset = gmath.Vec{X: (cameraWidth - (panel.layerSprite1.FrameWidth - 16)) + float64((18+bar.FrameWidth)*float64(i))}
 		bar.Centered = false
 		panel.cam.UI.AddGraphics(bar)
 
 		icon := panel.scene.NewSprite(assets.ImagePriorityIcons)
 		setPriorityIconFrame(icon, priority, gamedata.NeutralFactionTag)
-		icon.Pos.Offset = gmath.Vec{X: (cameraWidth - (panel.layerSprite1.FrameWidth - 16)) + ((18 + bar.FrameWidth) * float64(i))}
+		icon.Pos.Offset = gmath.Vec{X: (cameraWidth - (panel.layerSprite1.FrameWidth - 16)) + float64((18+bar.FrameWidth)*float64(i))}
 		icon.Centered = false
 		panel.cam.UI.AddGraphicsAbove(icon)
 
@@ -253,7 +253,7 @@ func (panel *rpanelNode) updateMetricsForColony() {
 	totalHeight := 344.0
 	height := topOffset
 	for i, kv := range panel.colony.factionWeights.Elems {
-		factionHeight := kv.Weight * totalHeight
+		factionHeight := float64(kv.Weight * totalHeight)
 		if kv.Key != gamedata.NeutralFactionTag {
 			rect := panel.factionRects[i-1]
 			rect.Height = factionHeight
@@ -265,9 +265,9 @@ func (panel *rpanelNode) updateMetricsForColony() {
 	fullPriorityOffset := 445.0
 	for i, kv := range panel.colony.priorities.Elems {
 		bar := panel.priorityBars[i]
-		bar.Pos.Offset.Y = fullPriorityOffset + ((bar.FrameHeight - 8) * (1.0 - kv.Weight))
+		bar.Pos.Offset.Y = fullPriorityOffset + float64((bar.FrameHeight-8)*(1.0-kv.Weight))
 		icon := panel.priorityIcons[i]
-		icon.Pos.Offset.Y = fullPriorityOffset + ((bar.FrameHeight - 8) * (1.0 - kv.Weight)) - icon.FrameHeight - 1
+		icon.Pos.Offset.Y = fullPriorityOffset + float64((bar.FrameHeight-8)*(1.0-kv.Weight)) - icon.FrameHeight - 1
 	}
 }
 
@@ -277,13 +277,13 @@ func (panel *rpanelNode) updateMetricsForCreeps() {
 
 	{
 		rect := panel.factionRects[0]
-		rect.Height = gmath.ClampMax(panel.creepsState.techLevel, 1) * totalHeight
+		rect.Height = float64(gmath.ClampMax(panel.creepsState.techLevel, 1) * totalHeight)
 		rect.Visible = rect.Height > 0
 		rect.Pos.Offset.Y = topOffset + totalHeight - rect.Height
 	}
 	{
 		rect := panel.factionRects[1]
-		rect.Height = gmath.Clamp(panel.creepsState.techLevel-1, 0, 1) * totalHeight
+		rect.Height = float64(gmath.Clamp(panel.creepsState.techLevel-1, 0, 1) * totalHeight)
 		rect.Visible = rect.Height > 0
 		rect.Pos.Offset.Y = topOffset + totalHeight - rect.Height
 	}
@@ -0,0 +1,303 @@
+package staging
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/roboden-game/gamedata"
+	"github.com/quasilyte/roboden-game/serverapi"
+	"github.com/quasilyte/roboden-game/session"
+)
+
+// SavedGameVersion is a current ActionLog format version.
+// Increment it when the log contents or the resuming process change.
+const SavedGameVersion = 1
+
+// resumeDelta is a fixed update delta used by the game.
+const resumeDelta = 1.0 / 60.0
+
+// resumeFrameBudget is a max time spent on the game re-simulation per frame.
+// It doesn't affect the simulation results, only the number of frames it takes.
+const resumeFrameBudget = 12 * time.Millisecond
+
+// ActionLog describes a running game by its inputs: the level config
+// plus the player actions executed so far.
+//
+// It's not a world state snapshot: none of the nodes are serialized.
+// A game is resumed by re-simulating it from the very first tick
+// with the recorded actions (the simulation is deterministic),
+// so the resuming time grows with the game length.
+// The re-simulation runs in chunks during the first frames of the game;
+// the game is not interactive until it's finished (see Controller.IsResuming).
+//
+// The state hash and the checkpoints are used to validate the resumed state.
+type ActionLog struct {
+	Version         int    `json:"version"`
+	GameVersion     int    `json:"game_version"`
+	RulesetChecksum string `json:"ruleset_checksum"`
+
+	Config serverapi.ReplayLevelConfig `json:"config"`
+
+	// Steps is a number of the executed controller update steps.
+	// A single step can run several ticks (see nodeRunner.Update).
+	Steps int `json:"steps"`
+	Ticks int `json:"ticks"`
+
+	Actions     [][]serverapi.PlayerAction `json:"actions"`
+	Checkpoints []int                      `json:"checkpoints"`
+	StateHash   uint64                     `json:"state_hash"`
+
+	// These results are not a part of the simulation,
+	// they're recorded from the user interactions.
+	NumPauses          int  `json:"num_pauses"`
+	NumFastForwards    int  `json:"num_fast_forwards"`
+	Paused             bool `json:"paused"`
+	OpenedEvolutionTab bool `json:"opened_evolution_tab"`
+}
+
+// SavedGame is an action log stored by the "Save & Quit" pause action.
+// There is only one saved game slot; it's cleared when the game is resumed successfully
+// (see Controller.EventResumed).
+type SavedGame struct {
+	Date time.Time
+	Log  *ActionLog
+}
+
+// LoadSavedGame returns the saved game that can be continued.
+// It returns nil if there is no such game or if it can't be resumed by this build.
+func LoadSavedGame(state *session.State) *SavedGame {
+	var saved SavedGame
+	if err := state.Context.LoadGameData(state.SavedGameDataKey(), &saved); err != nil {
+		state.Logf("load saved game: %v", err)
+		return nil
+	}
+	if saved.Log == nil {
+		return nil
+	}
+	if err := saved.Log.Validate(); err != nil {
+		state.Logf("can't continue the saved game: %v", err)
+		return nil
+	}
+	return &saved
+}
+
+// ClearSavedGame removes the saved game, so it can't be continued twice.
+func ClearSavedGame(state *session.State) {
+	state.Context.SaveGameData(state.SavedGameDataKey(), SavedGame{})
+}
+
+// Validate reports whether this log can be resumed by this game build.
+func (s *ActionLog) Validate() error {
+	if s.Version != SavedGameVersion {
+		return fmt.Errorf("unsupported saved game version %d (want %d)", s.Version, SavedGameVersion)
+	}
+	if s.GameVersion != gamedata.BuildNumber {
+		return fmt.Errorf("saved game version mismatch: %d != %d", s.GameVersion, gamedata.BuildNumber)
+	}
+	if s.RulesetChecksum != gamedata.RulesetChecksum {
+		return fmt.Errorf("saved game ruleset checksum mismatch: %q != %q", s.RulesetChecksum, gamedata.RulesetChecksum)
+	}
+	if s.Config.RawGameMode == "tutorial" {
+		return errors.New("tutorial can't be resumed")
+	}
+	return nil
+}
+
+// NewResumedController creates a controller that continues the logged game.
+// The game is re-simulated during the first controller updates.
+func NewResumedController(state *session.State, mode gamedata.ExecutionMode, s *ActionLog, back ge.SceneController) (*Controller, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	config := gamedata.MakeLevelConfig(mode, s.Config)
+	config.Finalize()
+	if len(s.Actions) != len(config.Players) {
+		return nil, fmt.Errorf("saved game has %d players actions, expected %d", len(s.Actions), len(config.Players))
+	}
+	c := NewController(state, config, back)
+	c.resumeLog = s
+	return c, nil
+}
+
+// RecordActionLog returns the log that can be used to resume the current game.
+// It should be called between the update steps.
+func (c *Controller) RecordActionLog() *ActionLog {
+	if c.resumeLog != nil {
+		// The game is left before it is fully resumed.
+		return c.resumeLog
+	}
+
+	s := &ActionLog{
+		Version:         SavedGameVersion,
+		GameVersion:     gamedata.BuildNumber,
+		RulesetChecksum: gamedata.RulesetChecksum,
+		Config:          c.config.ReplayLevelConfig,
+		Steps:           c.controllerTick,
+		Ticks:           c.nodeRunner.ticks,
+		Actions:         make([][]serverapi.PlayerAction, len(c.world.players)),
+		Checkpoints:     append([]int(nil), c.world.result.DebugCheckpoints...),
+		StateHash:       c.StateHash(),
+
+		NumPauses:          c.world.result.NumPauses,
+		NumFastForwards:    c.world.result.NumFastForwards,
+		Paused:             c.world.result.Paused,
+		OpenedEvolutionTab: c.world.result.OpenedEvolutionTab,
+	}
+
+	for i, p := range c.world.players {
+		var actions []serverapi.PlayerAction
+		if _, ok := p.(*replayPlayer); ok {
+			// The replay player consumes its actions;
+			// the executed ones are the prefix of the original replay.
+			numExecuted := len(c.replayActions[i]) - len(p.GetState().replay)
+			actions = c.replayActions[i][:numExecuted]
+		} else {
+			actions = p.GetState().replay
+		}
+		s.Actions[i] = append([]serverapi.PlayerAction(nil), actions...)
+	}
+
+	return s
+}
+
+// IsResuming reports whether the game re-simulation is not finished yet.
+func (c *Controller) IsResuming() bool {
+	return c.resumeLog != nil
+}
+
+// beginResume prepares the controller for the logged actions re-execution.
+// The re-simulation itself is split across the frames (see continueResume),
+// so the game window stays responsive even if the saved game is long.
+func (c *Controller) beginResume(s *ActionLog) {
+	// The human players are replaced by the replay players
+	// while the recorded actions are being re-executed.
+	c.resumedPlayers = c.world.players
+	c.world.players = make([]player, len(c.resumedPlayers))
+	for i, p := range c.resumedPlayers {
+		c.world.players[i] = p
+		human, ok := p.(*humanPlayer)
+		if !ok {
+			continue
+		}
+		human.state.replay = append([]serverapi.PlayerAction(nil), s.Actions[i]...)
+		c.world.players[i] = newReplayPlayer(c.world, human.state, human.choiceGen)
+	}
+
+	c.world.resuming = true
+	c.createResumeNotices()
+}
+
+// continueResume re-simulates the logged steps until the frame time budget is spent.
+// It reports whether the resuming is over: either the game is fully resumed
+// or the controller is leaving the scene due to a state mismatch.
+func (c *Controller) continueResume(s *ActionLog) bool {
+	start := time.Now()
+	computedDelta := c.nodeRunner.ComputeDelta(resumeDelta)
+	for c.controllerTick < s.Steps {
+		c.runUpdateStep(computedDelta, resumeDelta)
+		if time.Since(start) >= resumeFrameBudget {
+			break
+		}
+	}
+	if c.controllerTick < s.Steps {
+		c.updateResumeNotices(s)
+		return false
+	}
+
+	c.removeResumeNotices()
+	if err := c.finishResume(s); err != nil {
+		c.state.Logf("resume the saved game: %v", err)
+		c.EventResumed.Emit(err)
+		c.leaveScene(c.backController)
+		return true
+	}
+	c.EventResumed.Emit(nil)
+	return true
+}
+
+func (c *Controller) finishResume(s *ActionLog) error {
+	c.world.resuming = false
+
+	players := c.resumedPlayers
+	c.resumedPlayers = nil
+	c.world.players = players
+	for i, p := range players {
+		if human, ok := p.(*humanPlayer); ok {
+			if len(human.state.replay) != 0 {
+				return fmt.Errorf("player %d: %w: %d actions are not executed", i, errBadActionLog, len(human.state.replay))
+			}
+			human.state.replay = append([]serverapi.PlayerAction(nil), s.Actions[i]...)
+			if human.state.selectedColony != nil {
+				human.state.camera.CenterOn(human.state.selectedColony.pos)
+			}
+		}
+	}
+
+	if c.nodeRunner.ticks != s.Ticks {
+		return fmt.Errorf("%w: ticks %d != %d", errBadActionLog, c.nodeRunner.ticks, s.Ticks)
+	}
+	if c.StateHash() != s.StateHash {
+		return fmt.Errorf("%w: state hash %d != %d", errBadActionLog, c.StateHash(), s.StateHash)
+	}
+	checkpoints := c.world.result.DebugCheckpoints
+	if len(checkpoints) != len(s.Checkpoints) {
+		return fmt.Errorf("%w: %d checkpoints, expected %d", errBadActionLog, len(checkpoints), len(s.Checkpoints))
+	}
+	for i := range checkpoints {
+		if checkpoints[i] != s.Checkpoints[i] {
+			return fmt.Errorf("%w: checkpoint %d mismatch", errBadActionLog, i)
+		}
+	}
+
+	c.world.result.NumPauses = s.NumPauses
+	c.world.result.NumFastForwards = s.NumFastForwards
+	c.world.result.Paused = s.Paused
+	c.world.result.OpenedEvolutionTab = s.OpenedEvolutionTab
+
+	if c.fogOfWar != nil {
+		for _, colony := range c.world.allColonies {
+			c.updateFogOfWar(colony.pos)
+		}
+	}
+
+	return nil
+}
+
+// createResumeNotices shows the resuming progress to every human player.
+func (c *Controller) createResumeNotices() {
+	for _, p := range c.resumedPlayers {
+		if _, ok := p.(*humanPlayer); !ok {
+			continue
+		}
+		cam := p.GetState().camera
+		cam.UI.Visible = true
+		// The notice size is computed for the longest text.
+		notice := newScreenTutorialHintNode(cam.Camera, gmath.Vec{}, gmath.Vec{}, c.resumeNoticeText(100))
+		c.resumeNotices = append(c.resumeNotices, notice)
+		c.scene.AddObject(notice)
+		noticeSize := gmath.Vec{X: notice.width, Y: notice.height}
+		notice.SetPos(cam.Rect.Max.Sub(noticeSize).Mulf(0.5))
+		notice.UpdateText(c.resumeNoticeText(0))
+	}
+}
+
+func (c *Controller) updateResumeNotices(s *ActionLog) {
+	text := c.resumeNoticeText((100 * c.controllerTick) / s.Steps)
+	for _, n := range c.resumeNotices {
+		n.UpdateText(text)
+	}
+}
+
+func (c *Controller) removeResumeNotices() {
+	for _, n := range c.resumeNotices {
+		n.Dispose()
+	}
+	c.resumeNotices = nil
+}
+
+func (c *Controller) resumeNoticeText(percentage int) string {
+	return fmt.Sprintf("%s: %3d%%", c.scene.Dict().Get("game.resume.notice"), percentage)
+}
@@ -0,0 +1,174 @@
+package staging_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/roboden-game/gamedata"
+	"github.com/quasilyte/roboden-game/scenes/staging"
+	"github.com/quasilyte/roboden-game/serverapi"
+)
+
+func TestResumeHumanPlayer(t *testing.T) {
+	if testing.Short() {
+		t.Skip("the full game simulation is slow")
+	}
+
+	state := getSimulationState()
+
+	replayConfig := serverapi.ReplayLevelConfig{
+		RawGameMode:     "classic",
+		Seed:            4,
+		Tier2Recipes:    []string{"Fighter", "Skirmisher", "Defender"},
+		TurretDesign:    "Gunpoint",
+		CoreDesign:      "den",
+		PlayersMode:     serverapi.PmodeSinglePlayer,
+		DronesPower:     1,
+		Teleporters:     1,
+		OilRegenRate:    2,
+		Terrain:         1,
+		Resources:       2,
+		CreepDifficulty: 1,
+		InitialCreeps:   1,
+		NumCreepBases:   1,
+		CreepSpawnRate:  1,
+		BossDifficulty:  1,
+		GameSpeed:       3,
+	}
+	const delta = 1.0 / 60.0
+
+	// The played game: the human player activates the cards
+	// as soon as they're available, so there are some actions to re-execute.
+	config := gamedata.MakeLevelConfig(gamedata.ExecuteNormal, replayConfig)
+	config.Finalize()
+	controller := staging.NewController(state, config, nil)
+	runner, scene := ge.NewSimulatedScene(state.Context, controller)
+	controller.Init(scene)
+	numActions := 0
+	for step := 0; step < 3000; step++ {
+		if controller.TryExecuteHumanChoice(0, step%5) {
+			numActions++
+		}
+		runner.Update(delta)
+	}
+	if numActions == 0 {
+		t.Fatal("no actions were executed")
+	}
+
+	data, err := json.Marshal(controller.RecordActionLog())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var log staging.ActionLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatal(err)
+	}
+	savedHash := controller.StateHash()
+
+	// The games can't run side by side (the simulation uses the context rand),
+	// so the played game continuation is recorded to be compared later.
+	const numContinueSteps = 1200
+	var executed []bool
+	var hashes []uint64
+	for step := 0; step < numContinueSteps; step++ {
+		executed = append(executed, controller.TryExecuteHumanChoice(0, step%3))
+		runner.Update(delta)
+		hashes = append(hashes, controller.StateHash())
+	}
+
+	resumed, err := staging.NewResumedController(state, gamedata.ExecuteNormal, &log, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resumedRunner, resumedScene := ge.NewSimulatedScene(state.Context, resumed)
+	resumed.Init(resumedScene)
+	if !resumed.IsResuming() {
+		t.Fatal("the game should be resumed during the updates")
+	}
+	// A log recorded in the middle of the resuming is the original one.
+	if resumed.RecordActionLog() != &log {
+		t.Fatal("unexpected action log during the resuming")
+	}
+	for resumed.IsResuming() {
+		resumedRunner.Update(delta)
+	}
+	if resumed.StateHash() != savedHash {
+		t.Fatal("resumed state hash mismatch")
+	}
+
+	// The resumed game continues identically, including the new human actions.
+	for step := 0; step < numContinueSteps; step++ {
+		if resumed.TryExecuteHumanChoice(0, step%3) != executed[step] {
+			t.Fatalf("step %d: choice execution mismatch", step)
+		}
+		resumedRunner.Update(delta)
+		if resumed.StateHash() != hashes[step] {
+			t.Fatalf("step %d: state hash mismatch", step)
+		}
+	}
+}
+
+func TestResumeStateMismatch(t *testing.T) {
+	if testing.Short() {
+		t.Skip("the full game simulation is slow")
+	}
+
+	state := getSimulationState()
+
+	replayConfig := serverapi.ReplayLevelConfig{
+		RawGameMode:     "classic",
+		Seed:            7,
+		Tier2Recipes:    []string{"Fighter", "Skirmisher", "Defender"},
+		TurretDesign:    "Gunpoint",
+		CoreDesign:      "den",
+		PlayersMode:     serverapi.PmodeSinglePlayer,
+		DronesPower:     1,
+		Teleporters:     1,
+		OilRegenRate:    2,
+		Terrain:         1,
+		Resources:       2,
+		CreepDifficulty: 1,
+		InitialCreeps:   1,
+		NumCreepBases:   1,
+		CreepSpawnRate:  1,
+		BossDifficulty:  1,
+		GameSpeed:       1,
+	}
+	const delta = 1.0 / 60.0
+
+	config := gamedata.MakeLevelConfig(gamedata.ExecuteNormal, replayConfig)
+	config.Finalize()
+	controller := staging.NewController(state, config, nil)
+	runner, scene := ge.NewSimulatedScene(state.Context, controller)
+	controller.Init(scene)
+	for step := 0; step < 600; step++ {
+		controller.TryExecuteHumanChoice(0, step%5)
+		runner.Update(delta)
+	}
+
+	// A corrupted (or incompatible) saved game is reported instead of crashing the game.
+	log := controller.RecordActionLog()
+	log.StateHash++
+	resumed, err := staging.NewResumedController(state, gamedata.ExecuteNormal, log, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var resumeErr error
+	numResumed := 0
+	resumed.EventResumed.Connect(nil, func(err error) {
+		resumeErr = err
+		numResumed++
+	})
+	resumedRunner, resumedScene := ge.NewSimulatedScene(state.Context, resumed)
+	resumed.Init(resumedScene)
+	for resumed.IsResuming() {
+		resumedRunner.Update(delta)
+	}
+	if numResumed != 1 {
+		t.Fatalf("EventResumed is emitted %d times, expected once", numResumed)
+	}
+	if resumeErr == nil {
+		t.Fatal("expected a state mismatch error")
+	}
+}
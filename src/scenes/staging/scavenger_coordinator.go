@@ -0,0 +1,257 @@
+package staging
+
+import (
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/ge/xslices"
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/roboden-game/assets"
+	"github.com/quasilyte/roboden-game/detmath"
+	"github.com/quasilyte/roboden-game/gamedata"
+)
+
+const (
+	scavengerMaxCaravans = 2
+
+	// The colony pays this amount of resources to get the wagon cargo.
+	scavengerTradePrice = 20.0
+
+	scavengerTradeDist = 200.0
+)
+
+type scavengerCaravanState int
+
+const (
+	caravanTravel scavengerCaravanState = iota
+	caravanLeave
+)
+
+type scavengerCaravan struct {
+	state scavengerCaravanState
+
+	wagon *scavengerNode
+	units []*scavengerNode
+
+	colony  *colonyCoreNode
+	dest    gmath.Vec
+	retries int
+}
+
+// scavengerCoordinator controls the neutral scavengers faction.
+//
+// Scavenger caravans enter the map from its edges, visit a colony to trade
+// and then leave the map. A colony that raided the scavengers (see specialRaid)
+// is considered to be hostile: the caravans never trade with it and
+// their guards attack its drones on sight.
+// A stray shot or a splash damage doesn't count as a raid.
+type scavengerCoordinator struct {
+	world *worldState
+
+	caravans []*scavengerCaravan
+	hostile  map[*colonyCoreNode]bool
+
+	spawnDelay float64
+	orderDelay float64
+}
+
+func newScavengerCoordinator(world *worldState) *scavengerCoordinator {
+	return &scavengerCoordinator{
+		world:      world,
+		hostile:    make(map[*colonyCoreNode]bool, 2),
+		spawnDelay: detmath.FloatRange(world.rand, 60, 120),
+	}
+}
+
+func (c *scavengerCoordinator) HasHostiles() bool { return len(c.hostile) != 0 }
+
+func (c *scavengerCoordinator) IsHostile(colony *colonyCoreNode) bool {
+	return c.hostile[colony]
+}
+
+func (c *scavengerCoordinator) OnRaided(colony *colonyCoreNode) {
+	if c.hostile[colony] {
+		return
+	}
+	c.hostile[colony] = true
+	for _, caravan := range c.caravans {
+		if caravan.colony == colony {
+			c.leave(caravan)
+		}
+	}
+
+	if m := colony.player.GetState().messageManager; m != nil {
+		m.AddMessage(queuedMessageInfo{
+			text:          c.world.rootScene.Dict().Get("game.notice.scavengers_hostile"),
+			timer:         5,
+			targetPos:     ge.Pos{Base: &colony.pos},
+			forceWorldPos: true,
+		})
+	}
+}
+
+func (c *scavengerCoordinator) Update(delta float64) {
+	if len(c.caravans) < scavengerMaxCaravans {
+		c.spawnDelay = gmath.ClampMin(c.spawnDelay-delta, 0)
+		if c.spawnDelay == 0 {
+			c.spawnDelay = detmath.FloatRange(c.world.rand, 90, 150)
+			c.spawnCaravan()
+		}
+	}
+
+	c.orderDelay = gmath.ClampMin(c.orderDelay-delta, 0)
+	if c.orderDelay != 0 {
+		return
+	}
+	c.orderDelay = detmath.FloatRange(c.world.rand, 0.8, 1.2)
+
+	caravans := c.caravans[:0]
+	for _, caravan := range c.caravans {
+		if c.updateCaravan(caravan) {
+			caravans = append(caravans, caravan)
+		}
+	}
+	c.caravans = caravans
+}
+
+func (c *scavengerCoordinator) spawnCaravan() {
+	sector := gmath.RandElem(c.world.rand, c.world.spawnAreas)
+	spawnPos := correctedPos(c.world.rect, randomSectorPos(c.world.rand, sector), 48)
+	if !c.world.CellIsFree(c.world.pathgrid.PosToCoord(spawnPos), layerNormal) {
+		// Try again a bit later.
+		c.spawnDelay = detmath.FloatRange(c.world.rand, 5, 10)
+		return
+	}
+
+	caravan := &scavengerCaravan{}
+	caravan.wagon = c.newUnit(caravan, gamedata.ScavengerWagonStats, spawnPos)
+	numGuards := c.world.rand.IntRange(2, 3)
+	for i := 0; i < numGuards; i++ {
+		c.newUnit(caravan, gamedata.ScavengerGuardStats, spawnPos.Add(detmath.Offset(c.world.rand, -24, 24)))
+	}
+
+	caravan.colony = c.pickColony(spawnPos)
+	if caravan.colony != nil {
+		c.sendCaravan(caravan, caravan.colony.pos)
+	} else {
+		c.leave(caravan)
+	}
+	c.caravans = append(c.caravans, caravan)
+}
+
+func (c *scavengerCoordinator) newUnit(caravan *scavengerCaravan, stats *gamedata.ScavengerStats, pos gmath.Vec) *scavengerNode {
+	u := newScavengerNode(c.world, stats, pos)
+	u.caravan = caravan
+	u.EventDestroyed.Connect(nil, func(u *scavengerNode) {
+		caravan.units = xslices.Remove(caravan.units, u)
+		c.world.scavengers = xslices.Remove(c.world.scavengers, u)
+	})
+	caravan.units = append(caravan.units, u)
+	c.world.scavengers = append(c.world.scavengers, u)
+	c.world.nodeRunner.AddObject(u)
+	return u
+}
+
+func (c *scavengerCoordinator) pickColony(pos gmath.Vec) *colonyCoreNode {
+	var bestColony *colonyCoreNode
+	bestDistSqr := 0.0
+	for _, colony := range c.world.allColonies {
+		if c.hostile[colony] {
+			continue
+		}
+		distSqr := detmath.DistanceSquaredTo(colony.pos, pos)
+		if bestColony == nil || distSqr < bestDistSqr {
+			bestColony = colony
+			bestDistSqr = distSqr
+		}
+	}
+	return bestColony
+}
+
+func (c *scavengerCoordinator) sendCaravan(caravan *scavengerCaravan, dest gmath.Vec) {
+	caravan.dest = dest
+	for _, u := range caravan.units {
+		u.SendTo(dest.Add(detmath.Offset(c.world.rand, -32, 32)))
+	}
+}
+
+func (c *scavengerCoordinator) leave(caravan *scavengerCaravan) {
+	caravan.state = caravanLeave
+	caravan.colony = nil
+	caravan.retries = 0
+	sector := gmath.RandElem(c.world.rand, c.world.spawnAreas)
+	c.sendCaravan(caravan, correctedPos(c.world.rect, randomSectorPos(c.world.rand, sector), 32))
+}
+
+// updateCaravan returns false when the caravan is gone.
+func (c *scavengerCoordinator) updateCaravan(caravan *scavengerCaravan) bool {
+	if len(caravan.units) == 0 {
+		return false
+	}
+
+	if caravan.wagon != nil && caravan.wagon.IsDisposed() {
+		// There is nothing to trade anymore.
+		caravan.wagon = nil
+		if caravan.state != caravanLeave {
+			c.leave(caravan)
+		}
+	}
+
+	if caravan.state == caravanTravel {
+		if caravan.colony.IsDisposed() || c.hostile[caravan.colony] {
+			c.leave(caravan)
+			return true
+		}
+		if detmath.DistanceTo(caravan.wagon.pos, caravan.colony.pos) <= scavengerTradeDist {
+			c.trade(caravan)
+			c.leave(caravan)
+			return true
+		}
+		if detmath.DistanceSquaredTo(caravan.colony.pos, caravan.dest) > (96 * 96) {
+			// The colony has moved.
+			c.sendCaravan(caravan, caravan.colony.pos)
+			return true
+		}
+	}
+
+	for _, u := range caravan.units {
+		if !u.IsIdle() {
+			return true
+		}
+	}
+
+	if caravan.state == caravanLeave {
+		// Stuck caravans are leaving the map too, as if they found another way out.
+		if caravan.retries >= 5 || detmath.DistanceTo(caravan.units[0].pos, caravan.dest) < 96 {
+			for _, u := range caravan.units {
+				c.world.scavengers = xslices.Remove(c.world.scavengers, u)
+				u.Dispose()
+			}
+			return false
+		}
+	} else if caravan.retries >= 5 {
+		c.leave(caravan)
+		return true
+	}
+
+	// The path could be incomplete, try building it again.
+	caravan.retries++
+	c.sendCaravan(caravan, caravan.dest)
+	return true
+}
+
+func (c *scavengerCoordinator) trade(caravan *scavengerCaravan) {
+	colony := caravan.colony
+	if colony.resources < scavengerTradePrice {
+		return
+	}
+	colony.resources += caravan.wagon.stats.Cargo - scavengerTradePrice
+	playSound(c.world, assets.AudioAgentConsumed, colony.pos)
+
+	if m := colony.player.GetState().messageManager; m != nil {
+		m.AddMessage(queuedMessageInfo{
+			text:          c.world.rootScene.Dict().Get("game.notice.scavengers_trade"),
+			timer:         5,
+			targetPos:     ge.Pos{Base: &colony.pos},
+			forceWorldPos: true,
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package staging
+
+import (
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/gsignal"
+	"github.com/quasilyte/roboden-game/detmath"
+	"github.com/quasilyte/roboden-game/gamedata"
+	"github.com/quasilyte/roboden-game/pathing"
+)
+
+// scavengerNode is a ground unit of the neutral scavengers faction.
+// Scavengers are controlled by the scavengerCoordinator.
+type scavengerNode struct {
+	world *worldState
+	stats *gamedata.ScavengerStats
+
+	caravan *scavengerCaravan
+
+	pos      gmath.Vec
+	waypoint gmath.Vec
+	path     pathing.GridPath
+
+	health    float64
+	maxHealth float64
+
+	attackDelay float64
+
+	sprite         *ge.Sprite
+	anim           *ge.Animation
+	flashComponent damageFlashComponent
+
+	disposed bool
+
+	EventDestroyed gsignal.Event[*scavengerNode]
+}
+
+func newScavengerNode(world *worldState, stats *gamedata.ScavengerStats, pos gmath.Vec) *scavengerNode {
+	return &scavengerNode{
+		world: world,
+		stats: stats,
+		pos:   pos,
+	}
+}
+
+func (s *scavengerNode) Init(scene *ge.Scene) {
+	s.maxHealth = s.stats.MaxHealth * s.world.creepHealthMultiplier
+	s.health = s.maxHealth
+
+	s.sprite = scene.NewSprite(s.stats.Image)
+	s.sprite.Pos.Base = &s.pos
+	s.world.stage.AddSprite(s.sprite)
+	s.anim = ge.NewRepeatedAnimation(s.sprite, -1)
+	s.anim.SetSecondsPerFrame(s.stats.AnimSpeed)
+	s.anim.Tick(detmath.FloatRange(s.world.localRand, 0, 0.7))
+	s.flashComponent.sprite = s.sprite
+
+	if s.stats.Weapon != nil {
+		s.attackDelay = detmath.FloatRange(s.world.rand, 0.5, s.stats.Weapon.Reload)
+	}
+}
+
+func (s *scavengerNode) IsDisposed() bool { return s.disposed }
+
+func (s *scavengerNode) Dispose() {
+	s.disposed = true
+	s.sprite.Dispose()
+}
+
+func (s *scavengerNode) Destroy() {
+	s.EventDestroyed.Emit(s)
+	s.Dispose()
+}
+
+func (s *scavengerNode) GetPos() *gmath.Vec { return &s.pos }
+
+func (s *scavengerNode) GetVelocity() gmath.Vec {
+	if s.waypoint.IsZero() {
+		return gmath.Vec{}
+	}
+	return detmath.VecTowards(s.pos, s.waypoint, s.stats.Speed)
+}
+
+func (s *scavengerNode) IsFlying() bool { return false }
+
+func (s *scavengerNode) GetTargetInfo() targetInfo {
+	return targetInfo{}
+}
+
+func (s *scavengerNode) IsIdle() bool { return s.waypoint.IsZero() }
+
+func (s *scavengerNode) Update(delta float64) {
+	s.flashComponent.Update(delta)
+
+	if s.stats.Weapon != nil {
+		s.attackDelay = gmath.ClampMin(s.attackDelay-delta, 0)
+		if s.attackDelay == 0 {
+			s.attackDelay = s.stats.Weapon.Reload * detmath.FloatRange(s.world.rand, 0.8, 1.2)
+			if target := s.findTarget(); target != nil {
+				attackWithProjectile(s.world, s.stats.Weapon, s, target, s.stats.Weapon.BurstSize, false)
+				playSound(s.world, s.stats.Weapon.AttackSound, s.pos)
+			}
+		}
+	}
+
+	if s.waypoint.IsZero() {
+		return
+	}
+	s.anim.Tick(delta)
+	pos, reached := moveTowardsWithSpeed(s.pos, s.waypoint, delta, s.stats.Speed)
+	s.pos = pos
+	if !reached {
+		return
+	}
+	if s.path.HasNext() {
+		s.waypoint = nextPathWaypoint(s.world, s.pos, &s.path, layerNormal).Add(detmath.Offset(s.world.rand, -4, 4))
+		return
+	}
+	s.waypoint = gmath.Vec{}
+}
+
+func (s *scavengerNode) SendTo(pos gmath.Vec) {
+	p := s.world.BuildPath(s.pos, pos, layerNormal)
+	s.path = p.Steps
+	s.waypoint = s.world.pathgrid.AlignPos(s.pos)
+}
+
+func (s *scavengerNode) findTarget() targetable {
+	weapon := s.stats.Weapon
+
+	// Scavengers are competing with the creeps for the map control,
+	// so they always shoot them on sight.
+	var target targetable
+	s.world.WalkCreeps(s.pos, weapon.AttackRange, func(creep *creepNode) bool {
+		if isValidCreepTarget(s.pos, creep, weapon) {
+			target = creep
+			return true
+		}
+		return false
+	})
+	if target != nil {
+		return target
+	}
+
+	coordinator := s.world.scavengerCoordinator
+	if !coordinator.HasHostiles() {
+		return nil
+	}
+	skipGround := weapon.TargetFlags&gamedata.TargetGround == 0
+	s.world.FindTargetableAgents(s.pos, skipGround, weapon.AttackRange, func(a *colonyAgentNode) bool {
+		if !coordinator.IsHostile(a.colonyCore) || !isValidAgentTarget(s.pos, a, weapon) {
+			return false
+		}
+		target = a
+		return true
+	})
+	return target
+}
+
+func (s *scavengerNode) OnDamage(damage gamedata.DamageValue, source targetable) {
+	if s.disposed || damage.Health <= 0 {
+		return
+	}
+
+	if !damage.HasFlag(gamedata.DmgflagNoFlash) {
+		s.flashComponent.SetFlash(detmath.FloatRange(s.world.localRand, 0.07, 0.14))
+	}
+	s.health -= damage.Health
+	if s.health < 0 {
+		createExplosion(s.world, normalEffectLayer, s.pos)
+		if s.stats.Cargo != 0 {
+			// The cargo can be looted by the colony drones.
+			s.world.CreateScrapsAt(scrapCreepSource, s.pos.Add(gmath.Vec{Y: 2}))
+		}
+		s.Destroy()
+	}
+}
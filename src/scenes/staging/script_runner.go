@@ -0,0 +1,219 @@
+package staging
+
+import (
+	"math"
+
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/gsignal"
+	"github.com/quasilyte/roboden-game/detmath"
+	"github.com/quasilyte/roboden-game/gamedata"
+)
+
+// scriptRunner interprets a scenario script.
+//
+// All conditions are checked against the simulation state only,
+// so the runner is deterministic and works fine during the replay.
+// The visual-only actions (messages, camera) are delegated to the
+// controller via events; it decides whether to execute them.
+type scriptRunner struct {
+	world *worldState
+
+	script *gamedata.ScenarioScript
+
+	triggers []scriptTriggerState
+	fired    map[string]bool
+
+	scene *ge.Scene
+
+	timePassed float64
+	checkDelay float64
+
+	EventVictory     gsignal.Event[gsignal.Void]
+	EventMessage     gsignal.Event[gamedata.ScriptAction]
+	EventRevealFog   gsignal.Event[gmath.Vec]
+	EventCameraFocus gsignal.Event[gmath.Vec]
+}
+
+type scriptTriggerState struct {
+	info     *gamedata.ScriptTrigger
+	done     bool
+	cooldown float64
+}
+
+func newScriptRunner(world *worldState, script *gamedata.ScenarioScript) *scriptRunner {
+	r := &scriptRunner{
+		world:    world,
+		script:   script,
+		triggers: make([]scriptTriggerState, len(script.Triggers)),
+		fired:    make(map[string]bool, len(script.Triggers)),
+	}
+	for i := range script.Triggers {
+		r.triggers[i].info = &script.Triggers[i]
+	}
+	return r
+}
+
+func (r *scriptRunner) Init(scene *ge.Scene) {
+	r.scene = scene
+}
+
+func (r *scriptRunner) IsDisposed() bool {
+	return false
+}
+
+func (r *scriptRunner) Update(delta float64) {
+	r.timePassed += delta
+
+	r.checkDelay = gmath.ClampMin(r.checkDelay-delta, 0)
+	for i := range r.triggers {
+		t := &r.triggers[i]
+		t.cooldown = gmath.ClampMin(t.cooldown-delta, 0)
+	}
+	if r.checkDelay != 0 {
+		return
+	}
+	r.checkDelay = 0.5
+
+	// Triggers are checked in the order of their declaration.
+	// A trigger that depends on another trigger can fire during the same check.
+	for i := range r.triggers {
+		t := &r.triggers[i]
+		if t.done || t.cooldown != 0 {
+			continue
+		}
+		if !r.conditionsSatisfied(t.info.Conditions) {
+			continue
+		}
+		r.fired[t.info.ID] = true
+		if t.info.Repeat {
+			t.cooldown = t.info.Cooldown
+		} else {
+			t.done = true
+		}
+		for _, act := range t.info.Actions {
+			r.runAction(act)
+		}
+	}
+}
+
+func (r *scriptRunner) conditionsSatisfied(conditions []gamedata.ScriptCondition) bool {
+	for _, cond := range conditions {
+		if !r.conditionSatisfied(cond) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *scriptRunner) conditionSatisfied(cond gamedata.ScriptCondition) bool {
+	switch cond.Kind {
+	case gamedata.ScriptCondTimer:
+		return r.timePassed >= cond.Value
+
+	case gamedata.ScriptCondColonyInRect:
+		rect := gmath.Rect{
+			Min: gmath.Vec{X: cond.Rect.X1, Y: cond.Rect.Y1},
+			Max: gmath.Vec{X: cond.Rect.X2, Y: cond.Rect.Y2},
+		}
+		for _, colony := range r.world.allColonies {
+			if rect.Contains(colony.pos) {
+				return true
+			}
+		}
+		return false
+
+	case gamedata.ScriptCondNumAgents:
+		total := 0
+		for _, colony := range r.world.allColonies {
+			total += colony.agents.TotalNum()
+		}
+		return float64(total) >= cond.Value
+
+	case gamedata.ScriptCondNumColonies:
+		return float64(len(r.world.allColonies)) >= cond.Value
+
+	case gamedata.ScriptCondCreepsKilled:
+		return float64(r.world.result.CreepsDefeated) >= cond.Value
+
+	case gamedata.ScriptCondResources:
+		total := 0.0
+		for _, colony := range r.world.allColonies {
+			total += colony.resources
+		}
+		return total >= cond.Value
+
+	case gamedata.ScriptCondResourcesGathered:
+		return r.world.result.ResourcesGathered >= cond.Value
+
+	case gamedata.ScriptCondTrigger:
+		return r.fired[cond.Trigger]
+
+	default:
+		return false
+	}
+}
+
+func (r *scriptRunner) resolvePos(pos gamedata.ScriptPos) gmath.Vec {
+	offset := gmath.Vec{X: pos.X, Y: pos.Y}
+	var base gmath.Vec
+	switch pos.Anchor {
+	case "colony":
+		base = r.world.spawnPos
+		for _, p := range r.world.players {
+			colonies := p.GetState().colonies
+			if len(colonies) != 0 {
+				base = colonies[0].pos
+				break
+			}
+		}
+	case "spawn":
+		base = r.world.spawnPos
+	case "center":
+		base = r.world.rect.Center()
+	}
+	return correctedPos(r.world.rect, base.Add(offset), 64)
+}
+
+func (r *scriptRunner) runAction(act gamedata.ScriptAction) {
+	switch act.Kind {
+	case gamedata.ScriptActSpawnCreeps:
+		stats := gamedata.FindScriptCreep(act.Creep)
+		center := r.resolvePos(act.Pos)
+		for i := 0; i < act.Count; i++ {
+			pos := center
+			if i != 0 {
+				pos = correctedPos(r.world.rect, center.Add(detmath.Offset(r.world.rand, -48, 48)), 64)
+			}
+			if !stats.Flying && !r.world.PosIsFree(pos, layerNormal) {
+				continue
+			}
+			creep := r.world.NewCreepNode(pos, stats)
+			r.world.nodeRunner.AddObject(creep)
+		}
+
+	case gamedata.ScriptActMessage:
+		r.EventMessage.Emit(act)
+
+	case gamedata.ScriptActAddResources:
+		for _, p := range r.world.players {
+			colonies := p.GetState().colonies
+			if len(colonies) == 0 {
+				continue
+			}
+			// A grant doesn't go over the storage limit and a penalty doesn't go below zero.
+			colony := colonies[0]
+			maxResources := math.Max(colony.resources, colony.maxVisualResources())
+			colony.resources = gmath.Clamp(colony.resources+act.Value, 0, maxResources)
+		}
+
+	case gamedata.ScriptActRevealFog:
+		r.EventRevealFog.Emit(r.resolvePos(act.Pos))
+
+	case gamedata.ScriptActCameraFocus:
+		r.EventCameraFocus.Emit(r.resolvePos(act.Pos))
+
+	case gamedata.ScriptActVictory:
+		r.EventVictory.Emit(gsignal.Void{})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/quasilyte/ge"
 	"github.com/quasilyte/gmath"
 	"github.com/quasilyte/roboden-game/assets"
+	"github.com/quasilyte/roboden-game/detmath"
 	"github.com/quasilyte/roboden-game/gamedata"
 )
 
@@ -55,7 +56,7 @@ func (n *servantSpawnerNode) spawn() {
 }
 
 func (n *servantSpawnerNode) Update(delta float64) {
-	n.pos = n.dir.Mulf(80 * delta).Add(n.pos)
+	n.pos = detmath.AddScaled(n.pos, n.dir, 80*delta)
 
 	if n.rotateClockwise {
 		n.rotation += gmath.Rad(delta * 8)
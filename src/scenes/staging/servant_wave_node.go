@@ -4,6 +4,7 @@ import (
 	"github.com/quasilyte/ge"
 	"github.com/quasilyte/gmath"
 	"github.com/quasilyte/roboden-game/assets"
+	"github.com/quasilyte/roboden-game/detmath"
 	"github.com/quasilyte/roboden-game/gamedata"
 )
 
@@ -55,11 +56,11 @@ func (e *servantWaveNode) dealDamage() {
 		damage.Slow++
 	}
 	for _, colony := range e.owner.world.allColonies {
-		if colony.realRadius < 196 && colony.pos.DistanceSquaredTo(e.pos) > (maxRangeSqr*2) {
+		if colony.realRadius < 196 && detmath.DistanceSquaredTo(colony.pos, e.pos) > (maxRangeSqr*2) {
 			continue
 		}
 		colony.agents.Each(func(a *colonyAgentNode) {
-			distSqr := a.pos.DistanceSquaredTo(e.pos)
+			distSqr := detmath.DistanceSquaredTo(a.pos, e.pos)
 			if distSqr > maxRangeSqr {
 				return
 			}
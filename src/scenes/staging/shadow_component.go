@@ -51,7 +51,7 @@ func (shadow *shadowComponent) UpdateHeight(objectPos gmath.Vec, newHeight, maxH
 
 	if shadow.sprite != nil {
 		shadow.pos.Y = objectPos.Y + newHeight + shadow.offset
-		newShadowAlpha := float32(1.0 - ((newHeight / maxHeight) * 0.5))
+		newShadowAlpha := float32(1.0 - float64((newHeight/maxHeight)*0.5))
 		shadow.sprite.SetAlpha(newShadowAlpha)
 	}
 }
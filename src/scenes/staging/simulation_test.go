@@ -0,0 +1,33 @@
+package staging_test
+
+import (
+	"sync"
+
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/ge/langs"
+	"github.com/quasilyte/roboden-game/assets"
+	"github.com/quasilyte/roboden-game/runsim"
+	"github.com/quasilyte/roboden-game/session"
+)
+
+var (
+	simulationStateOnce sync.Once
+	simulationState     *session.State
+)
+
+// getSimulationState returns a session state shared by the simulation tests.
+// There can be only one context (it creates the audio context),
+// so the tests can't run the games in parallel.
+func getSimulationState() *session.State {
+	simulationStateOnce.Do(func() {
+		ctx := ge.NewContext(ge.ContextConfig{
+			Mute:       true,
+			FixedDelta: true,
+		})
+		ctx.Loader.OpenAssetFunc = assets.MakeOpenAssetFunc(ctx, "", "")
+		ctx.Dict = langs.NewDictionary("en", 2)
+		runsim.PrepareAssets(ctx)
+		simulationState = runsim.NewState(ctx)
+	})
+	return simulationState
+}
@@ -15,36 +15,42 @@ func _() {
 	_ = x[specialBuildColony-4]
 	_ = x[specialAttack-5]
 	_ = x[specialChoiceMoveColony-6]
-	_ = x[specialSendCreeps-7]
-	_ = x[specialRally-8]
-	_ = x[specialSpawnCrawlers-9]
-	_ = x[specialBossAttack-10]
-	_ = x[specialIncreaseTech-11]
-	_ = x[specialIncreaseTechX2-12]
-	_ = x[specialAtomicBomb-13]
-	_ = x[specialSendCenturions-14]
-	_ = x[_creepCardFirst-15]
-	_ = x[specialBuyCrawlers-16]
-	_ = x[specialBuyWanderers-17]
-	_ = x[specialBuyEliteCrawlers-18]
-	_ = x[specialBuyStunners-19]
-	_ = x[specialBuyStealthCrawlers-20]
-	_ = x[specialBuyHeavyCrawlers-21]
-	_ = x[specialBuyCenturions-22]
-	_ = x[specialBuyBuilders-23]
-	_ = x[specialBuyTemplars-24]
-	_ = x[specialBuyAssaults-25]
-	_ = x[specialBuyDominator-26]
-	_ = x[specialBuyHowitzer-27]
-	_ = x[_creepCardLast-28]
+	_ = x[specialResearch-7]
+	_ = x[specialBurrow-8]
+	_ = x[specialRecall-9]
+	_ = x[specialUpgradeTurret-10]
+	_ = x[specialRecycleTurret-11]
+	_ = x[specialRaid-12]
+	_ = x[specialSendCreeps-13]
+	_ = x[specialRally-14]
+	_ = x[specialSpawnCrawlers-15]
+	_ = x[specialBossAttack-16]
+	_ = x[specialIncreaseTech-17]
+	_ = x[specialIncreaseTechX2-18]
+	_ = x[specialAtomicBomb-19]
+	_ = x[specialSendCenturions-20]
+	_ = x[_creepCardFirst-21]
+	_ = x[specialBuyCrawlers-22]
+	_ = x[specialBuyWanderers-23]
+	_ = x[specialBuyEliteCrawlers-24]
+	_ = x[specialBuyStunners-25]
+	_ = x[specialBuyStealthCrawlers-26]
+	_ = x[specialBuyHeavyCrawlers-27]
+	_ = x[specialBuyCenturions-28]
+	_ = x[specialBuyBuilders-29]
+	_ = x[specialBuyTemplars-30]
+	_ = x[specialBuyAssaults-31]
+	_ = x[specialBuyDominator-32]
+	_ = x[specialBuyHowitzer-33]
+	_ = x[_creepCardLast-34]
 }
 
-const _specialChoiceKind_name = "ChoiceNoneIncreaseRadiusDecreaseRadiusBuildGunpointBuildColonyAttackChoiceMoveColonySendCreepsRallySpawnCrawlersBossAttackIncreaseTechIncreaseTechX2AtomicBombSendCenturions_creepCardFirstBuyCrawlersBuyWanderersBuyEliteCrawlersBuyStunnersBuyStealthCrawlersBuyHeavyCrawlersBuyCenturionsBuyBuildersBuyTemplarsBuyAssaultsBuyDominatorBuyHowitzer_creepCardLast"
+const _specialChoiceKind_name = "ChoiceNoneIncreaseRadiusDecreaseRadiusBuildGunpointBuildColonyAttackChoiceMoveColonyResearchBurrowRecallUpgradeTurretRecycleTurretRaidSendCreepsRallySpawnCrawlersBossAttackIncreaseTechIncreaseTechX2AtomicBombSendCenturions_creepCardFirstBuyCrawlersBuyWanderersBuyEliteCrawlersBuyStunnersBuyStealthCrawlersBuyHeavyCrawlersBuyCenturionsBuyBuildersBuyTemplarsBuyAssaultsBuyDominatorBuyHowitzer_creepCardLast"
 
-var _specialChoiceKind_index = [...]uint16{0, 10, 24, 38, 51, 62, 68, 84, 94, 99, 112, 122, 134, 148, 158, 172, 187, 198, 210, 226, 237, 255, 271, 284, 295, 306, 317, 329, 340, 354}
+var _specialChoiceKind_index = [...]uint16{0, 10, 24, 38, 51, 62, 68, 84, 92, 98, 104, 117, 130, 134, 144, 149, 162, 172, 184, 198, 208, 222, 237, 248, 260, 276, 287, 305, 321, 334, 345, 356, 367, 379, 390, 404}
 
 func (i specialChoiceKind) String() string {
-	if i < 0 || i >= specialChoiceKind(len(_specialChoiceKind_index)-1) {
+	if i >= specialChoiceKind(len(_specialChoiceKind_index)-1) {
 		return "specialChoiceKind(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
 	return _specialChoiceKind_name[_specialChoiceKind_index[i]:_specialChoiceKind_index[i+1]]
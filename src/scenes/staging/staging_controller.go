@@ -7,6 +7,7 @@ import (
 	"os"
 	"runtime"
 	"runtime/pprof"
+	"sort"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -14,11 +15,13 @@ import (
 	"github.com/quasilyte/ge"
 	"github.com/quasilyte/ge/gedraw"
 	"github.com/quasilyte/ge/input"
+	"github.com/quasilyte/ge/xslices"
 	"github.com/quasilyte/gmath"
 	"github.com/quasilyte/gsignal"
 
 	"github.com/quasilyte/roboden-game/assets"
 	"github.com/quasilyte/roboden-game/controls"
+	"github.com/quasilyte/roboden-game/detmath"
 	"github.com/quasilyte/roboden-game/gamedata"
 	"github.com/quasilyte/roboden-game/gameinput"
 	"github.com/quasilyte/roboden-game/gameui"
@@ -56,6 +59,9 @@ type Controller struct {
 	arenaManager *arenaManager
 	nodeRunner   *nodeRunner
 
+	campaignManager *campaignManager
+	kothManager     *kothManager
+
 	debugInfo        *ge.Label
 	debugUpdateDelay float64
 
@@ -63,7 +69,16 @@ type Controller struct {
 	replayActions     [][]serverapi.PlayerAction
 	replayCheckpoints []int
 
+	resumeLog      *ActionLog
+	resumedPlayers []player
+	resumeNotices  []*messageNode
+
 	EventBeforeLeaveScene gsignal.Event[gsignal.Void]
+
+	// EventResumed is emitted when the saved game re-simulation is finished.
+	// The error is not nil if the resumed state doesn't match the saved one;
+	// the controller leaves to the back scene in this case.
+	EventResumed gsignal.Event[error]
 }
 
 func NewController(state *session.State, config gamedata.LevelConfig, back ge.SceneController) *Controller {
@@ -87,7 +102,7 @@ func (c *Controller) SetReplayActions(replay serverapi.GameReplay) {
 
 func (c *Controller) CenterDemoCamera(pos gmath.Vec) {
 	c.camera.ToggleCamera(pos)
-	c.camera.cinematicSwitchDelay = c.world.localRand.FloatRange(20, 30)
+	c.camera.cinematicSwitchDelay = detmath.FloatRange(c.world.localRand, 20, 30)
 	c.camera.mode = camCinematic
 }
 
@@ -125,7 +140,7 @@ func (c *Controller) IsExcitingDemoFrame() (gmath.Vec, bool) {
 		}
 		if colony.mode == colonyModeRelocating {
 			for _, tp := range c.world.teleporters {
-				if tp.pos.DistanceSquaredTo(colony.relocationPoint) < (40 * 40) {
+				if detmath.DistanceSquaredTo(tp.pos, colony.relocationPoint) < (40 * 40) {
 					return tp.pos, true
 				}
 			}
@@ -134,7 +149,7 @@ func (c *Controller) IsExcitingDemoFrame() (gmath.Vec, bool) {
 		// Maybe tether tower is around?
 		if c.world.turretDesign == gamedata.TetherBeaconAgentStats {
 			for _, turret := range colony.turrets {
-				if turret.pos.DistanceTo(colony.pos) <= 260 {
+				if detmath.DistanceTo(turret.pos, colony.pos) <= 260 {
 					return colony.pos, true
 				}
 			}
@@ -232,7 +247,7 @@ func (c *Controller) IsExcitingDemoFrame() (gmath.Vec, bool) {
 			if colony.resources < 100 || colony.NumAgents() < 30 {
 				continue
 			}
-			if colony.pos.DistanceTo(construction.pos) > 180 {
+			if detmath.DistanceTo(colony.pos, construction.pos) > 180 {
 				continue
 			}
 			return colony.pos, true
@@ -386,6 +401,8 @@ func (c *Controller) doInit(scene *ge.Scene) {
 		hasForests:   gamedata.EnvironmentKind(c.config.Environment) == gamedata.EnvForest,
 		envKind:      gamedata.EnvironmentKind(c.config.Environment),
 		mapShape:     gamedata.WorldShape(c.config.WorldShape),
+
+		hostileColonies: c.config.GameMode == gamedata.ModeVersus,
 	}
 
 	switch world.mapShape {
@@ -410,7 +427,11 @@ func (c *Controller) doInit(scene *ge.Scene) {
 
 	world.inputMode = c.state.GetInput(0).DetectInputMode()
 	world.creepCoordinator = newCreepCoordinator(world)
+	world.influenceMap = newInfluenceMap(world)
 	world.bfs = pathing.NewGreedyBFS(world.pathgrid.Size())
+	world.groundPathgraph = pathing.NewHPAGraph(world.pathgrid, layerNormal, pathgraphClusterSize)
+	world.landingPathgraph = pathing.NewHPAGraph(world.pathgrid, layerLandColony, pathgraphClusterSize)
+	world.groundFlowFields = pathing.NewFlowFieldCache(world.pathgrid, layerNormal, 8)
 	c.world = world
 	world.Init()
 
@@ -448,6 +469,9 @@ func (c *Controller) doInit(scene *ge.Scene) {
 		case gamedata.EnvInferno:
 			img = assets.ImageBackgroundInfernoTiles
 			tileset = assets.RawInfernoTilesJSON
+		case gamedata.EnvIce:
+			img = assets.ImageBackgroundIceTiles
+			tileset = assets.RawIceTilesJSON
 		}
 		bg.LoadTilesetWithRand(scene.Context(), &localRand, viewportWorld.Width, viewportWorld.Height, img, tileset)
 	}
@@ -482,6 +506,7 @@ func (c *Controller) doInit(scene *ge.Scene) {
 	c.nodeRunner.world = world
 
 	c.nodeRunner.creepCoordinator = world.creepCoordinator
+	c.nodeRunner.influenceMap = world.influenceMap
 
 	c.world.EventColonyCreated.Connect(c, func(colony *colonyCoreNode) {
 		if c.fogOfWar != nil {
@@ -493,8 +518,8 @@ func (c *Controller) doInit(scene *ge.Scene) {
 		if c.config.ExecMode == gamedata.ExecuteNormal && isHumanPlayer(colony.player) {
 			colony.EventDestroyed.Connect(c, func(colony *colonyCoreNode) {
 				cam := colony.player.GetState().camera
-				center := cam.AbsPos(cam.Rect.Center())
-				if center.DistanceTo(colony.pos) < 300 {
+				center := cam.CenterPos()
+				if detmath.DistanceTo(center, colony.pos) < 300 {
 					return
 				}
 				colony.player.GetState().messageManager.AddMessage(queuedMessageInfo{
@@ -506,8 +531,8 @@ func (c *Controller) doInit(scene *ge.Scene) {
 			})
 			colony.EventUnderAttack.Connect(c, func(colony *colonyCoreNode) {
 				cam := colony.player.GetState().camera
-				center := cam.AbsPos(cam.Rect.Center())
-				if center.DistanceTo(colony.pos) < 250 {
+				center := cam.CenterPos()
+				if detmath.DistanceTo(center, colony.pos) < 250 {
 					return
 				}
 				colony.player.GetState().messageManager.AddMessage(queuedMessageInfo{
@@ -553,6 +578,29 @@ func (c *Controller) doInit(scene *ge.Scene) {
 		scene.AddGraphics(cam)
 	}
 
+	if c.config.GameMode == gamedata.ModeKingOfTheHill {
+		// This manager needs all cameras to be created.
+		c.kothManager = newKothManager(c.world)
+		c.nodeRunner.AddObject(c.kothManager)
+		c.kothManager.EventFinished.Connect(c, c.onKothFinished)
+	}
+
+	if c.world.envKind == gamedata.EnvIce {
+		// Blizzard overlays are also bound to the cameras.
+		c.world.blizzard = newBlizzardNode(c.world)
+		c.nodeRunner.AddObject(c.world.blizzard)
+	}
+
+	if c.config.WorldEvents {
+		c.world.worldEvents = newWorldEventScheduler(c.world)
+		c.nodeRunner.AddObject(c.world.worldEvents)
+	}
+
+	if c.config.Scavengers {
+		c.world.scavengerCoordinator = newScavengerCoordinator(c.world)
+		c.nodeRunner.scavengerCoordinator = c.world.scavengerCoordinator
+	}
+
 	if c.world.config.GameMode == gamedata.ModeTutorial {
 		p := c.world.players[0].(*humanPlayer)
 		c.tutorialManager = newTutorialManager(c.state.GetInput(0), c.world, p.GetState().messageManager)
@@ -569,6 +617,18 @@ func (c *Controller) doInit(scene *ge.Scene) {
 		})
 	}
 
+	if c.config.Mission != "" {
+		mission := gamedata.FindCampaignMission(c.config.Mission)
+		if c.config.GameMode != gamedata.ModeTutorial {
+			c.campaignManager = newCampaignManager(c.world, mission, c.world.players[0].GetState().messageManager)
+			c.nodeRunner.AddObject(c.campaignManager)
+			c.campaignManager.EventVictory.Connect(c, c.onVictoryTrigger)
+		}
+		if mission.Script != nil {
+			c.initScriptRunner(mission.Script)
+		}
+	}
+
 	if c.state.Persistent.Settings.ShowFPS || c.state.Persistent.Settings.ShowTimer {
 		if len(c.world.cameras) != 0 {
 			c.debugInfo = ge.NewLabel(assets.BitmapFont1)
@@ -580,6 +640,10 @@ func (c *Controller) doInit(scene *ge.Scene) {
 
 	c.world.stage.SortBelowLayer()
 
+	if c.resumeLog != nil {
+		c.beginResume(c.resumeLog)
+	}
+
 	if c.fogOfWar != nil {
 		for _, colony := range c.world.allColonies {
 			c.updateFogOfWar(colony.pos)
@@ -608,8 +672,9 @@ func (c *Controller) onCameraShake(cameraShake CameraShakeData) {
 func (c *Controller) updateFogOfWar(pos gmath.Vec) {
 	var options ebiten.DrawImageOptions
 	options.CompositeMode = ebiten.CompositeModeDestinationOut
-	options.GeoM.Translate(pos.X-colonyVisionRadius, pos.Y-colonyVisionRadius)
-	c.fogOfWar.DrawImage(c.world.visionCircle, &options)
+	r := c.world.VisionRadius()
+	options.GeoM.Translate(pos.X-r, pos.Y-r)
+	c.fogOfWar.DrawImage(c.world.getVisionCircle(r), &options)
 }
 
 func (c *Controller) createCameraManager(viewportWorld *viewport.World, main bool, h *gameinput.Handler) *cameraManager {
@@ -647,6 +712,9 @@ func (c *Controller) createPlayers() {
 	hasPlayers := false
 	isSimulation := c.world.config.ExecMode == gamedata.ExecuteReplay ||
 		c.world.config.ExecMode == gamedata.ExecuteSimulation
+	// The humans are not always the first players (see the reverse mode
+	// with a creeps bot), but the first human should use the first input and camera.
+	numHumans := 0
 	for i, pk := range c.config.Players {
 		var creepsState *creepsPlayerState
 		if i == 0 && c.world.config.GameMode == gamedata.ModeReverse {
@@ -666,12 +734,14 @@ func (c *Controller) createPlayers() {
 				p = newReplayPlayer(c.world, pstate, choiceGen)
 				pstate.replay = c.replayActions[i]
 			} else {
-				playerInput := c.state.GetInput(i)
+				humanIndex := numHumans
+				numHumans++
+				playerInput := c.state.GetInput(humanIndex)
 				if playerInput.HasMouseInput() {
 					hasMouseInput = true
 				}
 				pstate.camera = c.camera
-				if i != 0 {
+				if humanIndex != 0 {
 					c.secondCamera = c.createCameraManager(c.camera.World, false, playerInput)
 					pstate.camera = c.secondCamera
 				}
@@ -690,7 +760,7 @@ func (c *Controller) createPlayers() {
 				})
 				c.world.humanPlayers = append(c.world.humanPlayers, human)
 
-				if i == 0 {
+				if humanIndex == 0 {
 					human.EventRecipesToggled.Connect(c, func(visible bool) {
 						c.world.result.OpenedEvolutionTab = true
 						if c.debugInfo != nil {
@@ -723,7 +793,11 @@ func (c *Controller) createPlayers() {
 				c.scene.AddObject(cursor)
 			}
 		case gamedata.PlayerComputer:
-			p = newComputerPlayer(c.world, pstate, choiceGen)
+			if creepsState != nil {
+				p = newCreepsComputerPlayer(c.world, pstate, choiceGen)
+			} else {
+				p = newComputerPlayer(c.world, pstate, choiceGen)
+			}
 		default:
 			panic(fmt.Sprintf("unexpected player kind: %d", pk))
 		}
@@ -740,10 +814,56 @@ func (c *Controller) createPlayers() {
 	}
 }
 
+func (c *Controller) initScriptRunner(script *gamedata.ScenarioScript) {
+	r := newScriptRunner(c.world, script)
+	c.nodeRunner.AddObject(r)
+	r.EventVictory.Connect(c, c.onVictoryTrigger)
+	r.EventRevealFog.Connect(c, func(pos gmath.Vec) {
+		if c.fogOfWar != nil {
+			c.updateFogOfWar(pos)
+		}
+	})
+	r.EventMessage.Connect(c, func(act gamedata.ScriptAction) {
+		timer := act.Timer
+		if timer == 0 {
+			timer = 15
+		}
+		for _, p := range c.world.players {
+			m := p.GetState().messageManager
+			if m == nil {
+				continue
+			}
+			m.AddMessage(queuedMessageInfo{
+				text:  c.scene.Dict().Get(act.Text),
+				timer: timer,
+			})
+		}
+	})
+	r.EventCameraFocus.Connect(c, func(pos gmath.Vec) {
+		if c.config.ExecMode != gamedata.ExecuteNormal {
+			return
+		}
+		for _, p := range c.world.players {
+			if cam := p.GetState().camera; cam != nil {
+				cam.ToggleCamera(pos)
+			}
+		}
+	})
+}
+
 func (c *Controller) onVictoryTrigger(gsignal.Void) {
 	c.victory()
 }
 
+func (c *Controller) onKothFinished(gsignal.Void) {
+	// The hill can be taken by a bot too.
+	if c.isDefeatState() {
+		c.defeat()
+		return
+	}
+	c.victory()
+}
+
 func (c *Controller) onFastForwardPressed() {
 	if !c.world.canFastForward {
 		return
@@ -770,12 +890,17 @@ func (c *Controller) onExitButtonClicked() {
 		d := c.scene.Dict()
 		cam.UI.Visible = true
 		c.nodeRunner.SetPaused(true)
-		msg := cam.input.ReplaceKeyNames(d.Get("game.exit.notice", input.DetectInputMode()))
+		inputMode := input.DetectInputMode()
+		msg := d.Get("game.exit.notice", inputMode)
+		if c.canSaveGame() {
+			msg += "\n" + d.Get("game.exit.save_notice", inputMode)
+		}
+		msg = cam.input.ReplaceKeyNames(msg)
 		exitNotice := newScreenTutorialHintNode(cam.Camera, gmath.Vec{}, gmath.Vec{}, msg)
 		c.exitNotices = append(c.exitNotices, exitNotice)
 		c.scene.AddObject(exitNotice)
 		noticeSize := gmath.Vec{X: exitNotice.width, Y: exitNotice.height}
-		noticeCenterPos := cam.Rect.Center().Sub(noticeSize.Mulf(0.5))
+		noticeCenterPos := cam.Rect.Max.Sub(noticeSize).Mulf(0.5)
 		exitNotice.SetPos(noticeCenterPos)
 	}
 
@@ -797,7 +922,7 @@ func (c *Controller) onExitButtonClicked() {
 func (c *Controller) canBuildHere(pos gmath.Vec, turret bool) bool {
 	if !turret && c.world.coreDesign == gamedata.ArkCoreStats {
 		for _, colony := range c.world.allColonies {
-			if colony.pos.DistanceSquaredTo(pos) < (8 * 8) {
+			if detmath.DistanceSquaredTo(colony.pos, pos) < (8 * 8) {
 				return false
 			}
 		}
@@ -807,7 +932,7 @@ func (c *Controller) canBuildHere(pos gmath.Vec, turret bool) bool {
 
 func (c *Controller) hasTeleportersAt(pos gmath.Vec) bool {
 	for _, tp := range c.world.teleporters {
-		if tp.pos.DistanceSquaredTo(pos) < (52 * 52) {
+		if detmath.DistanceSquaredTo(tp.pos, pos) < (52 * 52) {
 			return true
 		}
 	}
@@ -828,7 +953,7 @@ func (c *Controller) executeAction(choice selectedChoice) bool {
 		case gamedata.BlueFactionTag:
 			c.world.result.BlueFactionUsed = true
 		}
-		selectedColony.factionWeights.AddWeight(choice.Faction, c.world.rand.FloatRange(0.15, 0.25))
+		selectedColony.factionWeights.AddWeight(choice.Faction, detmath.FloatRange(c.world.rand, 0.15, 0.25))
 		for _, e := range choice.Option.effects {
 			// Use priorities.AddWeight directly here to avoid the signal.
 			// We'll call UpdateMetrics() below ourselves.
@@ -842,21 +967,35 @@ func (c *Controller) executeAction(choice selectedChoice) bool {
 	case specialAttack:
 		c.launchAttack(selectedColony)
 		return true
+	case specialRaid:
+		return c.launchRaid(selectedColony)
 	case specialChoiceMoveColony:
-		maxDist := selectedColony.MaxFlyDistance() * c.world.rand.FloatRange(0.9, 1.1)
+		maxDist := float64(selectedColony.MaxFlyDistance() * detmath.FloatRange(c.world.rand, 0.9, 1.1))
 		clickPos := choice.Pos
-		clickDist := selectedColony.pos.DistanceTo(clickPos)
+		clickDist := detmath.DistanceTo(selectedColony.pos, clickPos)
 		dist := gmath.ClampMax(clickDist, maxDist)
-		relocationVec := selectedColony.pos.VecTowards(clickPos, 1).Mulf(dist)
-		relocationPos = correctedPos(c.world.rect, relocationVec.Add(selectedColony.pos), 128)
+		relocationVec := detmath.VecTowards(selectedColony.pos, clickPos, 1)
+		relocationPos = correctedPos(c.world.rect, detmath.AddScaled(selectedColony.pos, relocationVec, dist), 128)
 		return c.launchRelocation(selectedColony, dist, relocationPos)
+	case specialResearch:
+		if choice.Option.tech == techNone {
+			// The research options were shown or hidden.
+			return true
+		}
+		return selectedColony.Research(choice.Option.tech)
+	case specialBurrow, specialRecall:
+		return selectedColony.tryExecutingAction(colonyAction{Kind: actionCoreAbility})
+	case specialUpgradeTurret:
+		return selectedColony.UpgradeTurret(choice.Pos, turretUpgradeFromParam(choice.Param))
+	case specialRecycleTurret:
+		return selectedColony.RecycleTurret(choice.Pos)
 	case specialIncreaseRadius:
 		c.world.result.RadiusIncreases++
-		selectedColony.realRadius += c.world.rand.FloatRange(16, 32)
+		selectedColony.realRadius += detmath.FloatRange(c.world.rand, 16, 32)
 		selectedColony.realRadiusSqr = selectedColony.realRadius * selectedColony.realRadius
 		return true
 	case specialDecreaseRadius:
-		value := c.world.rand.FloatRange(40, 60)
+		value := detmath.FloatRange(c.world.rand, 40, 60)
 		selectedColony.realRadius = gmath.ClampMin(selectedColony.realRadius-value, 96)
 		selectedColony.realRadiusSqr = selectedColony.realRadius * selectedColony.realRadius
 		return true
@@ -883,7 +1022,7 @@ func (c *Controller) executeAction(choice selectedChoice) bool {
 				c.canBuildHere(c.world.pathgrid.CoordToPos(probe), true)
 		})
 		if !freeCoord.IsZero() {
-			pos := c.world.pathgrid.CoordToPos(coord.Add(freeCoord)).Add(c.world.rand.Offset(-3, 3))
+			pos := c.world.pathgrid.CoordToPos(coord.Add(freeCoord)).Add(detmath.Offset(c.world.rand, -3, 3))
 			construction := c.world.NewConstructionNode(choice.Player, pos, stats)
 			c.nodeRunner.AddObject(construction)
 			return true
@@ -968,7 +1107,7 @@ func (c *Controller) launchAbomb() bool {
 	if target == nil {
 		target = gmath.RandElem(c.world.rand, c.world.allColonies)
 	}
-	toPos := target.pos.Add(c.scene.Rand().Offset(-10, 10))
+	toPos := target.pos.Add(detmath.Offset(c.scene.Rand(), -10, 10))
 	if !target.IsFlying() {
 		toPos = toPos.Add(gmath.Vec{Y: 16})
 	}
@@ -992,13 +1131,13 @@ func (c *Controller) doRally() bool {
 
 	c.world.creepCoordinator.Rally(c.world.boss.pos, 375)
 
-	if c.world.centurionRallyPointPtr.DistanceTo(c.world.boss.pos) > 300 {
+	if detmath.DistanceTo(*c.world.centurionRallyPointPtr, c.world.boss.pos) > 300 {
 		centurionRally := false
 		for _, creep := range c.world.centurions {
 			if !creep.centurionReady {
 				continue
 			}
-			if creep.pos.DistanceSquaredTo(*c.world.centurionRallyPointPtr) < (210 * 210) {
+			if detmath.DistanceSquaredTo(creep.pos, *c.world.centurionRallyPointPtr) < (210 * 210) {
 				centurionRally = true
 				break
 			}
@@ -1019,7 +1158,7 @@ func (c *Controller) doSendCenturions(choice selectedChoice) bool {
 		return false
 	}
 
-	if choice.Pos.DistanceTo(c.world.boss.pos) < 128 {
+	if detmath.DistanceTo(choice.Pos, c.world.boss.pos) < 128 {
 		// Recall.
 		c.world.centurionRallyPointPtr = &c.world.boss.pos
 	} else {
@@ -1064,7 +1203,7 @@ func (c *Controller) doBossAttack() bool {
 	var closestColony *colonyCoreNode
 	closestDist := math.MaxFloat64
 	for _, colony := range c.world.allColonies {
-		dist := colony.pos.DistanceTo(c.world.boss.pos)
+		dist := detmath.DistanceTo(colony.pos, c.world.boss.pos)
 		if dist < closestDist {
 			closestDist = dist
 			closestColony = colony
@@ -1073,8 +1212,8 @@ func (c *Controller) doBossAttack() bool {
 	if closestColony == nil {
 		return false
 	}
-	dir := closestColony.pos.Add(c.world.rand.Offset(-60, 60)).DirectionTo(c.world.boss.pos)
-	targetPos := dir.Mulf(c.world.rand.FloatRange(340, 520)).Add(c.world.boss.pos)
+	dir := detmath.DirectionTo(closestColony.pos.Add(detmath.Offset(c.world.rand, -60, 60)), c.world.boss.pos)
+	targetPos := detmath.AddScaled(c.world.boss.pos, dir, detmath.FloatRange(c.world.rand, 340, 520))
 	c.world.boss.waypoint = targetPos
 	return true
 }
@@ -1116,6 +1255,7 @@ func (c *Controller) saveExecutedAction(choice selectedChoice) {
 	a := serverapi.PlayerAction{
 		Kind:           kind,
 		Pos:            [2]float64{choice.Pos.X, choice.Pos.Y},
+		Param:          choice.Param,
 		SelectedColony: colonyIndex,
 		Tick:           c.nodeRunner.ticks,
 	}
@@ -1128,7 +1268,7 @@ func (c *Controller) onChoiceSelected(choice selectedChoice) {
 	}
 
 	ok := c.executeAction(choice)
-	if c.config.ExecMode == gamedata.ExecuteNormal {
+	if c.config.ExecMode == gamedata.ExecuteNormal && !c.world.resuming {
 		if ok || choice.Option.special != specialChoiceMoveColony {
 			c.saveExecutedAction(choice)
 		}
@@ -1149,7 +1289,7 @@ func (c *Controller) launchAttack(selectedColony *colonyCoreNode) {
 	c.world.tmpTargetSlice2 = c.world.tmpTargetSlice2[:0]
 	closeFlyingTargets := &c.world.tmpTargetSlice
 	closeGroundTargets := &c.world.tmpTargetSlice2
-	maxDist := selectedColony.AttackRadius() * c.world.rand.FloatRange(0.95, 1.1)
+	maxDist := float64(selectedColony.AttackRadius() * detmath.FloatRange(c.world.rand, 0.95, 1.1))
 	for _, creep := range c.world.creeps {
 		if len(*closeFlyingTargets)+len(*closeGroundTargets) >= 8 {
 			break
@@ -1157,7 +1297,7 @@ func (c *Controller) launchAttack(selectedColony *colonyCoreNode) {
 		if !creep.CanBeTargeted() {
 			continue
 		}
-		if creep.pos.DistanceTo(selectedColony.pos) > maxDist {
+		if detmath.DistanceTo(creep.pos, selectedColony.pos) > maxDist {
 			continue
 		}
 		if creep.IsFlying() {
@@ -1166,17 +1306,79 @@ func (c *Controller) launchAttack(selectedColony *colonyCoreNode) {
 			*closeGroundTargets = append(*closeGroundTargets, creep)
 		}
 	}
-	if len(*closeFlyingTargets)+len(*closeGroundTargets) == 0 {
+	if c.world.hostileColonies {
+		c.world.FindTargetableAgents(selectedColony.pos, false, maxDist, func(a *colonyAgentNode) bool {
+			if !c.world.AreEnemies(selectedColony, a.colonyCore) || a.insideForest {
+				return false
+			}
+			if a.IsFlying() {
+				*closeFlyingTargets = append(*closeFlyingTargets, a)
+			} else {
+				*closeGroundTargets = append(*closeGroundTargets, a)
+			}
+			return len(*closeFlyingTargets)+len(*closeGroundTargets) >= 8
+		})
+		for _, enemy := range c.world.allColonies {
+			if !c.world.AreEnemies(selectedColony, enemy) {
+				continue
+			}
+			if detmath.DistanceTo(enemy.pos, selectedColony.pos) > maxDist+enemy.realRadius {
+				continue
+			}
+			if enemy.IsFlying() {
+				*closeFlyingTargets = append(*closeFlyingTargets, enemy)
+			} else {
+				*closeGroundTargets = append(*closeGroundTargets, enemy)
+			}
+		}
+	}
+	c.dispatchAttackers(selectedColony, *closeFlyingTargets, *closeGroundTargets)
+}
+
+// launchRaid sends the colony drones to attack the nearby scavengers.
+// Raiding is the only thing that makes the scavengers hostile to the colony.
+func (c *Controller) launchRaid(selectedColony *colonyCoreNode) bool {
+	if c.world.scavengerCoordinator == nil || selectedColony.agents.NumAvailableFighters() == 0 {
+		return false
+	}
+
+	c.world.tmpTargetSlice = c.world.tmpTargetSlice[:0]
+	closeTargets := &c.world.tmpTargetSlice
+	maxDist := float64(selectedColony.AttackRadius() * detmath.FloatRange(c.world.rand, 0.95, 1.1))
+	for _, s := range c.world.scavengers {
+		if len(*closeTargets) >= 8 {
+			break
+		}
+		if detmath.DistanceTo(s.pos, selectedColony.pos) > maxDist {
+			continue
+		}
+		*closeTargets = append(*closeTargets, s)
+	}
+	if len(*closeTargets) == 0 {
+		return false
+	}
+
+	c.world.scavengerCoordinator.OnRaided(selectedColony)
+	c.dispatchAttackers(selectedColony, nil, *closeTargets)
+	return true
+}
+
+func (c *Controller) dispatchAttackers(selectedColony *colonyCoreNode, closeFlyingTargets, closeGroundTargets []targetable) {
+	if len(closeFlyingTargets)+len(closeGroundTargets) == 0 {
 		return
 	}
 
 	maxDispatched := gmath.Clamp(int(float64(selectedColony.agents.NumAvailableFighters())*0.6), 1, 15)
 	selectedColony.agents.Find(searchFighters|searchOnlyAvailable|searchRandomized, func(a *colonyAgentNode) bool {
 		if a.stats == gamedata.BomberAgentStats {
-			if len(*closeGroundTargets) == 0 {
+			if len(closeGroundTargets) == 0 {
+				return false
+			}
+			target, ok := gmath.RandElem(c.world.rand, closeGroundTargets).(*creepNode)
+			if !ok {
+				// Bombers are only used against the creeps.
 				return false
 			}
-			target := gmath.RandElem(c.world.rand, *closeGroundTargets)
 			maxDispatched--
 			a.AssignMode(agentModeBomberAttack, gmath.Vec{}, target)
 			return maxDispatched <= 0
@@ -1187,21 +1389,21 @@ func (c *Controller) launchAttack(selectedColony *colonyCoreNode) {
 			var targetSlice []targetable
 			switch a.stats.Weapon.TargetFlags {
 			case gamedata.TargetFlying:
-				targetSlice = *closeFlyingTargets
+				targetSlice = closeFlyingTargets
 			case gamedata.TargetGround:
-				targetSlice = *closeGroundTargets
+				targetSlice = closeGroundTargets
 			default:
 				// Can attack both.
 				switch {
-				case len(*closeGroundTargets) == 0:
-					targetSlice = *closeFlyingTargets
-				case len(*closeFlyingTargets) == 0:
-					targetSlice = *closeGroundTargets
+				case len(closeGroundTargets) == 0:
+					targetSlice = closeFlyingTargets
+				case len(closeFlyingTargets) == 0:
+					targetSlice = closeGroundTargets
 				default:
 					if c.world.rand.Bool() {
-						targetSlice = *closeFlyingTargets
+						targetSlice = closeFlyingTargets
 					} else {
-						targetSlice = *closeGroundTargets
+						targetSlice = closeGroundTargets
 					}
 				}
 			}
@@ -1220,7 +1422,7 @@ func (c *Controller) colonyCanMoveAt(core *colonyCoreNode, coord pathing.GridCoo
 	switch core.stats {
 	case gamedata.ArkCoreStats:
 		return c.world.CellIsFree(coord, layerNormal)
-	case gamedata.DenCoreStats:
+	case gamedata.DenCoreStats, gamedata.HiveCoreStats, gamedata.BeaconCoreStats:
 		return c.world.CellIsFree2x2(coord, layerLandColony)
 	case gamedata.TankCoreStats:
 		return c.world.CellIsFree(coord, layerLandColony)
@@ -1244,7 +1446,7 @@ func (c *Controller) findRelocationPoint(core *colonyCoreNode, dist float64, dst
 	}
 
 	if dist > 160 {
-		nextDst := dst.MoveTowards(core.pos, 64)
+		nextDst := detmath.MoveTowards(dst, core.pos, 64)
 		return c.findRelocationPoint(core, dist-64, nextDst)
 	}
 
@@ -1253,7 +1455,7 @@ func (c *Controller) findRelocationPoint(core *colonyCoreNode, dist float64, dst
 
 func (c *Controller) launchRelocation(core *colonyCoreNode, dist float64, dst gmath.Vec) bool {
 	pos := c.findRelocationPoint(core, dist, dst)
-	if !pos.IsZero() && pos.DistanceTo(core.pos) > 16 {
+	if !pos.IsZero() && detmath.DistanceTo(pos, core.pos) > 16 {
 		ok := core.doRelocation(pos)
 		if ok {
 			c.createMoveConfirmEffect(core.relocationPoint, core.player)
@@ -1288,6 +1490,15 @@ func (c *Controller) prepareBattleResults() {
 			}
 		}
 	}
+	if c.kothManager != nil {
+		c.world.result.KothScores = c.kothManager.scores
+		c.world.result.Winner = c.kothManager.winner
+	}
+	if c.config.GameMode == gamedata.ModeVersus {
+		if loser := versusLoser(c.world.players); loser != -1 {
+			c.world.result.Winner = 1 - loser
+		}
+	}
 	c.world.result.Score = calcScore(c.world)
 	c.world.result.DifficultyScore = c.config.DifficultyScore
 	c.world.result.DronePointsAllocated = c.config.DronePointsAllocated
@@ -1327,18 +1538,13 @@ func (c *Controller) victory() {
 		c.gameFinished = true
 		switch c.config.ExecMode {
 		case gamedata.ExecuteNormal:
-			t3set := map[gamedata.ColonyAgentKind]struct{}{}
-			for _, colony := range c.world.players[0].GetState().colonies {
-				colony.agents.Each(func(a *colonyAgentNode) {
-					if a.stats.Tier != 3 {
-						return
-					}
-					t3set[a.stats.Kind] = struct{}{}
-				})
-			}
-			for k := range t3set {
-				c.world.result.Tier3Drones = append(c.world.result.Tier3Drones, k)
+			// The first player is not always a colony player
+			// (see the reverse mode with a creeps bot).
+			var colonies []*colonyCoreNode
+			for _, p := range c.world.humanPlayers {
+				colonies = append(colonies, p.state.colonies...)
 			}
+			c.world.result.Tier3Drones, c.world.result.Tier4Drones = collectHighTierDrones(colonies)
 			c.leaveScene(newResultsController(c.state, &c.config, c.backController, c.world.result))
 		case gamedata.ExecuteDemo, gamedata.ExecuteReplay:
 			c.leaveScene(c.backController)
@@ -1346,6 +1552,29 @@ func (c *Controller) victory() {
 	})
 }
 
+// collectHighTierDrones returns the unique tier-3 and tier-4 drone kinds
+// that are present in the colonies.
+// The results are sorted, so they don't depend on the agents order.
+func collectHighTierDrones(colonies []*colonyCoreNode) (tier3, tier4 []gamedata.ColonyAgentKind) {
+	for _, colony := range colonies {
+		colony.agents.Each(func(a *colonyAgentNode) {
+			switch a.stats.Tier {
+			case 3:
+				if !xslices.Contains(tier3, a.stats.Kind) {
+					tier3 = append(tier3, a.stats.Kind)
+				}
+			case 4:
+				if !xslices.Contains(tier4, a.stats.Kind) {
+					tier4 = append(tier4, a.stats.Kind)
+				}
+			}
+		})
+	}
+	sort.Slice(tier3, func(i, j int) bool { return tier3[i] < tier3[j] })
+	sort.Slice(tier4, func(i, j int) bool { return tier4[i] < tier4[j] })
+	return tier3, tier4
+}
+
 func (c *Controller) sharedActionIsJustPressed(a input.Action) bool {
 	if c.state.GetInput(0).ActionIsJustPressed(a) {
 		return true
@@ -1384,6 +1613,13 @@ func (c *Controller) handleInput() {
 		return
 	}
 
+	if len(c.exitNotices) != 0 && c.canSaveGame() {
+		if c.sharedActionIsJustPressed(controls.ActionSaveAndQuit) {
+			c.onSaveAndQuitPressed()
+			return
+		}
+	}
+
 	c.camera.HandleInput()
 	if c.secondCamera != nil {
 		c.secondCamera.HandleInput()
@@ -1416,20 +1652,60 @@ func (c *Controller) isDefeatState() bool {
 		}
 
 	case gamedata.ModeReverse:
-		if c.config.PlayersMode == serverapi.PmodeTwoPlayers {
-			colonyPlayer := c.world.players[1]
-			if len(colonyPlayer.GetState().colonies) == 0 {
-				return true
-			}
+		colonyPlayer := c.world.players[1]
+		colonyDestroyed := len(colonyPlayer.GetState().colonies) == 0
+		switch c.config.PlayersMode {
+		case serverapi.PmodeSinglePlayer:
+			return c.world.boss == nil
+		case serverapi.PmodePlayerAndBot:
+			// The human player controls the colony here.
+			return colonyDestroyed
+		default:
+			// Both sides can lose.
+			return colonyDestroyed || c.world.boss == nil
 		}
-		if c.world.boss == nil {
-			return true
+
+	case gamedata.ModeVersus:
+		return isVersusDefeat(c.config.Players, versusLoser(c.world.players))
+
+	case gamedata.ModeKingOfTheHill:
+		// The match is decided by the koth manager, see onKothFinished.
+		if !c.kothManager.finished {
+			return false
 		}
+		return isVersusDefeat(c.config.Players, 1-c.kothManager.winner)
 	}
 
 	return false
 }
 
+// versusLoser returns the index of the versus player that has no colonies left.
+// It returns -1 if the match is not over yet.
+func versusLoser(players []player) int {
+	for i, p := range players {
+		if len(p.GetState().colonies) == 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// isVersusDefeat reports whether the versus (or king of the hill) match outcome is a defeat.
+// This is the case when the only human player loses to a bot.
+// In two players mode, one of the humans always wins.
+func isVersusDefeat(kinds []gamedata.PlayerKind, loser int) bool {
+	if loser == -1 || kinds[loser] != gamedata.PlayerHuman {
+		return false
+	}
+	numHumans := 0
+	for _, k := range kinds {
+		if k == gamedata.PlayerHuman {
+			numHumans++
+		}
+	}
+	return numHumans == 1
+}
+
 func (c *Controller) checkDefeat() {
 	if c.transitionQueued {
 		return
@@ -1445,25 +1721,39 @@ func (c *Controller) checkVictory() {
 		return
 	}
 
+	if c.campaignManager != nil {
+		// Campaign missions are ended with an objective trigger.
+		return
+	}
+
 	victory := false
 
 	switch c.config.GameMode {
 	case gamedata.ModeClassic:
 		victory = c.world.boss == nil
 
-	case gamedata.ModeArena, gamedata.ModeTutorial:
+	case gamedata.ModeArena, gamedata.ModeTutorial, gamedata.ModeKingOfTheHill:
 		// Do nothing. This mode is ended with a trigger.
 
 	case gamedata.ModeInfArena:
 		// Do nothing. This mode is endless.
 
 	case gamedata.ModeReverse:
-		// In two players mode, the only way to finish a match
+		// In two players (or two bots) mode, the only way to finish a match
 		// is to trigger a defeat to either players.
-		if c.config.PlayersMode == serverapi.PmodeSinglePlayer {
+		switch c.config.PlayersMode {
+		case serverapi.PmodeSinglePlayer:
 			colonyPlayer := c.world.players[1]
 			victory = len(colonyPlayer.GetState().colonies) == 0
+		case serverapi.PmodePlayerAndBot:
+			victory = c.world.boss == nil
 		}
+
+	case gamedata.ModeVersus:
+		// The last player with colonies wins.
+		// The human loss is handled by checkDefeat.
+		loser := versusLoser(c.world.players)
+		victory = loser != -1 && !isVersusDefeat(c.config.Players, loser)
 	}
 
 	if victory {
@@ -1501,7 +1791,7 @@ func (c *Controller) onPausePressed() {
 		c.pauseNotices = append(c.pauseNotices, pauseNotice)
 		c.scene.AddObject(pauseNotice)
 		noticeSize := gmath.Vec{X: pauseNotice.width, Y: pauseNotice.height}
-		noticeCenterPos := cam.Rect.Center().Sub(noticeSize.Mulf(0.5))
+		noticeCenterPos := cam.Rect.Max.Sub(noticeSize).Mulf(0.5)
 		pauseNotice.SetPos(noticeCenterPos)
 	}
 
@@ -1523,6 +1813,23 @@ func (c *Controller) onPausePressed() {
 	c.nodeRunner.SetPaused(paused)
 }
 
+// canSaveGame reports whether the current game can be continued later
+// by re-simulating its action log (see RecordActionLog).
+func (c *Controller) canSaveGame() bool {
+	if c.config.ExecMode != gamedata.ExecuteNormal || c.config.GameMode == gamedata.ModeTutorial {
+		return false
+	}
+	return !c.transitionQueued && len(c.world.humanPlayers) != 0
+}
+
+func (c *Controller) onSaveAndQuitPressed() {
+	c.scene.Context().SaveGameData(c.state.SavedGameDataKey(), SavedGame{
+		Date: time.Now(),
+		Log:  c.RecordActionLog(),
+	})
+	c.leaveScene(c.backController)
+}
+
 func (c *Controller) GetSessionState() *session.State {
 	return c.state
 }
@@ -1535,6 +1842,14 @@ func (c *Controller) Update(delta float64) {
 	}
 	c.musicPlayer.Update(delta)
 
+	if c.resumeLog != nil {
+		// The player input is ignored until the game is resumed.
+		if c.continueResume(c.resumeLog) {
+			c.resumeLog = nil
+		}
+		return
+	}
+
 	if !c.nodeRunner.IsPaused() {
 		if c.fogOfWar != nil {
 			for _, colony := range c.world.allColonies {
@@ -1581,7 +1896,8 @@ func (c *Controller) runUpdateStep(computedDelta, delta float64) {
 			}
 		}
 	}
-	if c.world.simulation && checkpoint {
+	// The simulations without a replay (like the bot games) have no checkpoints to compare with.
+	if c.world.simulation && checkpoint && len(c.world.result.DebugCheckpoints) <= len(c.replayCheckpoints) {
 		i := len(c.world.result.DebugCheckpoints) - 1
 		if c.replayCheckpoints[i] != c.world.result.DebugCheckpoints[i] {
 			fmt.Printf("invalid checkpoint: %d vs %d\n", c.replayCheckpoints[i], c.world.result.DebugCheckpoints[i])
@@ -1597,7 +1913,7 @@ func (c *Controller) runUpdateStep(computedDelta, delta float64) {
 	if !c.transitionQueued {
 		c.victoryCheckDelay = gmath.ClampMin(c.victoryCheckDelay-delta, 0)
 		if c.victoryCheckDelay == 0 {
-			c.victoryCheckDelay = c.scene.Rand().FloatRange(2.0, 3.5)
+			c.victoryCheckDelay = detmath.FloatRange(c.scene.Rand(), 2.0, 3.5)
 			c.checkVictory()
 		}
 	}
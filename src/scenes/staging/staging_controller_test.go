@@ -0,0 +1,132 @@
+package staging
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/roboden-game/gamedata"
+)
+
+type testPlayer struct {
+	state *playerState
+}
+
+func (p *testPlayer) Init()                               {}
+func (p *testPlayer) Update(computedDelta, delta float64) {}
+func (p *testPlayer) GetState() *playerState              { return p.state }
+
+func TestVersusOutcome(t *testing.T) {
+	const (
+		human = gamedata.PlayerHuman
+		bot   = gamedata.PlayerComputer
+	)
+
+	tests := []struct {
+		kinds       []gamedata.PlayerKind
+		numColonies []int
+		wantLoser   int
+		wantDefeat  bool
+	}{
+		// The match is not over yet.
+		{[]gamedata.PlayerKind{human, bot}, []int{1, 1}, -1, false},
+		{[]gamedata.PlayerKind{human, human}, []int{2, 1}, -1, false},
+
+		// The human loses to a bot: defeat.
+		{[]gamedata.PlayerKind{human, bot}, []int{0, 1}, 0, true},
+		// The human defeats a bot: victory.
+		{[]gamedata.PlayerKind{human, bot}, []int{1, 0}, 1, false},
+
+		// Someone always wins in two players mode.
+		{[]gamedata.PlayerKind{human, human}, []int{0, 1}, 0, false},
+		{[]gamedata.PlayerKind{human, human}, []int{1, 0}, 1, false},
+
+		// Bots can't be defeated.
+		{[]gamedata.PlayerKind{bot, bot}, []int{0, 1}, 0, false},
+	}
+
+	for i, test := range tests {
+		players := make([]player, len(test.numColonies))
+		for j, n := range test.numColonies {
+			pstate := newPlayerState()
+			for k := 0; k < n; k++ {
+				pstate.colonies = append(pstate.colonies, &colonyCoreNode{})
+			}
+			players[j] = &testPlayer{state: pstate}
+		}
+		loser := versusLoser(players)
+		defeat := isVersusDefeat(test.kinds, loser)
+		if loser != test.wantLoser || defeat != test.wantDefeat {
+			t.Fatalf("test[%d]: kinds=%v colonies=%v:\nhave: loser=%d defeat=%v\nwant: loser=%d defeat=%v",
+				i, test.kinds, test.numColonies, loser, defeat, test.wantLoser, test.wantDefeat)
+		}
+	}
+}
+
+func TestKothOutcome(t *testing.T) {
+	const (
+		human = gamedata.PlayerHuman
+		bot   = gamedata.PlayerComputer
+	)
+
+	tests := []struct {
+		kinds      []gamedata.PlayerKind
+		finished   bool
+		winner     int
+		wantDefeat bool
+	}{
+		{[]gamedata.PlayerKind{human, bot}, false, -1, false},
+		{[]gamedata.PlayerKind{human, bot}, true, 0, false},
+
+		// The bot takes the hill: defeat.
+		{[]gamedata.PlayerKind{human, bot}, true, 1, true},
+
+		{[]gamedata.PlayerKind{human, human}, true, 0, false},
+		{[]gamedata.PlayerKind{human, human}, true, 1, false},
+		{[]gamedata.PlayerKind{bot, bot}, true, 1, false},
+	}
+
+	for i, test := range tests {
+		c := &Controller{
+			kothManager: &kothManager{finished: test.finished, winner: test.winner},
+		}
+		c.config.GameMode = gamedata.ModeKingOfTheHill
+		c.config.Players = test.kinds
+		if defeat := c.isDefeatState(); defeat != test.wantDefeat {
+			t.Fatalf("test[%d]: kinds=%v winner=%d: have defeat=%v, want %v",
+				i, test.kinds, test.winner, defeat, test.wantDefeat)
+		}
+	}
+}
+
+func TestCollectHighTierDrones(t *testing.T) {
+	var rand gmath.Rand
+	rand.SetSeed(1)
+	newColony := func(drones ...*gamedata.AgentStats) *colonyCoreNode {
+		colony := &colonyCoreNode{agents: newColonyAgentContainer(&rand)}
+		for _, stats := range drones {
+			colony.agents.Add(&colonyAgentNode{stats: stats})
+		}
+		return colony
+	}
+
+	colonies := []*colonyCoreNode{
+		newColony(gamedata.WorkerAgentStats, gamedata.TitanAgentStats, gamedata.GuardianAgentStats),
+		newColony(gamedata.DestroyerAgentStats, gamedata.GuardianAgentStats, gamedata.TitanAgentStats),
+		newColony(),
+	}
+	tier3, tier4 := collectHighTierDrones(colonies)
+	wantTier3 := []gamedata.ColonyAgentKind{gamedata.AgentGuardian, gamedata.AgentDestroyer}
+	wantTier4 := []gamedata.ColonyAgentKind{gamedata.AgentTitan}
+	if fmt.Sprint(tier3) != fmt.Sprint(wantTier3) {
+		t.Fatalf("tier3:\nhave: %v\nwant: %v", tier3, wantTier3)
+	}
+	if fmt.Sprint(tier4) != fmt.Sprint(wantTier4) {
+		t.Fatalf("tier4:\nhave: %v\nwant: %v", tier4, wantTier4)
+	}
+
+	tier3, tier4 = collectHighTierDrones(nil)
+	if len(tier3) != 0 || len(tier4) != 0 {
+		t.Fatalf("expected no drones, have %v %v", tier3, tier4)
+	}
+}
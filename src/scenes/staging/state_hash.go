@@ -0,0 +1,77 @@
+package staging
+
+import (
+	"math"
+
+	"github.com/quasilyte/gmath"
+)
+
+// StateHash returns a hash of the simulation-critical world state.
+//
+// It's used to find the first tick where two simulations diverge,
+// like the same replay that is executed on different platforms.
+// The hash should be computed between the update steps.
+func (c *Controller) StateHash() uint64 {
+	h := newStateHasher()
+	w := c.world
+
+	h.addInt(c.controllerTick)
+	h.addInt(len(w.creeps))
+	for _, creep := range w.creeps {
+		h.addVec(creep.pos)
+		h.addFloat(creep.health)
+	}
+	h.addInt(len(w.allColonies))
+	for _, colony := range w.allColonies {
+		h.addVec(colony.pos)
+		h.addFloat(colony.health)
+		h.addFloat(colony.resources)
+		h.addInt(colony.agents.TotalNum())
+		colony.agents.Each(func(a *colonyAgentNode) {
+			h.addVec(a.pos)
+			h.addFloat(a.health)
+			h.addFloat(a.energy)
+		})
+	}
+	h.addInt(len(w.turrets))
+	for _, turret := range w.turrets {
+		h.addVec(turret.pos)
+		h.addFloat(turret.health)
+	}
+	h.addInt(len(w.essenceSources))
+	for _, source := range w.essenceSources {
+		h.addVec(source.pos)
+		h.addInt(source.resource)
+	}
+	h.addInt(len(w.scavengers))
+	for _, s := range w.scavengers {
+		h.addVec(s.pos)
+	}
+
+	return h.value
+}
+
+// stateHasher is a 64-bit FNV-1a variation that consumes the whole words.
+// The extra shift makes the high bits affect the low bits too.
+type stateHasher struct {
+	value uint64
+}
+
+func newStateHasher() stateHasher {
+	return stateHasher{value: 14695981039346656037}
+}
+
+func (h *stateHasher) addUint(x uint64) {
+	h.value ^= x
+	h.value *= 1099511628211
+	h.value ^= h.value >> 32
+}
+
+func (h *stateHasher) addInt(x int) { h.addUint(uint64(x)) }
+
+func (h *stateHasher) addFloat(x float64) { h.addUint(math.Float64bits(x)) }
+
+func (h *stateHasher) addVec(v gmath.Vec) {
+	h.addFloat(v.X)
+	h.addFloat(v.Y)
+}
@@ -0,0 +1,117 @@
+package staging
+
+import (
+	"image/color"
+
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/ge/xslices"
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/roboden-game/assets"
+	"github.com/quasilyte/roboden-game/gamedata"
+	"github.com/quasilyte/roboden-game/viewport"
+)
+
+var (
+	techColorResearched = ge.RGB(0x9dd793)
+	techColorLocked     = color.RGBA{R: 0x13, G: 0x1a, B: 0x22, A: 160}
+	techColorOffered    = ge.RGB(0xe7c34b)
+)
+
+// techPanelNode is a column of the colony tech tree nodes
+// that is rendered to the left of the rpanel.
+type techPanelNode struct {
+	scene *ge.Scene
+
+	cam *viewport.Camera
+
+	colony *colonyCoreNode
+
+	// Indexed by colonyTech; techNone slot is unused.
+	rects [techNum]*ge.Rect
+}
+
+func newTechPanelNode(cam *viewport.Camera) *techPanelNode {
+	return &techPanelNode{cam: cam}
+}
+
+func (panel *techPanelNode) IsDisposed() bool { return false }
+
+func (panel *techPanelNode) Init(scene *ge.Scene) {
+	panel.scene = scene
+
+	const (
+		rectWidth  float64 = 8
+		rectHeight float64 = 16
+		rectMargin float64 = 4
+	)
+
+	panelWidth := scene.LoadImage(assets.ImageRightPanelLayer1).Data.Bounds().Dx()
+	offsetX := panel.cam.Rect.Width() - float64(panelWidth) - rectWidth - 4
+	for tech := techNone + 1; tech < techNum; tech++ {
+		rect := ge.NewRect(scene.Context(), rectWidth, rectHeight)
+		rect.Centered = false
+		rect.OutlineWidth = 1
+		rect.Pos.Offset = gmath.Vec{
+			X: offsetX,
+			Y: 8 + float64(float64(tech-1)*(rectHeight+rectMargin)),
+		}
+		panel.cam.UI.AddGraphicsAbove(rect)
+		panel.rects[tech] = rect
+	}
+}
+
+func (panel *techPanelNode) GetItemUnderCursor(pos gmath.Vec) colonyTech {
+	if panel.colony == nil {
+		return techNone
+	}
+	for tech := techNone + 1; tech < techNum; tech++ {
+		rect := panel.rects[tech].BoundsRect()
+		rect.Min.X -= 2
+		rect.Max.X += 2
+		if rect.Contains(pos) {
+			return tech
+		}
+	}
+	return techNone
+}
+
+func (panel *techPanelNode) SetBase(colony *colonyCoreNode) {
+	panel.colony = colony
+
+	for tech := techNone + 1; tech < techNum; tech++ {
+		panel.rects[tech].Visible = colony != nil
+	}
+}
+
+func (panel *techPanelNode) UpdateMetrics() {
+	if panel.colony == nil {
+		return
+	}
+
+	offered := panel.colony.ResearchOptions()
+	for tech := techNone + 1; tech < techNum; tech++ {
+		rect := panel.rects[tech]
+		info := colonyTechTable[tech]
+
+		clr := techColorResearched
+		if info.faction != gamedata.NeutralFactionTag {
+			clr = gamedata.FactionByTag(info.faction).Color
+		}
+		switch {
+		case panel.colony.HasTech(tech):
+			rect.FillColorScale.SetColor(clr)
+		case panel.colony.canResearch(tech):
+			rect.FillColorScale.SetRGBA(clr.R, clr.G, clr.B, 60)
+		default:
+			rect.FillColorScale.SetColor(techColorLocked)
+		}
+
+		if xslices.Contains(offered, tech) {
+			rect.OutlineColorScale.SetColor(techColorOffered)
+		} else {
+			rect.OutlineColorScale.SetRGBA(0, 0, 0, 0)
+		}
+	}
+}
+
+func (panel *techPanelNode) Update(delta float64) {}
@@ -4,6 +4,7 @@ import (
 	"github.com/quasilyte/ge"
 	"github.com/quasilyte/gmath"
 	"github.com/quasilyte/roboden-game/assets"
+	"github.com/quasilyte/roboden-game/detmath"
 	"github.com/quasilyte/roboden-game/gamedata"
 )
 
@@ -42,11 +43,11 @@ func (t *teleporterNode) CanBeUsedBy(user *colonyCoreNode) bool {
 	target := t.other
 	for _, c := range t.world.allColonies {
 		if c != user {
-			if c.pos.DistanceSquaredTo(t.pos) < (40 * 40) {
+			if detmath.DistanceSquaredTo(c.pos, t.pos) < (40 * 40) {
 				return false
 			}
 		}
-		if target.pos.DistanceSquaredTo(c.pos) <= (40 * 40) {
+		if detmath.DistanceSquaredTo(target.pos, c.pos) <= (40 * 40) {
 			// There is a colony on the other side that blocks the teleporter.
 			return false
 		}
@@ -4,6 +4,7 @@ import (
 	"github.com/quasilyte/ge"
 	"github.com/quasilyte/gmath"
 	"github.com/quasilyte/roboden-game/assets"
+	"github.com/quasilyte/roboden-game/detmath"
 	"github.com/quasilyte/roboden-game/gamedata"
 )
 
@@ -62,7 +63,7 @@ func (tether *tetherNode) dispose() {
 
 func (tether *tetherNode) Update(delta float64) {
 	if !tether.line.Shader.IsNil() {
-		tether.shaderTime += delta * gamedata.TetherBeaconAgentStats.BeamSlideSpeed
+		tether.shaderTime += float64(delta * gamedata.TetherBeaconAgentStats.BeamSlideSpeed)
 		tether.line.Shader.SetFloatValue("Time", tether.shaderTime)
 	}
 
@@ -92,9 +93,9 @@ func (tether *tetherNode) Update(delta float64) {
 
 	tether.lifespan -= delta
 	beamRange := gamedata.TetherBeaconAgentStats.SupportRange
-	distSqr := tether.source.pos.DistanceSquaredTo(*tether.target.GetPos())
+	distSqr := detmath.DistanceSquaredTo(tether.source.pos, *tether.target.GetPos())
 	if distSqr > (beamRange * beamRange) {
-		tether.lifespan -= delta * 3
+		tether.lifespan -= float64(delta * 3)
 	}
 	if distSqr > (beamRange*beamRange)*1.25 {
 		tether.dispose()
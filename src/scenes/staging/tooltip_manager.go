@@ -11,6 +11,7 @@ import (
 	"github.com/quasilyte/gmath"
 	"github.com/quasilyte/gsignal"
 	"github.com/quasilyte/roboden-game/controls"
+	"github.com/quasilyte/roboden-game/detmath"
 	"github.com/quasilyte/roboden-game/gamedata"
 )
 
@@ -136,6 +137,14 @@ func (m *tooltipManager) OnHover(pos gmath.Vec) {
 		}
 	}
 
+	if m.player.techPanel != nil {
+		tech := m.player.techPanel.GetItemUnderCursor(pos.Sub(m.player.state.camera.ScreenPos))
+		if tech != techNone {
+			m.createTooltip(pos, m.techHint(tech))
+			return
+		}
+	}
+
 	if m.player.choiceGen.IsReady() && m.player.choiceWindow != nil {
 		choice := m.player.choiceWindow.GetChoiceUnderCursor(pos.Sub(m.player.state.camera.ScreenPos))
 		if choice != nil {
@@ -146,6 +155,8 @@ func (m *tooltipManager) OnHover(pos gmath.Vec) {
 					info := creepOptionInfoList[creepCardID(choice.option.special)]
 					hint = fmt.Sprintf(d.Get("game.hint.action.garrison_f"), side) + "\n" +
 						fmt.Sprintf("x%d %s", numCreepsPerCard(m.player.creepsState, info), d.Get("creep", info.stats.NameTag))
+				} else if choice.option.special == specialResearch {
+					hint = m.researchHint(choice.option, choice == m.player.choiceWindow.choices[4])
 				} else {
 					key := strings.ToLower(choice.option.special.String())
 					hint = d.Get("game.hint.action", key)
@@ -184,7 +195,7 @@ func (m *tooltipManager) inHoverRange(hoverPos, objectPos gmath.Vec, objectSize
 		multiplier = 1.2
 	}
 	maxDistSqr := (objectSize * objectSize) * multiplier
-	return objectPos.DistanceSquaredTo(hoverPos) < maxDistSqr
+	return detmath.DistanceSquaredTo(objectPos, hoverPos) < maxDistSqr
 }
 
 func (m *tooltipManager) findHoverTargetHint(pos gmath.Vec) string {
@@ -197,6 +208,16 @@ func (m *tooltipManager) findHoverTargetHint(pos gmath.Vec) string {
 		return d.Get("game.hint.lava_geyser")
 	}
 
+	for _, lake := range m.world.iceLakes {
+		if !lake.rect.Contains(pos) {
+			continue
+		}
+		if lake.frozen {
+			return d.Get("game.hint.ice_lake.frozen")
+		}
+		return d.Get("game.hint.ice_lake")
+	}
+
 	for _, b := range m.world.neutralBuildings {
 		if !m.inHoverRange(pos, b.CurrentPos(), 26) {
 			continue
@@ -205,6 +226,12 @@ func (m *tooltipManager) findHoverTargetHint(pos gmath.Vec) string {
 		if b.agent != nil {
 			status = "functioning"
 		}
+		if b.stats == gamedata.ControlPointAgentStats {
+			status = "neutral"
+			if b.agent != nil {
+				status = "captured"
+			}
+		}
 		var tag string
 		switch b.stats {
 		case gamedata.DroneFactoryAgentStats:
@@ -215,6 +242,8 @@ func (m *tooltipManager) findHoverTargetHint(pos gmath.Vec) string {
 			tag = "tower"
 		case gamedata.MegaRoombaAgentStats:
 			tag = "megaroomba"
+		case gamedata.ControlPointAgentStats:
+			tag = "control_point"
 		}
 		return d.Get("game.hint.building", tag) + "\n" + d.Get("game.hint.building_status", status)
 	}
@@ -288,3 +317,85 @@ func (m *tooltipManager) createTooltip(pos gmath.Vec, s string) {
 	m.message = newScreenTutorialHintNode(camera, messagePos, gmath.Vec{}, s)
 	m.scene.AddObject(m.message)
 }
+
+func (m *tooltipManager) researchHint(option choiceOption, special bool) string {
+	d := m.scene.Dict()
+	if option.tech != techNone {
+		info := colonyTechTable[option.tech]
+		return fmt.Sprintf(d.Get("game.hint.action.research_f"), d.Get("game.tech", info.name), int(info.evoCost)) +
+			"\n" + d.Get("game.tech", info.name, "description")
+	}
+	if !special {
+		// An empty research option slot.
+		return ""
+	}
+	if m.player.choiceGen.researchColony != nil {
+		return d.Get("game.hint.action.research_back")
+	}
+	colony := m.player.state.selectedColony
+	if colony != nil && len(colony.ResearchOptions()) == 0 {
+		return d.Get("game.hint.action.research_done")
+	}
+	return d.Get("game.hint.action.research")
+}
+
+func (m *tooltipManager) upgradeTurretHint() string {
+	d := m.scene.Dict()
+	colony := m.player.state.selectedColony
+	if colony == nil {
+		return d.Get("game.hint.action.upgradeturret")
+	}
+	target := m.player.turretTargetPos(colony)
+	track := turretUpgradeAuto
+	if !target.IsZero() {
+		track = m.player.turretTargetTrack
+	}
+	turret, kind := colony.NextTurretUpgrade(target, track)
+	if turret == nil {
+		return d.Get("game.hint.action.upgradeturret_none")
+	}
+	hint := fmt.Sprintf(d.Get("game.hint.action.upgradeturret_f"),
+		d.Get("game.turret_upgrade", turretUpgradeNames[kind]),
+		turret.upgrades.levels[kind]+1,
+		int(turretUpgradePrice(turret, kind)))
+	if target.IsZero() {
+		hint += "\n" + d.Get("game.hint.action.turret_target")
+	} else {
+		hint += "\n" + d.Get("game.hint.action.turret_track")
+	}
+	return hint
+}
+
+func (m *tooltipManager) recycleTurretHint() string {
+	d := m.scene.Dict()
+	colony := m.player.state.selectedColony
+	if colony == nil {
+		return d.Get("game.hint.action.recycleturret")
+	}
+	target := m.player.turretTargetPos(colony)
+	turret := colony.NextTurretToRecycle(target)
+	if turret == nil {
+		return d.Get("game.hint.action.recycleturret_none")
+	}
+	if target.IsZero() {
+		hint := fmt.Sprintf(d.Get("game.hint.action.recycleturret_f"), int(turretRecycleRefund(turret)))
+		return hint + "\n" + d.Get("game.hint.action.turret_target")
+	}
+	return fmt.Sprintf(d.Get("game.hint.action.recycleturret_target_f"), int(turretRecycleRefund(turret)))
+}
+
+func (m *tooltipManager) techHint(tech colonyTech) string {
+	d := m.scene.Dict()
+	colony := m.player.state.selectedColony
+	info := colonyTechTable[tech]
+	hint := d.Get("game.tech", info.name) + "\n" + d.Get("game.tech", info.name, "description")
+	switch {
+	case colony.HasTech(tech):
+		hint += "\n" + d.Get("game.hint.tech.researched")
+	case colony.canResearch(tech):
+		hint += "\n" + fmt.Sprintf(d.Get("game.hint.tech.cost_f"), int(info.evoCost))
+	default:
+		hint += "\n" + d.Get("game.hint.tech.locked")
+	}
+	return hint
+}
@@ -0,0 +1,265 @@
+package staging
+
+import (
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/roboden-game/assets"
+	"github.com/quasilyte/roboden-game/detmath"
+	"github.com/quasilyte/roboden-game/gamedata"
+)
+
+// turretUpgrade is a per-turret stat track that is improved
+// with the colony resources through the upgrade card.
+type turretUpgrade int
+
+const (
+	turretUpgradeRange turretUpgrade = iota
+	turretUpgradeDamage
+	turretUpgradeArmor
+	turretUpgradeNum
+
+	// turretUpgradeAuto lets the upgrade card pick the least upgraded track.
+	turretUpgradeAuto turretUpgrade = -1
+)
+
+var turretUpgradeNames = [...]string{
+	turretUpgradeRange:  "range",
+	turretUpgradeDamage: "damage",
+	turretUpgradeArmor:  "armor",
+}
+
+const (
+	turretMaxUpgradeLevel = 2
+
+	// The upgrade price is multiplied by the next level.
+	turretUpgradeCost = 20.0
+
+	turretUpgradeRangeMultiplier  = 1.1
+	turretUpgradeDamageMultiplier = 1.25
+	turretUpgradeArmorMultiplier  = 1.3
+
+	// A recycled turret gives back a part of the resources spent on it.
+	// The turret construction itself is free, so only the upgrades are refunded.
+	turretRecycleRefundRate = 0.5
+
+	// The turret card target is a turret position,
+	// this tolerance is only needed to absorb the float errors.
+	turretTargetDist = 4.0
+)
+
+type turretUpgradeState struct {
+	levels [turretUpgradeNum]int
+	spent  float64
+}
+
+func (s *turretUpgradeState) totalLevel() int {
+	total := 0
+	for _, l := range s.levels {
+		total += l
+	}
+	return total
+}
+
+type turretWeaponKey struct {
+	weapon      *gamedata.WeaponStats
+	rangeLevel  int
+	damageLevel int
+}
+
+func isUpgradableTurret(turret *colonyAgentNode) bool {
+	return !turret.stats.IsNeutral && turret.stats.Weapon != nil
+}
+
+// TurretAt returns the colony turret that is closest to pos,
+// but not further than maxDist away from it.
+// This is how the turret selected by the player is resolved.
+func (c *colonyCoreNode) TurretAt(pos gmath.Vec, maxDist float64) *colonyAgentNode {
+	var bestTurret *colonyAgentNode
+	bestDistSqr := maxDist * maxDist
+	for _, turret := range c.turrets {
+		distSqr := detmath.DistanceSquaredTo(turret.pos, pos)
+		if distSqr <= bestDistSqr {
+			bestTurret = turret
+			bestDistSqr = distSqr
+		}
+	}
+	return bestTurret
+}
+
+// turretUpgradeParam encodes the upgrade track as a player action param.
+// The zero param stands for turretUpgradeAuto.
+func turretUpgradeParam(track turretUpgrade) int {
+	return int(track) + 1
+}
+
+func turretUpgradeFromParam(param int) turretUpgrade {
+	track := turretUpgrade(param - 1)
+	if track < turretUpgradeAuto || track >= turretUpgradeNum {
+		return turretUpgradeAuto
+	}
+	return track
+}
+
+// nextUpgradeTrack returns the turret stat track that will be upgraded next.
+// If the track is not selected by the player (turretUpgradeAuto),
+// the tracks are upgraded in the range, damage, armor order.
+// The second result is false if the turret can't be upgraded any further.
+func nextUpgradeTrack(turret *colonyAgentNode, track turretUpgrade) (turretUpgrade, bool) {
+	if !isUpgradableTurret(turret) {
+		return turretUpgradeRange, false
+	}
+	if track != turretUpgradeAuto {
+		return track, turret.upgrades.levels[track] < turretMaxUpgradeLevel
+	}
+	kind := turretUpgradeRange
+	for k := turretUpgradeRange; k < turretUpgradeNum; k++ {
+		if turret.upgrades.levels[k] < turret.upgrades.levels[kind] {
+			kind = k
+		}
+	}
+	return kind, turret.upgrades.levels[kind] < turretMaxUpgradeLevel
+}
+
+// NextTurretUpgrade returns the turret and the stat track that will
+// be upgraded by the next upgrade card.
+// A non-zero target is a position of the turret selected by the player.
+// Without a target, the least upgraded turret goes first.
+// The track is either selected by the player or turretUpgradeAuto.
+// Returns a nil turret if there is nothing to upgrade.
+func (c *colonyCoreNode) NextTurretUpgrade(target gmath.Vec, track turretUpgrade) (*colonyAgentNode, turretUpgrade) {
+	if !target.IsZero() {
+		turret := c.TurretAt(target, turretTargetDist)
+		if turret == nil {
+			return nil, turretUpgradeRange
+		}
+		kind, ok := nextUpgradeTrack(turret, track)
+		if !ok {
+			return nil, turretUpgradeRange
+		}
+		return turret, kind
+	}
+
+	var bestTurret *colonyAgentNode
+	bestLevel := 0
+	for _, turret := range c.turrets {
+		if _, ok := nextUpgradeTrack(turret, track); !ok {
+			continue
+		}
+		level := turret.upgrades.totalLevel()
+		if bestTurret == nil || level < bestLevel {
+			bestTurret = turret
+			bestLevel = level
+		}
+	}
+	if bestTurret == nil {
+		return nil, turretUpgradeRange
+	}
+	kind, _ := nextUpgradeTrack(bestTurret, track)
+	return bestTurret, kind
+}
+
+func turretUpgradePrice(turret *colonyAgentNode, kind turretUpgrade) float64 {
+	return float64(turretUpgradeCost * float64(turret.upgrades.levels[kind]+1))
+}
+
+func (c *colonyCoreNode) CanUpgradeTurret(target gmath.Vec, track turretUpgrade) bool {
+	turret, kind := c.NextTurretUpgrade(target, track)
+	return turret != nil && c.resources >= turretUpgradePrice(turret, kind)
+}
+
+func (c *colonyCoreNode) UpgradeTurret(target gmath.Vec, track turretUpgrade) bool {
+	if !c.CanUpgradeTurret(target, track) {
+		return false
+	}
+	turret, kind := c.NextTurretUpgrade(target, track)
+	price := turretUpgradePrice(turret, kind)
+	c.resources -= price
+	turret.upgrades.spent += price
+	turret.upgrades.levels[kind]++
+
+	if kind == turretUpgradeArmor {
+		turret.maxHealth *= turretUpgradeArmorMultiplier
+		turret.health *= turretUpgradeArmorMultiplier
+	}
+
+	playSound(c.world, assets.AudioAgentProduced, turret.pos)
+	return true
+}
+
+// NextTurretToRecycle returns the turret that will be removed by the recycle card.
+// A non-zero target is a position of the turret selected by the player.
+// Without a target, the turret that is most distant from the colony is selected,
+// since it's the most likely one to be left behind after the relocation.
+func (c *colonyCoreNode) NextTurretToRecycle(target gmath.Vec) *colonyAgentNode {
+	if !target.IsZero() {
+		turret := c.TurretAt(target, turretTargetDist)
+		if turret == nil || turret.stats.IsNeutral {
+			return nil
+		}
+		return turret
+	}
+
+	var bestTurret *colonyAgentNode
+	bestDistSqr := 0.0
+	for _, turret := range c.turrets {
+		if turret.stats.IsNeutral {
+			continue
+		}
+		distSqr := detmath.DistanceSquaredTo(turret.pos, c.pos)
+		if bestTurret == nil || distSqr > bestDistSqr {
+			bestTurret = turret
+			bestDistSqr = distSqr
+		}
+	}
+	return bestTurret
+}
+
+func turretRecycleRefund(turret *colonyAgentNode) float64 {
+	return float64(turret.upgrades.spent * turretRecycleRefundRate)
+}
+
+func (c *colonyCoreNode) RecycleTurret(target gmath.Vec) bool {
+	turret := c.NextTurretToRecycle(target)
+	if turret == nil {
+		return false
+	}
+	c.resources += turretRecycleRefund(turret)
+	playSound(c.world, assets.AudioAgentRecycled, turret.pos)
+	turret.Destroy()
+	return true
+}
+
+func (c *colonyCoreNode) turretWeapon(w *gamedata.WeaponStats, upgrades *turretUpgradeState) *gamedata.WeaponStats {
+	rangeMultiplier := 1.0
+	if c.HasTech(techTurretRange) {
+		rangeMultiplier *= techTurretRangeMultiplier
+	}
+	if c.HasTech(techTurretRange2) {
+		rangeMultiplier *= techTurretRangeMultiplier
+	}
+	key := turretWeaponKey{
+		weapon:      w,
+		rangeLevel:  upgrades.levels[turretUpgradeRange],
+		damageLevel: upgrades.levels[turretUpgradeDamage],
+	}
+	for i := 0; i < key.rangeLevel; i++ {
+		rangeMultiplier *= turretUpgradeRangeMultiplier
+	}
+	if rangeMultiplier == 1 && key.damageLevel == 0 {
+		return w
+	}
+
+	if c.techs.turretWeapons == nil {
+		c.techs.turretWeapons = make(map[turretWeaponKey]*gamedata.WeaponStats, 2)
+	}
+	upgraded, ok := c.techs.turretWeapons[key]
+	if !ok {
+		cloned := *w
+		cloned.AttackRange *= rangeMultiplier
+		for i := 0; i < key.damageLevel; i++ {
+			cloned.Damage.Health *= turretUpgradeDamageMultiplier
+		}
+		upgraded = gamedata.InitWeaponStats(&cloned)
+		c.techs.turretWeapons[key] = upgraded
+	}
+	return upgraded
+}
@@ -0,0 +1,120 @@
+package staging
+
+import (
+	"testing"
+
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/roboden-game/gamedata"
+)
+
+func TestTurretUpgradeTarget(t *testing.T) {
+	colony := &colonyCoreNode{pos: gmath.Vec{X: 100, Y: 100}}
+	newTurret := func(x, y float64, levels [turretUpgradeNum]int) *colonyAgentNode {
+		turret := &colonyAgentNode{
+			stats:      gamedata.GunpointAgentStats,
+			pos:        gmath.Vec{X: x, Y: y},
+			colonyCore: colony,
+		}
+		turret.upgrades.levels = levels
+		colony.turrets = append(colony.turrets, turret)
+		return turret
+	}
+	near := newTurret(150, 100, [turretUpgradeNum]int{1, 1, 0})
+	far := newTurret(400, 100, [turretUpgradeNum]int{1, 0, 0})
+	maxed := newTurret(100, 200, [turretUpgradeNum]int{2, 2, 2})
+	relict := newTurret(300, 300, [turretUpgradeNum]int{})
+	relict.stats = gamedata.RepulseTowerAgentStats
+
+	tests := []struct {
+		target      gmath.Vec
+		wantUpgrade *colonyAgentNode
+		wantKind    turretUpgrade
+		wantRecycle *colonyAgentNode
+	}{
+		// No target: the least upgraded turret and the most distant turret.
+		{gmath.Vec{}, far, turretUpgradeDamage, far},
+
+		{near.pos, near, turretUpgradeArmor, near},
+		{far.pos.Add(gmath.Vec{X: 1}), far, turretUpgradeDamage, far},
+		{maxed.pos, nil, turretUpgradeRange, maxed},
+		{relict.pos, nil, turretUpgradeRange, nil},
+
+		// A target without a turret is not replaced by any other turret.
+		{gmath.Vec{X: 150, Y: 120}, nil, turretUpgradeRange, nil},
+	}
+
+	for i, test := range tests {
+		turret, kind := colony.NextTurretUpgrade(test.target, turretUpgradeAuto)
+		if turret != test.wantUpgrade || kind != test.wantKind {
+			t.Fatalf("test[%d]: upgrade target %v:\nhave: %p %v\nwant: %p %v",
+				i, test.target, turret, kind, test.wantUpgrade, test.wantKind)
+		}
+		if turret := colony.NextTurretToRecycle(test.target); turret != test.wantRecycle {
+			t.Fatalf("test[%d]: recycle target %v:\nhave: %p\nwant: %p",
+				i, test.target, turret, test.wantRecycle)
+		}
+	}
+}
+
+func TestTurretUpgradeTrack(t *testing.T) {
+	colony := &colonyCoreNode{pos: gmath.Vec{X: 100, Y: 100}}
+	newTurret := func(x, y float64, levels [turretUpgradeNum]int) *colonyAgentNode {
+		turret := &colonyAgentNode{
+			stats:      gamedata.GunpointAgentStats,
+			pos:        gmath.Vec{X: x, Y: y},
+			colonyCore: colony,
+		}
+		turret.upgrades.levels = levels
+		colony.turrets = append(colony.turrets, turret)
+		return turret
+	}
+	a := newTurret(150, 100, [turretUpgradeNum]int{2, 0, 0})
+	b := newTurret(400, 100, [turretUpgradeNum]int{0, 1, 1})
+
+	tests := []struct {
+		target     gmath.Vec
+		track      turretUpgrade
+		wantTurret *colonyAgentNode
+		wantKind   turretUpgrade
+	}{
+		{a.pos, turretUpgradeAuto, a, turretUpgradeDamage},
+		{a.pos, turretUpgradeArmor, a, turretUpgradeArmor},
+		{a.pos, turretUpgradeRange, nil, turretUpgradeRange},
+		{b.pos, turretUpgradeAuto, b, turretUpgradeRange},
+		{b.pos, turretUpgradeDamage, b, turretUpgradeDamage},
+
+		// Without a target, the least upgraded turret that can upgrade the track.
+		{gmath.Vec{}, turretUpgradeRange, b, turretUpgradeRange},
+		{gmath.Vec{}, turretUpgradeDamage, a, turretUpgradeDamage},
+	}
+
+	for i, test := range tests {
+		turret, kind := colony.NextTurretUpgrade(test.target, test.track)
+		if turret != test.wantTurret || kind != test.wantKind {
+			t.Fatalf("test[%d]: upgrade target %v track %v:\nhave: %p %v\nwant: %p %v",
+				i, test.target, test.track, turret, kind, test.wantTurret, test.wantKind)
+		}
+	}
+
+	for track := turretUpgradeAuto; track < turretUpgradeNum; track++ {
+		if decoded := turretUpgradeFromParam(turretUpgradeParam(track)); decoded != track {
+			t.Fatalf("param roundtrip: have %v, want %v", decoded, track)
+		}
+	}
+	if turretUpgradeFromParam(0) != turretUpgradeAuto {
+		t.Fatalf("zero param should select the auto track")
+	}
+}
+
+func TestTurretRecycleRefund(t *testing.T) {
+	// A turret is built for free, so recycling a turret that was
+	// never upgraded must not create any resources.
+	turret := &colonyAgentNode{stats: gamedata.GunpointAgentStats}
+	if refund := turretRecycleRefund(turret); refund != 0 {
+		t.Fatalf("not upgraded turret refund: have %v, want 0", refund)
+	}
+	turret.upgrades.spent = turretUpgradeCost * 3
+	if refund := turretRecycleRefund(turret); refund >= turret.upgrades.spent {
+		t.Fatalf("upgraded turret refund %v is not less than the spent %v", refund, turret.upgrades.spent)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/quasilyte/ge"
 	"github.com/quasilyte/gmath"
 	"github.com/quasilyte/gsignal"
+	"github.com/quasilyte/roboden-game/detmath"
 	"github.com/quasilyte/roboden-game/gamedata"
 	"github.com/quasilyte/roboden-game/gameinput"
 )
@@ -110,7 +111,7 @@ func (m *tutorialManager) Update(delta float64) {
 
 	m.attackCountdown = gmath.ClampMin(m.attackCountdown-delta, 0)
 	if m.attackCountdown == 0 {
-		m.attackCountdown = m.world.rand.FloatRange(3.0*60, 5*60)
+		m.attackCountdown = detmath.FloatRange(m.world.rand, 3.0*60, 5*60)
 		m.spawnAttack()
 	}
 
@@ -118,7 +119,7 @@ func (m *tutorialManager) Update(delta float64) {
 	if m.updateDelay != 0 {
 		return
 	}
-	m.updateDelay = m.scene.Rand().FloatRange(0.75, 1.25)
+	m.updateDelay = detmath.FloatRange(m.scene.Rand(), 0.75, 1.25)
 
 	m.stepTicks = gmath.ClampMin(m.stepTicks-1, 0)
 
@@ -207,7 +208,7 @@ func (m *tutorialManager) maybeCompleteStep() bool {
 		if !colony.waypoint.IsZero() {
 			// Maybe the colony was already on the move?
 			for _, res := range m.world.essenceSources {
-				if res.pos.DistanceSquaredTo(colony.relocationPoint) < (128 * 128) {
+				if detmath.DistanceSquaredTo(res.pos, colony.relocationPoint) < (128 * 128) {
 					m.nearReasources = true
 					break
 				}
@@ -215,7 +216,7 @@ func (m *tutorialManager) maybeCompleteStep() bool {
 		} else {
 			// Maybe it's already around some resources.
 			for _, res := range m.world.essenceSources {
-				if res.pos.DistanceSquaredTo(colony.pos) < (128 * 128) {
+				if detmath.DistanceSquaredTo(res.pos, colony.pos) < (128 * 128) {
 					m.nearReasources = true
 					break
 				}
@@ -331,7 +332,7 @@ func (m *tutorialManager) maybeCompleteStep() bool {
 			return false
 		}
 		for _, res := range m.world.essenceSources {
-			if res.pos.DistanceSquaredTo(m.choice.Pos) < (128 * 128) {
+			if detmath.DistanceSquaredTo(res.pos, m.choice.Pos) < (128 * 128) {
 				return true
 			}
 		}
@@ -620,7 +621,7 @@ func (m *tutorialManager) findResourceStash(minDist float64) ge.Pos {
 		if res.stats.scrap || res.stats == redOilSource || res.stats == redCrystalSource {
 			continue
 		}
-		dist := res.pos.DistanceTo(colony.pos)
+		dist := detmath.DistanceTo(res.pos, colony.pos)
 		if dist < minDist {
 			continue
 		}
@@ -635,6 +636,6 @@ func (m *tutorialManager) findResourceStash(minDist float64) ge.Pos {
 		}
 	}
 
-	pos = pos.DirectionTo(colony.pos).Mulf(40).Add(pos)
+	pos = detmath.AddScaled(pos, detmath.DirectionTo(pos, colony.pos), 40)
 	return ge.Pos{Offset: pos}
 }
@@ -9,8 +9,10 @@ import (
 	"github.com/quasilyte/ge"
 	"github.com/quasilyte/gmath"
 	"github.com/quasilyte/roboden-game/assets"
+	"github.com/quasilyte/roboden-game/detmath"
 	"github.com/quasilyte/roboden-game/gamedata"
 	"github.com/quasilyte/roboden-game/pathing"
+	"github.com/quasilyte/roboden-game/spatial"
 )
 
 func resizedRect(rect gmath.Rect, delta float64) gmath.Rect {
@@ -83,12 +85,46 @@ func isValidCreepTarget(pos gmath.Vec, creep *creepNode, weapon *gamedata.Weapon
 	if creep.marked > 0 {
 		attackRangeSqr *= weapon.AttackRangeMarkMultiplier
 	}
-	if creep.pos.DistanceSquaredTo(pos) > attackRangeSqr {
+	if detmath.DistanceSquaredTo(creep.pos, pos) > attackRangeSqr {
 		return false
 	}
 	return true
 }
 
+func isValidAgentTarget(pos gmath.Vec, a *colonyAgentNode, weapon *gamedata.WeaponStats) bool {
+	if a.IsCloaked() || a.insideForest {
+		return false
+	}
+	if weapon.TargetFlags&targetKindOf(a) == 0 {
+		return false
+	}
+	return detmath.DistanceSquaredTo(a.pos, pos) <= weapon.AttackRangeSqr
+}
+
+// canFollowTarget reports whether a followed target is still visible.
+func canFollowTarget(t targetable) bool {
+	switch t := t.(type) {
+	case *creepNode:
+		return t.CanBeTargeted()
+	case *colonyAgentNode:
+		return !t.IsCloaked() && !t.insideForest
+	default:
+		return true
+	}
+}
+
+// targetKindOf returns a target kind for an arbitrary target.
+// Creeps have their own rules, everything else is classified by its flying status.
+func targetKindOf(t targetable) gamedata.TargetKind {
+	if creep, ok := t.(*creepNode); ok {
+		return creep.TargetKind()
+	}
+	if t.IsFlying() {
+		return gamedata.TargetFlying
+	}
+	return gamedata.TargetGround
+}
+
 func attackWithProjectile(world *worldState, weapon *gamedata.WeaponStats, attacker, target targetable, burstSize int, guided bool) {
 	toPos := snipePos(weapon.ProjectileSpeed, *attacker.GetPos(), *target.GetPos(), target.GetVelocity())
 	j := 0
@@ -155,11 +191,12 @@ func createSubImage(img resource.Image, offsetX int) *ebiten.Image {
 func pointToLineDistance(point, a, b gmath.Vec) float64 {
 	s1 := -b.Y + a.Y
 	s2 := b.X - a.X
-	return math.Abs((point.X-a.X)*s1+(point.Y-a.Y)*s2) / math.Sqrt(s1*s1+s2*s2)
+	return math.Abs(float64((point.X-a.X)*s1)+float64((point.Y-a.Y)*s2)) / math.Sqrt(float64(s1*s1)+float64(s2*s2))
 }
 
 func midpoint(a, b gmath.Vec) gmath.Vec {
-	return a.Add(b).Mulf(0.5)
+	sum := a.Add(b)
+	return gmath.Vec{X: float64(sum.X * 0.5), Y: float64(sum.Y * 0.5)}
 }
 
 func sideName(side int) string {
@@ -177,10 +214,67 @@ func sideName(side int) string {
 
 func moveTowardsWithSpeed(from, to gmath.Vec, delta, speed float64) (gmath.Vec, bool) {
 	travelled := speed * delta
-	result := from.MoveTowards(to, travelled)
+	result := detmath.MoveTowards(from, to, travelled)
 	return result, result == to
 }
 
+// randWalkIndex is like randIterate, but it walks the index objects that are
+// located in the cells around pos (the object distances are not checked).
+// The cells are visited in a random order; the outside objects go last.
+//
+// The cell objects are copied into buf before they're passed to f,
+// so f is allowed to insert and remove the index objects.
+func randWalkIndex[T comparable](rand *gmath.Rand, index *spatial.Index[T], buf *[]T, pos gmath.Vec, r float64, f func(x T) bool) T {
+	var result T
+	if index.Len() == 0 {
+		return result
+	}
+
+	walkObjects := func(objects []T) T {
+		// The nested walks append their objects after ours.
+		start := len(*buf)
+		*buf = append(*buf, objects...)
+		x := randIterate(rand, (*buf)[start:], f)
+		*buf = (*buf)[:start]
+		return x
+	}
+
+	if startX, startY, endX, endY, ok := index.CellRange(pos, r); ok {
+		numStepsX := endX - startX + 1
+		numStepsY := endY - startY + 1
+
+		// Now decide the cells traversal order.
+		// This is needed to add some randomness to the target selection.
+		dx := 1
+		if rand.Bool() {
+			dx = -1
+			startX = endX
+		}
+		dy := 1
+		if rand.Bool() {
+			dy = -1
+			startY = endY
+		}
+
+		for i, y := 0, startY; i < numStepsY; i, y = i+1, y+dy {
+			for j, x := 0, startX; j < numStepsX; j, x = j+1, x+dx {
+				if objects := index.Cell(x, y); len(objects) != 0 {
+					if found := walkObjects(objects); found != result {
+						return found
+					}
+				}
+			}
+		}
+	}
+
+	// New creeps are created outside of the map, so they end up
+	// in the outside bucket that includes everything that is out of bounds.
+	if objects := index.Outside(); len(objects) != 0 {
+		return walkObjects(objects)
+	}
+	return result
+}
+
 func randIterate[T any](rand *gmath.Rand, slice []T, f func(x T) bool) T {
 	var result T
 	if len(slice) == 0 {
@@ -216,8 +310,8 @@ func randIterate[T any](rand *gmath.Rand, slice []T, f func(x T) bool) T {
 
 func randomSectorPos(rng *gmath.Rand, sector gmath.Rect) gmath.Vec {
 	return gmath.Vec{
-		X: rng.FloatRange(sector.Min.X, sector.Max.X),
-		Y: rng.FloatRange(sector.Min.Y, sector.Max.Y),
+		X: detmath.FloatRange(rng, sector.Min.X, sector.Max.X),
+		Y: detmath.FloatRange(rng, sector.Min.Y, sector.Max.Y),
 	}
 }
 
@@ -239,15 +333,15 @@ func posMove(pos gmath.Vec, d pathing.Direction) gmath.Vec {
 func orbitingWaypoint(world *worldState, pos, center gmath.Vec, dist float64, clockwise bool) gmath.Vec {
 	var direction gmath.Vec
 	if pos == center {
-		direction = gmath.RadToVec(world.rand.Rad())
+		direction = detmath.RadToVec(detmath.RandRad(world.rand))
 	} else {
-		direction = pos.DirectionTo(center)
+		direction = detmath.DirectionTo(pos, center)
 	}
 	angle := gmath.Rad(0.4)
 	if !clockwise {
 		angle = -0.4
 	}
-	return direction.Rotated(angle).Mulf(dist).Add(center)
+	return detmath.AddScaled(center, detmath.Rotated(direction, angle), dist)
 }
 
 type collisionFlags int
@@ -272,11 +366,19 @@ func posIsFreeWithFlags(world *worldState, skipColony *colonyCoreNode, pos gmath
 		}
 	}
 
-	radiusSqr := radius * radius
+	radiusSqr := float64(radius * radius)
+
+	if world.envKind == gamedata.EnvIce {
+		for _, lake := range world.iceLakes {
+			if lake.CollidesWith(pos, radius) {
+				return false
+			}
+		}
+	}
 
 	if world.envKind == gamedata.EnvInferno {
 		for _, g := range world.lavaGeysers {
-			if g.pos.DistanceSquaredTo(pos) < (radiusSqr + (40 * 40)) {
+			if detmath.DistanceSquaredTo(g.pos, pos) < (radiusSqr + (40 * 40)) {
 				return false
 			}
 		}
@@ -288,31 +390,33 @@ func posIsFreeWithFlags(world *worldState, skipColony *colonyCoreNode, pos gmath
 	}
 
 	for _, b := range world.neutralBuildings {
-		if b.pos.DistanceSquaredTo(pos) < (radiusSqr + (40 * 40)) {
+		if detmath.DistanceSquaredTo(b.pos, pos) < (radiusSqr + (40 * 40)) {
 			return false
 		}
 	}
-	for _, source := range world.essenceSources {
-		if source.pos.DistanceTo(pos) < (radius + source.stats.size) {
-			return false
-		}
+	_, collides := world.essenceIndex.WalkRadius(pos, radius+maxEssenceSourceSize, func(source *essenceSourceNode) bool {
+		return detmath.DistanceTo(source.pos, pos) < (radius + source.stats.size)
+	})
+	if collides {
+		return false
 	}
 	for _, construction := range world.constructions {
-		if construction.pos.DistanceTo(pos) < (radius + 40) {
+		if detmath.DistanceTo(construction.pos, pos) < (radius + 40) {
 			return false
 		}
 	}
-	for _, turret := range world.turrets {
-		if turret.pos.DistanceTo(pos) < (radius + 32) {
-			return false
-		}
+	_, collides = world.turretIndex.WalkRadius(pos, radius+32, func(turret *colonyAgentNode) bool {
+		return detmath.DistanceTo(turret.pos, pos) < (radius + 32)
+	})
+	if collides {
+		return false
 	}
 	for _, colony := range world.allColonies {
 		// TODO: flying colonies are not a problem?
 		if colony == skipColony {
 			continue
 		}
-		if colony.pos.DistanceTo(pos) < (radius + 40) {
+		if detmath.DistanceTo(colony.pos, pos) < (radius + 40) {
 			return false
 		}
 	}
@@ -322,14 +426,14 @@ func posIsFreeWithFlags(world *worldState, skipColony *colonyCoreNode, pos gmath
 		if skipSmall && creep.stats.Kind == gamedata.CreepCrawler {
 			continue
 		}
-		if creep.stats.ShadowImage == assets.ImageNone && creep.pos.DistanceTo(pos) < (radius+creep.stats.Size) {
+		if creep.stats.ShadowImage == assets.ImageNone && detmath.DistanceTo(creep.pos, pos) < (radius+creep.stats.Size) {
 			return false
 		}
 	}
 
 	if flags&collisionSkipTeleporters == 0 {
 		for _, tp := range world.teleporters {
-			if tp.pos.DistanceTo(pos) < (radius + 54) {
+			if detmath.DistanceTo(tp.pos, pos) < (radius + 54) {
 				return false
 			}
 		}
@@ -421,8 +525,8 @@ func createAreaExplosion(world *worldState, rect gmath.Rect, layer effectLayer)
 
 	// FIXME: Rect.Center() does not work properly in gmath.
 	center := gmath.Vec{
-		X: rect.Max.X - rect.Width()*0.5,
-		Y: rect.Max.Y - rect.Height()*0.5,
+		X: rect.Max.X - float64(rect.Width()*0.5),
+		Y: rect.Max.Y - float64(rect.Height()*0.5),
 	}
 
 	if world.cameraShakingEnabled {
@@ -448,8 +552,8 @@ func createAreaExplosion(world *worldState, rect gmath.Rect, layer effectLayer)
 	allowVertical := layer == normalEffectLayer
 	for numExplosions > 0 {
 		offset := gmath.Vec{
-			X: world.localRand.FloatRange(-rect.Width()*0.4, rect.Width()*0.4),
-			Y: world.localRand.FloatRange(-rect.Height()*0.4, rect.Height()*0.4),
+			X: detmath.FloatRange(world.localRand, -rect.Width()*0.4, rect.Width()*0.4),
+			Y: detmath.FloatRange(world.localRand, -rect.Height()*0.4, rect.Height()*0.4),
 		}
 		if numExplosions >= 4 && world.localRand.Chance(0.4) {
 			numExplosions -= 4
@@ -516,7 +620,7 @@ func createExplosion(world *worldState, layer effectLayer, pos gmath.Vec) {
 }
 
 func spriteRect(pos gmath.Vec, sprite *ge.Sprite) gmath.Rect {
-	offset := gmath.Vec{X: sprite.FrameWidth * 0.5, Y: sprite.FrameHeight * 0.5}
+	offset := gmath.Vec{X: float64(sprite.FrameWidth * 0.5), Y: float64(sprite.FrameHeight * 0.5)}
 	return gmath.Rect{
 		Min: pos.Sub(offset),
 		Max: pos.Add(offset),
@@ -548,18 +652,18 @@ func snipePos(projectileSpeed float64, fireFrom, targetPos, targetVelocity gmath
 	if targetVelocity.IsZero() || projectileSpeed == 0 {
 		return targetPos
 	}
-	dist := targetPos.DistanceTo(fireFrom)
-	predictedPos := targetPos.Add(targetVelocity.Mulf(dist / projectileSpeed))
+	dist := detmath.DistanceTo(targetPos, fireFrom)
+	predictedPos := detmath.AddScaled(targetPos, targetVelocity, dist/projectileSpeed)
 	return predictedPos
 }
 
 func retreatPos(rand *gmath.Rand, dist float64, objectPos, threatPos gmath.Vec) gmath.Vec {
-	direction := threatPos.AngleToPoint(objectPos) + gmath.Rad(rand.FloatRange(-0.2, 0.2))
-	return objectPos.MoveInDirection(dist, direction)
+	direction := detmath.AngleToPoint(threatPos, objectPos) + gmath.Rad(detmath.FloatRange(rand, -0.2, 0.2))
+	return detmath.MoveInDirection(objectPos, dist, direction)
 }
 
 func playSound(world *worldState, id resource.AudioID, pos gmath.Vec) {
-	if world.simulation {
+	if world.simulation || world.resuming {
 		return
 	}
 	for _, cam := range world.cameras {
@@ -9,6 +9,7 @@ import (
 	"github.com/quasilyte/ge"
 	"github.com/quasilyte/gmath"
 	"github.com/quasilyte/roboden-game/assets"
+	"github.com/quasilyte/roboden-game/detmath"
 	"github.com/quasilyte/roboden-game/gamedata"
 	"github.com/quasilyte/roboden-game/pathing"
 )
@@ -175,6 +176,8 @@ func (w *wallClusterNode) drawMountains(bg *ge.TiledBackground, scene *ge.Scene)
 			texture += 5
 		case gamedata.EnvInferno:
 			texture += 10
+		case gamedata.EnvIce:
+			texture += 15
 		}
 
 		for j := 0; j < numSprites; j++ {
@@ -200,9 +203,9 @@ func (w *wallClusterNode) drawMountains(bg *ge.TiledBackground, scene *ge.Scene)
 			}
 			var offset gmath.Vec
 			if numSprites == 1 {
-				offset = w.world.localRand.Offset(-4, 4)
+				offset = detmath.Offset(w.world.localRand, -4, 4)
 			} else {
-				offset = w.world.localRand.Offset(-8, 8)
+				offset = detmath.Offset(w.world.localRand, -8, 8)
 			}
 			imageRect.Min = imageRect.Min.Add(offset)
 			imageRect.Max = imageRect.Max.Add(offset)
@@ -369,7 +372,7 @@ func (w *wallClusterNode) CollidesWith(pos gmath.Vec, r float64) bool {
 
 	r2 := r * r
 	for _, p := range w.points {
-		if p.DistanceSquaredTo(pos) < r2 {
+		if detmath.DistanceSquaredTo(p, pos) < r2 {
 			return true
 		}
 	}
@@ -0,0 +1,207 @@
+package staging
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/roboden-game/assets"
+	"github.com/quasilyte/roboden-game/detmath"
+	"github.com/quasilyte/roboden-game/gamedata"
+	"github.com/quasilyte/roboden-game/session"
+)
+
+type worldEventKind int
+
+const (
+	worldEventNone worldEventKind = iota
+	worldEventNight
+	worldEventStorm
+	worldEventMeteorShower
+)
+
+const (
+	// The fog of war reveal radius multiplier during the night.
+	nightVisionMultiplier = 0.7
+
+	// Stealth crawlers are faster during the night.
+	nightStealthCrawlerSpeedMultiplier = 1.25
+
+	// Flying units movement speed multiplier during the storm.
+	stormFlyingSpeedMultiplier = 0.75
+)
+
+// worldEventScheduler runs the periodic world events like nights and storms.
+//
+// It uses its own rand seeded with the level seed, so the
+// events timeline is identical for all runs of the same seed.
+type worldEventScheduler struct {
+	world *worldState
+
+	rand gmath.Rand
+
+	event    worldEventKind
+	delay    float64
+	duration float64
+
+	meteorDelay float64
+	meteorsLeft int
+	attacker    magmaDummyAttacker
+
+	shader       *ebiten.Shader
+	shaderParams map[string]any
+	nightAlpha   float64
+	stormAlpha   float64
+}
+
+func newWorldEventScheduler(world *worldState) *worldEventScheduler {
+	return &worldEventScheduler{world: world}
+}
+
+func (s *worldEventScheduler) Init(scene *ge.Scene) {
+	s.rand.SetSeed(s.world.config.Seed)
+	s.delay = detmath.FloatRange(&s.rand, 120, 180)
+
+	canUseShader := !s.world.simulation &&
+		s.world.graphicsSettings.AllShadersEnabled &&
+		s.world.sessionState.Persistent.Settings.Graphics.ScreenFilter == session.ScreenFilterNone
+	if canUseShader {
+		// The stage can have only one shader; screen filters have a priority.
+		s.shader = scene.Context().Loader.LoadShader(assets.ShaderWorldEvent).Data
+		s.shaderParams = map[string]any{
+			"Night": float32(0),
+			"Storm": float32(0),
+		}
+	}
+}
+
+func (s *worldEventScheduler) IsDisposed() bool { return false }
+
+func (s *worldEventScheduler) IsNight() bool { return s.event == worldEventNight }
+
+func (s *worldEventScheduler) IsStorm() bool { return s.event == worldEventStorm }
+
+func (s *worldEventScheduler) Update(delta float64) {
+	s.updateShader(delta)
+
+	if s.event == worldEventNone {
+		s.delay = gmath.ClampMin(s.delay-delta, 0)
+		if s.delay == 0 {
+			s.startEvent()
+		}
+		return
+	}
+
+	if s.event == worldEventMeteorShower {
+		s.meteorDelay = gmath.ClampMin(s.meteorDelay-delta, 0)
+		if s.meteorDelay == 0 {
+			s.dropMeteor()
+			s.meteorsLeft--
+			s.meteorDelay = detmath.FloatRange(&s.rand, 1.5, 3)
+		}
+		if s.meteorsLeft == 0 {
+			s.stopEvent()
+		}
+		return
+	}
+
+	s.duration = gmath.ClampMin(s.duration-delta, 0)
+	if s.duration == 0 {
+		s.stopEvent()
+	}
+}
+
+func (s *worldEventScheduler) startEvent() {
+	roll := s.rand.Float()
+	switch {
+	case roll < 0.4:
+		s.event = worldEventNight
+		s.duration = detmath.FloatRange(&s.rand, 60, 90)
+		s.announce("game.notice.night")
+	case roll < 0.75:
+		s.event = worldEventStorm
+		s.duration = detmath.FloatRange(&s.rand, 30, 50)
+		s.announce("game.notice.storm")
+	default:
+		s.event = worldEventMeteorShower
+		s.meteorsLeft = s.rand.IntRange(3, 6)
+		s.meteorDelay = detmath.FloatRange(&s.rand, 2, 4)
+		s.announce("game.notice.meteor_shower")
+	}
+}
+
+func (s *worldEventScheduler) stopEvent() {
+	if s.event == worldEventNight {
+		s.announce("game.notice.dawn")
+	}
+	s.event = worldEventNone
+	s.delay = detmath.FloatRange(&s.rand, 90, 150)
+}
+
+func (s *worldEventScheduler) dropMeteor() {
+	pos := correctedPos(s.world.innerRect, randomSectorPos(&s.rand, s.world.innerRect), 64)
+	spawnPos := pos.Sub(gmath.Vec{X: detmath.FloatRange(&s.rand, -80, 80), Y: 320})
+
+	s.attacker.pos = spawnPos
+	p := s.world.newProjectileNode(projectileConfig{
+		World:    s.world,
+		Weapon:   gamedata.MeteorHazardWeapon,
+		Attacker: &s.attacker,
+		ToPos:    pos,
+	})
+	p.trailCounter = 0.1
+	s.world.nodeRunner.AddProjectile(p)
+	p.EventDetonated.Connect(nil, func(pos gmath.Vec) {
+		if !posIsFree(s.world, nil, pos, 20) {
+			return
+		}
+		res := s.world.NewEssenceSourceNode(crystalSource, pos)
+		s.world.nodeRunner.AddObject(res)
+	})
+}
+
+func (s *worldEventScheduler) announce(key string) {
+	if s.world.simulation {
+		return
+	}
+	text := s.world.rootScene.Dict().Get(key)
+	for _, p := range s.world.players {
+		m := p.GetState().messageManager
+		if m == nil {
+			continue
+		}
+		m.AddMessage(queuedMessageInfo{
+			text:  text,
+			timer: 5,
+		})
+	}
+}
+
+func (s *worldEventScheduler) updateShader(delta float64) {
+	if s.shader == nil {
+		return
+	}
+
+	nightAlpha := approachValue(s.nightAlpha, s.IsNight(), delta*0.1)
+	stormAlpha := approachValue(s.stormAlpha, s.IsStorm(), delta*0.2)
+	if nightAlpha == s.nightAlpha && stormAlpha == s.stormAlpha {
+		return
+	}
+	s.nightAlpha = nightAlpha
+	s.stormAlpha = stormAlpha
+
+	if s.nightAlpha == 0 && s.stormAlpha == 0 {
+		s.world.stage.SetShader(nil, nil)
+		return
+	}
+	s.shaderParams["Night"] = float32(s.nightAlpha)
+	s.shaderParams["Storm"] = float32(s.stormAlpha)
+	s.world.stage.SetShader(s.shader, s.shaderParams)
+}
+
+func approachValue(value float64, active bool, step float64) float64 {
+	step = float64(step) // The callers pass a product here
+	if active {
+		return gmath.ClampMax(value+step, 1)
+	}
+	return gmath.ClampMin(value-step, 0)
+}
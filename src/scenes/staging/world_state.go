@@ -2,7 +2,6 @@ package staging
 
 import (
 	"image/color"
-	"math"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/quasilyte/ge"
@@ -10,14 +9,20 @@ import (
 	"github.com/quasilyte/ge/xslices"
 	"github.com/quasilyte/gmath"
 	"github.com/quasilyte/gsignal"
+	"github.com/quasilyte/roboden-game/detmath"
 	"github.com/quasilyte/roboden-game/gamedata"
 	"github.com/quasilyte/roboden-game/pathing"
 	"github.com/quasilyte/roboden-game/serverapi"
 	"github.com/quasilyte/roboden-game/session"
+	"github.com/quasilyte/roboden-game/spatial"
 	"github.com/quasilyte/roboden-game/userdevice"
 	"github.com/quasilyte/roboden-game/viewport"
 )
 
+// spatialCellSize is a cell size of the world spatial indexes.
+// Most of the radius queries are in 100-300 range.
+const spatialCellSize = 128.0
+
 type worldState struct {
 	rand      *gmath.Rand
 	localRand *gmath.Rand
@@ -30,7 +35,7 @@ type worldState struct {
 	stage   *viewport.CameraStage
 	cameras []*viewport.Camera
 
-	visionCircle *ebiten.Image
+	visionCircles map[float64]*ebiten.Image
 
 	humanPlayers     []*humanPlayer
 	players          []player
@@ -47,22 +52,31 @@ type worldState struct {
 	neutralBuildings []*neutralBuildingNode
 	lavaGeysers      []*lavaGeyserNode
 	lavaPuddles      []*lavaPuddleNode
+	iceLakes         []*iceLakeNode
+	scavengers       []*scavengerNode
+
+	blizzard    *blizzardNode
+	worldEvents *worldEventScheduler
 
-	boss              *creepNode
-	wispLair          *creepNode
-	fortress          *creepNode
-	creepCoordinator  *creepCoordinator
-	creepsPlayerState *creepsPlayerState
+	boss                 *creepNode
+	wispLair             *creepNode
+	fortress             *creepNode
+	creepCoordinator     *creepCoordinator
+	scavengerCoordinator *scavengerCoordinator
+	influenceMap         *influenceMap
+	creepsPlayerState    *creepsPlayerState
 
 	centurionRallyPoint    gmath.Vec
 	centurionRallyPointPtr *gmath.Vec
 
-	creepClusterWidth       float64
-	creepClusterHeight      float64
-	creepClusterMultiplierX float64
-	creepClusterMultiplierY float64
-	creepClusters           [8][8][]*creepNode
-	fallbackCreepCluster    []*creepNode
+	creepIndex   *spatial.Index[*creepNode]
+	agentIndex   *spatial.Index[*colonyAgentNode]
+	turretIndex  *spatial.Index[*colonyAgentNode]
+	essenceIndex *spatial.Index[*essenceSourceNode]
+
+	// These buffers are used by the randomized index walks.
+	creepWalkBuf []*creepNode
+	agentWalkBuf []*colonyAgentNode
 
 	graphicsSettings session.GraphicsSettings
 	tier2recipes     []gamedata.AgentMergeRecipe
@@ -102,9 +116,18 @@ type worldState struct {
 	pathgrid     *pathing.Grid
 	bfs          *pathing.GreedyBFS
 
+	// The hierarchical path graphs for the most common layers.
+	// They find the optimal long paths that respect the cell costs.
+	groundPathgraph  *pathing.HPAGraph
+	landingPathgraph *pathing.HPAGraph
+
+	// The flow fields for the big ground creep groups.
+	groundFlowFields *pathing.FlowFieldCache
+
 	result battleResults
 
 	simulation   bool
+	resuming     bool // Re-executing the saved game actions
 	seedKind     gamedata.SeedKind
 	config       *gamedata.LevelConfig
 	gameSettings *session.GameSettings
@@ -125,6 +148,10 @@ type worldState struct {
 	mapShape gamedata.WorldShape
 	spawnPos gmath.Vec
 
+	// hostileColonies is set for the modes where
+	// different players colonies are fighting each other.
+	hostileColonies bool
+
 	EventCheckDefeatState      gsignal.Event[gsignal.Void]
 	EventColonyCreated         gsignal.Event[*colonyCoreNode]
 	EventCenturionCreated      gsignal.Event[*creepNode]
@@ -150,18 +177,19 @@ func (w *worldState) Adjust2x2CellPos(pos gmath.Vec, offset float64) gmath.Vec {
 	if offset == 0 {
 		return roundedPos(aligned)
 	}
-	return roundedPos(aligned.Add(w.rand.Offset(-offset, offset)))
+	return roundedPos(aligned.Add(detmath.Offset(w.rand, -offset, offset)))
 }
 
 func (w *worldState) AdjustCellPos(pos gmath.Vec, offset float64) gmath.Vec {
 	aligned := w.pathgrid.AlignPos(pos)
-	return roundedPos(aligned.Add(w.rand.Offset(-offset, offset)))
+	return roundedPos(aligned.Add(detmath.Offset(w.rand, -offset, offset)))
 }
 
 func (w *worldState) MarkCell(coord pathing.GridCoord, tag uint8) {
 	key := w.pathgrid.CoordToIndex(coord)
 	if v := w.gridCounters[key]; v == 0 {
 		w.pathgrid.SetCellTag(coord, tag)
+		w.onCellTagChanged(coord)
 	}
 	w.gridCounters[key]++
 }
@@ -210,12 +238,19 @@ func (w *worldState) UnmarkCell(coord pathing.GridCoord) {
 	key := w.pathgrid.CoordToIndex(coord)
 	if v := w.gridCounters[key]; v == 1 {
 		w.pathgrid.SetCellTag(coord, 0)
+		w.onCellTagChanged(coord)
 		delete(w.gridCounters, key)
 	} else {
 		w.gridCounters[key]--
 	}
 }
 
+func (w *worldState) onCellTagChanged(coord pathing.GridCoord) {
+	w.groundPathgraph.MarkCellChanged(coord)
+	w.landingPathgraph.MarkCellChanged(coord)
+	w.groundFlowFields.MarkCellChanged(coord)
+}
+
 func (w *worldState) Init() {
 	w.gridCounters = make(map[int]uint8)
 
@@ -236,16 +271,12 @@ func (w *worldState) Init() {
 		}
 	}
 
-	w.creepClusterWidth = w.width / 8
-	w.creepClusterHeight = w.height / 8
-	w.creepClusterMultiplierX = 1.0 / w.creepClusterWidth
-	w.creepClusterMultiplierY = 1.0 / w.creepClusterHeight
-	w.fallbackCreepCluster = make([]*creepNode, 0, 32)
-	for y := range w.creepClusters {
-		for x := range w.creepClusters {
-			w.creepClusters[y][x] = make([]*creepNode, 0, 16)
-		}
-	}
+	w.creepIndex = spatial.NewIndex[*creepNode](w.width, w.height, spatialCellSize)
+	w.agentIndex = spatial.NewIndex[*colonyAgentNode](w.width, w.height, spatialCellSize)
+	w.turretIndex = spatial.NewIndex[*colonyAgentNode](w.width, w.height, spatialCellSize)
+	w.essenceIndex = spatial.NewIndex[*essenceSourceNode](w.width, w.height, spatialCellSize)
+	w.creepWalkBuf = make([]*creepNode, 0, 64)
+	w.agentWalkBuf = make([]*colonyAgentNode, 0, 64)
 
 	w.projectilePool = make([]*projectileNode, 0, 128)
 	w.simulation = w.config.ExecMode == gamedata.ExecuteSimulation
@@ -281,16 +312,15 @@ func (w *worldState) Init() {
 		}
 	}
 
-	w.droneHealthMultiplier = 0.8 + (float64(w.config.DronesPower) * 0.2)
-	w.dronePowerMultiplier = 0.9 + (float64(w.config.DronesPower) * 0.1)
-	w.creepHealthMultiplier = 0.25 + (float64(w.config.CreepDifficulty) * 0.25)
-	w.bossHealthMultiplier = 0.7 + (float64(w.config.BossDifficulty) * 0.3)
+	w.droneHealthMultiplier = 0.8 + float64(float64(w.config.DronesPower)*0.2)
+	w.dronePowerMultiplier = 0.9 + float64(float64(w.config.DronesPower)*0.1)
+	w.creepHealthMultiplier = 0.25 + float64(float64(w.config.CreepDifficulty)*0.25)
+	w.bossHealthMultiplier = 0.7 + float64(float64(w.config.BossDifficulty)*0.3)
 	w.oilRegenMultiplier = float64(w.config.OilRegenRate) * 0.5
-	w.superCreepChanceMultiplier = 0.1 + (float64(w.config.ReverseSuperCreepRate) * 0.3)
+	w.superCreepChanceMultiplier = 0.1 + float64(float64(w.config.ReverseSuperCreepRate)*0.3)
 
 	if w.config.FogOfWar && w.config.ExecMode != gamedata.ExecuteSimulation {
-		w.visionCircle = ebiten.NewImage(int(colonyVisionRadius*2), int(colonyVisionRadius*2))
-		gedraw.DrawCircle(w.visionCircle, gmath.Vec{X: colonyVisionRadius, Y: colonyVisionRadius}, colonyVisionRadius, color.RGBA{A: 255})
+		w.visionCircles = make(map[float64]*ebiten.Image, 2)
 	}
 
 	switch w.config.WorldSize {
@@ -305,6 +335,29 @@ func (w *worldState) Init() {
 	}
 }
 
+// VisionRadius returns the current fog of war reveal radius.
+// It can be reduced by the weather.
+func (w *worldState) VisionRadius() float64 {
+	r := colonyVisionRadius
+	if w.blizzard != nil && w.blizzard.IsActive() {
+		r *= blizzardVisionMultiplier
+	}
+	if w.worldEvents != nil && w.worldEvents.IsNight() {
+		r *= nightVisionMultiplier
+	}
+	return r
+}
+
+func (w *worldState) getVisionCircle(r float64) *ebiten.Image {
+	img, ok := w.visionCircles[r]
+	if !ok {
+		img = ebiten.NewImage(int(r*2), int(r*2))
+		gedraw.DrawCircle(img, gmath.Vec{X: r, Y: r}, r, color.RGBA{A: 255})
+		w.visionCircles[r] = img
+	}
+	return img
+}
+
 func (w *worldState) HasTreesAt(pos gmath.Vec, r float64) bool {
 	for _, forest := range w.forests {
 		if forest.CollidesWith(pos, r) {
@@ -326,26 +379,6 @@ func (w *worldState) newProjectileNode(config projectileConfig) *projectileNode
 	return p
 }
 
-func (w *worldState) GetCellRect(x, y int) gmath.Rect {
-	min := gmath.Vec{X: float64(x) * w.creepClusterWidth, Y: float64(y) * w.creepClusterHeight}
-	return gmath.Rect{
-		Min: min,
-		Max: min.Add(gmath.Vec{X: w.creepClusterWidth, Y: w.creepClusterHeight}),
-	}
-}
-
-func (w *worldState) GetPosCell(pos gmath.Vec) (x int, y int, ok bool) {
-	if pos.X < 0 || pos.X > w.width {
-		return 0, 0, false
-	}
-	if pos.Y < 0 || pos.Y > w.height {
-		return 0, 0, false
-	}
-	cellX := int(pos.X * w.creepClusterMultiplierX)
-	cellY := int(pos.Y * w.creepClusterMultiplierY)
-	return cellX, cellY, true
-}
-
 func (w *worldState) GetPingDst(src *humanPlayer) *humanPlayer {
 	if len(w.players) < 2 {
 		return nil
@@ -356,26 +389,27 @@ func (w *worldState) GetPingDst(src *humanPlayer) *humanPlayer {
 	return w.players[0].(*humanPlayer)
 }
 
-func (w *worldState) Update() {
-	w.fallbackCreepCluster = w.fallbackCreepCluster[:0]
-	for y := range w.creepClusters {
-		for x := range w.creepClusters[y] {
-			w.creepClusters[y][x] = w.creepClusters[y][x][:0]
-		}
+func (w *worldState) updateCreepIndex(creep *creepNode) {
+	if creep.marked != 0 {
+		// Marked creeps are reported by every WalkCreeps call.
+		w.creepIndex.MoveOutside(creep.spatialHandle, creep.pos)
+		return
 	}
+	w.creepIndex.Move(creep.spatialHandle, creep.pos)
+}
 
-	for _, creep := range w.creeps {
-		if creep.marked == 0 {
-			x, y, ok := w.GetPosCell(creep.pos)
-			if ok && y < len(w.creepClusters) {
-				if x < len(w.creepClusters[y]) {
-					w.creepClusters[y][x] = append(w.creepClusters[y][x], creep)
-					continue
-				}
-			}
-		}
-		w.fallbackCreepCluster = append(w.fallbackCreepCluster, creep)
+func (w *worldState) indexAgent(index *spatial.Index[*colonyAgentNode], a *colonyAgentNode) {
+	w.unindexAgent(a)
+	a.spatialIndex = index
+	a.spatialHandle = index.Insert(a, a.pos)
+}
+
+func (w *worldState) unindexAgent(a *colonyAgentNode) {
+	if a.spatialIndex == nil {
+		return
 	}
+	a.spatialIndex.Remove(a.spatialHandle)
+	a.spatialIndex = nil
 }
 
 func (w *worldState) freeProjectileNode(p *projectileNode) {
@@ -465,6 +499,7 @@ func (w *worldState) NewCreepNode(pos gmath.Vec, stats *gamedata.CreepStats) *cr
 			w.UnmarkPos(pos)
 		}
 		w.creeps = xslices.Remove(w.creeps, x)
+		w.creepIndex.Remove(x.spatialHandle)
 		if x.stats.Kind == gamedata.CreepCrawler {
 			w.creepCoordinator.crawlers = xslices.Remove(w.creepCoordinator.crawlers, x)
 		}
@@ -496,6 +531,7 @@ func (w *worldState) NewCreepNode(pos gmath.Vec, stats *gamedata.CreepStats) *cr
 		w.MarkPos(pos, ptagBlocked)
 	}
 	w.creeps = append(w.creeps, n)
+	n.spatialHandle = w.creepIndex.Insert(n, pos)
 	switch stats.Kind {
 	case gamedata.CreepCrawler:
 		w.creepCoordinator.crawlers = append(w.creepCoordinator.crawlers, n)
@@ -529,11 +565,13 @@ func (w *worldState) NewEssenceSourceNode(stats *essenceSourceStats, pos gmath.V
 			w.UnmarkPos(x.pos)
 		}
 		w.essenceSources = xslices.Remove(w.essenceSources, x)
+		w.essenceIndex.Remove(x.spatialHandle)
 	})
 	if !stats.passable {
 		w.MarkPos(pos, ptagBlocked)
 	}
 	w.essenceSources = append(w.essenceSources, n)
+	n.spatialHandle = w.essenceIndex.Insert(n, pos)
 	return n
 }
 
@@ -548,120 +586,56 @@ var nearBaseModeTable = [256]bool{
 	agentModeBuildBuilding:  true,
 }
 
-func (w *worldState) findColonyAgent(agents []*colonyAgentNode, pos gmath.Vec, r float64, skipIdling bool, f func(a *colonyAgentNode) bool) *colonyAgentNode {
-	if len(agents) == 0 {
-		return nil
-	}
-
-	var slider gmath.Slider
-	slider.SetBounds(0, len(agents)-1)
-	slider.TrySetValue(w.rand.IntRange(0, len(agents)-1))
+func (w *worldState) findColonyAgent(pos gmath.Vec, r float64, fighters bool, f func(a *colonyAgentNode) bool) *colonyAgentNode {
 	radiusSqr := r * r
-	for i := 0; i < len(agents); i++ {
-		slider.Inc()
-		a := agents[slider.Value()]
-		if skipIdling && nearBaseModeTable[byte(a.mode)] {
-			continue
+	return randWalkIndex(w.rand, w.agentIndex, &w.agentWalkBuf, pos, r, func(a *colonyAgentNode) bool {
+		if a.stats.CanPatrol != fighters {
+			return false
+		}
+		if nearBaseModeTable[byte(a.mode)] {
+			// The idling agents are not considered if their colony is too far away.
+			c := a.colonyCore
+			dist := detmath.DistanceTo(c.pos, pos)
+			colonyEffectiveRadius := c.PatrolRadius()
+			if dist > colonyEffectiveRadius && (dist-colonyEffectiveRadius) > r {
+				return false
+			}
 		}
 		// Since normal drones can't be inside forest, this condition will suffice.
 		if a.IsCloaked() {
-			continue
+			return false
 		}
-		distSqr := a.pos.DistanceSquaredTo(pos)
+		distSqr := detmath.DistanceSquaredTo(a.pos, pos)
 		if distSqr > radiusSqr {
-			continue
-		}
-		if f(a) {
-			return a
+			return false
 		}
-	}
-	return nil
+		return f(a)
+	})
 }
 
 func (w *worldState) BuildPath(from, to gmath.Vec, l pathing.GridLayer) pathing.BuildPathResult {
-	return w.bfs.BuildPath(w.pathgrid, w.pathgrid.PosToCoord(from), w.pathgrid.PosToCoord(to), l)
-}
-
-func (w *worldState) findSearchClusters(pos gmath.Vec, r float64) (startX, startY, endX, endY int) {
-	// Find a sector that contains this pos.
-	cellX, cellY, ok := w.GetPosCell(pos)
-	if !ok {
-		return 0, 0, 0, 0
-	}
-	cellRect := w.GetCellRect(cellX, cellY)
-
-	// Determine how many sectors we need to consider.
-	// In the simplest case, it's a single sector,
-	// but sometimes we need to check the adjacent sectors too.
-	startX = cellX
-	startY = cellY
-	endX = cellX
-	endY = cellY
-	searchRange := r
-	leftmostPos := gmath.Vec{X: pos.X - searchRange, Y: pos.Y - searchRange}
-	rightmostPos := gmath.Vec{X: pos.X + searchRange, Y: pos.Y + searchRange}
-	if leftmostPos.X < cellRect.Min.X {
-		delta := cellRect.Min.X - leftmostPos.X
-		startX -= int(math.Ceil(delta * w.creepClusterMultiplierX))
-	}
-	if rightmostPos.X > cellRect.Max.X {
-		delta := rightmostPos.X - cellRect.Max.X
-		endX += int(math.Ceil(delta * w.creepClusterMultiplierX))
-	}
-	if leftmostPos.Y < cellRect.Min.Y {
-		delta := cellRect.Min.Y - leftmostPos.Y
-		startY -= int(math.Ceil(delta * w.creepClusterMultiplierY))
-	}
-	if rightmostPos.Y > cellRect.Max.Y {
-		delta := rightmostPos.Y - cellRect.Max.Y
-		endY += int(math.Ceil(delta * w.creepClusterMultiplierY))
+	fromCoord := w.pathgrid.PosToCoord(from)
+	toCoord := w.pathgrid.PosToCoord(to)
+	switch l {
+	case layerNormal:
+		return w.groundPathgraph.BuildPath(fromCoord, toCoord)
+	case layerLandColony:
+		return w.landingPathgraph.BuildPath(fromCoord, toCoord)
+	default:
+		return w.bfs.BuildPath(w.pathgrid, fromCoord, toCoord, l)
 	}
+}
 
-	startX = gmath.Clamp(startX, 0, 7)
-	startY = gmath.Clamp(startY, 0, 7)
-	endX = gmath.Clamp(endX, 0, 7)
-	endY = gmath.Clamp(endY, 0, 7)
-	return startX, startY, endX, endY
+// BuildFlowPath builds a layerNormal path using a shared flow field.
+// It's much cheaper than BuildPath when many units move to the same destination.
+// An empty path is returned if the flow field can't be used from this pos.
+func (w *worldState) BuildFlowPath(from, to gmath.Vec) pathing.BuildPathResult {
+	f := w.groundFlowFields.Get(w.pathgrid.PosToCoord(to))
+	return f.BuildPath(w.pathgrid.PosToCoord(from))
 }
 
 func (w *worldState) WalkCreeps(pos gmath.Vec, r float64, f func(creep *creepNode) bool) *creepNode {
-	creeps := w.creeps
-	if len(creeps) == 0 {
-		return nil
-	}
-
-	startX, startY, endX, endY := w.findSearchClusters(pos, r)
-	numStepsX := endX - startX + 1
-	numStepsY := endY - startY + 1
-
-	// Now decide the sector traversal order.
-	// This is needed to add some randomness to the target selection.
-	dx := 1
-	if w.rand.Bool() {
-		dx = -1
-		startX = endX
-	}
-	dy := 1
-	if w.rand.Bool() {
-		dy = -1
-		startY = endY
-	}
-
-	for i, y := 0, startY; i < numStepsY; i, y = i+1, y+dy {
-		for j, x := 0, startX; j < numStepsX; j, x = j+1, x+dx {
-			clusterCreeps := w.creepClusters[y][x]
-			if creep := randIterate(w.rand, clusterCreeps, f); creep != nil {
-				return creep
-			}
-		}
-	}
-
-	// New creeps are created outside of the map, so they end up
-	// in the fallback cluster that includes everything that is out of bounds.
-	if len(w.fallbackCreepCluster) != 0 {
-		return randIterate(w.rand, w.fallbackCreepCluster, f)
-	}
-	return nil
+	return randWalkIndex(w.rand, w.creepIndex, &w.creepWalkBuf, pos, r, f)
 }
 
 func (w *worldState) AllCenturionsReady() bool {
@@ -674,8 +648,7 @@ func (w *worldState) AllCenturionsReady() bool {
 }
 
 func (w *worldState) FindTargetableAgents(pos gmath.Vec, skipGround bool, r float64, f func(a *colonyAgentNode) bool) {
-	// TODO: use an agent container for turrets too?
-	// Also, this "find" function is used to collect N units, not a single unit (see its usage).
+	// TODO: this "find" function is used to collect N units, not a single unit (see its usage).
 
 	found := false
 	radiusSqr := r * r
@@ -683,7 +656,7 @@ func (w *worldState) FindTargetableAgents(pos gmath.Vec, skipGround bool, r floa
 	// Neutral units have the highest priority.
 	if len(w.mercs) != 0 {
 		randIterate(w.rand, w.mercs, func(a *colonyAgentNode) bool {
-			if a.pos.DistanceSquaredTo(pos) > radiusSqr {
+			if detmath.DistanceSquaredTo(a.pos, pos) > radiusSqr {
 				return false
 			}
 			if f(a) {
@@ -699,11 +672,11 @@ func (w *worldState) FindTargetableAgents(pos gmath.Vec, skipGround bool, r floa
 
 	if !skipGround {
 		// Turrets have the second highest targeting priority.
-		randIterate(w.rand, w.turrets, func(turret *colonyAgentNode) bool {
+		randWalkIndex(w.rand, w.turretIndex, &w.agentWalkBuf, pos, r, func(turret *colonyAgentNode) bool {
 			if turret.insideForest {
 				return false
 			}
-			distSqr := turret.pos.DistanceSquaredTo(pos)
+			distSqr := detmath.DistanceSquaredTo(turret.pos, pos)
 			if distSqr > radiusSqr {
 				return false
 			}
@@ -726,7 +699,7 @@ func (w *worldState) FindTargetableAgents(pos gmath.Vec, skipGround bool, r floa
 				if roomba.insideForest {
 					continue
 				}
-				distSqr := roomba.pos.DistanceSquaredTo(pos)
+				distSqr := detmath.DistanceSquaredTo(roomba.pos, pos)
 				if distSqr > radiusSqr {
 					continue
 				}
@@ -742,20 +715,65 @@ func (w *worldState) FindTargetableAgents(pos gmath.Vec, skipGround bool, r floa
 		}
 	}
 
+	// The fighters are preferred over the workers.
+	if a := w.findColonyAgent(pos, r, true, f); a != nil {
+		return
+	}
+	w.findColonyAgent(pos, r, false, f)
+}
+
+// AreEnemies reports whether two colonies are hostile to each other.
+// Colonies are never hostile outside of the colony-vs-colony modes.
+func (w *worldState) AreEnemies(a, b *colonyCoreNode) bool {
+	if !w.hostileColonies || a == nil || b == nil {
+		return false
+	}
+	return a.player != b.player
+}
+
+// FindEnemyTargets is like FindTargetableAgents, but it only
+// reports the units and cores of the colonies that are hostile to the colony.
+// The scavengers are reported too if they're hostile to the colony.
+func (w *worldState) FindEnemyTargets(colony *colonyCoreNode, pos gmath.Vec, weapon *gamedata.WeaponStats, f func(t targetable) bool) {
+	skipGround := weapon.TargetFlags&gamedata.TargetGround == 0
+	if !skipGround && w.scavengerCoordinator != nil && w.scavengerCoordinator.IsHostile(colony) {
+		for _, s := range w.scavengers {
+			if detmath.DistanceSquaredTo(s.pos, pos) > weapon.AttackRangeSqr {
+				continue
+			}
+			if f(s) {
+				return
+			}
+		}
+	}
+
+	if !w.hostileColonies {
+		return
+	}
+
+	found := false
+	w.FindTargetableAgents(pos, skipGround, weapon.AttackRange, func(a *colonyAgentNode) bool {
+		if !w.AreEnemies(colony, a.colonyCore) || !isValidAgentTarget(pos, a, weapon) {
+			return false
+		}
+		found = f(a)
+		return found
+	})
+	if found {
+		return
+	}
+
 	randIterate(w.rand, w.allColonies, func(c *colonyCoreNode) bool {
-		skipIdling := false
-		dist := c.pos.DistanceTo(pos)
-		colonyEffectiveRadius := c.PatrolRadius()
-		if dist > colonyEffectiveRadius {
-			skipIdling = (dist - colonyEffectiveRadius) > r
+		if !w.AreEnemies(colony, c) {
+			return false
 		}
-		if a := w.findColonyAgent(c.agents.fighters, pos, r, skipIdling, f); a != nil {
-			return true
+		if weapon.TargetFlags&targetKindOf(c) == 0 {
+			return false
 		}
-		if a := w.findColonyAgent(c.agents.workers, pos, r, skipIdling, f); a != nil {
-			return true
+		if detmath.DistanceSquaredTo(c.pos, pos) > weapon.AttackRangeSqr {
+			return false
 		}
-		return false
+		return f(c)
 	})
 }
 
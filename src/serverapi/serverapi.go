@@ -15,6 +15,12 @@ type GameReplay struct {
 
 	LevelGenChecksum int `json:"level_gen_checksum"`
 
+	RulesetChecksum string `json:"ruleset_checksum"`
+
+	// Mod is a name of the mod that was used during the game.
+	// It's empty for the unmodded games.
+	Mod string `json:"mod,omitempty"`
+
 	Results GameResults `json:"results"`
 
 	Config ReplayLevelConfig `json:"config"`
@@ -48,6 +54,7 @@ type PlayerAction struct {
 	Pos            [2]float64       `json:"pos"`
 	Kind           PlayerActionKind `json:"kind"`
 	SelectedColony int              `json:"selected_colony"`
+	Param          int              `json:"param,omitempty"`
 }
 
 const (
@@ -75,6 +82,7 @@ type ReplayLevelConfig struct {
 	GoldEnabled bool `json:"gold_enabled"`
 
 	RawGameMode string `json:"mode"`
+	Mission     string `json:"mission,omitempty"`
 
 	PlayersMode   int `json:"players_mode"`
 	InterfaceMode int `json:"ui_mode"`
@@ -86,6 +94,10 @@ type ReplayLevelConfig struct {
 	CoordinatorCreeps bool `json:"coordinator_creeps"`
 	AtomicBomb        bool `json:"atomic_bomb"`
 	IonMortars        bool `json:"ion_mortars"`
+	TechTree          bool `json:"tech_tree"`
+	TurretUpgrades    bool `json:"turret_upgrades"`
+	WorldEvents       bool `json:"world_events"`
+	Scavengers        bool `json:"scavengers"`
 
 	InitialCreeps         int  `json:"initial_creeps"`
 	NumCreepBases         int  `json:"num_creep_bases"`
@@ -101,6 +113,9 @@ type ReplayLevelConfig struct {
 
 	Teleporters int `json:"teleporters"`
 
+	BotProfile         int `json:"bot_profile,omitempty"`
+	OpponentBotProfile int `json:"opponent_bot_profile,omitempty"`
+
 	Seed int64 `json:"seed"`
 
 	WorldShape   int `json:"world_shape"`
@@ -108,6 +123,7 @@ type ReplayLevelConfig struct {
 	OilRegenRate int `json:"oil_regen_rage"`
 	Terrain      int `json:"terrain"`
 	Environment  int `json:"environment"`
+	Symmetry     int `json:"symmetry"`
 
 	DifficultyScore int `json:"difficulty"`
 
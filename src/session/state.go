@@ -1,8 +1,11 @@
 package session
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
+	"path/filepath"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -29,6 +32,12 @@ type State struct {
 
 	ExtraMusic bool
 
+	// ModFolder is a loaded mod folder path (see LoadMod).
+	// ModName is derived from the folder name; it's recorded in replays.
+	// Both are empty when the game is running without mods.
+	ModFolder string
+	ModName   string
+
 	ServerProtocol string
 	ServerHost     string
 	ServerPath     string
@@ -47,6 +56,8 @@ type State struct {
 	ArenaLevelConfig    *gamedata.LevelConfig
 	InfArenaLevelConfig *gamedata.LevelConfig
 	ReverseLevelConfig  *gamedata.LevelConfig
+	KothLevelConfig     *gamedata.LevelConfig
+	VersusLevelConfig   *gamedata.LevelConfig
 	TutorialLevelConfig *gamedata.LevelConfig
 
 	Persistent PersistentData
@@ -116,10 +127,13 @@ type PlayerStats struct {
 	TurretsUnlocked []string
 	DronesUnlocked  []string
 	Tier3DronesSeen []string
+	Tier4DronesSeen []string
 	ModesUnlocked   []string
 
 	TutorialCompleted bool
 
+	CampaignMissionsCompleted []string
+
 	TotalPlayTime time.Duration
 	TotalScore    int
 
@@ -279,9 +293,51 @@ func (state *State) ReloadLanguage(ctx *ge.Context) {
 	if err := dict.Load("", ctx.Loader.LoadRaw(id+3).Data); err != nil {
 		panic(err)
 	}
+	if state.ModFolder != "" {
+		// Translation files are optional for the mods.
+		data, err := assets.ReadModFile(state.ModFolder, filepath.Join("lang", lang+".txt"))
+		if err == nil {
+			if err := dict.Load("", data); err != nil {
+				panic(err)
+			}
+		}
+	}
 	ctx.Dict = dict
 }
 
+// LoadMod applies the mod rules overlay and remembers the mod folder,
+// so its assets and translations can be used by the game.
+// It should be called before the assets and translations are loaded.
+//
+// See assets.ReadModFile for the mod folder layout.
+func (state *State) LoadMod(folder string) error {
+	data, err := assets.ReadModFile(folder, "stats.json")
+	switch {
+	case err == nil:
+		if err := gamedata.LoadRuleset(data); err != nil {
+			return fmt.Errorf("%s stats.json: %w", folder, err)
+		}
+	case errors.Is(err, fs.ErrNotExist):
+		// A mod without rules changes, it's OK.
+	default:
+		return err
+	}
+	data, err = assets.ReadModFile(folder, "campaign.json")
+	switch {
+	case err == nil:
+		if err := gamedata.LoadCampaign(data); err != nil {
+			return fmt.Errorf("%s campaign.json: %w", folder, err)
+		}
+	case errors.Is(err, fs.ErrNotExist):
+		// A mod without new missions, it's OK.
+	default:
+		return err
+	}
+	state.ModFolder = folder
+	state.ModName = filepath.Base(folder)
+	return nil
+}
+
 func (state *State) FindNextReplayIndex() int {
 	var minDate time.Time
 	minIndex := 0
@@ -309,3 +365,7 @@ func (state *State) FindNextReplayIndex() int {
 func (state *State) ReplayDataKey(i int) string {
 	return fmt.Sprintf("saved_replay_%d", i)
 }
+
+func (state *State) SavedGameDataKey() string {
+	return "saved_game"
+}
@@ -0,0 +1,291 @@
+package spatial
+
+import (
+	"math"
+
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/roboden-game/detmath"
+)
+
+// Index is a uniform grid that keeps track of the objects positions.
+//
+// The indexed area is split into square cells of the same size;
+// every object is stored inside the cell that contains its position.
+// The objects that are outside of the indexed area (and the objects that
+// were explicitly moved outside) are kept in a separate outside bucket.
+//
+// The objects iteration order depends only on the sequence of the
+// index operations, so it's deterministic. This is important for the
+// game replays: they're verified by re-running the simulation.
+type Index[T any] struct {
+	width          float64
+	height         float64
+	cellSize       float64
+	cellMultiplier float64
+	numCols        int
+	numRows        int
+
+	cells   []bucket[T]
+	outside bucket[T]
+
+	slots      []slot
+	freeSlots  []int32
+	numObjects int
+}
+
+// Handle is an indexed object identifier.
+// It's returned by Insert and it becomes stale after Remove.
+// The operations over the stale handles have no effect.
+type Handle struct {
+	id  int32
+	gen uint32
+}
+
+type bucket[T any] struct {
+	objects   []T
+	positions []gmath.Vec
+	slots     []int32
+}
+
+type slot struct {
+	cell  int32
+	index int32
+	gen   uint32
+}
+
+const (
+	outsideCell = -1
+	freeCell    = -2
+)
+
+// NewIndex creates an index that covers the [0,0]-[width,height] area.
+func NewIndex[T any](width, height, cellSize float64) *Index[T] {
+	numCols := int(math.Ceil(width / cellSize))
+	numRows := int(math.Ceil(height / cellSize))
+	if numCols < 1 {
+		numCols = 1
+	}
+	if numRows < 1 {
+		numRows = 1
+	}
+	return &Index[T]{
+		width:          width,
+		height:         height,
+		cellSize:       cellSize,
+		cellMultiplier: 1.0 / cellSize,
+		numCols:        numCols,
+		numRows:        numRows,
+		cells:          make([]bucket[T], numCols*numRows),
+	}
+}
+
+func (idx *Index[T]) Len() int { return idx.numObjects }
+
+func (idx *Index[T]) Size() (numCols, numRows int) { return idx.numCols, idx.numRows }
+
+func (idx *Index[T]) Insert(x T, pos gmath.Vec) Handle {
+	var id int32
+	if len(idx.freeSlots) != 0 {
+		id = idx.freeSlots[len(idx.freeSlots)-1]
+		idx.freeSlots = idx.freeSlots[:len(idx.freeSlots)-1]
+	} else {
+		idx.slots = append(idx.slots, slot{})
+		id = int32(len(idx.slots) - 1)
+	}
+	idx.numObjects++
+	idx.add(id, idx.posCell(pos), x, pos)
+	return Handle{id: id + 1, gen: idx.slots[id].gen}
+}
+
+func (idx *Index[T]) Remove(h Handle) {
+	id, ok := idx.slotID(h)
+	if !ok {
+		return
+	}
+	idx.remove(id)
+	idx.slots[id].cell = freeCell
+	idx.slots[id].gen++
+	idx.freeSlots = append(idx.freeSlots, id)
+	idx.numObjects--
+}
+
+// Move updates the object position.
+func (idx *Index[T]) Move(h Handle, pos gmath.Vec) {
+	idx.move(h, idx.posCell(pos), pos)
+}
+
+// MoveOutside puts the object into the outside bucket,
+// as if it was out of the indexed area bounds.
+func (idx *Index[T]) MoveOutside(h Handle, pos gmath.Vec) {
+	idx.move(h, outsideCell, pos)
+}
+
+// CellRange returns the cells that intersect the pos-centered square
+// with a side of 2*r; the max coords are inclusive.
+// The ok result is false if this square is outside of the indexed area.
+func (idx *Index[T]) CellRange(pos gmath.Vec, r float64) (minX, minY, maxX, maxY int, ok bool) {
+	if pos.X+r < 0 || pos.Y+r < 0 || pos.X-r > idx.width || pos.Y-r > idx.height {
+		return 0, 0, 0, 0, false
+	}
+	minX = idx.clampCol(int(math.Floor((pos.X - r) * idx.cellMultiplier)))
+	minY = idx.clampRow(int(math.Floor((pos.Y - r) * idx.cellMultiplier)))
+	// The square that ends exactly on the cell border does not need the next cell.
+	maxX = idx.clampCol(int(math.Ceil((pos.X+r)*idx.cellMultiplier)) - 1)
+	maxY = idx.clampRow(int(math.Ceil((pos.Y+r)*idx.cellMultiplier)) - 1)
+	if maxX < minX {
+		maxX = minX
+	}
+	if maxY < minY {
+		maxY = minY
+	}
+	return minX, minY, maxX, maxY, true
+}
+
+// Cell returns the objects of the specified cell.
+// The returned slice should not be modified.
+func (idx *Index[T]) Cell(x, y int) []T {
+	return idx.cells[y*idx.numCols+x].objects
+}
+
+// Outside returns the objects of the outside bucket.
+// The returned slice should not be modified.
+func (idx *Index[T]) Outside() []T {
+	return idx.outside.objects
+}
+
+// WalkRadius calls f for every object within the radius r around pos
+// until f returns true. That object is returned as a result.
+//
+// The cells are visited row by row; the outside objects go last.
+// The f func should not insert, move or remove the index objects.
+func (idx *Index[T]) WalkRadius(pos gmath.Vec, r float64, f func(x T) bool) (T, bool) {
+	rSqr := r * r
+	if minX, minY, maxX, maxY, ok := idx.CellRange(pos, r); ok {
+		for y := minY; y <= maxY; y++ {
+			for x := minX; x <= maxX; x++ {
+				b := &idx.cells[y*idx.numCols+x]
+				for i, objectPos := range b.positions {
+					if detmath.DistanceSquaredTo(objectPos, pos) > rSqr {
+						continue
+					}
+					if f(b.objects[i]) {
+						return b.objects[i], true
+					}
+				}
+			}
+		}
+	}
+	for i, objectPos := range idx.outside.positions {
+		if detmath.DistanceSquaredTo(objectPos, pos) > rSqr {
+			continue
+		}
+		if f(idx.outside.objects[i]) {
+			return idx.outside.objects[i], true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// WalkRect is like WalkRadius, but it reports the objects inside the rect.
+func (idx *Index[T]) WalkRect(rect gmath.Rect, f func(x T) bool) (T, bool) {
+	// Note that rect.Center() can't be used here: it ignores the rect.Min.
+	center := rect.Min.Add(rect.Max).Mulf(0.5)
+	r := gmath.ClampMin(rect.Width(), rect.Height()) * 0.5
+	if minX, minY, maxX, maxY, ok := idx.CellRange(center, r); ok {
+		for y := minY; y <= maxY; y++ {
+			for x := minX; x <= maxX; x++ {
+				b := &idx.cells[y*idx.numCols+x]
+				for i, objectPos := range b.positions {
+					if !rect.Contains(objectPos) {
+						continue
+					}
+					if f(b.objects[i]) {
+						return b.objects[i], true
+					}
+				}
+			}
+		}
+	}
+	for i, objectPos := range idx.outside.positions {
+		if !rect.Contains(objectPos) {
+			continue
+		}
+		if f(idx.outside.objects[i]) {
+			return idx.outside.objects[i], true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+func (idx *Index[T]) slotID(h Handle) (int32, bool) {
+	id := h.id - 1
+	if id < 0 || idx.slots[id].gen != h.gen || idx.slots[id].cell == freeCell {
+		return 0, false
+	}
+	return id, true
+}
+
+func (idx *Index[T]) move(h Handle, cell int32, pos gmath.Vec) {
+	id, ok := idx.slotID(h)
+	if !ok {
+		return
+	}
+	s := idx.slots[id]
+	b := idx.bucket(s.cell)
+	if s.cell == cell {
+		b.positions[s.index] = pos
+		return
+	}
+	x := b.objects[s.index]
+	idx.remove(id)
+	idx.add(id, cell, x, pos)
+}
+
+func (idx *Index[T]) add(id, cell int32, x T, pos gmath.Vec) {
+	b := idx.bucket(cell)
+	idx.slots[id].cell = cell
+	idx.slots[id].index = int32(len(b.objects))
+	b.objects = append(b.objects, x)
+	b.positions = append(b.positions, pos)
+	b.slots = append(b.slots, id)
+}
+
+func (idx *Index[T]) remove(id int32) {
+	s := idx.slots[id]
+	b := idx.bucket(s.cell)
+	last := len(b.objects) - 1
+	if int(s.index) != last {
+		// Move the last element into the freed place.
+		b.objects[s.index] = b.objects[last]
+		b.positions[s.index] = b.positions[last]
+		b.slots[s.index] = b.slots[last]
+		idx.slots[b.slots[s.index]].index = s.index
+	}
+	var zero T
+	b.objects[last] = zero // Don't keep the removed object alive
+	b.objects = b.objects[:last]
+	b.positions = b.positions[:last]
+	b.slots = b.slots[:last]
+}
+
+func (idx *Index[T]) bucket(cell int32) *bucket[T] {
+	if cell == outsideCell {
+		return &idx.outside
+	}
+	return &idx.cells[cell]
+}
+
+func (idx *Index[T]) posCell(pos gmath.Vec) int32 {
+	if pos.X < 0 || pos.Y < 0 || pos.X >= idx.width || pos.Y >= idx.height {
+		return outsideCell
+	}
+	x := idx.clampCol(int(pos.X * idx.cellMultiplier))
+	y := idx.clampRow(int(pos.Y * idx.cellMultiplier))
+	return int32(y*idx.numCols + x)
+}
+
+func (idx *Index[T]) clampCol(x int) int { return gmath.Clamp(x, 0, idx.numCols-1) }
+
+func (idx *Index[T]) clampRow(y int) int { return gmath.Clamp(y, 0, idx.numRows-1) }
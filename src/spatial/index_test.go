@@ -0,0 +1,265 @@
+package spatial_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/roboden-game/spatial"
+)
+
+type testObject struct {
+	id  int
+	pos gmath.Vec
+}
+
+func BenchmarkIndex(b *testing.B) {
+	// A big arena game: the world is 3840x3840 and there are thousands of units.
+	const (
+		worldSize = 3840.0
+		cellSize  = 128.0
+		numUnits  = 3500
+		radius    = 250.0
+	)
+	rng := rand.New(rand.NewSource(1))
+	objects := make([]*testObject, numUnits)
+	for i := range objects {
+		objects[i] = &testObject{id: i, pos: testRandomPos(rng, worldSize)}
+	}
+	queries := make([]gmath.Vec, 256)
+	for i := range queries {
+		queries[i] = testRandomPos(rng, worldSize)
+	}
+
+	idx := spatial.NewIndex[*testObject](worldSize, worldSize, cellSize)
+	handles := make([]spatial.Handle, len(objects))
+	for i, o := range objects {
+		handles[i] = idx.Insert(o, o.pos)
+	}
+
+	b.Run("radius_linear", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			pos := queries[i%len(queries)]
+			for _, o := range objects {
+				if o.pos.DistanceSquaredTo(pos) <= radius*radius && o.id < 0 {
+					break
+				}
+			}
+		}
+	})
+	b.Run("radius_index", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			pos := queries[i%len(queries)]
+			idx.WalkRadius(pos, radius, func(o *testObject) bool {
+				return o.id < 0
+			})
+		}
+	})
+	b.Run("move_all", func(b *testing.B) {
+		// This is what a game tick costs if every unit moves a bit.
+		for i := 0; i < b.N; i++ {
+			d := gmath.Vec{X: 1, Y: 1}
+			if i%2 == 1 {
+				d = d.Neg()
+			}
+			for j, o := range objects {
+				o.pos = o.pos.Add(d)
+				idx.Move(handles[j], o.pos)
+			}
+		}
+	})
+}
+
+func TestIndexCellRange(t *testing.T) {
+	tests := []struct {
+		pos  gmath.Vec
+		r    float64
+		want [4]int
+	}{
+		{gmath.Vec{X: 5, Y: 5}, 2, [4]int{0, 0, 0, 0}},
+		{gmath.Vec{X: 0, Y: 0}, 9.5, [4]int{0, 0, 0, 0}},
+		{gmath.Vec{X: 0, Y: 0}, 10, [4]int{0, 0, 0, 0}},
+
+		{gmath.Vec{X: 0, Y: 0}, 10.1, [4]int{0, 0, 1, 1}},
+		{gmath.Vec{X: 5, Y: 5}, 6, [4]int{0, 0, 1, 1}},
+		{gmath.Vec{X: 2, Y: 5}, 6, [4]int{0, 0, 0, 1}},
+		{gmath.Vec{X: 5, Y: 2}, 6, [4]int{0, 0, 1, 0}},
+
+		{gmath.Vec{X: 10, Y: 10}, 10, [4]int{0, 0, 1, 1}},
+		{gmath.Vec{X: 10, Y: 10}, 10.1, [4]int{0, 0, 2, 2}},
+		{gmath.Vec{X: 10.1, Y: 10.1}, 10.1, [4]int{0, 0, 2, 2}},
+		{gmath.Vec{X: 10.1, Y: 10.1}, 6, [4]int{0, 0, 1, 1}},
+		{gmath.Vec{X: 19.9, Y: 19.9}, 6, [4]int{1, 1, 2, 2}},
+		{gmath.Vec{X: 15, Y: 15}, 6, [4]int{0, 0, 2, 2}},
+		{gmath.Vec{X: 12, Y: 15}, 6, [4]int{0, 0, 1, 2}},
+		{gmath.Vec{X: 15, Y: 12}, 6, [4]int{0, 0, 2, 1}},
+
+		{gmath.Vec{X: 15, Y: 15}, 10, [4]int{0, 0, 2, 2}},
+		{gmath.Vec{X: 19, Y: 19}, 5, [4]int{1, 1, 2, 2}},
+		{gmath.Vec{X: 19, Y: 19}, 20, [4]int{0, 0, 3, 3}},
+
+		{gmath.Vec{X: 35, Y: 35}, 30, [4]int{0, 0, 6, 6}},
+		{gmath.Vec{X: 45, Y: 45}, 30, [4]int{1, 1, 7, 7}},
+
+		{gmath.Vec{X: 35, Y: 35}, 50, [4]int{0, 0, 7, 7}},
+		{gmath.Vec{X: 45, Y: 45}, 50, [4]int{0, 0, 7, 7}},
+		{gmath.Vec{X: 35, Y: 35}, 100, [4]int{0, 0, 7, 7}},
+		{gmath.Vec{X: 45, Y: 45}, 100, [4]int{0, 0, 7, 7}},
+		{gmath.Vec{X: 40, Y: 40}, 100, [4]int{0, 0, 7, 7}},
+		{gmath.Vec{X: 2, Y: 5}, 100, [4]int{0, 0, 7, 7}},
+		{gmath.Vec{X: 2, Y: 5}, 500, [4]int{0, 0, 7, 7}},
+		{gmath.Vec{X: 2, Y: 5}, 1000, [4]int{0, 0, 7, 7}},
+
+		{gmath.Vec{X: -5, Y: 5}, 6, [4]int{0, 0, 0, 1}},
+		{gmath.Vec{X: 85, Y: 75}, 6, [4]int{7, 6, 7, 7}},
+	}
+
+	idx := spatial.NewIndex[int](80, 80, 10)
+	for i, test := range tests {
+		startX, startY, endX, endY, ok := idx.CellRange(test.pos, test.r)
+		have := [4]int{startX, startY, endX, endY}
+		if !ok || test.want != have {
+			t.Fatalf("test[%d] pos=%v r=%f\nhave: %v (ok=%v)\nwant: %v",
+				i, test.pos, test.r, have, ok, test.want)
+		}
+	}
+
+	if _, _, _, _, ok := idx.CellRange(gmath.Vec{X: -20, Y: 5}, 10); ok {
+		t.Fatalf("expected the out of bounds area to be rejected")
+	}
+}
+
+func TestIndex(t *testing.T) {
+	const worldSize = 1000.0
+	rng := rand.New(rand.NewSource(3))
+	idx := spatial.NewIndex[*testObject](worldSize, worldSize, 64)
+
+	type entry struct {
+		o       *testObject
+		h       spatial.Handle
+		outside bool
+	}
+	var entries []entry
+	nextID := 0
+
+	randomPos := func() gmath.Vec {
+		// Some of the objects will be out of the index bounds.
+		pos := testRandomPos(rng, worldSize+200)
+		return pos.Sub(gmath.Vec{X: 100, Y: 100})
+	}
+
+	for step := 0; step < 3000; step++ {
+		switch roll := rng.Intn(10); {
+		case roll < 4 || len(entries) == 0:
+			o := &testObject{id: nextID, pos: randomPos()}
+			nextID++
+			entries = append(entries, entry{o: o, h: idx.Insert(o, o.pos)})
+		case roll < 7:
+			e := &entries[rng.Intn(len(entries))]
+			e.o.pos = randomPos()
+			e.outside = rng.Intn(8) == 0
+			if e.outside {
+				idx.MoveOutside(e.h, e.o.pos)
+			} else {
+				idx.Move(e.h, e.o.pos)
+			}
+		default:
+			i := rng.Intn(len(entries))
+			idx.Remove(entries[i].h)
+			entries[i] = entries[len(entries)-1]
+			entries = entries[:len(entries)-1]
+		}
+
+		if idx.Len() != len(entries) {
+			t.Fatalf("step %d: len mismatch: have %d, want %d", step, idx.Len(), len(entries))
+		}
+
+		pos := randomPos()
+		r := rng.Float64() * 300
+		var want []int
+		for _, e := range entries {
+			if e.o.pos.DistanceSquaredTo(pos) <= r*r {
+				want = append(want, e.o.id)
+			}
+		}
+		var have []int
+		idx.WalkRadius(pos, r, func(o *testObject) bool {
+			have = append(have, o.id)
+			return false
+		})
+		testCompareIDs(t, "radius", step, have, want)
+
+		rect := gmath.Rect{Min: pos, Max: pos.Add(gmath.Vec{X: rng.Float64() * 300, Y: rng.Float64() * 300})}
+		want = want[:0]
+		for _, e := range entries {
+			if rect.Contains(e.o.pos) {
+				want = append(want, e.o.id)
+			}
+		}
+		have = have[:0]
+		idx.WalkRect(rect, func(o *testObject) bool {
+			have = append(have, o.id)
+			return false
+		})
+		testCompareIDs(t, "rect", step, have, want)
+	}
+
+	for _, e := range entries {
+		idx.Remove(e.h)
+		// The stale handles are ignored.
+		idx.Remove(e.h)
+		idx.Move(e.h, gmath.Vec{})
+	}
+	if idx.Len() != 0 {
+		t.Fatalf("expected an empty index, got %d objects", idx.Len())
+	}
+}
+
+func TestIndexWalkOrder(t *testing.T) {
+	idx := spatial.NewIndex[int](100, 100, 10)
+	idx.Insert(1, gmath.Vec{X: 55, Y: 55})
+	idx.Insert(2, gmath.Vec{X: 45, Y: 55})
+	idx.Insert(3, gmath.Vec{X: 55, Y: 45})
+	h := idx.Insert(4, gmath.Vec{X: 46, Y: 46})
+	idx.Insert(5, gmath.Vec{X: 47, Y: 47})
+	idx.MoveOutside(h, gmath.Vec{X: 46, Y: 46})
+
+	// Row by row, the outside objects go last.
+	var have []int
+	idx.WalkRadius(gmath.Vec{X: 50, Y: 50}, 20, func(x int) bool {
+		have = append(have, x)
+		return false
+	})
+	want := []int{5, 3, 2, 1, 4}
+	for i := range want {
+		if len(have) != len(want) || have[i] != want[i] {
+			t.Fatalf("walk order mismatch:\nhave: %v\nwant: %v", have, want)
+		}
+	}
+
+	x, ok := idx.WalkRadius(gmath.Vec{X: 50, Y: 50}, 20, func(x int) bool {
+		return x%2 == 0
+	})
+	if !ok || x != 2 {
+		t.Fatalf("expected the first matching object to be found, got %v", x)
+	}
+}
+
+func testCompareIDs(t *testing.T, kind string, step int, have, want []int) {
+	t.Helper()
+	sort.Ints(have)
+	sort.Ints(want)
+	if len(have) != len(want) {
+		t.Fatalf("step %d: %s query mismatch:\nhave: %v\nwant: %v", step, kind, have, want)
+	}
+	for i := range have {
+		if have[i] != want[i] {
+			t.Fatalf("step %d: %s query mismatch:\nhave: %v\nwant: %v", step, kind, have, want)
+		}
+	}
+}
+
+func testRandomPos(rng *rand.Rand, size float64) gmath.Vec {
+	return gmath.Vec{X: rng.Float64() * size, Y: rng.Float64() * size}
+}
@@ -335,14 +335,21 @@ func (c *Camera) Pan(delta gmath.Vec) {
 }
 
 func (c Camera) CenterPos() gmath.Vec {
-	return c.Offset.Add(c.Rect.Center())
+	return c.Offset.Add(c.rectCenter())
 }
 
 func (c *Camera) CenterOn(pos gmath.Vec) {
-	c.Offset = pos.Sub(c.Rect.Center())
+	c.Offset = pos.Sub(c.rectCenter())
 	c.checkBounds()
 }
 
+// rectCenter is like c.Rect.Center(), but its result can't be fused
+// with the Offset arithmetics on the platforms that have FMA.
+func (c Camera) rectCenter() gmath.Vec {
+	center := c.Rect.Center()
+	return gmath.Vec{X: float64(center.X), Y: float64(center.Y)}
+}
+
 func (c *Camera) SetOffset(pos gmath.Vec) {
 	c.Offset = pos
 	c.checkBounds()